@@ -1,32 +1,60 @@
 package cmd
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/dev-shimada/phantom-ecs/internal/arn"
+	"github.com/dev-shimada/phantom-ecs/internal/aws"
 	"github.com/dev-shimada/phantom-ecs/internal/batch"
 	"github.com/dev-shimada/phantom-ecs/internal/config"
 	"github.com/dev-shimada/phantom-ecs/internal/errors"
 	"github.com/dev-shimada/phantom-ecs/internal/logger"
+	"github.com/dev-shimada/phantom-ecs/internal/scanner"
 	"github.com/spf13/cobra"
 )
 
 var (
-	batchConfigFile   string
-	batchProfile      string
-	batchServices     []string
-	batchConcurrency  int
-	batchRetryCount   int
-	batchRetryDelay   time.Duration
-	batchShowProgress bool
-	batchDryRun       bool
+	batchConfigFiles   []string
+	batchProfile       string
+	batchServices      []string
+	batchConcurrency   int
+	batchRetryCount    int
+	batchRetryDelay    time.Duration
+	batchShowProgress  bool
+	batchForceProgress bool
+	batchDryRun        bool
+	batchOrderTag      string
+	batchCluster       string
+	batchSummaryJSON   bool
+	batchMetricsFile   string
 )
 
 // NewBatchCommand はバッチ処理コマンドを作成する
 func NewBatchCommand() *cobra.Command {
+	return NewBatchCommandWithProcessor(nil)
+}
+
+// NewBatchCommandWithProcessor は各サービスの処理を指定したProcessorで行うバッチ処理コマンドを作成する（テスト用）
+// processorがnilの場合はBatchServiceProcessorを使用
+func NewBatchCommandWithProcessor(processor batch.Processor) *cobra.Command {
+	return newBatchCommand(processor, aws.NewClientFactory())
+}
+
+// NewBatchCommandWithClientFactory は--order-tagでのタグ取得に使うAWSクライアントを指定したファクトリで作成するバッチ処理コマンドを作成
+func NewBatchCommandWithClientFactory(clientFactory aws.ClientFactory) *cobra.Command {
+	return newBatchCommand(nil, clientFactory)
+}
+
+// newBatchCommand はバッチ処理コマンドを作成（processorがnilの場合はBatchServiceProcessorを使用）
+func newBatchCommand(processor batch.Processor, clientFactory aws.ClientFactory) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "batch",
 		Short: "複数のECSサービスをバッチ処理します",
@@ -35,26 +63,43 @@ func NewBatchCommand() *cobra.Command {
 
 例:
   phantom-ecs batch --services service1,service2,service3
-  phantom-ecs batch --config-file batch-config.yaml --profile production
-  phantom-ecs batch --services service1,service2 --concurrency 5 --retry-count 3`,
-		RunE: runBatch,
+  phantom-ecs batch --config-file batch-config.yaml --config-profile production
+  phantom-ecs batch --services service1,service2 --concurrency 5 --retry-count 3
+  phantom-ecs batch --services db,api,web --cluster prod --order-tag deploy-order
+  grep web services.txt | phantom-ecs batch --services -
+  phantom-ecs batch --services service1,service2 --metrics-file metrics.prom`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBatch(cmd, processor, clientFactory)
+		},
 	}
 
-	cmd.Flags().StringVar(&batchConfigFile, "config-file", "", "バッチ設定ファイルのパス")
+	cmd.Flags().StringArrayVar(&batchConfigFiles, "config-file", nil, "バッチ設定ファイルのパス（複数指定時は後のファイルを前のファイルに深くマージ）")
+	cmd.Flags().StringVar(&batchProfile, "config-profile", "default", "使用する設定ファイルのプロファイル (プロファイルのregion、aws_profileを使用)")
+	// --batch-profileは他のコマンドと揃えた--config-profileの旧名として残す
 	cmd.Flags().StringVar(&batchProfile, "batch-profile", "default", "使用するバッチプロファイル")
-	cmd.Flags().StringSliceVar(&batchServices, "services", []string{}, "処理対象のサービス名（カンマ区切り）")
+	cmd.Flags().MarkDeprecated("batch-profile", "use --config-profile instead")
+	cmd.Flags().StringSliceVar(&batchServices, "services", []string{}, "処理対象のサービス名（カンマ区切り、- を指定すると標準入力から改行区切りで読み込み）")
 	cmd.Flags().IntVar(&batchConcurrency, "concurrency", 3, "同時実行数")
 	cmd.Flags().IntVar(&batchRetryCount, "retry-count", 3, "リトライ回数")
 	cmd.Flags().DurationVar(&batchRetryDelay, "retry-delay", time.Second*2, "リトライ間隔")
-	cmd.Flags().BoolVar(&batchShowProgress, "progress", true, "プログレスバーを表示")
+	cmd.Flags().BoolVar(&batchShowProgress, "progress", true, "プログレスバーを表示（出力先が端末でない場合は自動的に無効）")
+	cmd.Flags().BoolVar(&batchForceProgress, "force-progress", false, "出力先が端末でなくてもプログレスバーを表示")
 	cmd.Flags().BoolVar(&batchDryRun, "dry-run", false, "実際には実行せず、処理内容のみ表示")
+	cmd.Flags().StringVar(&batchOrderTag, "order-tag", "", "指定したタグの整数値の昇順にサービスを1件ずつ処理（--clusterが必要）")
+	cmd.Flags().BoolVar(&batchSummaryJSON, "summary-json", false, "処理結果の統計をJSON形式で標準出力に出力（ログは標準エラー出力に出力）")
+	cmd.Flags().StringVar(&batchMetricsFile, "metrics-file", "", "処理結果の統計をPrometheusのエクスポジション形式で書き出すファイルのパス")
+	cmd.Flags().StringVar(&batchCluster, "cluster", "", "--order-tag 使用時にタグを取得するクラスター名またはクラスターARN")
 
 	return cmd
 }
 
-func runBatch(cmd *cobra.Command, args []string) error {
-	// ロガーの初期化
-	log, err := logger.NewDefaultLogger()
+func runBatch(cmd *cobra.Command, processor batch.Processor, clientFactory aws.ClientFactory) error {
+	// ロガーの初期化（JSON出力時は標準出力を汚さないようログを標準エラー出力に書き出す）
+	loggerConfig := logger.GetDefaultConfig()
+	if batchSummaryJSON {
+		loggerConfig.Output = cmd.ErrOrStderr()
+	}
+	log, err := logger.NewLogger(loggerConfig)
 	if err != nil {
 		return errors.NewGeneralError("ロガーの初期化に失敗しました", err)
 	}
@@ -63,8 +108,8 @@ func runBatch(cmd *cobra.Command, args []string) error {
 
 	// 設定の読み込み
 	var enhancedConfig *config.EnhancedConfig
-	if batchConfigFile != "" {
-		enhancedConfig, err = config.LoadFromFile(batchConfigFile, batchProfile)
+	if len(batchConfigFiles) > 0 {
+		enhancedConfig, err = config.LoadFromFiles(batchConfigFiles, batchProfile)
 		if err != nil {
 			return errors.NewConfigError("設定ファイルの読み込みに失敗しました", err)
 		}
@@ -75,6 +120,7 @@ func runBatch(cmd *cobra.Command, args []string) error {
 	// コマンドライン引数で設定を上書き
 	if cmd.Flags().Changed("concurrency") {
 		enhancedConfig.Batch.MaxConcurrency = batchConcurrency
+		enhancedConfig.Concurrency.Batch = batchConcurrency
 	}
 	if cmd.Flags().Changed("retry-count") {
 		enhancedConfig.Batch.RetryAttempts = batchRetryCount
@@ -93,18 +139,42 @@ func runBatch(cmd *cobra.Command, args []string) error {
 	if err := enhancedConfig.Validate(); err != nil {
 		return errors.NewValidationError("設定の検証に失敗しました", err)
 	}
+	for _, warning := range enhancedConfig.Warnings() {
+		log.Warn(warning)
+	}
 
 	// サービスリストの取得
-	var services []string
-	if len(batchServices) > 0 {
-		services = batchServices
-	} else {
-		// サービスリストが指定されていない場合はエラー
+	services, err := resolveBatchServices(batchServices, cmd.InOrStdin())
+	if err != nil {
+		return errors.NewValidationError("標準入力からのサービス名の読み込みに失敗しました", err)
+	}
+	if len(services) == 0 {
+		// 標準入力から読み込んだがサービス名がなかった場合と、サービスリストが指定されていない場合を区別する
+		if slices.Contains(batchServices, stdinServicesArg) {
+			return errors.NewValidationError("処理対象のサービスが見つかりません（標準入力にサービス名がありません）", nil)
+		}
 		return errors.NewValidationError("処理対象のサービスを指定してください（--servicesフラグ）", nil)
 	}
 
-	if len(services) == 0 {
-		return errors.NewValidationError("処理対象のサービスが見つかりません", nil)
+	// タグで処理順序を指定する場合はサービスのタグを取得して並び替え、順次処理する
+	if batchOrderTag != "" {
+		if batchCluster == "" {
+			return errors.NewValidationError("--order-tag を使用する場合は --cluster を指定してください", nil)
+		}
+
+		ctx := commandContext(cmd)
+		client, err := newECSClient(ctx, cmd, clientFactory, enhancedConfig.Region, enhancedConfig.Profile)
+		if err != nil {
+			return errors.NewAWSError("AWSクライアントの作成に失敗しました", err)
+		}
+		described, err := scanner.NewScanner(client).ScanServices(ctx, []string{arn.ClusterName(batchCluster)})
+		if err != nil {
+			return errors.NewAWSError("サービスのタグ取得に失敗しました", err)
+		}
+		services, err = batch.OrderByTag(services, described, batchOrderTag)
+		if err != nil {
+			return errors.NewValidationError("タグによる処理順序の決定に失敗しました", err)
+		}
 	}
 
 	log.WithFields(map[string]interface{}{
@@ -116,7 +186,11 @@ func runBatch(cmd *cobra.Command, args []string) error {
 	if batchDryRun {
 		fmt.Printf("=== Dry Run モード ===\n")
 		fmt.Printf("処理対象サービス数: %d\n", len(services))
-		fmt.Printf("同時実行数: %d\n", enhancedConfig.Batch.MaxConcurrency)
+		if batchOrderTag != "" {
+			fmt.Printf("処理順序: タグ %s の昇順（順次処理）\n", batchOrderTag)
+		} else {
+			fmt.Printf("同時実行数: %d\n", enhancedConfig.Concurrency.Batch)
+		}
 		fmt.Printf("リトライ回数: %d\n", enhancedConfig.Batch.RetryAttempts)
 		fmt.Printf("リトライ間隔: %v\n", enhancedConfig.Batch.RetryDelay)
 		fmt.Printf("\n処理対象サービス:\n")
@@ -128,21 +202,26 @@ func runBatch(cmd *cobra.Command, args []string) error {
 	}
 
 	// バッチ処理の実行
-	processor := &BatchServiceProcessor{
-		config: enhancedConfig,
-		logger: log,
+	if processor == nil {
+		processor = &BatchServiceProcessor{
+			config: enhancedConfig,
+			logger: log,
+		}
 	}
 
 	batchConfig := &batch.Config{
-		MaxConcurrency: enhancedConfig.Batch.MaxConcurrency,
+		MaxConcurrency: enhancedConfig.Concurrency.Batch,
 		RetryAttempts:  enhancedConfig.Batch.RetryAttempts,
 		RetryDelay:     enhancedConfig.Batch.RetryDelay,
 		ShowProgress:   enhancedConfig.Batch.ShowProgress,
+		ForceProgress:  batchForceProgress,
+		Sequential:     batchOrderTag != "",
 	}
 
-	batchProcessor := batch.NewBatchProcessor(batchConfig, processor)
+	// プログレスバーは結果の出力と混ざらないよう標準エラー出力に表示
+	batchProcessor := batch.NewBatchProcessorWithOutput(batchConfig, processor, cmd.ErrOrStderr())
 
-	ctx := context.Background()
+	ctx := commandContext(cmd)
 	start := time.Now()
 
 	results, err := batchProcessor.ProcessServices(ctx, services)
@@ -155,17 +234,27 @@ func runBatch(cmd *cobra.Command, args []string) error {
 	// 結果の表示
 	stats := batch.CalculateStatistics(results)
 
-	fmt.Printf("\n=== バッチ処理結果 ===\n")
-	fmt.Printf("総処理時間: %v\n", duration)
-	fmt.Printf("総サービス数: %d\n", stats.TotalServices)
-	fmt.Printf("成功: %d\n", stats.SuccessfulCount)
-	fmt.Printf("失敗: %d\n", stats.FailedCount)
-	fmt.Printf("平均処理時間: %v\n", stats.AverageDuration)
-
-	if len(stats.FailedServices) > 0 {
-		fmt.Printf("\n失敗したサービス:\n")
-		for _, service := range stats.FailedServices {
-			fmt.Printf("  - %s\n", service)
+	out := cmd.OutOrStdout()
+	if batchSummaryJSON {
+		data, err := json.MarshalIndent(stats, "", "  ")
+		if err != nil {
+			return errors.NewGeneralError("統計情報のJSON変換に失敗しました", err)
+		}
+		fmt.Fprintln(out, string(data))
+	} else {
+		fmt.Fprintf(out, "\n=== バッチ処理結果 ===\n")
+		fmt.Fprintf(out, "総処理時間: %v\n", duration)
+		fmt.Fprintf(out, "総サービス数: %d\n", stats.TotalServices)
+		fmt.Fprintf(out, "成功: %d\n", stats.SuccessfulCount)
+		fmt.Fprintf(out, "失敗: %d\n", stats.FailedCount)
+		fmt.Fprintf(out, "平均処理時間: %v\n", stats.AverageDuration)
+		fmt.Fprintf(out, "総リトライ回数: %d\n", stats.TotalRetries)
+
+		if len(stats.FailedServices) > 0 {
+			fmt.Fprintf(out, "\n失敗したサービス:\n")
+			for _, service := range stats.FailedServices {
+				fmt.Fprintf(out, "  - %s\n", service)
+			}
 		}
 	}
 
@@ -176,16 +265,60 @@ func runBatch(cmd *cobra.Command, args []string) error {
 		"successful_count": stats.SuccessfulCount,
 		"failed_count":     stats.FailedCount,
 		"average_duration": stats.AverageDuration.String(),
+		"total_retries":    stats.TotalRetries,
 	}).Info("バッチ処理が完了しました")
 
-	// 失敗があった場合は非ゼロ終了コード
+	// 失敗時も監視で検知できるよう、終了コードの判定前にメトリクスを書き出す
+	if batchMetricsFile != "" {
+		if err := os.WriteFile(batchMetricsFile, []byte(batch.FormatPrometheus(stats, duration)), 0644); err != nil {
+			return errors.NewGeneralError("メトリクスファイルの書き込みに失敗しました", err)
+		}
+	}
+
+	// 失敗があった場合は全件失敗か一部失敗かで異なる終了コード（使い方の表示は抑制）
 	if stats.FailedCount > 0 {
-		os.Exit(1)
+		cmd.SilenceUsage = true
+		return errors.NewBatchFailureError(stats.TotalServices, stats.FailedCount)
 	}
 
 	return nil
 }
 
+// stdinServicesArg は --services で標準入力からの読み込みを表す値
+const stdinServicesArg = "-"
+
+// resolveBatchServices は --services の値を展開し、"-" の位置に標準入力から読み込んだサービス名を挿入する
+func resolveBatchServices(values []string, stdin io.Reader) ([]string, error) {
+	var services []string
+	for _, value := range values {
+		if value != stdinServicesArg {
+			services = append(services, value)
+			continue
+		}
+		names, err := readServiceNames(stdin)
+		if err != nil {
+			return nil, err
+		}
+		services = append(services, names...)
+	}
+	return services, nil
+}
+
+// readServiceNames は改行区切りのサービス名を読み込む（前後の空白と空行は無視）
+func readServiceNames(r io.Reader) ([]string, error) {
+	var names []string
+	lineScanner := bufio.NewScanner(r)
+	for lineScanner.Scan() {
+		if name := strings.TrimSpace(lineScanner.Text()); name != "" {
+			names = append(names, name)
+		}
+	}
+	if err := lineScanner.Err(); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
 // BatchServiceProcessor はバッチ処理用のサービスプロセッサ
 type BatchServiceProcessor struct {
 	config *config.EnhancedConfig
@@ -0,0 +1,227 @@
+package cmd_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/dev-shimada/phantom-ecs/cmd"
+	phantomaws "github.com/dev-shimada/phantom-ecs/internal/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchCommand_SummaryJSON(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	batchCmd := cmd.NewBatchCommand()
+	batchCmd.SetOut(&stdout)
+	batchCmd.SetErr(&stderr)
+	batchCmd.SetArgs([]string{"--services", "service1,service2", "--summary-json", "--progress=false"})
+
+	err := batchCmd.Execute()
+	require.NoError(t, err)
+
+	// 標準出力は統計情報のJSONのみで、ログは含まれない
+	var summary map[string]interface{}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &summary))
+	assert.Equal(t, float64(2), summary["total_services"])
+	assert.Equal(t, float64(2), summary["successful_count"])
+	assert.Equal(t, float64(0), summary["failed_count"])
+	assert.Equal(t, float64(0), summary["total_retries"])
+	assert.Equal(t, []interface{}{}, summary["failed_services"])
+
+	assert.Contains(t, stderr.String(), "バッチ処理が完了しました")
+}
+
+func TestBatchCommand_ServicesFromStdin(t *testing.T) {
+	tests := []struct {
+		name      string
+		services  string
+		wantTotal float64
+	}{
+		{
+			name:      "標準入力のみ",
+			services:  "-",
+			wantTotal: 3,
+		},
+		{
+			name:      "--servicesの値と組み合わせ",
+			services:  "service0,-",
+			wantTotal: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			batchCmd := cmd.NewBatchCommand()
+			batchCmd.SetIn(strings.NewReader("service1\n  service2  \n\nservice3\n"))
+			batchCmd.SetOut(&stdout)
+			batchCmd.SetErr(&stderr)
+			batchCmd.SetArgs([]string{"--services", tt.services, "--summary-json", "--progress=false"})
+
+			err := batchCmd.Execute()
+			require.NoError(t, err)
+
+			var summary map[string]interface{}
+			require.NoError(t, json.Unmarshal(stdout.Bytes(), &summary))
+			assert.Equal(t, tt.wantTotal, summary["total_services"])
+			assert.Equal(t, tt.wantTotal, summary["successful_count"])
+			assert.Contains(t, stderr.String(), "service3")
+		})
+	}
+}
+
+func TestBatchCommand_EmptyStdin(t *testing.T) {
+	batchCmd := cmd.NewBatchCommand()
+	batchCmd.SetIn(strings.NewReader("\n\n"))
+	batchCmd.SetOut(&bytes.Buffer{})
+	batchCmd.SetErr(&bytes.Buffer{})
+	batchCmd.SetArgs([]string{"--services", "-", "--summary-json"})
+
+	err := batchCmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "処理対象のサービスが見つかりません")
+}
+
+func TestBatchCommand_NoServices(t *testing.T) {
+	batchCmd := cmd.NewBatchCommand()
+	batchCmd.SetOut(&bytes.Buffer{})
+	batchCmd.SetErr(&bytes.Buffer{})
+	batchCmd.SetArgs([]string{"--summary-json"})
+
+	err := batchCmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "処理対象のサービスを指定してください")
+}
+
+func TestBatchCommand_MetricsFile(t *testing.T) {
+	metricsFile := filepath.Join(t.TempDir(), "metrics.prom")
+
+	batchCmd := cmd.NewBatchCommand()
+	batchCmd.SetOut(&bytes.Buffer{})
+	batchCmd.SetErr(&bytes.Buffer{})
+	batchCmd.SetArgs([]string{"--services", "service1,service2", "--summary-json", "--progress=false", "--metrics-file", metricsFile})
+
+	err := batchCmd.Execute()
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(metricsFile)
+	require.NoError(t, err)
+	metrics := string(content)
+	assert.Contains(t, metrics, "phantom_ecs_batch_services_total 2\n")
+	assert.Contains(t, metrics, "phantom_ecs_batch_success_total 2\n")
+	assert.Contains(t, metrics, "phantom_ecs_batch_failure_total 0\n")
+	assert.Regexp(t, `phantom_ecs_batch_duration_seconds [0-9.e-]+\n`, metrics)
+}
+
+// failingProcessor は指定されたサービスの処理のみ失敗させるテスト用Processor
+type failingProcessor struct {
+	failures map[string]bool
+}
+
+func (p *failingProcessor) Process(ctx context.Context, service string) error {
+	if p.failures[service] {
+		return fmt.Errorf("failed to process %s", service)
+	}
+	return nil
+}
+
+func TestRun_BatchExitCodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures map[string]bool
+		wantCode int
+	}{
+		{name: "全て成功", failures: nil, wantCode: 0},
+		{name: "一部失敗", failures: map[string]bool{"service2": true}, wantCode: 6},
+		{name: "全て失敗", failures: map[string]bool{"service1": true, "service2": true}, wantCode: 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			batchCmd := cmd.NewBatchCommandWithProcessor(&failingProcessor{failures: tt.failures})
+			batchCmd.SetOut(&stdout)
+			batchCmd.SetErr(&stderr)
+			batchCmd.SetArgs([]string{"--services", "service1,service2", "--summary-json", "--progress=false", "--retry-count", "0"})
+
+			code := cmd.Run(batchCmd)
+
+			assert.Equal(t, tt.wantCode, code)
+			var summary map[string]interface{}
+			require.NoError(t, json.Unmarshal(stdout.Bytes(), &summary))
+			assert.Equal(t, float64(len(tt.failures)), summary["failed_count"])
+		})
+	}
+}
+
+// concurrencyCountingProcessor は同時に処理中のサービス数の最大値を記録するテスト用Processor
+type concurrencyCountingProcessor struct {
+	mu            sync.Mutex
+	inFlight      int
+	maxConcurrent int
+}
+
+func (p *concurrencyCountingProcessor) Process(ctx context.Context, service string) error {
+	p.mu.Lock()
+	p.inFlight++
+	if p.inFlight > p.maxConcurrent {
+		p.maxConcurrent = p.inFlight
+	}
+	p.mu.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	p.mu.Lock()
+	p.inFlight--
+	p.mu.Unlock()
+	return nil
+}
+
+func TestBatchCommand_ConcurrencyBatchFromConfig(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "batch.yaml")
+	// concurrency.batchはconcurrency.scan/inspectやbatch.max_concurrencyより優先される
+	yamlContent := "profiles:\n  default:\n    region: us-east-1\nbatch:\n  max_concurrency: 8\n  show_progress: false\nconcurrency:\n  scan: 8\n  inspect: 8\n  batch: 2\n"
+	require.NoError(t, os.WriteFile(configFile, []byte(yamlContent), 0644))
+
+	processor := &concurrencyCountingProcessor{}
+	batchCmd := cmd.NewBatchCommandWithProcessor(processor)
+	batchCmd.SetOut(&bytes.Buffer{})
+	batchCmd.SetErr(&bytes.Buffer{})
+	batchCmd.SetArgs([]string{"--config-file", configFile, "--services", "s1,s2,s3,s4,s5,s6", "--summary-json"})
+
+	require.NoError(t, batchCmd.Execute())
+	assert.Equal(t, 2, processor.maxConcurrent)
+}
+
+func TestBatchCommand_ClientFactory(t *testing.T) {
+	db := fakeService("db", "ACTIVE", "FARGATE", 1, 1)
+	db.Tags = []types.Tag{{Key: aws.String("deploy-order"), Value: aws.String("1")}}
+	api := fakeService("api", "ACTIVE", "FARGATE", 1, 1)
+	api.Tags = []types.Tag{{Key: aws.String("deploy-order"), Value: aws.String("2")}}
+	client := &FakeECSClient{Services: map[string][]types.Service{"prod": {db, api}}}
+	factory := &FakeClientFactory{Clients: map[string]phantomaws.ECSClient{"us-east-1": client}}
+
+	var stdout bytes.Buffer
+	batchCmd := cmd.NewBatchCommandWithClientFactory(factory)
+	batchCmd.SetOut(&stdout)
+	batchCmd.SetErr(&bytes.Buffer{})
+	batchCmd.SetArgs([]string{"--services", "api,db", "--cluster", "prod", "--order-tag", "deploy-order", "--summary-json", "--progress=false"})
+
+	require.NoError(t, batchCmd.Execute())
+
+	var summary map[string]interface{}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &summary))
+	assert.Equal(t, float64(2), summary["total_services"])
+	assert.Positive(t, client.DescribeServicesCalls)
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dev-shimada/phantom-ecs/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// maxJSONIndentSpaces は--json-indentで指定できるスペース数の上限
+const maxJSONIndentSpaces = 8
+
+// addCompactFlag はJSONを1行で出力する--compactフラグと、インデントを指定する--json-indentフラグ、
+// YAMLをフロースタイルで出力する--yaml-flowフラグ、テーブルの値を切り詰めない--wideフラグを追加
+func addCompactFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("compact", false, "JSONを改行なしの1行で出力 (jsonのみ、ログ取り込み向け)")
+	indent := jsonIndentValue(utils.DefaultJSONIndent)
+	cmd.Flags().Var(&indent, "json-indent", "JSONのインデント (スペース数 0-8 または tab、jsonのみ)")
+	cmd.Flags().Bool("yaml-flow", false, "YAMLを[]や{}によるインラインのフロースタイルで出力 (yamlのみ)")
+	cmd.Flags().Bool("wide", false, "値を切り詰めずに出力 (tableのみ)")
+}
+
+// prettyPrint は--compactが指定されていない場合にtrueを返す
+func prettyPrint(cmd *cobra.Command) bool {
+	compact, err := cmd.Flags().GetBool("compact")
+	return err != nil || !compact
+}
+
+// yamlFlow は--yaml-flowが指定された場合にtrueを返す
+func yamlFlow(cmd *cobra.Command) bool {
+	flow, err := cmd.Flags().GetBool("yaml-flow")
+	return err == nil && flow
+}
+
+// wide は--wideが指定された場合にtrueを返す
+func wide(cmd *cobra.Command) bool {
+	enabled, err := cmd.Flags().GetBool("wide")
+	return err == nil && enabled
+}
+
+// jsonIndent は--json-indentで指定されたインデント文字列を返す
+func jsonIndent(cmd *cobra.Command) string {
+	flag := cmd.Flags().Lookup("json-indent")
+	if flag == nil {
+		return utils.DefaultJSONIndent
+	}
+	return string(*flag.Value.(*jsonIndentValue))
+}
+
+// jsonIndentValue はスペース数またはtabで指定されたインデントを文字列として保持するフラグ値
+type jsonIndentValue string
+
+// String はフラグのヘルプ表示用にインデントをスペース数またはtabで返す
+func (v *jsonIndentValue) String() string {
+	if *v == "\t" {
+		return "tab"
+	}
+	return strconv.Itoa(len(*v))
+}
+
+// Set はスペース数またはtabを解析してインデント文字列を設定
+func (v *jsonIndentValue) Set(value string) error {
+	if value == "tab" {
+		*v = "\t"
+		return nil
+	}
+	spaces, err := strconv.Atoi(value)
+	if err != nil || spaces < 0 || spaces > maxJSONIndentSpaces {
+		return fmt.Errorf("must be a number of spaces (0-%d) or tab", maxJSONIndentSpaces)
+	}
+	*v = jsonIndentValue(strings.Repeat(" ", spaces))
+	return nil
+}
+
+// Type はヘルプ表示用のフラグの型名を返す
+func (v *jsonIndentValue) Type() string {
+	return "indent"
+}
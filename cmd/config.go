@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/dev-shimada/phantom-ecs/internal/config"
+	"github.com/dev-shimada/phantom-ecs/internal/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// maskedValue はマスクした設定値の表示
+const maskedValue = "***"
+
+// effectiveConfig はデフォルト値・設定ファイル・環境変数・フラグを適用した結果の設定
+type effectiveConfig struct {
+	ConfigFiles    []string                 `yaml:"config_files"`
+	ConfigProfile  string                   `yaml:"config_profile,omitempty"`
+	Region         string                   `yaml:"region"`
+	AWSProfile     string                   `yaml:"aws_profile"`
+	OutputFormat   string                   `yaml:"output_format"`
+	DefaultCluster string                   `yaml:"default_cluster"`
+	Columns        []string                 `yaml:"columns"`
+	Logging        config.LoggingConfig     `yaml:"logging"`
+	Batch          config.BatchConfig       `yaml:"batch"`
+	Concurrency    config.ConcurrencyConfig `yaml:"concurrency"`
+	AWS            effectiveAWSConfig       `yaml:"aws"`
+}
+
+// effectiveAWSConfig は設定ファイル（--config）とフラグから読み込んだAWS SDKクライアントの設定
+type effectiveAWSConfig struct {
+	HTTP                 effectiveHTTPConfig `yaml:"http"`
+	UseFIPSEndpoint      bool                `yaml:"use_fips_endpoint"`
+	UseDualStackEndpoint bool                `yaml:"use_dualstack_endpoint"`
+}
+
+// effectiveHTTPConfig はAWS SDKのHTTPクライアント設定（0の場合はSDKのデフォルト値）
+type effectiveHTTPConfig struct {
+	MaxConns int    `yaml:"max_conns"`
+	Timeout  string `yaml:"timeout"`
+}
+
+// NewConfigCommand はconfigコマンドを作成
+func NewConfigCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "phantom-ecsの設定を操作",
+		Long:  `phantom-ecsの設定を確認します。`,
+	}
+
+	cmd.AddCommand(NewConfigShowCommand())
+
+	return cmd
+}
+
+// NewConfigShowCommand はconfig showコマンドを作成
+func NewConfigShowCommand() *cobra.Command {
+	var outputFormat string
+	var region string
+	var profile string
+	var configFiles []string
+	var configProfile string
+
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "実際に適用される設定を表示",
+		Long: `デフォルト値、設定ファイル、環境変数、フラグをすべて適用した、
+実際に使用される設定を表示します。
+
+設定は以下の順に上書きされます（後のものが優先）。
+  1. デフォルト値
+  2. 設定ファイル（--config-file、--config-profileで選択したプロファイル）
+  3. 環境変数（PHANTOM_ECS_*）
+  4. フラグ（--region、--profile）
+
+aws（HTTPクライアント設定、FIPS・デュアルスタックエンドポイント）は
+--config で指定した設定ファイル（省略時は $HOME/.phantom-ecs.yaml）と
+--use-fips-endpoint、--use-dualstack-endpoint フラグから読み込んだ値を表示します。
+
+キー名にpassword、secret、tokenなどを含む値は *** でマスクして表示します。`,
+		Example: `  # デフォルト値と環境変数を適用した設定を表示
+  phantom-ecs config show
+
+  # 設定ファイルのプロファイルを適用した設定をJSON形式で表示
+  phantom-ecs config show --config-file phantom-ecs.yaml --config-profile production --output json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigShow(cmd, outputFormat, region, profile, configFiles, configProfile)
+		},
+	}
+
+	// ローカルフラグを定義
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "yaml", "出力形式 (json|yaml)")
+	addCompactFlag(cmd)
+	cmd.Flags().StringVarP(&region, "region", "r", "", "AWSリージョン (設定ファイル・環境変数の値を上書き)")
+	cmd.Flags().StringVarP(&profile, "profile", "p", "", "AWSプロファイル (設定ファイル・環境変数の値を上書き)")
+	addConfigFileFlags(cmd, &configFiles, &configProfile)
+
+	return cmd
+}
+
+// runConfigShow はconfig showコマンドの実行ロジック
+func runConfigShow(cmd *cobra.Command, outputFormat, region, profile string, configFiles []string, configProfile string) error {
+	formatter := utils.NewFormatter()
+	outputFormat = formatter.NormalizeFormat(outputFormat)
+	if outputFormat != "json" && outputFormat != "yaml" {
+		return fmt.Errorf("unsupported output format: %s. Supported formats: [json yaml]", outputFormat)
+	}
+	if len(configFiles) == 0 && cmd.Flags().Changed("config-profile") {
+		return fmt.Errorf("--config-profile requires --config-file")
+	}
+
+	resolved, err := resolveEffectiveConfig(cmd, region, profile, configFiles, configProfile)
+	if err != nil {
+		return err
+	}
+
+	// YAMLのキー名で機密情報をマスクし、JSONでも同じキー名で出力する
+	var node yaml.Node
+	if err := node.Encode(resolved); err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+	maskSensitiveValues(&node, regexp.MustCompile(utils.DefaultSensitiveEnvPattern))
+
+	var data interface{} = &node
+	if outputFormat == "json" {
+		var values map[string]interface{}
+		if err := node.Decode(&values); err != nil {
+			return fmt.Errorf("failed to encode config: %w", err)
+		}
+		data = values
+	}
+
+	output, err := formatter.FormatWithOptions(data, utils.FormatOptions{
+		Format:      outputFormat,
+		PrettyPrint: prettyPrint(cmd),
+		Indent:      jsonIndent(cmd),
+		YAMLFlow:    yamlFlow(cmd),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), output)
+	return nil
+}
+
+// resolveEffectiveConfig はデフォルト値・設定ファイル・環境変数・フラグの順に適用した設定を返す
+func resolveEffectiveConfig(cmd *cobra.Command, region, profile string, configFiles []string, configProfile string) (*effectiveConfig, error) {
+	enhancedConfig := config.GetDefaultEnhancedConfig()
+	if len(configFiles) > 0 {
+		loaded, err := config.LoadFromFiles(configFiles, configProfile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load config file: %w", err)
+		}
+		enhancedConfig = loaded
+	}
+	enhancedConfig.MergeWithEnvironment()
+
+	if cmd.Flags().Changed("region") {
+		enhancedConfig.Region = region
+	}
+	if cmd.Flags().Changed("profile") {
+		enhancedConfig.Profile = profile
+	}
+
+	resolved := &effectiveConfig{
+		ConfigFiles:    configFiles,
+		Region:         enhancedConfig.Region,
+		AWSProfile:     enhancedConfig.Profile,
+		OutputFormat:   enhancedConfig.OutputFormat,
+		DefaultCluster: enhancedConfig.DefaultCluster,
+		Columns:        enhancedConfig.Columns,
+		Logging:        enhancedConfig.Logging,
+		Batch:          enhancedConfig.Batch,
+		Concurrency:    enhancedConfig.Concurrency,
+		AWS:            resolveEffectiveAWSConfig(),
+	}
+	if resolved.ConfigFiles == nil {
+		resolved.ConfigFiles = []string{}
+	}
+	if len(configFiles) > 0 {
+		resolved.ConfigProfile = configProfile
+	}
+	return resolved, nil
+}
+
+// resolveEffectiveAWSConfig はAWSクライアントの作成時と同じくviperから読み込んだAWS SDKクライアントの設定を返す
+func resolveEffectiveAWSConfig() effectiveAWSConfig {
+	httpOptions := httpOptionsFromViper()
+	return effectiveAWSConfig{
+		HTTP: effectiveHTTPConfig{
+			MaxConns: httpOptions.MaxConns,
+			Timeout:  httpOptions.Timeout.String(),
+		},
+		UseFIPSEndpoint:      viper.GetBool("aws.use_fips_endpoint"),
+		UseDualStackEndpoint: viper.GetBool("aws.use_dualstack_endpoint"),
+	}
+}
+
+// maskSensitiveValues はキー名がパターンに一致するマッピングの値を再帰的にマスクする
+func maskSensitiveValues(node *yaml.Node, pattern *regexp.Regexp) {
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if pattern.MatchString(key.Value) && value.Kind == yaml.ScalarNode && value.Value != "" {
+				value.SetString(maskedValue)
+				continue
+			}
+			maskSensitiveValues(value, pattern)
+		}
+		return
+	}
+	for _, child := range node.Content {
+		maskSensitiveValues(child, pattern)
+	}
+}
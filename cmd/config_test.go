@@ -0,0 +1,159 @@
+package cmd_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dev-shimada/phantom-ecs/cmd"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestConfigShowCommand_Defaults(t *testing.T) {
+	var buf bytes.Buffer
+	configCmd := cmd.NewConfigCommand()
+	configCmd.SetOut(&buf)
+	configCmd.SetArgs([]string{"show"})
+
+	require.NoError(t, configCmd.Execute())
+
+	var shown map[string]interface{}
+	require.NoError(t, yaml.Unmarshal(buf.Bytes(), &shown))
+	assert.Equal(t, "us-east-1", shown["region"])
+	assert.Equal(t, "table", shown["output_format"])
+	batch := shown["batch"].(map[string]interface{})
+	assert.Equal(t, 3, batch["retry_attempts"])
+	assert.Equal(t, "2s", batch["retry_delay"])
+	aws := shown["aws"].(map[string]interface{})
+	assert.Equal(t, false, aws["use_fips_endpoint"])
+	assert.Equal(t, false, aws["use_dualstack_endpoint"])
+}
+
+func TestConfigShowCommand_Overrides(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "phantom-ecs.yaml")
+	content := `profiles:
+  production:
+    region: ap-northeast-1
+    aws_profile: prod
+    default_cluster: prod-cluster
+logging:
+  level: info
+batch:
+  max_concurrency: 5
+`
+	require.NoError(t, os.WriteFile(configFile, []byte(content), 0o600))
+	// 環境変数は設定ファイルの値を上書きする
+	t.Setenv("PHANTOM_ECS_LOG_LEVEL", "debug")
+	t.Setenv("PHANTOM_ECS_REGION", "eu-west-1")
+
+	t.Run("環境変数が設定ファイルの値より優先される", func(t *testing.T) {
+		var buf bytes.Buffer
+		configCmd := cmd.NewConfigCommand()
+		configCmd.SetOut(&buf)
+		configCmd.SetArgs([]string{"show", "--config-file", configFile, "--config-profile", "production", "--output", "json"})
+
+		require.NoError(t, configCmd.Execute())
+
+		var shown map[string]interface{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &shown))
+		assert.Equal(t, "production", shown["config_profile"])
+		assert.Equal(t, "eu-west-1", shown["region"])
+		assert.Equal(t, "prod", shown["aws_profile"])
+		assert.Equal(t, "prod-cluster", shown["default_cluster"])
+		assert.Equal(t, "debug", shown["logging"].(map[string]interface{})["level"])
+		assert.Equal(t, float64(5), shown["batch"].(map[string]interface{})["max_concurrency"])
+	})
+
+	t.Run("フラグが環境変数と設定ファイルの値より優先される", func(t *testing.T) {
+		var buf bytes.Buffer
+		configCmd := cmd.NewConfigCommand()
+		configCmd.SetOut(&buf)
+		configCmd.SetArgs([]string{"show", "--config-file", configFile, "--config-profile", "production",
+			"--region", "us-west-2", "--profile", "staging", "--output", "json"})
+
+		require.NoError(t, configCmd.Execute())
+
+		var shown map[string]interface{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &shown))
+		assert.Equal(t, "us-west-2", shown["region"])
+		assert.Equal(t, "staging", shown["aws_profile"])
+	})
+}
+
+func TestConfigShowCommand_AWSSettings(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), ".phantom-ecs.yaml")
+	content := `aws:
+  http:
+    max_conns: 64
+    timeout: 30s
+  use_fips_endpoint: true
+`
+	require.NoError(t, os.WriteFile(configFile, []byte(content), 0o600))
+	// ルートコマンドの--configで読み込んだ設定は後続のテストに影響しないよう破棄する
+	t.Cleanup(viper.Reset)
+
+	var buf bytes.Buffer
+	rootCmd := cmd.NewRootCommand()
+	rootCmd.SetOut(&buf)
+	rootCmd.SetArgs([]string{"--config", configFile, "--use-dualstack-endpoint", "config", "show", "--output", "json"})
+
+	require.NoError(t, rootCmd.Execute())
+
+	var shown map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &shown))
+	aws := shown["aws"].(map[string]interface{})
+	http := aws["http"].(map[string]interface{})
+	assert.Equal(t, float64(64), http["max_conns"])
+	assert.Equal(t, "30s", http["timeout"])
+	assert.Equal(t, true, aws["use_fips_endpoint"])
+	assert.Equal(t, true, aws["use_dualstack_endpoint"])
+}
+
+func TestConfigShowCommand_MergedRootConfigFiles(t *testing.T) {
+	dir := t.TempDir()
+	baseFile := filepath.Join(dir, "base.yaml")
+	overlayFile := filepath.Join(dir, "overlay.yaml")
+	require.NoError(t, os.WriteFile(baseFile, []byte(`aws:
+  http:
+    max_conns: 64
+    timeout: 30s
+  use_fips_endpoint: true
+`), 0o600))
+	// オーバーレイはタイムアウトのみ変更し、それ以外はベースの値を引き継ぐ
+	require.NoError(t, os.WriteFile(overlayFile, []byte(`aws:
+  http:
+    timeout: 5s
+`), 0o600))
+	t.Cleanup(viper.Reset)
+
+	var buf bytes.Buffer
+	rootCmd := cmd.NewRootCommand()
+	rootCmd.SetOut(&buf)
+	rootCmd.SetArgs([]string{"--config", baseFile, "--config", overlayFile, "config", "show", "--output", "json"})
+
+	require.NoError(t, rootCmd.Execute())
+
+	var shown map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &shown))
+	aws := shown["aws"].(map[string]interface{})
+	http := aws["http"].(map[string]interface{})
+	assert.Equal(t, float64(64), http["max_conns"])
+	assert.Equal(t, "5s", http["timeout"])
+	assert.Equal(t, true, aws["use_fips_endpoint"])
+}
+
+func TestConfigShowCommand_UnsupportedFormat(t *testing.T) {
+	configCmd := cmd.NewConfigCommand()
+	configCmd.SetOut(&bytes.Buffer{})
+	configCmd.SetErr(&bytes.Buffer{})
+	configCmd.SetArgs([]string{"show", "--output", "table"})
+
+	err := configCmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported output format: table")
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/dev-shimada/phantom-ecs/internal/prompt"
+	"github.com/spf13/cobra"
+)
+
+// ErrCancelled は実行の確認が拒否された場合に返すエラー
+var ErrCancelled = errors.New("operation cancelled")
+
+// addYesFlag は実行前の確認を省略する--yesフラグを追加
+func addYesFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("yes", false, "実行前の確認を省略 (標準入力が端末でない場合は必須)")
+}
+
+// confirmAction は--yesが指定されていなければ標準入力で実行の確認を求め、承認されなかった場合はエラーを返す
+// プロンプトは出力結果と混ざらないよう標準エラー出力に表示する
+func confirmAction(cmd *cobra.Command, msg string) error {
+	assumeYes, _ := cmd.Flags().GetBool("yes")
+	confirmed, err := prompt.Confirm(cmd.InOrStdin(), cmd.ErrOrStderr(), msg, assumeYes)
+	if err == nil && !confirmed {
+		err = ErrCancelled
+	}
+	if err != nil {
+		cmd.SilenceUsage = true
+	}
+	return err
+}
@@ -0,0 +1,17 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+)
+
+// commandContext はコマンドに渡されたコンテキストを返す
+// ExecuteContextで渡されたキャンセルや期限をAWS呼び出しまで伝播させるため、
+// 各コマンドはcontext.Background()ではなくこの関数でコンテキストを取得する
+func commandContext(cmd *cobra.Command) context.Context {
+	if ctx := cmd.Context(); ctx != nil {
+		return ctx
+	}
+	return context.Background()
+}
@@ -0,0 +1,67 @@
+package cmd_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dev-shimada/phantom-ecs/cmd"
+	phantomaws "github.com/dev-shimada/phantom-ecs/internal/aws"
+	"github.com/dev-shimada/phantom-ecs/internal/inspector"
+	"github.com/dev-shimada/phantom-ecs/internal/scanner"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommands_CancelledContext(t *testing.T) {
+	// RetryingClientはキャンセル済みのコンテキストではECS APIを呼び出さずに終了する
+	client := phantomaws.NewRetryingClient(&FakeECSClient{
+		TaskDefinitionFamilies: map[string][]int{"web-task": {1}},
+	})
+
+	tests := []struct {
+		name    string
+		command *cobra.Command
+		args    []string
+	}{
+		{
+			name:    "taskdefs",
+			command: cmd.NewTaskDefsCommand(scanner.NewScanner(client)),
+			args:    []string{},
+		},
+		{
+			name:    "instances",
+			command: cmd.NewInstancesCommand(scanner.NewScanner(client)),
+			args:    []string{"--cluster", "test-cluster"},
+		},
+		{
+			name:    "diff-clusters",
+			command: cmd.NewDiffClustersCommand(scanner.NewScanner(client)),
+			args:    []string{"--from", "staging", "--to", "prod"},
+		},
+		{
+			name:    "inspect",
+			command: cmd.NewInspectCommand(inspector.NewInspector(client)),
+			args:    []string{"web-service", "--cluster", "test-cluster"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			var buf bytes.Buffer
+			tt.command.SetOut(&buf)
+			tt.command.SetErr(&buf)
+			tt.command.SetArgs(tt.args)
+
+			start := time.Now()
+			err := tt.command.ExecuteContext(ctx)
+
+			assert.ErrorIs(t, err, context.Canceled)
+			assert.Less(t, time.Since(start), time.Second)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/dev-shimada/phantom-ecs/internal/arn"
+	"github.com/dev-shimada/phantom-ecs/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// addConfigFileFlags は設定ファイルを指定する--config-fileと、使用するプロファイルを選択する--config-profileフラグを追加
+func addConfigFileFlags(cmd *cobra.Command, configFiles *[]string, configProfile *string) {
+	cmd.Flags().StringArrayVar(configFiles, "config-file", nil, "設定ファイルのパス（複数指定時は後のファイルを前のファイルに深くマージ）")
+	cmd.Flags().StringVar(configProfile, "config-profile", "default", "使用する設定ファイルのプロファイル (プロファイルのregion、output_format、aws_profileを未指定のフラグに適用)")
+}
+
+// applyConfigProfile は--config-profileで選択したプロファイルのリージョン・出力形式・AWSプロファイルを、
+// コマンドラインで指定されていない--region、--output、--profileフラグに適用する
+// AWSクライアントの作成前に値が反映されるよう、コマンドのPreRunEで呼び出す
+func applyConfigProfile(cmd *cobra.Command, configFiles []string, configProfile string) error {
+	if len(configFiles) == 0 {
+		if cmd.Flags().Changed("config-profile") {
+			return fmt.Errorf("--config-profile requires --config-file")
+		}
+		return nil
+	}
+
+	profile, err := config.LoadProfile(configFiles, configProfile)
+	if err != nil {
+		return fmt.Errorf("failed to load config file: %w", err)
+	}
+
+	settings := []struct {
+		flag  string
+		value string
+	}{
+		{flag: "region", value: profile.Region},
+		{flag: "output", value: profile.OutputFormat},
+		{flag: "profile", value: profile.AWSProfile},
+	}
+	for _, setting := range settings {
+		flag := cmd.Flags().Lookup(setting.flag)
+		if flag == nil || flag.Changed || setting.value == "" {
+			continue
+		}
+		// Changedを立てずに値のみ設定し、サービスARNのリージョン等の推定を引き続き優先できるようにする
+		if err := flag.Value.Set(setting.value); err != nil {
+			return fmt.Errorf("invalid %s in config profile %s: %w", setting.flag, configProfile, err)
+		}
+	}
+	return nil
+}
+
+// resolveCluster はフラグで指定されたクラスター名を返し、未指定の場合は設定ファイルのdefault_clusterを返す
+func resolveCluster(clusterName string, configFiles []string, configProfile string) (string, error) {
+	if clusterName != "" || len(configFiles) == 0 {
+		return clusterName, nil
+	}
+
+	enhancedConfig, err := config.LoadFromFiles(configFiles, configProfile)
+	if err != nil {
+		return "", fmt.Errorf("failed to load config file: %w", err)
+	}
+	return enhancedConfig.DefaultCluster, nil
+}
+
+// resolveServiceARN はサービス名またはサービスARNを受け取り、サービス名とクラスター名を返す
+// サービスARNにクラスター名が含まれる場合は--clusterを省略でき、両方指定されて異なる場合はエラーとする
+func resolveServiceARN(nameOrARN, clusterName string) (string, string, error) {
+	if !arn.IsARN(nameOrARN) {
+		return nameOrARN, clusterName, nil
+	}
+
+	arnCluster, serviceName, err := arn.ParseServiceARN(nameOrARN)
+	if err != nil {
+		return "", "", err
+	}
+	if arnCluster == "" {
+		return serviceName, clusterName, nil
+	}
+	if clusterName != "" && arn.ClusterName(clusterName) != arnCluster {
+		return "", "", fmt.Errorf("--cluster %q does not match the cluster %q in service ARN", clusterName, arnCluster)
+	}
+	return serviceName, arnCluster, nil
+}
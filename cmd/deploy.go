@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+
+	"github.com/dev-shimada/phantom-ecs/internal/arn"
 	"github.com/dev-shimada/phantom-ecs/internal/aws"
 	"github.com/dev-shimada/phantom-ecs/internal/deployer"
-	"github.com/dev-shimada/phantom-ecs/internal/inspector"
+	"github.com/dev-shimada/phantom-ecs/internal/logger"
 	"github.com/dev-shimada/phantom-ecs/internal/models"
 	"github.com/dev-shimada/phantom-ecs/internal/utils"
 	"github.com/spf13/cobra"
@@ -17,15 +20,32 @@ type DeployerInterface interface {
 	DeployService(ctx context.Context, inspectionResult *models.InspectionResult, targetCluster, newServiceName string, dryRun bool) (*models.DeploymentResult, error)
 }
 
+// TaskDefinitionFileDeployerInterface はファイルから読み込んだタスク定義でのデプロイ操作を定義するインターフェース
+type TaskDefinitionFileDeployerInterface interface {
+	DeployServiceWithTaskDefinition(ctx context.Context, inspectionResult *models.InspectionResult, taskDefinition *ecs.RegisterTaskDefinitionInput, targetCluster, newServiceName string, dryRun bool) (*models.DeploymentResult, error)
+}
+
+// TaskDefinitionAnalyzerInterface は指定したタスク定義の分析操作を定義するインターフェース
+type TaskDefinitionAnalyzerInterface interface {
+	AnalyzeTaskDefinition(ctx context.Context, taskDefArn string) (*models.ECSTaskDefinition, error)
+}
+
 // NewDeployCommand はdeployコマンドを作成
 func NewDeployCommand(deployerImpl DeployerInterface, inspectorImpl InspectorInterface) *cobra.Command {
-	var fromCluster string
-	var targetCluster string
-	var newServiceName string
-	var dryRun bool
-	var outputFormat string
-	var region string
-	var profile string
+	return newDeployCommand(deployerImpl, inspectorImpl, aws.NewClientFactory())
+}
+
+// NewDeployCommandWithClientFactory はリージョンごとのAWSクライアントを指定したファクトリで作成するdeployコマンドを作成
+func NewDeployCommandWithClientFactory(clientFactory aws.ClientFactory) *cobra.Command {
+	return newDeployCommand(nil, nil, clientFactory)
+}
+
+// newDeployCommand はdeployコマンドを作成（DeployerとInspectorがnilの場合はclientFactoryのクライアントを使用）
+func newDeployCommand(deployerImpl DeployerInterface, inspectorImpl InspectorInterface, clientFactory aws.ClientFactory) *cobra.Command {
+	var options deployOptions
+	var configFiles []string
+	var configProfile string
+	var assignPublicIP bool
 
 	cmd := &cobra.Command{
 		Use:   "deploy <service-name>",
@@ -34,36 +54,85 @@ func NewDeployCommand(deployerImpl DeployerInterface, inspectorImpl InspectorInt
 
 元のサービスを詳細調査し、その設定を基に新しいクラスターに
 同じ構成のサービスを作成します。dry-runモードで事前に
-実行内容を確認することができます。`,
+実行内容を確認することができます。
+
+デプロイ前に確認を求めます。CIなど標準入力が端末でない環境では
+--yes を指定してください。`,
 		Example: `  # ドライランでデプロイ内容を確認
   phantom-ecs deploy my-service --from-cluster source-cluster --target-cluster target-cluster --dry-run
 
   # 実際にサービスをデプロイ
   phantom-ecs deploy my-service --from-cluster prod-cluster --target-cluster staging-cluster
 
+  # 確認を省略してデプロイ（CI向け）
+  phantom-ecs deploy my-service --from-cluster prod-cluster --target-cluster staging-cluster --yes
+
   # 新しいサービス名を指定してデプロイ
   phantom-ecs deploy my-service --from-cluster prod-cluster --target-cluster dev-cluster --new-service-name dev-my-service
 
   # 特定のリージョンとプロファイルを使用
-  phantom-ecs deploy my-service --from-cluster source --target-cluster target --region us-west-2 --profile production`,
+  phantom-ecs deploy my-service --from-cluster source --target-cluster target --region us-west-2 --profile production
+
+  # 別リージョンのクラスターに複製
+  phantom-ecs deploy my-service --from-cluster prod-cluster --target-cluster dr-cluster --region us-east-1 --target-region us-west-2
+
+  # 設定ファイルのdefault_clusterをコピー元として使用
+  phantom-ecs deploy my-service --target-cluster target --config-file phantom-ecs.yaml
+
+  # 過去のタスク定義リビジョンを複製してデプロイ
+  phantom-ecs deploy my-service --from-cluster prod-cluster --target-cluster staging-cluster --source-revision 3
+
+  # タスク定義のCPU・メモリを上書きしてデプロイ
+  phantom-ecs deploy my-service --from-cluster prod-cluster --target-cluster staging-cluster --cpu "0.5 vCPU" --memory 1GB
+
+  # 別のVPCのサブネットとセキュリティグループを指定してデプロイ
+  phantom-ecs deploy my-service --from-cluster prod-cluster --target-cluster other-vpc-cluster --subnet subnet-aaa --subnet subnet-bbb --security-group sg-ccc
+
+  # パブリックIPの割り当てをコピー元の設定から変更してデプロイ（awsvpcのみ）
+  phantom-ecs deploy my-service --from-cluster prod-cluster --target-cluster public-cluster --assign-public-ip=true
+
+  # 複製の代わりにファイルのタスク定義を登録してデプロイ
+  phantom-ecs deploy my-service --from-cluster prod-cluster --target-cluster staging-cluster --taskdef-file taskdef.json`,
 		Args: cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return applyConfigProfile(cmd, configFiles, configProfile)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			serviceName := args[0]
-			return runDeploy(cmd, deployerImpl, inspectorImpl, serviceName, fromCluster, targetCluster, newServiceName, dryRun, outputFormat, region, profile)
+			options.serviceName = args[0]
+			fromCluster, err := resolveCluster(options.fromCluster, configFiles, configProfile)
+			if err != nil {
+				return err
+			}
+			options.fromCluster = fromCluster
+			// 未指定の場合はコピー元のネットワーク設定を引き継ぐ
+			if cmd.Flags().Changed("assign-public-ip") {
+				options.assignPublicIP = &assignPublicIP
+			}
+			return runDeploy(cmd, deployerImpl, inspectorImpl, clientFactory, options)
 		},
 	}
 
 	// ローカルフラグを定義
-	cmd.Flags().StringVar(&fromCluster, "from-cluster", "", "コピー元のクラスター名 (必須)")
-	cmd.Flags().StringVar(&targetCluster, "target-cluster", "", "デプロイ先のクラスター名 (必須)")
-	cmd.Flags().StringVar(&newServiceName, "new-service-name", "", "新しいサービス名 (未指定時は元のサービス名を使用)")
-	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "実際には実行せずに処理内容を表示")
-	cmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "出力形式 (json|yaml|table)")
-	cmd.Flags().StringVarP(&region, "region", "r", "us-east-1", "AWSリージョン")
-	cmd.Flags().StringVarP(&profile, "profile", "p", "", "AWSプロファイル")
+	cmd.Flags().StringVar(&options.fromCluster, "from-cluster", "", "コピー元のクラスター名またはクラスターARN (省略時は設定ファイルのdefault_cluster)")
+	cmd.Flags().StringVar(&options.targetCluster, "target-cluster", "", "デプロイ先のクラスター名 (必須)")
+	cmd.Flags().StringVar(&options.newServiceName, "new-service-name", "", "新しいサービス名 (未指定時は元のサービス名を使用)")
+	cmd.Flags().BoolVar(&options.dryRun, "dry-run", false, "実際には実行せずに処理内容を表示")
+	addYesFlag(cmd)
+	cmd.Flags().StringVarP(&options.outputFormat, "output", "o", "table", "出力形式 (json|yaml|table)")
+	addCompactFlag(cmd)
+	cmd.Flags().StringVarP(&options.region, "region", "r", "us-east-1", "AWSリージョン（コピー元）")
+	cmd.Flags().StringVar(&options.targetRegion, "target-region", "", "デプロイ先のAWSリージョン (未指定時は--regionと同じ)")
+	cmd.Flags().StringVarP(&options.profile, "profile", "p", "", "AWSプロファイル")
+	cmd.Flags().IntVar(&options.sourceRevision, "source-revision", 0, "複製するタスク定義のリビジョン (未指定時はサービスに設定中のリビジョン)")
+	cmd.Flags().StringVar(&options.cpu, "cpu", "", "タスク定義のCPUを上書き (例: 256, \"0.25 vCPU\")")
+	cmd.Flags().StringVar(&options.memory, "memory", "", "タスク定義のメモリを上書き (例: 512, 0.5GB)")
+	cmd.Flags().StringSliceVar(&options.subnets, "subnet", nil, "コピー元のサブネットを置き換えるサブネットID (繰り返し指定可、--security-groupと併用、awsvpcのみ)")
+	cmd.Flags().StringSliceVar(&options.securityGroups, "security-group", nil, "コピー元のセキュリティグループを置き換えるセキュリティグループID (繰り返し指定可、--subnetと併用、awsvpcのみ)")
+	cmd.Flags().BoolVar(&assignPublicIP, "assign-public-ip", false, "パブリックIPの割り当てをコピー元の設定から上書き (awsvpcネットワークモードのみ)")
+	cmd.Flags().StringVar(&options.taskDefFile, "taskdef-file", "", "複製の代わりに登録するタスク定義のJSONファイル (register-task-definitionの--cli-input-json形式)")
+	addConfigFileFlags(cmd, &configFiles, &configProfile)
 
 	// 必須フラグを設定
-	cmd.MarkFlagRequired("from-cluster")
 	cmd.MarkFlagRequired("target-cluster")
 
 	return cmd
@@ -74,71 +143,290 @@ func NewDeployCommandWithDefaults() *cobra.Command {
 	return NewDeployCommand(nil, nil) // 実際の実装では適切なDeployerとInspectorを渡す
 }
 
+// deployOptions はdeployコマンドのフラグで指定するデプロイの設定
+type deployOptions struct {
+	serviceName    string
+	fromCluster    string
+	targetCluster  string
+	newServiceName string
+	dryRun         bool
+	outputFormat   string
+	region         string
+	targetRegion   string
+	profile        string
+	sourceRevision int
+	cpu            string
+	memory         string
+	subnets        []string
+	securityGroups []string
+	// assignPublicIP はnilの場合にコピー元のネットワーク設定を引き継ぐ
+	assignPublicIP *bool
+	taskDefFile    string
+}
+
 // runDeploy はdeployコマンドの実行ロジック
-func runDeploy(cmd *cobra.Command, deployerImpl DeployerInterface, inspectorImpl InspectorInterface, serviceName, fromCluster, targetCluster, newServiceName string, dryRun bool, outputFormat, region, profile string) error {
-	ctx := context.Background()
+func runDeploy(cmd *cobra.Command, deployerImpl DeployerInterface, inspectorImpl InspectorInterface, clientFactory aws.ClientFactory, options deployOptions) error {
+	ctx := commandContext(cmd)
 
 	// 必須パラメータの検証
-	if serviceName == "" {
+	if options.serviceName == "" {
 		return fmt.Errorf("service name is required")
 	}
-	if fromCluster == "" {
+	if options.fromCluster == "" {
 		return fmt.Errorf("from-cluster is required")
 	}
-	if targetCluster == "" {
+	if options.targetCluster == "" {
 		return fmt.Errorf("target-cluster is required")
 	}
+	if options.sourceRevision < 0 {
+		return fmt.Errorf("source-revision must be a positive integer: %d", options.sourceRevision)
+	}
+	// サブネットとセキュリティグループはVPCごとに対応するため、片方だけの置き換えは認めない
+	if (len(options.subnets) > 0) != (len(options.securityGroups) > 0) {
+		return fmt.Errorf("--subnet and --security-group must be specified together")
+	}
+	// CPU・メモリの上書き値をECSが要求する数値文字列に正規化
+	var err error
+	options.cpu, options.memory, err = deployer.NormalizeResources(options.cpu, options.memory)
+	if err != nil {
+		return err
+	}
+	// タスク定義ファイルはAWSを呼び出す前に読み込んで検証する（複製元の指定や上書きとは併用できない）
+	var taskDefinition *ecs.RegisterTaskDefinitionInput
+	if options.taskDefFile != "" {
+		if options.sourceRevision > 0 || options.cpu != "" || options.memory != "" {
+			return fmt.Errorf("--taskdef-file cannot be combined with --source-revision, --cpu or --memory")
+		}
+		taskDefinition, err = deployer.LoadTaskDefinitionFile(options.taskDefFile)
+		if err != nil {
+			return err
+		}
+	}
+	// クラスターARNが指定された場合はクラスター名に正規化
+	options.fromCluster = arn.ClusterName(options.fromCluster)
+	options.targetCluster = arn.ClusterName(options.targetCluster)
 
 	// 新しいサービス名のデフォルト設定
-	if newServiceName == "" {
-		newServiceName = serviceName
+	if options.newServiceName == "" {
+		options.newServiceName = options.serviceName
 	}
 
 	// 出力形式の検証
 	formatter := utils.NewFormatter()
-	if !formatter.ValidateFormat(outputFormat) {
+	if !formatter.ValidateFormat(options.outputFormat) {
 		return fmt.Errorf("unsupported output format: %s. Supported formats: %v",
-			outputFormat, formatter.GetSupportedFormats())
+			options.outputFormat, formatter.GetSupportedFormats())
 	}
+	options.outputFormat = formatter.NormalizeFormat(options.outputFormat)
+	// deployはファイル・S3出力に対応していないため標準出力のみ
+	sink := newOutputSink(cmd.OutOrStdout(), outputFileOptions{}, outputS3Options{})
 
 	// DeployerとInspectorがnilの場合（実際のAWS呼び出し用）は、AWS実装を作成
 	var deployerToUse DeployerInterface
 	var inspectorToUse InspectorInterface
 
+	// デプロイ先リージョンのデフォルト設定
+	if options.targetRegion == "" {
+		options.targetRegion = options.region
+	}
+
 	if deployerImpl != nil && inspectorImpl != nil {
 		deployerToUse = deployerImpl
 		inspectorToUse = inspectorImpl
 	} else {
-		// 実際のAWS呼び出し用の実装
-		awsClient, err := aws.NewClient(ctx, region, profile)
+		// 実際のAWS呼び出し用の実装（ファクトリのクライアントは一時的な障害による失敗を再試行する）
+		// 調査はコピー元リージョン、タスク定義の登録とサービス作成はデプロイ先リージョンのクライアントで行う
+		sourceClient, err := newECSClient(ctx, cmd, clientFactory, options.region, options.profile)
 		if err != nil {
 			return fmt.Errorf("failed to create AWS client: %w", err)
 		}
-		deployerToUse = deployer.NewDeployer(awsClient)
-		inspectorToUse = inspector.NewInspector(awsClient)
+		targetClient := sourceClient
+		if options.targetRegion != options.region {
+			targetClient, err = newECSClient(ctx, cmd, clientFactory, options.targetRegion, options.profile)
+			if err != nil {
+				return fmt.Errorf("failed to create AWS client for target region %s: %w", options.targetRegion, err)
+			}
+		}
+		// 監査ログはデプロイ結果の出力と混ざらないよう標準エラー出力にJSON形式で書き出す
+		auditLogger, err := logger.NewLogger(&logger.Config{Level: "info", Format: "json", Output: cmd.ErrOrStderr()})
+		if err != nil {
+			return fmt.Errorf("failed to create logger: %w", err)
+		}
+		deployerToUse = deployer.NewDeployerWithLogger(targetClient, auditLogger)
+		inspectorToUse = newInspector(sourceClient)
 	}
 
-	// ソースサービスの詳細調査を実行
-	inspectionResult, err := inspectorToUse.InspectService(ctx, serviceName, fromCluster)
+	// ソースサービスの詳細調査を実行（端末ではJSON出力時を除きスピナーを表示）
+	showSpinner := options.outputFormat != "json"
+	spinner := utils.NewSpinner(cmd.ErrOrStderr(), "Inspecting service...", showSpinner)
+	spinner.Start()
+	inspectionResult, err := inspectorToUse.InspectService(ctx, options.serviceName, options.fromCluster)
+	spinner.Stop()
 	if err != nil {
 		return fmt.Errorf("failed to inspect source service: %w", err)
 	}
 
+	// リビジョンが指定された場合は同じファミリーの指定リビジョンを複製元とする
+	if options.sourceRevision > 0 {
+		inspectionResult, err = withSourceRevision(ctx, inspectorToUse, inspectionResult, options.sourceRevision)
+		if err != nil {
+			return err
+		}
+	}
+
+	// タスク定義ファイルが指定された場合はネットワーク設定の検証をファイルのタスク定義で行う
+	if taskDefinition != nil {
+		inspectionResult = withTaskDefinitionFile(inspectionResult, taskDefinition)
+	}
+
+	// CPU・メモリが指定された場合はタスク定義を上書き
+	if options.cpu != "" || options.memory != "" {
+		inspectionResult = withResourceOverrides(inspectionResult, options.cpu, options.memory)
+	}
+
+	// サブネット・セキュリティグループが指定された場合はコピー元のネットワーク設定を置き換え
+	networkOverridden := len(options.subnets) > 0
+	if networkOverridden {
+		inspectionResult, err = withNetworkOverrides(inspectionResult, options.subnets, options.securityGroups)
+		if err != nil {
+			return err
+		}
+	}
+
+	// パブリックIPの割り当てが指定された場合はネットワーク設定を上書き
+	if options.assignPublicIP != nil {
+		inspectionResult, err = withAssignPublicIP(inspectionResult, *options.assignPublicIP)
+		if err != nil {
+			return err
+		}
+	}
+
+	// サブネットやセキュリティグループはリージョン固有のため、別リージョンへの複製時は警告する
+	if network := inspectionResult.NetworkConfig; options.targetRegion != options.region && !networkOverridden && network != nil && (len(network.Subnets) > 0 || len(network.SecurityGroups) > 0) {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Warning: network configuration (subnets, security groups) is copied from %s and may not exist in %s\n", options.region, options.targetRegion)
+	}
+
+	// 実際にデプロイする場合は実行前に確認（--yesで省略）
+	if !options.dryRun {
+		if err := confirmAction(cmd, fmt.Sprintf("Deploy service %s to cluster %s?", options.newServiceName, options.targetCluster)); err != nil {
+			return err
+		}
+	}
+
 	// サービスのデプロイを実行
-	deploymentResult, err := deployerToUse.DeployService(ctx, inspectionResult, targetCluster, newServiceName, dryRun)
+	spinner = utils.NewSpinner(cmd.ErrOrStderr(), "Deploying service...", showSpinner)
+	spinner.Start()
+	deploymentResult, err := deployWithTaskDefinition(ctx, deployerToUse, inspectionResult, taskDefinition, options.targetCluster, options.newServiceName, options.dryRun)
+	spinner.Stop()
 	if err != nil {
 		return fmt.Errorf("failed to deploy service: %w", err)
 	}
 
 	// 結果をフォーマットして出力
 	output, err := formatter.FormatWithOptions(*deploymentResult, utils.FormatOptions{
-		Format:      outputFormat,
-		PrettyPrint: true,
+		Format:      options.outputFormat,
+		PrettyPrint: prettyPrint(cmd),
+		Indent:      jsonIndent(cmd),
+		YAMLFlow:    yamlFlow(cmd),
+		Wide:        wide(cmd),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to format output: %w", err)
 	}
 
-	fmt.Print(output)
+	fmt.Fprint(sink, output)
+	for _, warning := range deploymentResult.Warnings {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %s\n", warning)
+	}
 	return nil
 }
+
+// deployWithTaskDefinition はタスク定義ファイルが指定された場合はその定義を登録し、それ以外はソースのタスク定義を複製してデプロイする
+func deployWithTaskDefinition(ctx context.Context, deployerToUse DeployerInterface, inspectionResult *models.InspectionResult, taskDefinition *ecs.RegisterTaskDefinitionInput, targetCluster, newServiceName string, dryRun bool) (*models.DeploymentResult, error) {
+	if taskDefinition == nil {
+		return deployerToUse.DeployService(ctx, inspectionResult, targetCluster, newServiceName, dryRun)
+	}
+	fileDeployer, ok := deployerToUse.(TaskDefinitionFileDeployerInterface)
+	if !ok {
+		return nil, fmt.Errorf("deployer does not support deploying with a task definition file")
+	}
+	return fileDeployer.DeployServiceWithTaskDefinition(ctx, inspectionResult, taskDefinition, targetCluster, newServiceName, dryRun)
+}
+
+// withTaskDefinitionFile はインスペクション結果のタスク定義をファイルから読み込んだタスク定義に差し替える
+func withTaskDefinitionFile(inspectionResult *models.InspectionResult, taskDefinition *ecs.RegisterTaskDefinitionInput) *models.InspectionResult {
+	result := *inspectionResult
+	result.TaskDefinition = deployer.TaskDefinitionSummary(taskDefinition)
+	return &result
+}
+
+// withSourceRevision はインスペクション結果のタスク定義を指定リビジョンのものに差し替える
+func withSourceRevision(ctx context.Context, inspectorToUse InspectorInterface, inspectionResult *models.InspectionResult, revision int) (*models.InspectionResult, error) {
+	analyzer, ok := inspectorToUse.(TaskDefinitionAnalyzerInterface)
+	if !ok {
+		return nil, fmt.Errorf("inspector does not support analyzing a specific task definition revision")
+	}
+
+	family := inspectionResult.TaskDefinition.Family
+	taskDefinition := fmt.Sprintf("%s:%d", family, revision)
+	taskDef, err := analyzer.AnalyzeTaskDefinition(ctx, taskDefinition)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find task definition revision %s: %w", taskDefinition, err)
+	}
+	if taskDef.Family != family || taskDef.Revision != revision {
+		return nil, fmt.Errorf("task definition revision %s does not exist", taskDefinition)
+	}
+
+	result := *inspectionResult
+	result.TaskDefinition = *taskDef
+	return &result, nil
+}
+
+// withResourceOverrides はインスペクション結果のタスク定義のCPU・メモリを上書きした複製を返す
+func withResourceOverrides(inspectionResult *models.InspectionResult, cpu, memory string) *models.InspectionResult {
+	result := *inspectionResult
+	if cpu != "" {
+		result.TaskDefinition.CPU = cpu
+	}
+	if memory != "" {
+		result.TaskDefinition.Memory = memory
+	}
+	return &result
+}
+
+// withNetworkOverrides はインスペクション結果のサブネットとセキュリティグループを置き換えた複製を返す
+// パブリックIPの割り当てはコピー元の設定を引き継ぐ
+func withNetworkOverrides(inspectionResult *models.InspectionResult, subnets, securityGroups []string) (*models.InspectionResult, error) {
+	networkMode := inspectionResult.TaskDefinition.NetworkMode
+	if networkMode != "awsvpc" {
+		return nil, fmt.Errorf("--subnet and --security-group are only supported for awsvpc network mode (task definition uses %q)", networkMode)
+	}
+
+	result := *inspectionResult
+	network := models.NetworkConfig{}
+	if inspectionResult.NetworkConfig != nil {
+		network.AssignPublicIP = inspectionResult.NetworkConfig.AssignPublicIP
+	}
+	network.Subnets = append([]string{}, subnets...)
+	network.SecurityGroups = append([]string{}, securityGroups...)
+	result.NetworkConfig = &network
+	return &result, nil
+}
+
+// withAssignPublicIP はインスペクション結果のネットワーク設定のパブリックIP割り当てを上書きした複製を返す
+// パブリックIPの割り当てはawsvpcネットワークモードでのみ指定できる
+func withAssignPublicIP(inspectionResult *models.InspectionResult, assignPublicIP bool) (*models.InspectionResult, error) {
+	networkMode := inspectionResult.TaskDefinition.NetworkMode
+	if networkMode != "awsvpc" {
+		return nil, fmt.Errorf("--assign-public-ip is only supported for awsvpc network mode (task definition uses %q)", networkMode)
+	}
+	if inspectionResult.NetworkConfig == nil {
+		return nil, fmt.Errorf("--assign-public-ip requires the source service to have a network configuration")
+	}
+
+	result := *inspectionResult
+	network := *inspectionResult.NetworkConfig
+	network.AssignPublicIP = assignPublicIP
+	result.NetworkConfig = &network
+	return &result, nil
+}
@@ -1,13 +1,21 @@
 package cmd_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
 	"github.com/dev-shimada/phantom-ecs/cmd"
+	phantomaws "github.com/dev-shimada/phantom-ecs/internal/aws"
 	"github.com/dev-shimada/phantom-ecs/internal/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockDeployer はDeployerのモック
@@ -30,6 +38,14 @@ func (m *MockInspectorForDeploy) InspectService(ctx context.Context, serviceName
 	return args.Get(0).(*models.InspectionResult), args.Error(1)
 }
 
+func (m *MockInspectorForDeploy) AnalyzeTaskDefinition(ctx context.Context, taskDefArn string) (*models.ECSTaskDefinition, error) {
+	args := m.Called(ctx, taskDefArn)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ECSTaskDefinition), args.Error(1)
+}
+
 func TestDeployCommand(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -73,7 +89,7 @@ func TestDeployCommand(t *testing.T) {
 		},
 		{
 			name:          "実際のデプロイ",
-			args:          []string{"deploy", "prod-service", "--from-cluster", "prod-cluster", "--target-cluster", "staging-cluster", "--new-service-name", "staging-prod-service"},
+			args:          []string{"deploy", "prod-service", "--from-cluster", "prod-cluster", "--target-cluster", "staging-cluster", "--new-service-name", "staging-prod-service", "--yes"},
 			expectedError: false,
 			setupMocks: func(mockDeployer *MockDeployer, mockInspector *MockInspectorForDeploy) {
 				inspectionResult := &models.InspectionResult{
@@ -164,6 +180,7 @@ func TestDeployCommandFlags(t *testing.T) {
 	assert.NotNil(t, cmd.Flags().Lookup("new-service-name"))
 	assert.NotNil(t, cmd.Flags().Lookup("dry-run"))
 	assert.NotNil(t, cmd.Flags().Lookup("region"))
+	assert.NotNil(t, cmd.Flags().Lookup("target-region"))
 	assert.NotNil(t, cmd.Flags().Lookup("profile"))
 	assert.NotNil(t, cmd.Flags().Lookup("output"))
 }
@@ -179,3 +196,500 @@ func TestDeployCommandHelp(t *testing.T) {
 	assert.NotEmpty(t, cmd.Long)
 	assert.NotEmpty(t, cmd.Example)
 }
+
+func TestDeployCommand_ClusterARNNormalization(t *testing.T) {
+	tests := []struct {
+		name          string
+		fromCluster   string
+		targetCluster string
+	}{
+		{name: "クラスター名", fromCluster: "prod", targetCluster: "staging"},
+		{
+			name:          "クラスターARN",
+			fromCluster:   "arn:aws:ecs:us-east-1:123456789012:cluster/prod",
+			targetCluster: "arn:aws:ecs:us-east-1:123456789012:cluster/staging",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inspectionResult := &models.InspectionResult{
+				Service: models.ECSService{ServiceName: "web-service", ClusterName: "prod"},
+			}
+
+			mockDeployer := &MockDeployer{}
+			mockInspector := &MockInspectorForDeploy{}
+			mockInspector.On("InspectService", mock.Anything, "web-service", "prod").Return(inspectionResult, nil)
+			mockDeployer.On("DeployService", mock.Anything, inspectionResult, "staging", "web-service", true).Return(&models.DeploymentResult{
+				ServiceName: "web-service",
+				ClusterName: "staging",
+				Success:     true,
+				DryRun:      true,
+			}, nil)
+
+			cmd := cmd.NewDeployCommand(mockDeployer, mockInspector)
+			cmd.SetArgs([]string{"web-service", "--from-cluster", tt.fromCluster, "--target-cluster", tt.targetCluster, "--dry-run", "--output", "json"})
+
+			err := cmd.Execute()
+			assert.NoError(t, err)
+			mockInspector.AssertExpectations(t)
+			mockDeployer.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDeployCommand_DefaultClusterFromConfig(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "phantom-ecs.yaml")
+	yamlContent := "profiles:\n  production:\n    region: us-east-1\n    default_cluster: config-cluster\n"
+	require.NoError(t, os.WriteFile(configFile, []byte(yamlContent), 0644))
+
+	inspectionResult := &models.InspectionResult{
+		Service: models.ECSService{ServiceName: "web-service", ClusterName: "config-cluster"},
+	}
+
+	mockDeployer := &MockDeployer{}
+	mockInspector := &MockInspectorForDeploy{}
+	mockInspector.On("InspectService", mock.Anything, "web-service", "config-cluster").Return(inspectionResult, nil)
+	mockDeployer.On("DeployService", mock.Anything, inspectionResult, "staging", "web-service", true).Return(&models.DeploymentResult{
+		ServiceName: "web-service",
+		ClusterName: "staging",
+		Success:     true,
+		DryRun:      true,
+	}, nil)
+
+	cmd := cmd.NewDeployCommand(mockDeployer, mockInspector)
+	cmd.SetArgs([]string{"web-service", "--target-cluster", "staging", "--config-file", configFile, "--config-profile", "production", "--dry-run", "--output", "json"})
+
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	mockInspector.AssertExpectations(t)
+	mockDeployer.AssertExpectations(t)
+}
+
+func TestDeployCommand_SourceRevision(t *testing.T) {
+	liveResult := &models.InspectionResult{
+		Service: models.ECSService{
+			ServiceName:    "web-service",
+			ClusterName:    "prod",
+			Status:         "ACTIVE",
+			TaskDefinition: "arn:aws:ecs:us-east-1:123456789012:task-definition/web-task:5",
+		},
+		TaskDefinition: models.ECSTaskDefinition{
+			Family:   "web-task",
+			Revision: 5,
+			Status:   "ACTIVE",
+			CPU:      "1024",
+		},
+	}
+
+	t.Run("指定リビジョンを分析して複製する", func(t *testing.T) {
+		mockDeployer := &MockDeployer{}
+		mockInspector := &MockInspectorForDeploy{}
+		mockInspector.On("InspectService", mock.Anything, "web-service", "prod").Return(liveResult, nil)
+		mockInspector.On("AnalyzeTaskDefinition", mock.Anything, "web-task:3").Return(&models.ECSTaskDefinition{
+			TaskDefinitionArn: "arn:aws:ecs:us-east-1:123456789012:task-definition/web-task:3",
+			Family:            "web-task",
+			Revision:          3,
+			Status:            "ACTIVE",
+			CPU:               "256",
+		}, nil)
+		mockDeployer.On("DeployService", mock.Anything, mock.MatchedBy(func(result *models.InspectionResult) bool {
+			return result.TaskDefinition.Revision == 3 && result.TaskDefinition.CPU == "256" && result.Service.ServiceName == "web-service"
+		}), "staging", "web-service", true).Return(&models.DeploymentResult{ServiceName: "web-service", ClusterName: "staging", Success: true, DryRun: true}, nil)
+
+		deployCmd := cmd.NewDeployCommand(mockDeployer, mockInspector)
+		deployCmd.SetArgs([]string{"web-service", "--from-cluster", "prod", "--target-cluster", "staging", "--source-revision", "3", "--dry-run"})
+
+		err := deployCmd.Execute()
+		require.NoError(t, err)
+
+		mockDeployer.AssertExpectations(t)
+		mockInspector.AssertExpectations(t)
+		// 元のインスペクション結果は変更されない
+		assert.Equal(t, 5, liveResult.TaskDefinition.Revision)
+	})
+
+	t.Run("存在しないリビジョンはエラー", func(t *testing.T) {
+		mockDeployer := &MockDeployer{}
+		mockInspector := &MockInspectorForDeploy{}
+		mockInspector.On("InspectService", mock.Anything, "web-service", "prod").Return(liveResult, nil)
+		mockInspector.On("AnalyzeTaskDefinition", mock.Anything, "web-task:99").Return(nil, fmt.Errorf("ClientException: Unable to describe task definition."))
+
+		deployCmd := cmd.NewDeployCommand(mockDeployer, mockInspector)
+		deployCmd.SetArgs([]string{"web-service", "--from-cluster", "prod", "--target-cluster", "staging", "--source-revision", "99"})
+
+		err := deployCmd.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "web-task:99")
+
+		mockDeployer.AssertNotCalled(t, "DeployService", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockInspector.AssertExpectations(t)
+	})
+
+	t.Run("負のリビジョンはエラー", func(t *testing.T) {
+		deployCmd := cmd.NewDeployCommand(&MockDeployer{}, &MockInspectorForDeploy{})
+		deployCmd.SetArgs([]string{"web-service", "--from-cluster", "prod", "--target-cluster", "staging", "--source-revision", "-1"})
+
+		err := deployCmd.Execute()
+		assert.Error(t, err)
+	})
+}
+
+func TestDeployCommand_ResourceOverrides(t *testing.T) {
+	liveResult := &models.InspectionResult{
+		Service: models.ECSService{ServiceName: "web-service", ClusterName: "prod", Status: "ACTIVE"},
+		TaskDefinition: models.ECSTaskDefinition{
+			Family: "web-task",
+			CPU:    "1024",
+			Memory: "2048",
+		},
+	}
+
+	t.Run("人間向けの単位を正規化して上書きする", func(t *testing.T) {
+		mockDeployer := &MockDeployer{}
+		mockInspector := &MockInspectorForDeploy{}
+		mockInspector.On("InspectService", mock.Anything, "web-service", "prod").Return(liveResult, nil)
+		mockDeployer.On("DeployService", mock.Anything, mock.MatchedBy(func(result *models.InspectionResult) bool {
+			return result.TaskDefinition.CPU == "256" && result.TaskDefinition.Memory == "1024"
+		}), "staging", "web-service", true).Return(&models.DeploymentResult{ServiceName: "web-service", ClusterName: "staging", Success: true, DryRun: true}, nil)
+
+		deployCmd := cmd.NewDeployCommand(mockDeployer, mockInspector)
+		deployCmd.SetArgs([]string{"web-service", "--from-cluster", "prod", "--target-cluster", "staging", "--cpu", "0.25 vCPU", "--memory", "1GB", "--dry-run"})
+
+		err := deployCmd.Execute()
+		require.NoError(t, err)
+
+		mockDeployer.AssertExpectations(t)
+		// 元のインスペクション結果は変更されない
+		assert.Equal(t, "1024", liveResult.TaskDefinition.CPU)
+	})
+
+	t.Run("不正な単位はエラー", func(t *testing.T) {
+		mockDeployer := &MockDeployer{}
+		mockInspector := &MockInspectorForDeploy{}
+
+		deployCmd := cmd.NewDeployCommand(mockDeployer, mockInspector)
+		deployCmd.SetArgs([]string{"web-service", "--from-cluster", "prod", "--target-cluster", "staging", "--memory", "lots"})
+
+		err := deployCmd.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid memory "lots"`)
+		mockInspector.AssertNotCalled(t, "InspectService", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestDeployCommand_TargetRegion(t *testing.T) {
+	family := "web-task"
+	taskDefArn := "arn:aws:ecs:us-east-1:123456789012:task-definition/web-task:3"
+	sourceService := fakeService("web-service", "ACTIVE", "FARGATE", 2, 2)
+	sourceService.TaskDefinition = &taskDefArn
+
+	sourceClient := &FakeECSClient{
+		Services: map[string][]types.Service{"prod": {sourceService}},
+		TaskDefinitions: map[string]*types.TaskDefinition{
+			taskDefArn: {TaskDefinitionArn: &taskDefArn, Family: &family, Revision: 3, Status: types.TaskDefinitionStatusActive},
+		},
+	}
+	targetClient := &FakeECSClient{}
+	factory := &FakeClientFactory{Clients: map[string]phantomaws.ECSClient{
+		"us-east-1": sourceClient,
+		"us-west-2": targetClient,
+	}}
+
+	deployCmd := cmd.NewDeployCommandWithClientFactory(factory)
+	deployCmd.SetOut(&bytes.Buffer{})
+	deployCmd.SetErr(&bytes.Buffer{})
+	deployCmd.SetArgs([]string{"web-service", "--from-cluster", "prod", "--target-cluster", "dr",
+		"--region", "us-east-1", "--target-region", "us-west-2", "--output", "json", "--yes"})
+
+	err := deployCmd.Execute()
+	require.NoError(t, err)
+
+	// 調査はコピー元、登録と作成はデプロイ先のクライアントで行われる
+	assert.Equal(t, 1, sourceClient.DescribeServicesCalls)
+	assert.Empty(t, sourceClient.RegisteredTaskDefinitions)
+	assert.Empty(t, sourceClient.CreatedServices)
+	require.Len(t, targetClient.RegisteredTaskDefinitions, 1)
+	assert.Equal(t, "web-task-copy", *targetClient.RegisteredTaskDefinitions[0].Family)
+	require.Len(t, targetClient.CreatedServices, 1)
+	assert.Equal(t, "dr", *targetClient.CreatedServices[0].Cluster)
+}
+
+func TestDeployCommand_SameRegionByDefault(t *testing.T) {
+	family := "web-task"
+	taskDefArn := "arn:aws:ecs:us-east-1:123456789012:task-definition/web-task:3"
+	sourceService := fakeService("web-service", "ACTIVE", "FARGATE", 1, 1)
+	sourceService.TaskDefinition = &taskDefArn
+
+	client := &FakeECSClient{
+		Services: map[string][]types.Service{"prod": {sourceService}},
+		TaskDefinitions: map[string]*types.TaskDefinition{
+			taskDefArn: {TaskDefinitionArn: &taskDefArn, Family: &family, Revision: 3, Status: types.TaskDefinitionStatusActive},
+		},
+	}
+	factory := &FakeClientFactory{Clients: map[string]phantomaws.ECSClient{"us-east-1": client}}
+
+	var stderr bytes.Buffer
+	deployCmd := cmd.NewDeployCommandWithClientFactory(factory)
+	deployCmd.SetOut(&bytes.Buffer{})
+	deployCmd.SetErr(&stderr)
+	deployCmd.SetArgs([]string{"web-service", "--from-cluster", "prod", "--target-cluster", "staging", "--output", "json", "--yes"})
+
+	err := deployCmd.Execute()
+	require.NoError(t, err)
+
+	assert.Len(t, client.RegisteredTaskDefinitions, 1)
+	assert.Len(t, client.CreatedServices, 1)
+	// 監査ログはコマンドの標準エラー出力に書き出される
+	assert.Contains(t, stderr.String(), `"audit":true`)
+}
+
+func TestDeployCommand_ConfirmationDeclined(t *testing.T) {
+	family := "web-task"
+	taskDefArn := "arn:aws:ecs:us-east-1:123456789012:task-definition/web-task:3"
+	sourceService := fakeService("web-service", "ACTIVE", "FARGATE", 1, 1)
+	sourceService.TaskDefinition = &taskDefArn
+
+	client := &FakeECSClient{
+		Services: map[string][]types.Service{"prod": {sourceService}},
+		TaskDefinitions: map[string]*types.TaskDefinition{
+			taskDefArn: {TaskDefinitionArn: &taskDefArn, Family: &family, Revision: 3, Status: types.TaskDefinitionStatusActive},
+		},
+	}
+	factory := &FakeClientFactory{Clients: map[string]phantomaws.ECSClient{"us-east-1": client}}
+
+	var stderr bytes.Buffer
+	deployCmd := cmd.NewDeployCommandWithClientFactory(factory)
+	deployCmd.SetIn(bytes.NewBufferString("n\n"))
+	deployCmd.SetOut(&bytes.Buffer{})
+	deployCmd.SetErr(&stderr)
+	deployCmd.SetArgs([]string{"web-service", "--from-cluster", "prod", "--target-cluster", "staging"})
+
+	err := deployCmd.Execute()
+	assert.ErrorIs(t, err, cmd.ErrCancelled)
+	assert.Contains(t, stderr.String(), "Deploy service web-service to cluster staging? [y/N]: ")
+
+	// 拒否した場合はタスク定義もサービスも作成しない
+	assert.Empty(t, client.RegisteredTaskDefinitions)
+	assert.Empty(t, client.CreatedServices)
+}
+
+func TestDeployCommand_WritesToCommandOutput(t *testing.T) {
+	family := "web-task"
+	taskDefArn := "arn:aws:ecs:us-east-1:123456789012:task-definition/web-task:3"
+	sourceService := fakeService("web-service", "ACTIVE", "FARGATE", 1, 1)
+	sourceService.TaskDefinition = &taskDefArn
+
+	client := &FakeECSClient{
+		Services: map[string][]types.Service{"prod": {sourceService}},
+		TaskDefinitions: map[string]*types.TaskDefinition{
+			taskDefArn: {TaskDefinitionArn: &taskDefArn, Family: &family, Revision: 3, Status: types.TaskDefinitionStatusActive},
+		},
+	}
+	factory := &FakeClientFactory{Clients: map[string]phantomaws.ECSClient{"us-east-1": client}}
+
+	var buf bytes.Buffer
+	deployCmd := cmd.NewDeployCommandWithClientFactory(factory)
+	deployCmd.SetOut(&buf)
+	deployCmd.SetErr(&bytes.Buffer{})
+	deployCmd.SetArgs([]string{"web-service", "--from-cluster", "prod", "--target-cluster", "staging", "--dry-run", "--output", "json"})
+
+	require.NoError(t, deployCmd.Execute())
+
+	// 結果は標準出力ではなくコマンドに設定された出力先に書き込まれる
+	var result models.DeploymentResult
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+	assert.Equal(t, "web-service", result.ServiceName)
+	assert.Equal(t, "staging", result.ClusterName)
+	assert.True(t, result.DryRun)
+}
+
+// newNetworkDeployClient はネットワーク設定を持つコピー元サービスのテスト用クライアントを作成
+func newNetworkDeployClient(networkMode types.NetworkMode) *FakeECSClient {
+	family := "web-task"
+	taskDefArn := "arn:aws:ecs:us-east-1:123456789012:task-definition/web-task:3"
+	service := fakeService("web-service", "ACTIVE", "FARGATE", 1, 1)
+	service.TaskDefinition = &taskDefArn
+	service.NetworkConfiguration = &types.NetworkConfiguration{
+		AwsvpcConfiguration: &types.AwsVpcConfiguration{
+			Subnets:        []string{"subnet-private"},
+			SecurityGroups: []string{"sg-web"},
+			AssignPublicIp: types.AssignPublicIpDisabled,
+		},
+	}
+	return &FakeECSClient{
+		Services: map[string][]types.Service{"prod": {service}},
+		TaskDefinitions: map[string]*types.TaskDefinition{
+			taskDefArn: {TaskDefinitionArn: &taskDefArn, Family: &family, Revision: 3, Status: types.TaskDefinitionStatusActive, NetworkMode: networkMode},
+		},
+	}
+}
+
+// executeNetworkDeploy はprodのweb-serviceをpublicクラスターへデプロイするコマンドを実行
+func executeNetworkDeploy(client *FakeECSClient, extraArgs ...string) error {
+	factory := &FakeClientFactory{Clients: map[string]phantomaws.ECSClient{"us-east-1": client}}
+	deployCmd := cmd.NewDeployCommandWithClientFactory(factory)
+	deployCmd.SetOut(&bytes.Buffer{})
+	deployCmd.SetErr(&bytes.Buffer{})
+	deployCmd.SetArgs(append([]string{"web-service", "--from-cluster", "prod", "--target-cluster", "public", "--output", "json", "--yes"}, extraArgs...))
+	return deployCmd.Execute()
+}
+
+func TestDeployCommand_AssignPublicIP(t *testing.T) {
+	t.Run("指定した値でコピー元の設定を上書き", func(t *testing.T) {
+		client := newNetworkDeployClient(types.NetworkModeAwsvpc)
+		require.NoError(t, executeNetworkDeploy(client, "--assign-public-ip=true"))
+
+		require.Len(t, client.CreatedServices, 1)
+		vpcConfig := client.CreatedServices[0].NetworkConfiguration.AwsvpcConfiguration
+		assert.Equal(t, types.AssignPublicIpEnabled, vpcConfig.AssignPublicIp)
+		assert.Equal(t, []string{"subnet-private"}, vpcConfig.Subnets)
+	})
+
+	t.Run("未指定の場合はコピー元の設定を引き継ぐ", func(t *testing.T) {
+		client := newNetworkDeployClient(types.NetworkModeAwsvpc)
+		require.NoError(t, executeNetworkDeploy(client))
+
+		require.Len(t, client.CreatedServices, 1)
+		assert.Equal(t, types.AssignPublicIpDisabled, client.CreatedServices[0].NetworkConfiguration.AwsvpcConfiguration.AssignPublicIp)
+	})
+
+	t.Run("bridgeモードでは拒否", func(t *testing.T) {
+		client := newNetworkDeployClient(types.NetworkModeBridge)
+		err := executeNetworkDeploy(client, "--assign-public-ip=false")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "only supported for awsvpc network mode")
+		assert.Empty(t, client.RegisteredTaskDefinitions)
+		assert.Empty(t, client.CreatedServices)
+	})
+}
+
+func TestDeployCommand_NetworkOverrides(t *testing.T) {
+	t.Run("コピー元のサブネットとセキュリティグループを置き換え", func(t *testing.T) {
+		client := newNetworkDeployClient(types.NetworkModeAwsvpc)
+		require.NoError(t, executeNetworkDeploy(client,
+			"--subnet", "subnet-a", "--subnet", "subnet-b", "--security-group", "sg-other"))
+
+		require.Len(t, client.CreatedServices, 1)
+		vpcConfig := client.CreatedServices[0].NetworkConfiguration.AwsvpcConfiguration
+		assert.Equal(t, []string{"subnet-a", "subnet-b"}, vpcConfig.Subnets)
+		assert.Equal(t, []string{"sg-other"}, vpcConfig.SecurityGroups)
+		assert.Equal(t, types.AssignPublicIpDisabled, vpcConfig.AssignPublicIp)
+	})
+
+	t.Run("パブリックIPの上書きと併用", func(t *testing.T) {
+		client := newNetworkDeployClient(types.NetworkModeAwsvpc)
+		require.NoError(t, executeNetworkDeploy(client,
+			"--subnet", "subnet-public", "--security-group", "sg-public", "--assign-public-ip=true"))
+
+		require.Len(t, client.CreatedServices, 1)
+		vpcConfig := client.CreatedServices[0].NetworkConfiguration.AwsvpcConfiguration
+		assert.Equal(t, []string{"subnet-public"}, vpcConfig.Subnets)
+		assert.Equal(t, types.AssignPublicIpEnabled, vpcConfig.AssignPublicIp)
+	})
+
+	t.Run("片方のみの指定は拒否", func(t *testing.T) {
+		tests := [][]string{
+			{"--subnet", "subnet-a"},
+			{"--security-group", "sg-other"},
+		}
+		for _, args := range tests {
+			client := newNetworkDeployClient(types.NetworkModeAwsvpc)
+			err := executeNetworkDeploy(client, args...)
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "--subnet and --security-group must be specified together")
+			assert.Equal(t, 0, client.DescribeServicesCalls)
+			assert.Empty(t, client.CreatedServices)
+		}
+	})
+
+	t.Run("bridgeモードでは拒否", func(t *testing.T) {
+		client := newNetworkDeployClient(types.NetworkModeBridge)
+		err := executeNetworkDeploy(client, "--subnet", "subnet-a", "--security-group", "sg-other")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "only supported for awsvpc network mode")
+		assert.Empty(t, client.CreatedServices)
+	})
+}
+
+func TestDeployCommand_TaskDefinitionFile(t *testing.T) {
+	newClient := func() *FakeECSClient {
+		family := "web-task"
+		taskDefArn := "arn:aws:ecs:us-east-1:123456789012:task-definition/web-task:3"
+		sourceService := fakeService("web-service", "ACTIVE", "FARGATE", 2, 2)
+		sourceService.TaskDefinition = &taskDefArn
+		return &FakeECSClient{
+			Services: map[string][]types.Service{"prod": {sourceService}},
+			TaskDefinitions: map[string]*types.TaskDefinition{
+				taskDefArn: {TaskDefinitionArn: &taskDefArn, Family: &family, Revision: 3, Status: types.TaskDefinitionStatusActive},
+			},
+		}
+	}
+	writeTaskDefFile := func(t *testing.T, content string) string {
+		path := filepath.Join(t.TempDir(), "taskdef.json")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+	execute := func(client *FakeECSClient, args ...string) error {
+		factory := &FakeClientFactory{Clients: map[string]phantomaws.ECSClient{"us-east-1": client}}
+		deployCmd := cmd.NewDeployCommandWithClientFactory(factory)
+		deployCmd.SetOut(&bytes.Buffer{})
+		deployCmd.SetErr(&bytes.Buffer{})
+		deployCmd.SetArgs(append([]string{"web-service", "--from-cluster", "prod", "--target-cluster", "staging", "--output", "json", "--yes"}, args...))
+		return deployCmd.Execute()
+	}
+
+	t.Run("ファイルのタスク定義を登録してサービスを作成", func(t *testing.T) {
+		client := newClient()
+		path := writeTaskDefFile(t, `{
+  "family": "web-from-file",
+  "requiresCompatibilities": ["FARGATE"],
+  "cpu": "512",
+  "memory": "1024",
+  "containerDefinitions": [
+    {"name": "app", "image": "nginx:1.27"},
+    {"name": "sidecar", "image": "envoy:v1.30"}
+  ]
+}`)
+
+		require.NoError(t, execute(client, "--taskdef-file", path))
+
+		// ソースのタスク定義は複製されず、ファイルの定義のみが登録される
+		require.Len(t, client.RegisteredTaskDefinitions, 1)
+		registered := client.RegisteredTaskDefinitions[0]
+		assert.Equal(t, "web-from-file", *registered.Family)
+		assert.Equal(t, "512", *registered.Cpu)
+		require.Len(t, registered.ContainerDefinitions, 2)
+		assert.Equal(t, "app", *registered.ContainerDefinitions[0].Name)
+		assert.Equal(t, "nginx:1.27", *registered.ContainerDefinitions[0].Image)
+		assert.Equal(t, "sidecar", *registered.ContainerDefinitions[1].Name)
+
+		require.Len(t, client.CreatedServices, 1)
+		assert.Equal(t, "arn:aws:ecs:us-east-1:123456789012:task-definition/web-from-file:1", *client.CreatedServices[0].TaskDefinition)
+		assert.Equal(t, int32(2), *client.CreatedServices[0].DesiredCount)
+	})
+
+	t.Run("不正なファイルはAWSを呼び出す前にエラー", func(t *testing.T) {
+		client := newClient()
+		path := writeTaskDefFile(t, `{"family": "web-from-file", "containerDefinitions": [{"name": "app"}]}`)
+
+		err := execute(client, "--taskdef-file", path)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "image is required")
+		assert.Equal(t, 0, client.DescribeServicesCalls)
+		assert.Empty(t, client.RegisteredTaskDefinitions)
+	})
+
+	t.Run("複製元の指定や上書きとは併用できない", func(t *testing.T) {
+		client := newClient()
+		path := writeTaskDefFile(t, `{"family": "web-from-file", "containerDefinitions": [{"name": "app", "image": "nginx"}]}`)
+
+		err := execute(client, "--taskdef-file", path, "--cpu", "256")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--taskdef-file cannot be combined")
+		assert.Equal(t, 0, client.DescribeServicesCalls)
+	})
+}
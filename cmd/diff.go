@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dev-shimada/phantom-ecs/internal/arn"
+	"github.com/dev-shimada/phantom-ecs/internal/aws"
+	"github.com/dev-shimada/phantom-ecs/internal/differ"
+	"github.com/dev-shimada/phantom-ecs/internal/inspector"
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+	"github.com/dev-shimada/phantom-ecs/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// ErrDriftDetected は稼働中のタスク定義とファイルの間に差分がある場合に返すエラー
+var ErrDriftDetected = errors.New("drift detected")
+
+// NewDiffCommand はdiffコマンドを作成
+func NewDiffCommand(inspectorImpl InspectorInterface) *cobra.Command {
+	return newDiffCommand(inspectorImpl, aws.NewClientFactory())
+}
+
+// NewDiffCommandWithClientFactory はAWSクライアントを指定したファクトリで作成するdiffコマンドを作成
+func NewDiffCommandWithClientFactory(clientFactory aws.ClientFactory) *cobra.Command {
+	return newDiffCommand(nil, clientFactory)
+}
+
+// newDiffCommand はdiffコマンドを作成（Inspectorがnilの場合はclientFactoryのクライアントを使用）
+func newDiffCommand(inspectorImpl InspectorInterface, clientFactory aws.ClientFactory) *cobra.Command {
+	var clusterName string
+	var againstFile string
+	var outputFormat string
+	var region string
+	var profile string
+	var configFiles []string
+	var configProfile string
+
+	cmd := &cobra.Command{
+		Use:   "diff <service-name>",
+		Short: "稼働中のタスク定義とタスク定義ファイルの差分を表示",
+		Long: `稼働中のECSサービスのタスク定義と、リポジトリで管理している
+タスク定義ファイルを比較して差分を表示します。
+
+差分が存在する場合は0以外の終了コードで終了するため、
+CIでのドリフト検出に利用できます。`,
+		Example: `  # タスク定義ファイルとの差分を表示
+  phantom-ecs diff my-service --cluster my-cluster --against taskdef.json
+
+  # JSON形式で出力
+  phantom-ecs diff my-service --cluster my-cluster --against taskdef.json --output json`,
+		Args: cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return applyConfigProfile(cmd, configFiles, configProfile)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			serviceName := args[0]
+			return runDiff(cmd, inspectorImpl, clientFactory, serviceName, clusterName, againstFile, outputFormat, region, profile)
+		},
+	}
+
+	// ローカルフラグを定義
+	cmd.Flags().StringVarP(&clusterName, "cluster", "c", "", "クラスター名またはクラスターARN (必須)")
+	cmd.Flags().StringVar(&againstFile, "against", "", "比較対象のタスク定義ファイル (JSON) (必須)")
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "出力形式 (json|yaml|table)")
+	addCompactFlag(cmd)
+	cmd.Flags().StringVarP(&region, "region", "r", "us-east-1", "AWSリージョン")
+	cmd.Flags().StringVarP(&profile, "profile", "p", "", "AWSプロファイル")
+	addConfigFileFlags(cmd, &configFiles, &configProfile)
+
+	// 必須フラグを設定
+	cmd.MarkFlagRequired("cluster")
+	cmd.MarkFlagRequired("against")
+
+	return cmd
+}
+
+// NewDiffCommandWithDefaults はデフォルトのInspectorでdiffコマンドを作成
+func NewDiffCommandWithDefaults() *cobra.Command {
+	return NewDiffCommand(nil)
+}
+
+// runDiff はdiffコマンドの実行ロジック
+func runDiff(cmd *cobra.Command, inspectorImpl InspectorInterface, clientFactory aws.ClientFactory, serviceName, clusterName, againstFile, outputFormat, region, profile string) error {
+	ctx := commandContext(cmd)
+
+	// 必須パラメータの検証
+	if clusterName == "" {
+		return fmt.Errorf("cluster name is required")
+	}
+	clusterName = arn.ClusterName(clusterName)
+
+	// 出力形式の検証
+	formatter := utils.NewFormatter()
+	if !formatter.ValidateFormat(outputFormat) {
+		return fmt.Errorf("unsupported output format: %s. Supported formats: %v",
+			outputFormat, formatter.GetSupportedFormats())
+	}
+	outputFormat = formatter.NormalizeFormat(outputFormat)
+
+	// 比較対象のタスク定義ファイルを読み込み
+	expected, err := inspector.LoadTaskDefinitionFile(againstFile)
+	if err != nil {
+		return err
+	}
+
+	// Inspectorがnilの場合（実際のAWS呼び出し用）は、AWS Inspectorを作成
+	var inspectorToUse InspectorInterface
+	if inspectorImpl != nil {
+		inspectorToUse = inspectorImpl
+	} else {
+		client, err := newECSClient(ctx, cmd, clientFactory, region, profile)
+		if err != nil {
+			return fmt.Errorf("failed to create AWS client: %w", err)
+		}
+		inspectorToUse = newInspector(client)
+	}
+
+	// 稼働中のタスク定義を取得
+	result, err := inspectorToUse.InspectService(ctx, serviceName, clusterName)
+	if err != nil {
+		return fmt.Errorf("failed to inspect service: %w", err)
+	}
+
+	differences, err := differ.NewDiffer().CompareTaskDefinitions(result.TaskDefinition, *expected)
+	if err != nil {
+		return fmt.Errorf("failed to compare task definitions: %w", err)
+	}
+
+	diff := models.TaskDefinitionDiff{
+		ServiceName: serviceName,
+		ClusterName: clusterName,
+		Family:      result.TaskDefinition.Family,
+		Differences: differences,
+	}
+
+	// 結果をフォーマットして出力
+	output, err := formatter.FormatWithOptions(diff, utils.FormatOptions{
+		Format:      outputFormat,
+		PrettyPrint: prettyPrint(cmd),
+		Indent:      jsonIndent(cmd),
+		YAMLFlow:    yamlFlow(cmd),
+		Wide:        wide(cmd),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), output)
+
+	// 差分がある場合はCIで検出できるようエラーを返す（使い方の表示は抑制）
+	if diff.HasDifferences() {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%w: %d difference(s) in task definition %s", ErrDriftDetected, len(differences), diff.Family)
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/dev-shimada/phantom-ecs/internal/arn"
+	"github.com/dev-shimada/phantom-ecs/internal/aws"
+	"github.com/dev-shimada/phantom-ecs/internal/differ"
+	"github.com/dev-shimada/phantom-ecs/internal/scanner"
+	"github.com/dev-shimada/phantom-ecs/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// NewDiffClustersCommand はdiff-clustersコマンドを作成
+func NewDiffClustersCommand(scannerImpl ScannerInterface) *cobra.Command {
+	return newDiffClustersCommand(scannerImpl, aws.NewClientFactory())
+}
+
+// NewDiffClustersCommandWithClientFactory はAWSクライアントを指定したファクトリで作成するdiff-clustersコマンドを作成
+func NewDiffClustersCommandWithClientFactory(clientFactory aws.ClientFactory) *cobra.Command {
+	return newDiffClustersCommand(nil, clientFactory)
+}
+
+// newDiffClustersCommand はdiff-clustersコマンドを作成（Scannerがnilの場合はclientFactoryのクライアントを使用）
+func newDiffClustersCommand(scannerImpl ScannerInterface, clientFactory aws.ClientFactory) *cobra.Command {
+	var fromCluster string
+	var toCluster string
+	var outputFormat string
+	var region string
+	var profile string
+	var configFiles []string
+	var configProfile string
+
+	cmd := &cobra.Command{
+		Use:   "diff-clusters",
+		Short: "2つのクラスターのサービス構成を比較",
+		Long: `2つのクラスターのサービス一覧を比較します。
+
+比較先にのみ存在するサービス (added)、比較元にのみ存在するサービス (removed)、
+両方に存在するサービス (common) を表示し、共通のサービスについては
+ヘルス状態の違いも表示します。INACTIVE状態のサービスは比較対象外です。`,
+		Example: `  # 移行元と移行先のクラスターを比較
+  phantom-ecs diff-clusters --from old-cluster --to new-cluster
+
+  # JSON形式で出力
+  phantom-ecs diff-clusters --from old-cluster --to new-cluster --output json`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return applyConfigProfile(cmd, configFiles, configProfile)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDiffClusters(cmd, scannerImpl, clientFactory, fromCluster, toCluster, outputFormat, region, profile)
+		},
+	}
+
+	// ローカルフラグを定義
+	cmd.Flags().StringVar(&fromCluster, "from", "", "比較元のクラスター名またはクラスターARN (必須)")
+	cmd.Flags().StringVar(&toCluster, "to", "", "比較先のクラスター名またはクラスターARN (必須)")
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "出力形式 (json|yaml|table)")
+	addCompactFlag(cmd)
+	cmd.Flags().StringVarP(&region, "region", "r", "us-east-1", "AWSリージョン")
+	cmd.Flags().StringVarP(&profile, "profile", "p", "", "AWSプロファイル")
+	addConfigFileFlags(cmd, &configFiles, &configProfile)
+
+	// 必須フラグを設定
+	cmd.MarkFlagRequired("from")
+	cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+// NewDiffClustersCommandWithDefaults はデフォルトのScannerでdiff-clustersコマンドを作成
+func NewDiffClustersCommandWithDefaults() *cobra.Command {
+	return NewDiffClustersCommand(nil)
+}
+
+// runDiffClusters はdiff-clustersコマンドの実行ロジック
+func runDiffClusters(cmd *cobra.Command, scannerImpl ScannerInterface, clientFactory aws.ClientFactory, fromCluster, toCluster, outputFormat, region, profile string) error {
+	ctx := commandContext(cmd)
+
+	// 必須パラメータの検証
+	if fromCluster == "" {
+		return fmt.Errorf("from cluster is required")
+	}
+	if toCluster == "" {
+		return fmt.Errorf("to cluster is required")
+	}
+	// クラスターARNが指定された場合はクラスター名に正規化
+	fromCluster = arn.ClusterName(fromCluster)
+	toCluster = arn.ClusterName(toCluster)
+
+	// 出力形式の検証
+	formatter := utils.NewFormatter()
+	if !formatter.ValidateFormat(outputFormat) {
+		return fmt.Errorf("unsupported output format: %s. Supported formats: %v",
+			outputFormat, formatter.GetSupportedFormats())
+	}
+	outputFormat = formatter.NormalizeFormat(outputFormat)
+
+	// Scannerがnilの場合（実際のAWS呼び出し用）は、AWS Scannerを作成
+	var scannerToUse ScannerInterface
+	if scannerImpl != nil {
+		scannerToUse = scannerImpl
+	} else {
+		client, err := newECSClient(ctx, cmd, clientFactory, region, profile)
+		if err != nil {
+			return fmt.Errorf("failed to create AWS client: %w", err)
+		}
+		scannerToUse = scanner.NewScanner(client)
+	}
+
+	fromServices, err := scannerToUse.ScanServices(ctx, []string{fromCluster})
+	if err != nil {
+		return fmt.Errorf("failed to scan cluster %s: %w", fromCluster, err)
+	}
+	toServices, err := scannerToUse.ScanServices(ctx, []string{toCluster})
+	if err != nil {
+		return fmt.Errorf("failed to scan cluster %s: %w", toCluster, err)
+	}
+
+	diff := differ.CompareClusterServices(fromCluster, toCluster,
+		scanner.ExcludeInactive(fromServices), scanner.ExcludeInactive(toServices))
+
+	// 結果をフォーマットして出力
+	output, err := formatter.FormatWithOptions(diff, utils.FormatOptions{
+		Format:      outputFormat,
+		PrettyPrint: prettyPrint(cmd),
+		Indent:      jsonIndent(cmd),
+		YAMLFlow:    yamlFlow(cmd),
+		Wide:        wide(cmd),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), output)
+	return nil
+}
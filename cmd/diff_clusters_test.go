@@ -0,0 +1,73 @@
+package cmd_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/dev-shimada/phantom-ecs/cmd"
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffClustersCommand(t *testing.T) {
+	newMockScanner := func() *MockScanner {
+		mockScanner := &MockScanner{}
+		mockScanner.On("ScanServices", mock.Anything, []string{"old"}).Return([]models.ECSService{
+			{ServiceName: "web", ClusterName: "old", Status: "ACTIVE", DesiredCount: 1, RunningCount: 1},
+			{ServiceName: "legacy", ClusterName: "old", Status: "ACTIVE", DesiredCount: 1, RunningCount: 1},
+			{ServiceName: "deleted", ClusterName: "old", Status: "INACTIVE"},
+		}, nil)
+		mockScanner.On("ScanServices", mock.Anything, []string{"new"}).Return([]models.ECSService{
+			{ServiceName: "web", ClusterName: "new", Status: "ACTIVE", DesiredCount: 1, RunningCount: 0},
+			{ServiceName: "worker", ClusterName: "new", Status: "ACTIVE", DesiredCount: 1, RunningCount: 1},
+		}, nil)
+		return mockScanner
+	}
+
+	t.Run("JSON出力で3分類を返す", func(t *testing.T) {
+		mockScanner := newMockScanner()
+
+		var buf bytes.Buffer
+		cmd := cmd.NewDiffClustersCommand(mockScanner)
+		cmd.SetOut(&buf)
+		cmd.SetArgs([]string{"--from", "old", "--to", "arn:aws:ecs:us-east-1:123456789012:cluster/new", "--output", "json"})
+
+		require.NoError(t, cmd.Execute())
+
+		var diff models.ClusterServicesDiff
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &diff))
+		assert.Equal(t, []string{"worker"}, diff.Added)
+		assert.Equal(t, []string{"legacy"}, diff.Removed)
+		require.Len(t, diff.Common, 1)
+		assert.Equal(t, "web", diff.Common[0].ServiceName)
+		assert.True(t, diff.Common[0].HealthDiffers)
+		mockScanner.AssertExpectations(t)
+	})
+
+	t.Run("テーブル出力", func(t *testing.T) {
+		var buf bytes.Buffer
+		cmd := cmd.NewDiffClustersCommand(newMockScanner())
+		cmd.SetOut(&buf)
+		cmd.SetArgs([]string{"--from", "old", "--to", "new"})
+
+		require.NoError(t, cmd.Execute())
+		assert.Contains(t, buf.String(), "=== ADDED (1) ===")
+		assert.Contains(t, buf.String(), "+ worker")
+		assert.Contains(t, buf.String(), "- legacy")
+		assert.Contains(t, buf.String(), "DIFFERS")
+		assert.NotContains(t, buf.String(), "deleted")
+	})
+}
+
+func TestDiffClustersCommand_RequiresFlags(t *testing.T) {
+	cmd := cmd.NewDiffClustersCommand(&MockScanner{})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--from", "old"})
+
+	err := cmd.Execute()
+	assert.Error(t, err)
+}
@@ -0,0 +1,194 @@
+package cmd_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/dev-shimada/phantom-ecs/cmd"
+	phantomaws "github.com/dev-shimada/phantom-ecs/internal/aws"
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestDiffCommand(t *testing.T) {
+	liveResult := &models.InspectionResult{
+		Service: models.ECSService{ServiceName: "web-service", ClusterName: "prod"},
+		TaskDefinition: models.ECSTaskDefinition{
+			TaskDefinitionArn: "arn:aws:ecs:us-east-1:123456789012:task-definition/web-task:3",
+			Family:            "web-task",
+			Revision:          3,
+			Status:            "ACTIVE",
+			CPU:               "256",
+			Memory:            "512",
+			NetworkMode:       "awsvpc",
+			Containers: []models.ContainerDefinition{
+				{Name: "app", Image: "nginx:1.25", Essential: true},
+			},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		fileContent  string
+		expectDrift  bool
+		expectOutput string
+	}{
+		{
+			name:         "一致するタスク定義ファイル",
+			fileContent:  `{"family": "web-task", "cpu": "256", "memory": "512", "networkMode": "awsvpc", "containerDefinitions": [{"name": "app", "image": "nginx:1.25", "essential": true}]}`,
+			expectOutput: "No differences found.",
+		},
+		{
+			name:         "ドリフトしたタスク定義ファイル",
+			fileContent:  `{"family": "web-task", "cpu": "256", "memory": "1024", "networkMode": "awsvpc", "containerDefinitions": [{"name": "app", "image": "nginx:1.27", "essential": true}]}`,
+			expectDrift:  true,
+			expectOutput: "containers.0.image",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "taskdef.json")
+			require.NoError(t, os.WriteFile(filename, []byte(tt.fileContent), 0644))
+
+			mockInspector := &MockInspector{}
+			mockInspector.On("InspectService", mock.Anything, "web-service", "prod").Return(liveResult, nil)
+
+			var buf bytes.Buffer
+			diffCmd := cmd.NewDiffCommand(mockInspector)
+			diffCmd.SetOut(&buf)
+			diffCmd.SetErr(&bytes.Buffer{})
+			diffCmd.SetArgs([]string{"web-service", "--cluster", "prod", "--against", filename})
+
+			err := diffCmd.Execute()
+			if tt.expectDrift {
+				assert.Error(t, err)
+				assert.True(t, errors.Is(err, cmd.ErrDriftDetected))
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Contains(t, buf.String(), tt.expectOutput)
+
+			mockInspector.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDiffCommand_FileNotFound(t *testing.T) {
+	mockInspector := &MockInspector{}
+
+	diffCmd := cmd.NewDiffCommand(mockInspector)
+	diffCmd.SetOut(&bytes.Buffer{})
+	diffCmd.SetErr(&bytes.Buffer{})
+	diffCmd.SetArgs([]string{"web-service", "--cluster", "prod", "--against", "/nonexistent/taskdef.json"})
+
+	err := diffCmd.Execute()
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, cmd.ErrDriftDetected))
+	mockInspector.AssertNotCalled(t, "InspectService", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDiffCommand_StructuredOutput(t *testing.T) {
+	liveResult := &models.InspectionResult{
+		Service: models.ECSService{ServiceName: "web-service", ClusterName: "prod"},
+		TaskDefinition: models.ECSTaskDefinition{
+			Family:      "web-task",
+			Revision:    3,
+			Status:      "ACTIVE",
+			CPU:         "256",
+			Memory:      "512",
+			NetworkMode: "awsvpc",
+			Containers: []models.ContainerDefinition{
+				{Name: "app", Image: "nginx:1.25", Essential: true},
+			},
+		},
+	}
+	filename := filepath.Join(t.TempDir(), "taskdef.json")
+	require.NoError(t, os.WriteFile(filename, []byte(`{"family": "web-task", "cpu": "256", "memory": "512", "networkMode": "awsvpc", "containerDefinitions": [{"name": "app", "image": "nginx:1.27", "essential": true}]}`), 0644))
+
+	execute := func(t *testing.T, format string) string {
+		mockInspector := &MockInspector{}
+		mockInspector.On("InspectService", mock.Anything, "web-service", "prod").Return(liveResult, nil)
+
+		var buf bytes.Buffer
+		diffCmd := cmd.NewDiffCommand(mockInspector)
+		diffCmd.SetOut(&buf)
+		diffCmd.SetErr(&bytes.Buffer{})
+		diffCmd.SetArgs([]string{"web-service", "--cluster", "prod", "--against", filename, "--output", format})
+
+		err := diffCmd.Execute()
+		require.ErrorIs(t, err, cmd.ErrDriftDetected)
+		return buf.String()
+	}
+
+	t.Run("JSONは変更されたフィールドの稼働中の値と期待する値を含む", func(t *testing.T) {
+		var diff models.TaskDefinitionDiff
+		require.NoError(t, json.Unmarshal([]byte(execute(t, "json")), &diff))
+
+		assert.Equal(t, "web-service", diff.ServiceName)
+		assert.Equal(t, "web-task", diff.Family)
+		assert.Contains(t, diff.Differences, models.FieldDifference{
+			Field:    "containers.0.image",
+			Live:     "nginx:1.25",
+			Expected: "nginx:1.27",
+		})
+	})
+
+	t.Run("YAMLは変更されたフィールドの稼働中の値と期待する値を含む", func(t *testing.T) {
+		var diff models.TaskDefinitionDiff
+		require.NoError(t, yaml.Unmarshal([]byte(execute(t, "yaml")), &diff))
+
+		assert.Contains(t, diff.Differences, models.FieldDifference{
+			Field:    "containers.0.image",
+			Live:     "nginx:1.25",
+			Expected: "nginx:1.27",
+		})
+	})
+
+	t.Run("テーブルはフィールドごとに稼働中の値と期待する値を並べる", func(t *testing.T) {
+		output := execute(t, "table")
+
+		assert.Contains(t, output, "FIELD")
+		assert.Contains(t, output, "LIVE")
+		assert.Contains(t, output, "EXPECTED")
+		assert.Regexp(t, `containers\.0\.image\s+nginx:1\.25\s+nginx:1\.27`, output)
+	})
+}
+
+func TestDiffCommand_ClientFactory(t *testing.T) {
+	family := "web-task"
+	taskDefArn := "arn:aws:ecs:eu-west-1:123456789012:task-definition/web-task:3"
+	cpu, memory := "256", "512"
+	service := fakeService("web-service", "ACTIVE", "FARGATE", 1, 1)
+	service.TaskDefinition = &taskDefArn
+	factory := &FakeClientFactory{Clients: map[string]phantomaws.ECSClient{
+		"eu-west-1": &FakeECSClient{
+			Services: map[string][]types.Service{"prod": {service}},
+			TaskDefinitions: map[string]*types.TaskDefinition{
+				taskDefArn: {TaskDefinitionArn: &taskDefArn, Family: &family, Revision: 3, Status: types.TaskDefinitionStatusActive, Cpu: &cpu, Memory: &memory},
+			},
+		},
+	}}
+	filename := filepath.Join(t.TempDir(), "taskdef.json")
+	require.NoError(t, os.WriteFile(filename, []byte(`{"family": "web-task", "cpu": "256", "memory": "1024"}`), 0644))
+
+	var buf bytes.Buffer
+	diffCmd := cmd.NewDiffCommandWithClientFactory(factory)
+	diffCmd.SetOut(&buf)
+	diffCmd.SetErr(&bytes.Buffer{})
+	diffCmd.SetArgs([]string{"web-service", "--cluster", "prod", "--against", filename, "--region", "eu-west-1"})
+
+	err := diffCmd.Execute()
+
+	// 稼働中のタスク定義（--regionのクライアントから取得）とファイルのmemoryの差分を検出する
+	require.Error(t, err)
+	assert.Contains(t, buf.String(), "memory")
+}
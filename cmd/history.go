@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/dev-shimada/phantom-ecs/internal/arn"
+	"github.com/dev-shimada/phantom-ecs/internal/history"
+	"github.com/dev-shimada/phantom-ecs/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// NewHistoryCommand はhistoryコマンドを作成
+func NewHistoryCommand() *cobra.Command {
+	var clusterName string
+	var historyDir string
+	var diff bool
+	var fromID string
+	var toID string
+	var outputFormat string
+	var configFiles []string
+	var configProfile string
+
+	cmd := &cobra.Command{
+		Use:   "history <service-name>",
+		Short: "保存したサービス調査結果の一覧と差分を表示",
+		Long: `inspect --history-dir で保存したサービス調査結果の一覧を表示します。
+
+--diff を指定すると、保存した調査結果のうち最新の2件（--from、--toで
+IDを指定した場合はその2件）を比較し、変更されたフィールドを表示します。
+サービスの設定が時間とともにどう変化したか（ドリフト）の追跡に利用できます。
+
+AWS APIは呼び出さず、履歴ディレクトリのファイルのみを参照します。`,
+		Example: `  # 保存した調査結果の一覧を表示
+  phantom-ecs history my-service --cluster my-cluster --history-dir ./history
+
+  # 最新の2件の差分を表示
+  phantom-ecs history my-service --cluster my-cluster --history-dir ./history --diff
+
+  # IDを指定して差分を表示
+  phantom-ecs history my-service --cluster my-cluster --history-dir ./history --from 20240101T090000.000Z --to 20240108T090000.000Z`,
+		Args: cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return applyConfigProfile(cmd, configFiles, configProfile)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			serviceName, clusterName, err := resolveServiceARN(args[0], clusterName)
+			if err != nil {
+				return err
+			}
+			clusterName, err = resolveCluster(clusterName, configFiles, configProfile)
+			if err != nil {
+				return err
+			}
+			// --from、--toを指定した場合は--diffを省略できる
+			diff = diff || fromID != "" || toID != ""
+			return runHistory(cmd, serviceName, clusterName, historyDir, diff, fromID, toID, outputFormat)
+		},
+	}
+
+	// ローカルフラグを定義
+	cmd.Flags().StringVarP(&clusterName, "cluster", "c", "", "クラスター名またはクラスターARN (省略時はサービスARNのクラスター、または設定ファイルのdefault_cluster)")
+	cmd.Flags().StringVar(&historyDir, "history-dir", "", "調査結果を保存したディレクトリ (inspect --history-dirと同じパス、必須)")
+	cmd.Flags().BoolVar(&diff, "diff", false, "保存した調査結果の差分を表示 (省略時は一覧を表示)")
+	cmd.Flags().StringVar(&fromID, "from", "", "差分の比較元の調査結果のID (省略時は最新の1つ前)")
+	cmd.Flags().StringVar(&toID, "to", "", "差分の比較先の調査結果のID (省略時は最新)")
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "出力形式 (json|yaml|table)")
+	addCompactFlag(cmd)
+	addConfigFileFlags(cmd, &configFiles, &configProfile)
+
+	cmd.MarkFlagRequired("history-dir")
+
+	return cmd
+}
+
+// runHistory はhistoryコマンドの実行ロジック
+func runHistory(cmd *cobra.Command, serviceName, clusterName, historyDir string, diff bool, fromID, toID, outputFormat string) error {
+	// 必須パラメータの検証
+	if clusterName == "" {
+		return fmt.Errorf("cluster name is required")
+	}
+	// クラスターARNが指定された場合はクラスター名に正規化
+	clusterName = arn.ClusterName(clusterName)
+
+	// 出力形式の検証
+	formatter := utils.NewFormatter()
+	if !formatter.ValidateFormat(outputFormat) {
+		return fmt.Errorf("unsupported output format: %s. Supported formats: %v",
+			outputFormat, formatter.GetSupportedFormats())
+	}
+	outputFormat = formatter.NormalizeFormat(outputFormat)
+
+	store := history.NewStore(historyDir)
+	var data interface{}
+	if diff {
+		historyDiff, err := store.Diff(clusterName, serviceName, fromID, toID)
+		if err != nil {
+			return fmt.Errorf("failed to diff history: %w", err)
+		}
+		data = *historyDiff
+	} else {
+		captures, err := store.List(clusterName, serviceName)
+		if err != nil {
+			return fmt.Errorf("failed to list history: %w", err)
+		}
+		data = captures
+	}
+
+	output, err := formatter.FormatWithOptions(data, utils.FormatOptions{
+		Format:      outputFormat,
+		PrettyPrint: prettyPrint(cmd),
+		Indent:      jsonIndent(cmd),
+		YAMLFlow:    yamlFlow(cmd),
+		Wide:        wide(cmd),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), output)
+	return nil
+}
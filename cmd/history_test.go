@@ -0,0 +1,111 @@
+package cmd_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/dev-shimada/phantom-ecs/cmd"
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// captureInspection はinspect --history-dirで調査結果を履歴ディレクトリに保存する
+func captureInspection(t *testing.T, historyDir, image string, desired int32) {
+	t.Helper()
+	mockInspector := &MockInspector{}
+	mockInspector.On("InspectService", mock.Anything, "web-service", "prod").Return(&models.InspectionResult{
+		Service: models.ECSService{ServiceName: "web-service", ClusterName: "prod", Status: "ACTIVE", DesiredCount: desired},
+		TaskDefinition: models.ECSTaskDefinition{
+			Family:     "web-task",
+			Revision:   2,
+			Containers: []models.ContainerDefinition{{Name: "web", Image: image}},
+		},
+	}, nil)
+
+	var stdout, stderr bytes.Buffer
+	inspectCmd := cmd.NewInspectCommand(mockInspector)
+	inspectCmd.SetOut(&stdout)
+	inspectCmd.SetErr(&stderr)
+	inspectCmd.SetArgs([]string{"web-service", "--cluster", "prod", "--output", "json", "--history-dir", historyDir})
+
+	require.NoError(t, inspectCmd.Execute())
+	assert.Contains(t, stderr.String(), "Saved inspection history: ")
+}
+
+func TestHistoryCommand_ListAndDiff(t *testing.T) {
+	historyDir := t.TempDir()
+	captureInspection(t, historyDir, "nginx:1.26", 2)
+	// 取得時刻（ミリ秒単位）がIDになるため、2件目が別の時刻になるよう待つ
+	time.Sleep(5 * time.Millisecond)
+	captureInspection(t, historyDir, "nginx:1.27", 3)
+
+	var listOut bytes.Buffer
+	historyCmd := cmd.NewHistoryCommand()
+	historyCmd.SetOut(&listOut)
+	historyCmd.SetArgs([]string{"web-service", "--cluster", "prod", "--history-dir", historyDir, "--output", "json"})
+	require.NoError(t, historyCmd.Execute())
+
+	var captures []models.HistoryCapture
+	require.NoError(t, json.Unmarshal(listOut.Bytes(), &captures))
+	require.Len(t, captures, 2)
+	assert.Less(t, captures[0].ID, captures[1].ID)
+	assert.Equal(t, "web-service", captures[0].ServiceName)
+
+	var diffOut bytes.Buffer
+	historyCmd = cmd.NewHistoryCommand()
+	historyCmd.SetOut(&diffOut)
+	historyCmd.SetArgs([]string{"web-service", "--cluster", "prod", "--history-dir", historyDir, "--diff", "--output", "json"})
+	require.NoError(t, historyCmd.Execute())
+
+	var diff models.HistoryDiff
+	require.NoError(t, json.Unmarshal(diffOut.Bytes(), &diff))
+	assert.Equal(t, captures[0].ID, diff.From.ID)
+	assert.Equal(t, captures[1].ID, diff.To.ID)
+	assert.Equal(t, []models.RevisionChange{
+		{Field: "service.desired_count", Previous: "2", Current: "3"},
+		{Field: "task_definition.containers.0.image", Previous: "nginx:1.26", Current: "nginx:1.27"},
+	}, diff.Changes)
+
+	// --from、--toを指定すると--diffを省略でき、テーブル形式では変更されたフィールドを表示する
+	var tableOut bytes.Buffer
+	historyCmd = cmd.NewHistoryCommand()
+	historyCmd.SetOut(&tableOut)
+	historyCmd.SetArgs([]string{"web-service", "--cluster", "prod", "--history-dir", historyDir, "--from", captures[1].ID, "--to", captures[0].ID})
+	require.NoError(t, historyCmd.Execute())
+
+	assert.Contains(t, tableOut.String(), "=== CHANGES (2) ===")
+	assert.Contains(t, tableOut.String(), "task_definition.containers.0.image")
+}
+
+func TestHistoryCommand_NotEnoughCaptures(t *testing.T) {
+	historyDir := t.TempDir()
+	captureInspection(t, historyDir, "nginx:1.27", 2)
+
+	var buf bytes.Buffer
+	historyCmd := cmd.NewHistoryCommand()
+	historyCmd.SetOut(&buf)
+	historyCmd.SetErr(&buf)
+	historyCmd.SetArgs([]string{"web-service", "--cluster", "prod", "--history-dir", historyDir, "--diff"})
+
+	err := historyCmd.Execute()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "at least two captures are required to diff")
+}
+
+func TestHistoryCommand_RequiresHistoryDir(t *testing.T) {
+	var buf bytes.Buffer
+	historyCmd := cmd.NewHistoryCommand()
+	historyCmd.SetOut(&buf)
+	historyCmd.SetErr(&buf)
+	historyCmd.SetArgs([]string{"web-service", "--cluster", "prod"})
+
+	err := historyCmd.Execute()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "history-dir")
+}
@@ -3,10 +3,18 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/dev-shimada/phantom-ecs/internal/arn"
 	"github.com/dev-shimada/phantom-ecs/internal/aws"
+	"github.com/dev-shimada/phantom-ecs/internal/differ"
+	"github.com/dev-shimada/phantom-ecs/internal/errors"
+	"github.com/dev-shimada/phantom-ecs/internal/export"
+	"github.com/dev-shimada/phantom-ecs/internal/history"
 	"github.com/dev-shimada/phantom-ecs/internal/inspector"
 	"github.com/dev-shimada/phantom-ecs/internal/models"
+	"github.com/dev-shimada/phantom-ecs/internal/scanner"
 	"github.com/dev-shimada/phantom-ecs/internal/utils"
 	"github.com/spf13/cobra"
 )
@@ -16,43 +24,189 @@ type InspectorInterface interface {
 	InspectService(ctx context.Context, serviceName, clusterName string) (*models.InspectionResult, error)
 }
 
+// TaskDefinitionTagsConfigurer はタスク定義のタグ取得を切り替えられるInspectorのインターフェース
+type TaskDefinitionTagsConfigurer interface {
+	SetIncludeTaskDefinitionTags(include bool)
+}
+
+// inspectPrefixIncompatibleFlags は--prefixと同時に指定できない、単一サービスの調査専用のフラグ
+var inspectPrefixIncompatibleFlags = []string{
+	"flatten", "export", "output-file", "output-s3", "mask-secrets", "compare-revision", "container-pattern",
+	"include-taskdef-tags", "sort-recommendations", "recommendation-plugin", "fail-if-unhealthy", "fail-on-recommendation", "history-dir", "tail-events",
+}
+
 // NewInspectCommand はinspectコマンドを作成
 func NewInspectCommand(inspectorImpl InspectorInterface) *cobra.Command {
-	var clusterName string
-	var outputFormat string
-	var region string
-	var profile string
+	return NewInspectCommandWithScanner(inspectorImpl, nil)
+}
+
+// NewInspectCommandWithScanner は--prefixで対象サービスを一覧するScannerを指定してinspectコマンドを作成
+func NewInspectCommandWithScanner(inspectorImpl InspectorInterface, scannerImpl ScannerInterface) *cobra.Command {
+	return newInspectCommand(inspectorImpl, scannerImpl, aws.NewClientFactory())
+}
+
+// NewInspectCommandWithClientFactory はAWSクライアントを指定したファクトリで作成するinspectコマンドを作成
+func NewInspectCommandWithClientFactory(clientFactory aws.ClientFactory) *cobra.Command {
+	return newInspectCommand(nil, nil, clientFactory)
+}
+
+// newInspectCommand はinspectコマンドを作成（InspectorとScannerがnilの場合はclientFactoryのクライアントを使用）
+func newInspectCommand(inspectorImpl InspectorInterface, scannerImpl ScannerInterface, clientFactory aws.ClientFactory) *cobra.Command {
+	var options inspectOptions
+	var prefix string
+	var configFiles []string
+	var configProfile string
+	var tailEvents bool
+	var tailInterval time.Duration
 
 	cmd := &cobra.Command{
-		Use:   "inspect <service-name>",
+		Use:   "inspect <service-name|service-arn>",
 		Short: "指定されたECSサービスの詳細情報を表示",
 		Long: `指定されたECSサービスの詳細情報を表示します。
 
 サービスの基本情報、タスク定義、ネットワーク設定、
-レコメンデーションを含む包括的な分析結果を提供します。`,
+レコメンデーションを含む包括的な分析結果を提供します。
+
+サービス名の代わりに --prefix を指定すると、クラスター内で名前が
+プレフィックスに一致するサービスをすべて並列に調査します（チームごとの
+命名規則でサービスをまとめて確認する場合など）。同時に調査するサービス数は
+設定ファイルの concurrency.inspect を使用します。`,
 		Example: `  # 基本的なサービス検査
   phantom-ecs inspect my-service --cluster my-cluster
 
   # JSON形式で出力
   phantom-ecs inspect my-service --cluster my-cluster --output json
 
+  # サービスARNを指定（クラスターとリージョンはARNから取得）
+  phantom-ecs inspect arn:aws:ecs:us-west-2:123456789012:service/my-cluster/my-service
+
   # 特定のリージョンとプロファイルを使用
-  phantom-ecs inspect my-service --cluster my-cluster --region us-west-2 --profile production`,
-		Args: cobra.ExactArgs(1),
+  phantom-ecs inspect my-service --cluster my-cluster --region us-west-2 --profile production
+
+  # ネストしたキーをドット区切りで平坦化してYAML出力
+  phantom-ecs inspect my-service --cluster my-cluster --output yaml --flatten
+
+  # 設定ファイルのdefault_clusterを使用
+  phantom-ecs inspect my-service --config-file phantom-ecs.yaml --config-profile production
+
+  # 共通の設定ファイルに環境別の設定ファイルを重ねて使用
+  phantom-ecs inspect my-service --config-file base.yaml --config-file overlay.yaml --config-profile production
+
+  # Terraformのリソース定義として出力
+  phantom-ecs inspect my-service --cluster my-cluster --export terraform
+
+  # 標準出力にはテーブル形式、ファイルにはJSON形式で出力
+  phantom-ecs inspect my-service --cluster my-cluster --output-file inspect.json
+
+  # 結果をS3にもアップロード
+  phantom-ecs inspect my-service --cluster my-cluster --output json --output-s3 s3://my-bucket/reports/inspect.json
+
+  # 前リビジョンのタスク定義からの変更点を表示
+  phantom-ecs inspect my-service --cluster my-cluster --compare-revision
+
+  # 名前がパターンに一致するコンテナのみ表示
+  phantom-ecs inspect my-service --cluster my-cluster --container-pattern 'web-*'
+
+  # タスク定義のタグも取得して表示
+  phantom-ecs inspect my-service --cluster my-cluster --include-taskdef-tags
+
+  # レコメンデーションを優先度の高い順に表示
+  phantom-ecs inspect my-service --cluster my-cluster --sort-recommendations priority
+
+  # 組織独自のポリシーを外部プラグインでレコメンデーションに追加
+  phantom-ecs inspect my-service --cluster my-cluster --recommendation-plugin ./policy-check
+
+  # 名前がteam-a-で始まるサービスをまとめて調査
+  phantom-ecs inspect --prefix team-a- --cluster my-cluster
+
+  # CIでサービスが健全でない場合に失敗させる
+  phantom-ecs inspect my-service --cluster my-cluster --fail-if-unhealthy
+
+  # 優先度highのレコメンデーションがあればCIを失敗させる
+  phantom-ecs inspect my-service --cluster my-cluster --fail-on-recommendation high
+
+  # 調査結果を履歴ディレクトリに保存（phantom-ecs historyで一覧・差分を表示）
+  phantom-ecs inspect my-service --cluster my-cluster --history-dir ./history
+
+  # デプロイ中のサービスイベントを中断されるまで追跡
+  phantom-ecs inspect my-service --cluster my-cluster --tail-events`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if prefix == "" {
+				return cobra.ExactArgs(1)(cmd, args)
+			}
+			if len(args) > 0 {
+				return fmt.Errorf("cannot specify a service name together with --prefix")
+			}
+			return nil
+		},
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return applyConfigProfile(cmd, configFiles, configProfile)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			serviceName := args[0]
-			return runInspect(cmd, inspectorImpl, serviceName, clusterName, outputFormat, region, profile)
+			if prefix != "" {
+				for _, name := range inspectPrefixIncompatibleFlags {
+					if cmd.Flags().Changed(name) {
+						return fmt.Errorf("--prefix cannot be combined with --%s", name)
+					}
+				}
+				clusterName, err := resolveCluster(options.clusterName, configFiles, configProfile)
+				if err != nil {
+					return err
+				}
+				enhancedConfig, err := loadEnhancedConfig(configFiles, configProfile)
+				if err != nil {
+					return err
+				}
+				return runInspectPrefix(cmd, scannerImpl, inspectorImpl, clientFactory, prefix, clusterName, options.outputFormat, options.region, options.profile, enhancedConfig.Concurrency.Inspect)
+			}
+
+			serviceName, clusterName, err := resolveServiceARN(args[0], options.clusterName)
+			if err != nil {
+				return err
+			}
+			clusterName, err = resolveCluster(clusterName, configFiles, configProfile)
+			if err != nil {
+				return err
+			}
+			options.serviceName = serviceName
+			options.clusterName = clusterName
+			// サービスARNが指定された場合は--region未指定時にARNのリージョンを使用
+			if arn.IsARN(args[0]) && !cmd.Flags().Changed("region") {
+				if parsed, err := arn.Parse(args[0]); err == nil && parsed.Region != "" {
+					options.region = parsed.Region
+				}
+			}
+			if tailEvents {
+				return runTailEvents(cmd, inspectorImpl, clientFactory, serviceName, clusterName, options.region, options.profile, tailInterval)
+			}
+			return runInspect(cmd, inspectorImpl, clientFactory, options)
 		},
 	}
 
 	// ローカルフラグを定義
-	cmd.Flags().StringVarP(&clusterName, "cluster", "c", "", "クラスター名 (必須)")
-	cmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "出力形式 (json|yaml|table)")
-	cmd.Flags().StringVarP(&region, "region", "r", "us-east-1", "AWSリージョン")
-	cmd.Flags().StringVarP(&profile, "profile", "p", "", "AWSプロファイル")
-
-	// 必須フラグを設定
-	cmd.MarkFlagRequired("cluster")
+	cmd.Flags().StringVarP(&options.clusterName, "cluster", "c", "", "クラスター名またはクラスターARN (省略時はサービスARNのクラスター、または設定ファイルのdefault_cluster)")
+	cmd.Flags().StringVar(&prefix, "prefix", "", "サービス名の代わりに指定し、名前がプレフィックスに一致するクラスター内のサービスをすべて調査")
+	cmd.Flags().StringVarP(&options.outputFormat, "output", "o", "table", "出力形式 (json|yaml|table)")
+	addCompactFlag(cmd)
+	cmd.Flags().StringVarP(&options.region, "region", "r", "us-east-1", "AWSリージョン")
+	cmd.Flags().StringVarP(&options.profile, "profile", "p", "", "AWSプロファイル")
+	cmd.Flags().BoolVar(&options.flatten, "flatten", false, "ネストしたキーをドット区切りで平坦化 (json|yamlのみ)")
+	addOutputFileFlags(cmd, &options.outputFile)
+	addOutputS3Flag(cmd, &options.outputS3)
+	addMaskSecretsFlags(cmd, &options.maskSecrets)
+	cmd.Flags().BoolVar(&options.compareRevision, "compare-revision", false, "前リビジョンのタスク定義との差分（イメージ、環境変数、リソース等）を表示")
+	cmd.Flags().StringVar(&options.containerPattern, "container-pattern", "", "表示するコンテナ名のパターン (例: web-*、一致しないコンテナは件数のみ表示)")
+	cmd.Flags().BoolVar(&options.includeTaskDefTags, "include-taskdef-tags", false, "タスク定義のタグを取得して表示 (ecs:ListTagsForResource権限が必要)")
+	cmd.Flags().StringVar(&options.sortRecommendations, "sort-recommendations", "", "レコメンデーションの並び順 (priority|category)")
+	cmd.Flags().StringVar(&options.recommendationPlugin, "recommendation-plugin", "", "追加のレコメンデーションを返す外部プラグインの実行ファイル (標準入力で機密情報をマスクしたJSONを受け取り標準出力にJSON配列を返す)")
+	cmd.Flags().DurationVar(&options.pluginTimeout, "recommendation-plugin-timeout", inspector.DefaultPluginTimeout, "レコメンデーションプラグインの実行タイムアウト")
+	cmd.Flags().BoolVar(&options.failIfUnhealthy, "fail-if-unhealthy", false, "実行中タスク数が希望タスク数と異なるか、ステータスがACTIVEでない場合に結果を出力した上で非ゼロで終了")
+	cmd.Flags().StringVar(&options.historyDir, "history-dir", "", "調査結果を取得時刻付きのJSONファイルとしてディレクトリに保存 (機密情報は--output-fileと同様にマスク)")
+	cmd.Flags().StringVar(&options.failOnRecommendation, "fail-on-recommendation", "", "指定した優先度以上のレコメンデーションがあれば結果を出力した上で非ゼロで終了 (high|medium|low)")
+	cmd.Flags().BoolVar(&tailEvents, "tail-events", false, "サービスイベントを定期的に取得し、新しいイベントを中断されるまで表示")
+	cmd.Flags().DurationVar(&tailInterval, "tail-interval", DefaultTailEventsInterval, "--tail-events のポーリング間隔")
+	cmd.Flags().StringVar(&options.exportFormat, "export", "", "IaCのスニペットとして出力 (terraform|cloudformation、指定時は--outputを無視)")
+	addConfigFileFlags(cmd, &configFiles, &configProfile)
 
 	return cmd
 }
@@ -62,17 +216,24 @@ func NewInspectCommandWithDefaults() *cobra.Command {
 	return NewInspectCommand(nil) // 実際の実装では適切なInspectorを渡す
 }
 
-// runInspect はinspectコマンドの実行ロジック
-func runInspect(cmd *cobra.Command, inspectorImpl InspectorInterface, serviceName, clusterName, outputFormat, region, profile string) error {
-	ctx := context.Background()
+// newInspector はEC2クラスターの空き容量の確認にScannerでコンテナインスタンスを取得するInspectorを作成
+func newInspector(client aws.ECSClient) *inspector.Inspector {
+	inspectorImpl := inspector.NewInspector(client)
+	inspectorImpl.SetContainerInstanceLister(scanner.NewScanner(client))
+	return inspectorImpl
+}
+
+// runInspectPrefix は名前がプレフィックスに一致するクラスター内のサービスを並列に詳細調査する
+func runInspectPrefix(cmd *cobra.Command, scannerImpl ScannerInterface, inspectorImpl InspectorInterface, clientFactory aws.ClientFactory, prefix, clusterName, outputFormat, region, profile string, concurrency int) error {
+	ctx := commandContext(cmd)
 
 	// 必須パラメータの検証
-	if serviceName == "" {
-		return fmt.Errorf("service name is required")
-	}
 	if clusterName == "" {
 		return fmt.Errorf("cluster name is required")
 	}
+	if concurrency < 1 {
+		return fmt.Errorf("concurrency must be at least 1: %d", concurrency)
+	}
 
 	// 出力形式の検証
 	formatter := utils.NewFormatter()
@@ -80,6 +241,103 @@ func runInspect(cmd *cobra.Command, inspectorImpl InspectorInterface, serviceNam
 		return fmt.Errorf("unsupported output format: %s. Supported formats: %v",
 			outputFormat, formatter.GetSupportedFormats())
 	}
+	outputFormat = formatter.NormalizeFormat(outputFormat)
+
+	// ScannerとInspectorがnilの場合（実際のAWS呼び出し用）は、AWS実装を作成
+	var scannerToUse ScannerInterface
+	var inspectorToUse InspectorInterface
+	if scannerImpl != nil && inspectorImpl != nil {
+		scannerToUse = scannerImpl
+		inspectorToUse = inspectorImpl
+	} else {
+		client, err := newECSClient(ctx, cmd, clientFactory, region, profile)
+		if err != nil {
+			return fmt.Errorf("failed to create AWS client: %w", err)
+		}
+		scannerToUse = scanner.NewScanner(client)
+		inspectorToUse = newInspector(client)
+	}
+
+	return inspectClusterServices(ctx, cmd, scannerToUse, inspectorToUse, []string{arn.ClusterName(clusterName)}, prefix, formatter, outputFormat, concurrency, "")
+}
+
+// inspectOptions はinspectコマンドのフラグで指定するサービス調査の設定
+type inspectOptions struct {
+	serviceName          string
+	clusterName          string
+	outputFormat         string
+	region               string
+	profile              string
+	flatten              bool
+	exportFormat         string
+	outputFile           outputFileOptions
+	outputS3             outputS3Options
+	maskSecrets          maskSecretsOptions
+	compareRevision      bool
+	includeTaskDefTags   bool
+	containerPattern     string
+	sortRecommendations  string
+	recommendationPlugin string
+	pluginTimeout        time.Duration
+	failIfUnhealthy      bool
+	failOnRecommendation string
+	historyDir           string
+}
+
+// runInspect はinspectコマンドの実行ロジック
+func runInspect(cmd *cobra.Command, inspectorImpl InspectorInterface, clientFactory aws.ClientFactory, options inspectOptions) error {
+	ctx := commandContext(cmd)
+
+	// 必須パラメータの検証
+	if options.serviceName == "" {
+		return fmt.Errorf("service name is required")
+	}
+	if options.clusterName == "" {
+		return fmt.Errorf("cluster name is required")
+	}
+	// クラスターARNが指定された場合はクラスター名に正規化
+	options.clusterName = arn.ClusterName(options.clusterName)
+
+	// 出力形式の検証
+	formatter := utils.NewFormatter()
+	if !formatter.ValidateFormat(options.outputFormat) {
+		return fmt.Errorf("unsupported output format: %s. Supported formats: %v",
+			options.outputFormat, formatter.GetSupportedFormats())
+	}
+	options.outputFormat = formatter.NormalizeFormat(options.outputFormat)
+
+	// エクスポート形式の検証
+	exporter := export.NewExporter()
+	if options.exportFormat != "" && !exporter.ValidateFormat(options.exportFormat) {
+		return fmt.Errorf("unsupported export format: %s. Supported formats: %v",
+			options.exportFormat, exporter.GetSupportedFormats())
+	}
+
+	if err := options.maskSecrets.validate(); err != nil {
+		return err
+	}
+	if options.containerPattern != "" {
+		if err := utils.ValidateContainerPattern(options.containerPattern); err != nil {
+			return err
+		}
+	}
+	if options.sortRecommendations != "" {
+		if err := utils.ValidateRecommendationSort(options.sortRecommendations); err != nil {
+			return err
+		}
+	}
+	if options.failOnRecommendation != "" {
+		if err := utils.ValidateRecommendationPriority(options.failOnRecommendation); err != nil {
+			return err
+		}
+	}
+	sink, err := resolveOutputSink(ctx, cmd, formatter, options.region, options.profile, options.outputFile, options.outputS3)
+	if err != nil {
+		return err
+	}
+	if options.recommendationPlugin != "" && options.pluginTimeout <= 0 {
+		return fmt.Errorf("recommendation-plugin-timeout must be positive: %s", options.pluginTimeout)
+	}
 
 	// Inspectorがnilの場合（実際のAWS呼び出し用）は、AWS Inspectorを作成
 	var inspectorToUse InspectorInterface
@@ -87,28 +345,222 @@ func runInspect(cmd *cobra.Command, inspectorImpl InspectorInterface, serviceNam
 		inspectorToUse = inspectorImpl
 	} else {
 		// 実際のAWS呼び出し用の実装
-		awsClient, err := aws.NewClient(ctx, region, profile)
+		client, err := newECSClient(ctx, cmd, clientFactory, options.region, options.profile)
 		if err != nil {
 			return fmt.Errorf("failed to create AWS client: %w", err)
 		}
-		inspectorToUse = inspector.NewInspector(awsClient)
+		inspectorToUse = newInspector(client)
 	}
 
-	// サービスの詳細調査を実行
-	result, err := inspectorToUse.InspectService(ctx, serviceName, clusterName)
+	// タスク定義のタグは追加の権限が必要なため、指定時のみ取得
+	if options.includeTaskDefTags {
+		configurer, ok := inspectorToUse.(TaskDefinitionTagsConfigurer)
+		if !ok {
+			return fmt.Errorf("inspector does not support fetching task definition tags")
+		}
+		configurer.SetIncludeTaskDefinitionTags(true)
+	}
+
+	// サービスの詳細調査を実行（端末ではJSON出力時を除きスピナーを表示）
+	spinner := utils.NewSpinner(cmd.ErrOrStderr(), "Inspecting service...", options.outputFormat != "json")
+	spinner.Start()
+	result, err := inspectorToUse.InspectService(ctx, options.serviceName, options.clusterName)
+	spinner.Stop()
 	if err != nil {
 		return fmt.Errorf("failed to inspect service: %w", err)
 	}
 
-	// 結果をフォーマットして出力
-	output, err := formatter.FormatWithOptions(*result, utils.FormatOptions{
-		Format:      outputFormat,
-		PrettyPrint: true,
-	})
+	// 外部プラグインのレコメンデーションを追加（プラグインには常に機密情報をマスクした結果を渡す）
+	if options.recommendationPlugin != "" {
+		pluginInput, err := options.maskSecrets.mask(*result)
+		if err != nil {
+			return err
+		}
+		extra, err := inspector.RunRecommendationPlugin(ctx, options.recommendationPlugin, &pluginInput, options.pluginTimeout)
+		if err != nil {
+			return err
+		}
+		withPlugin := *result
+		withPlugin.Recommendations = append(append([]models.Recommendation{}, result.Recommendations...), extra...)
+		result = &withPlugin
+	}
+
+	// プラグイン分も含めてレコメンデーションを並び替え
+	if options.sortRecommendations != "" {
+		sorted, err := utils.SortRecommendations(result.Recommendations, options.sortRecommendations)
+		if err != nil {
+			return err
+		}
+		withSorted := *result
+		withSorted.Recommendations = sorted
+		result = &withSorted
+	}
+
+	// 前リビジョンとの差分を付与（リビジョン1の場合は比較対象がないため通知のみ）
+	if options.compareRevision {
+		if result.TaskDefinition.Revision <= 1 {
+			fmt.Fprintf(cmd.ErrOrStderr(), "No previous revision to compare: %s is at revision %d\n",
+				result.TaskDefinition.Family, result.TaskDefinition.Revision)
+		} else {
+			comparison, err := compareWithPreviousRevision(ctx, inspectorToUse, result.TaskDefinition)
+			if err != nil {
+				return err
+			}
+			withComparison := *result
+			withComparison.RevisionComparison = comparison
+			result = &withComparison
+		}
+	}
+
+	// 表示するコンテナをパターンで絞り込み（リビジョン比較は全コンテナで行うため比較後に適用）
+	if options.containerPattern != "" {
+		filtered, err := utils.FilterContainers(*result, options.containerPattern)
+		if err != nil {
+			return err
+		}
+		result = &filtered
+	}
+
+	// 機密情報のマスク（ファイル・S3出力は未指定時もマスク）
+	stdoutResult, destinationResult, err := options.maskSecrets.resolve(cmd, *result)
 	if err != nil {
-		return fmt.Errorf("failed to format output: %w", err)
+		return err
+	}
+
+	// 標準出力とは別形式でファイルにも書き出す
+	if err := sink.WriteFile(formatter, destinationResult); err != nil {
+		return err
+	}
+
+	// ドリフトの追跡用に、ファイル出力と同じマスク設定を適用した結果を履歴ディレクトリに保存
+	if options.historyDir != "" {
+		capture, err := history.NewStore(options.historyDir).Save(destinationResult, time.Now())
+		if err != nil {
+			return fmt.Errorf("failed to save inspection history: %w", err)
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "Saved inspection history: %s\n", capture.Path)
+	}
+
+	// エクスポート形式が指定された場合はIaCのスニペットとして出力し、それ以外は指定形式でフォーマット
+	render := func(r models.InspectionResult) (string, error) {
+		if options.exportFormat != "" {
+			snippet, err := exporter.Export(r, options.exportFormat)
+			if err != nil {
+				return "", fmt.Errorf("failed to export inspection result: %w", err)
+			}
+			return snippet, nil
+		}
+		output, err := formatter.FormatWithOptions(r, utils.FormatOptions{
+			Format:      options.outputFormat,
+			PrettyPrint: prettyPrint(cmd),
+			Indent:      jsonIndent(cmd),
+			YAMLFlow:    yamlFlow(cmd),
+			Wide:        wide(cmd),
+			Flatten:     options.flatten,
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to format output: %w", err)
+		}
+		return output, nil
 	}
 
-	fmt.Print(output)
+	output, err := render(stdoutResult)
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(sink.Stdout(), output)
+
+	// 標準出力と同じ形式で、ファイル・S3出力向けのマスク設定を適用してS3にもアップロード
+	if sink.uploadsToS3() {
+		destinationOutput, err := render(destinationResult)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(sink.Destination(), destinationOutput)
+		contentFormat := options.outputFormat
+		if options.exportFormat != "" {
+			contentFormat = options.exportFormat
+		}
+		if err := sink.Flush(ctx, contentFormat); err != nil {
+			return err
+		}
+	}
+
+	// 結果を出力した上で、サービスが健全でなければ失敗させる（CIのゲート用）
+	if options.failIfUnhealthy {
+		if err := checkServiceHealth(result.Service); err != nil {
+			cmd.SilenceUsage = true
+			return err
+		}
+	}
+	// プラグイン分も含め、しきい値以上の優先度のレコメンデーションがあれば失敗させる
+	if options.failOnRecommendation != "" {
+		if err := checkRecommendations(result.Recommendations, options.failOnRecommendation); err != nil {
+			cmd.SilenceUsage = true
+			return err
+		}
+	}
 	return nil
 }
+
+// checkServiceHealth はサービスが健全でない場合にその詳細を含むバリデーションエラーを返す
+func checkServiceHealth(service models.ECSService) error {
+	if service.IsHealthy() {
+		return nil
+	}
+	return errors.NewValidationError(fmt.Sprintf("service %s is unhealthy: status=%s, running=%d, desired=%d",
+		service.ServiceName, service.Status, service.RunningCount, service.DesiredCount), nil)
+}
+
+// checkRecommendations は優先度がしきい値以上のレコメンデーションがある場合にその一覧を含むバリデーションエラーを返す
+func checkRecommendations(recommendations []models.Recommendation, threshold string) error {
+	matched, err := utils.RecommendationsAtOrAbove(recommendations, threshold)
+	if err != nil {
+		return err
+	}
+	if len(matched) == 0 {
+		return nil
+	}
+
+	summaries := make([]string, 0, len(matched))
+	for _, rec := range matched {
+		summaries = append(summaries, fmt.Sprintf("[%s] %s", strings.ToUpper(rec.Priority), rec.Title))
+	}
+	return errors.NewValidationError(fmt.Sprintf("%d recommendations at or above %s priority: %s",
+		len(matched), strings.ToLower(threshold), strings.Join(summaries, ", ")), nil)
+}
+
+// compareWithPreviousRevision は現在のタスク定義を同一ファミリーの1つ前のリビジョンと比較
+func compareWithPreviousRevision(ctx context.Context, inspectorToUse InspectorInterface, current models.ECSTaskDefinition) (*models.RevisionComparison, error) {
+	analyzer, ok := inspectorToUse.(TaskDefinitionAnalyzerInterface)
+	if !ok {
+		return nil, fmt.Errorf("inspector does not support analyzing a specific task definition revision")
+	}
+
+	previousRevision := current.Revision - 1
+	taskDefinition := fmt.Sprintf("%s:%d", current.Family, previousRevision)
+	previous, err := analyzer.AnalyzeTaskDefinition(ctx, taskDefinition)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find task definition revision %s: %w", taskDefinition, err)
+	}
+
+	differences, err := differ.NewDiffer().CompareTaskDefinitions(*previous, current)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare task definitions: %w", err)
+	}
+
+	changes := make([]models.RevisionChange, 0, len(differences))
+	for _, d := range differences {
+		changes = append(changes, models.RevisionChange{
+			Field:    d.Field,
+			Previous: d.Live,
+			Current:  d.Expected,
+		})
+	}
+
+	return &models.RevisionComparison{
+		PreviousRevision: previousRevision,
+		CurrentRevision:  current.Revision,
+		Changes:          changes,
+	}, nil
+}
@@ -0,0 +1,304 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/dev-shimada/phantom-ecs/internal/arn"
+	"github.com/dev-shimada/phantom-ecs/internal/aws"
+	"github.com/dev-shimada/phantom-ecs/internal/batch"
+	"github.com/dev-shimada/phantom-ecs/internal/config"
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+	"github.com/dev-shimada/phantom-ecs/internal/scanner"
+	"github.com/dev-shimada/phantom-ecs/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// NewInspectAllCommand はinspect-allコマンドを作成
+func NewInspectAllCommand(scannerImpl ScannerInterface, inspectorImpl InspectorInterface) *cobra.Command {
+	return newInspectAllCommand(scannerImpl, inspectorImpl, aws.NewClientFactory())
+}
+
+// NewInspectAllCommandWithClientFactory はAWSクライアントを指定したファクトリで作成するinspect-allコマンドを作成
+func NewInspectAllCommandWithClientFactory(clientFactory aws.ClientFactory) *cobra.Command {
+	return newInspectAllCommand(nil, nil, clientFactory)
+}
+
+// newInspectAllCommand はinspect-allコマンドを作成（ScannerとInspectorがnilの場合はclientFactoryのクライアントを使用）
+func newInspectAllCommand(scannerImpl ScannerInterface, inspectorImpl InspectorInterface, clientFactory aws.ClientFactory) *cobra.Command {
+	var clusterNames []string
+	var allClusters bool
+	var outputFormat string
+	var region string
+	var profile string
+	var concurrency int
+	var sortBy string
+	var configFiles []string
+	var configProfile string
+
+	cmd := &cobra.Command{
+		Use:   "inspect-all",
+		Short: "クラスター内のすべてのECSサービスを詳細調査",
+		Long: `クラスター内のすべてのECSサービスを並列に詳細調査します。
+
+--cluster を複数指定するか --all-clusters を指定すると、複数クラスターの
+サービスを1回の実行でまとめて調査し、結果をクラスターごとにまとめて出力します。
+
+テーブル形式ではサービスごとの優先度別レコメンデーション件数を最後に表示します。
+--sort-by recommendations を指定すると、優先度highのレコメンデーションが
+多いサービスから順に出力します（同数の場合はmedium、lowの件数で比較）。
+
+同時に実行する調査の数は --concurrency で指定でき、
+省略時は設定ファイルの concurrency.inspect を使用します。`,
+		Example: `  # クラスター内のすべてのサービスを調査
+  phantom-ecs inspect-all --cluster my-cluster
+
+  # 複数のクラスターをまとめて調査
+  phantom-ecs inspect-all --cluster prod --cluster staging
+
+  # すべてのクラスターを調査
+  phantom-ecs inspect-all --all-clusters
+
+  # 同時実行数を指定してJSON形式で出力
+  phantom-ecs inspect-all --cluster my-cluster --concurrency 5 --output json
+
+  # 優先度の高いレコメンデーションが多いサービスから順に表示
+  phantom-ecs inspect-all --cluster my-cluster --sort-by recommendations`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return applyConfigProfile(cmd, configFiles, configProfile)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			enhancedConfig, err := loadEnhancedConfig(configFiles, configProfile)
+			if err != nil {
+				return err
+			}
+			if len(clusterNames) == 0 && !allClusters && enhancedConfig.DefaultCluster != "" {
+				clusterNames = []string{enhancedConfig.DefaultCluster}
+			}
+			if !cmd.Flags().Changed("concurrency") {
+				concurrency = enhancedConfig.Concurrency.Inspect
+			}
+			return runInspectAll(cmd, scannerImpl, inspectorImpl, clientFactory, clusterNames, allClusters, outputFormat, region, profile, concurrency, sortBy)
+		},
+	}
+
+	// ローカルフラグを定義
+	cmd.Flags().StringSliceVarP(&clusterNames, "cluster", "c", nil, "クラスター名またはクラスターARN (複数指定可、省略時は設定ファイルのdefault_cluster)")
+	cmd.Flags().BoolVar(&allClusters, "all-clusters", false, "すべてのクラスターのサービスを調査")
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "出力形式 (json|yaml|table)")
+	addCompactFlag(cmd)
+	cmd.Flags().StringVarP(&region, "region", "r", "us-east-1", "AWSリージョン")
+	cmd.Flags().StringVarP(&profile, "profile", "p", "", "AWSプロファイル")
+	cmd.Flags().IntVar(&concurrency, "concurrency", 0, "同時に調査するサービス数 (省略時は設定ファイルのconcurrency.inspect)")
+	cmd.Flags().StringVar(&sortBy, "sort-by", "", "結果の並び順 (recommendations: 優先度の高いレコメンデーションが多い順、省略時はクラスターごとのスキャン順)")
+	addConfigFileFlags(cmd, &configFiles, &configProfile)
+	cmd.MarkFlagsMutuallyExclusive("cluster", "all-clusters")
+
+	return cmd
+}
+
+// NewInspectAllCommandWithDefaults はデフォルトのScannerとInspectorでinspect-allコマンドを作成
+func NewInspectAllCommandWithDefaults() *cobra.Command {
+	return NewInspectAllCommand(nil, nil)
+}
+
+// runInspectAll はinspect-allコマンドの実行ロジック
+func runInspectAll(cmd *cobra.Command, scannerImpl ScannerInterface, inspectorImpl InspectorInterface, clientFactory aws.ClientFactory, clusterNames []string, allClusters bool, outputFormat, region, profile string, concurrency int, sortBy string) error {
+	ctx := commandContext(cmd)
+
+	// 必須パラメータの検証
+	if len(clusterNames) == 0 && !allClusters {
+		return fmt.Errorf("cluster name is required")
+	}
+	if concurrency < 1 {
+		return fmt.Errorf("concurrency must be at least 1: %d", concurrency)
+	}
+
+	// 出力形式の検証
+	formatter := utils.NewFormatter()
+	if !formatter.ValidateFormat(outputFormat) {
+		return fmt.Errorf("unsupported output format: %s. Supported formats: %v",
+			outputFormat, formatter.GetSupportedFormats())
+	}
+	outputFormat = formatter.NormalizeFormat(outputFormat)
+	if sortBy != "" {
+		if err := utils.ValidateInspectionSort(sortBy); err != nil {
+			return err
+		}
+	}
+
+	// ScannerとInspectorがnilの場合（実際のAWS呼び出し用）は、AWS実装を作成
+	var scannerToUse ScannerInterface
+	var inspectorToUse InspectorInterface
+	if scannerImpl != nil && inspectorImpl != nil {
+		scannerToUse = scannerImpl
+		inspectorToUse = inspectorImpl
+	} else {
+		client, err := newECSClient(ctx, cmd, clientFactory, region, profile)
+		if err != nil {
+			return fmt.Errorf("failed to create AWS client: %w", err)
+		}
+		scannerToUse = scanner.NewScanner(client)
+		inspectorToUse = newInspector(client)
+	}
+
+	// 調査対象のクラスターを決定（名前とARNで同じクラスターを指定した場合は1つにまとめる）
+	clusters := uniqueClusterNames(clusterNames)
+	if allClusters {
+		discovered, err := scannerToUse.DiscoverClusters(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to discover clusters: %w", err)
+		}
+		clusters = discovered
+	}
+
+	return inspectClusterServices(ctx, cmd, scannerToUse, inspectorToUse, clusters, "", formatter, outputFormat, concurrency, sortBy)
+}
+
+// inspectClusterServices はクラスター内のサービスのうち名前がprefixで始まるものを並列に詳細調査して出力する
+// prefixが空の場合はすべてのサービスを調査し、sortByが空の場合はスキャンした順序で出力する
+func inspectClusterServices(ctx context.Context, cmd *cobra.Command, scannerToUse ScannerInterface, inspectorToUse InspectorInterface, clusters []string, prefix string, formatter *utils.Formatter, outputFormat string, concurrency int, sortBy string) error {
+	// 調査対象のサービスを取得（クラスターごとにまとまった順序で返る）
+	var services []models.ECSService
+	if len(clusters) > 0 {
+		scanned, err := scannerToUse.ScanServices(ctx, clusters)
+		if err != nil {
+			return fmt.Errorf("failed to scan services: %w", err)
+		}
+		services = filterServicesByPrefix(scanner.ExcludeInactive(scanned), prefix)
+	}
+
+	// クラスターが異なれば同名のサービスも別に調査するため、クラスター名付きのキーで処理する
+	serviceKeys := make([]string, 0, len(services))
+	for _, service := range services {
+		serviceKeys = append(serviceKeys, inspectTargetKey(service.ClusterName, service.ServiceName))
+	}
+
+	// バッチ処理のワーカープールで同時実行数を制限して調査
+	// （AWS APIの一時的な障害はRetryingClientで再試行されるため、ここでは再試行しない）
+	processor := &inspectProcessor{
+		inspector: inspectorToUse,
+		results:   make(map[string]*models.InspectionResult),
+	}
+	batchProcessor := batch.NewBatchProcessorWithOutput(&batch.Config{
+		MaxConcurrency: concurrency,
+	}, processor, cmd.ErrOrStderr())
+
+	processResults, err := batchProcessor.ProcessServices(ctx, serviceKeys)
+	if err != nil {
+		return fmt.Errorf("failed to inspect services: %w", err)
+	}
+
+	// 結果はスキャンした順序（クラスターごと）で出力し、--sort-by指定時は並び替える
+	results := make([]models.InspectionResult, 0, len(serviceKeys))
+	var failed []string
+	for _, processResult := range processResults {
+		if !processResult.Success {
+			failed = append(failed, fmt.Sprintf("%s (%v)", processResult.ServiceName, processResult.Error))
+			continue
+		}
+		results = append(results, *processor.results[processResult.ServiceName])
+	}
+	if sortBy != "" {
+		sorted, err := utils.SortInspectionResults(results, sortBy)
+		if err != nil {
+			return err
+		}
+		results = sorted
+	}
+
+	// 結果をフォーマットして出力
+	output, err := formatter.FormatWithOptions(results, utils.FormatOptions{
+		Format:      outputFormat,
+		PrettyPrint: prettyPrint(cmd),
+		Indent:      jsonIndent(cmd),
+		YAMLFlow:    yamlFlow(cmd),
+		Wide:        wide(cmd),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), output)
+	printScannerWarnings(cmd, scannerToUse)
+
+	if len(failed) > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("failed to inspect %d service(s): %s", len(failed), strings.Join(failed, ", "))
+	}
+
+	return nil
+}
+
+// filterServicesByPrefix は名前がprefixで始まるサービスのみを返す
+func filterServicesByPrefix(services []models.ECSService, prefix string) []models.ECSService {
+	if prefix == "" {
+		return services
+	}
+	filtered := make([]models.ECSService, 0, len(services))
+	for _, service := range services {
+		if strings.HasPrefix(service.ServiceName, prefix) {
+			filtered = append(filtered, service)
+		}
+	}
+	return filtered
+}
+
+// loadEnhancedConfig は設定ファイルが指定されていれば読み込み、なければデフォルト設定を返す
+func loadEnhancedConfig(configFiles []string, configProfile string) (*config.EnhancedConfig, error) {
+	if len(configFiles) == 0 {
+		return config.GetDefaultEnhancedConfig(), nil
+	}
+
+	enhancedConfig, err := config.LoadFromFiles(configFiles, configProfile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config file: %w", err)
+	}
+	enhancedConfig.MergeWithEnvironment()
+	return enhancedConfig, nil
+}
+
+// uniqueClusterNames はクラスターARNをクラスター名に正規化し、重複を除いたクラスター名を指定順に返す
+func uniqueClusterNames(clusterNames []string) []string {
+	seen := map[string]bool{}
+	var clusters []string
+	for _, clusterName := range clusterNames {
+		name := arn.ClusterName(clusterName)
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		clusters = append(clusters, name)
+	}
+	return clusters
+}
+
+// inspectTargetKey はバッチ処理で調査対象を識別する "クラスター名/サービス名" 形式のキーを返す
+func inspectTargetKey(clusterName, serviceName string) string {
+	return clusterName + "/" + serviceName
+}
+
+// inspectProcessor はバッチ処理のワーカーからサービスの詳細調査を実行するプロセッサ
+type inspectProcessor struct {
+	inspector InspectorInterface
+
+	mu      sync.Mutex
+	results map[string]*models.InspectionResult
+}
+
+// Process は "クラスター名/サービス名" 形式のキーで指定されたサービスを詳細調査して結果を保持する
+func (p *inspectProcessor) Process(ctx context.Context, key string) error {
+	clusterName, serviceName, _ := strings.Cut(key, "/")
+	result, err := p.inspector.InspectService(ctx, serviceName, clusterName)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.results[key] = result
+	return nil
+}
@@ -0,0 +1,430 @@
+package cmd_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/dev-shimada/phantom-ecs/cmd"
+	phantomaws "github.com/dev-shimada/phantom-ecs/internal/aws"
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+	"github.com/dev-shimada/phantom-ecs/internal/scanner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// CountingInspector は同時に実行中のInspectService呼び出し数の最大値を記録するInspector
+type CountingInspector struct {
+	Delay time.Duration
+
+	mu            sync.Mutex
+	inFlight      int
+	MaxConcurrent int
+	Calls         int
+}
+
+func (c *CountingInspector) InspectService(ctx context.Context, serviceName, clusterName string) (*models.InspectionResult, error) {
+	c.mu.Lock()
+	c.inFlight++
+	c.Calls++
+	if c.inFlight > c.MaxConcurrent {
+		c.MaxConcurrent = c.inFlight
+	}
+	c.mu.Unlock()
+
+	time.Sleep(c.Delay)
+
+	c.mu.Lock()
+	c.inFlight--
+	c.mu.Unlock()
+
+	return &models.InspectionResult{
+		Service: models.ECSService{ServiceName: serviceName, ClusterName: clusterName, Status: "ACTIVE"},
+	}, nil
+}
+
+// newInspectAllScanner は指定数のサービスを持つクラスターをスキャンするScannerを作成
+func newInspectAllScanner(clusterName string, count int) *scanner.Scanner {
+	var services []types.Service
+	for i := 0; i < count; i++ {
+		services = append(services, fakeService(fmt.Sprintf("service-%d", i), "ACTIVE", "FARGATE", 1, 1))
+	}
+	return scanner.NewScanner(&FakeECSClient{Services: map[string][]types.Service{clusterName: services}})
+}
+
+func TestInspectAllCommand_ConcurrencyBoundsInspectCalls(t *testing.T) {
+	tests := []struct {
+		name        string
+		concurrency int
+	}{
+		{name: "同時実行数1", concurrency: 1},
+		{name: "同時実行数2", concurrency: 2},
+		{name: "同時実行数4", concurrency: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inspector := &CountingInspector{Delay: 20 * time.Millisecond}
+			inspectAllCmd := cmd.NewInspectAllCommand(newInspectAllScanner("prod", 8), inspector)
+			var buf bytes.Buffer
+			inspectAllCmd.SetOut(&buf)
+			inspectAllCmd.SetErr(&bytes.Buffer{})
+			inspectAllCmd.SetArgs([]string{"--cluster", "prod", "--concurrency", strconv.Itoa(tt.concurrency), "--output", "json"})
+
+			err := inspectAllCmd.Execute()
+			require.NoError(t, err)
+
+			assert.Equal(t, 8, inspector.Calls)
+			assert.LessOrEqual(t, inspector.MaxConcurrent, tt.concurrency)
+
+			// 結果はスキャンした順序で出力される
+			var results []models.InspectionResult
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &results))
+			require.Len(t, results, 8)
+			assert.Equal(t, "service-0", results[0].Service.ServiceName)
+			assert.Equal(t, "service-7", results[7].Service.ServiceName)
+		})
+	}
+}
+
+func TestInspectAllCommand_DefaultConcurrencyFromConfig(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "phantom-ecs.yaml")
+	yamlContent := "profiles:\n  default:\n    region: us-east-1\n    default_cluster: prod\nbatch:\n  max_concurrency: 2\n"
+	require.NoError(t, os.WriteFile(configFile, []byte(yamlContent), 0644))
+
+	inspector := &CountingInspector{Delay: 20 * time.Millisecond}
+	inspectAllCmd := cmd.NewInspectAllCommand(newInspectAllScanner("prod", 6), inspector)
+	inspectAllCmd.SetOut(&bytes.Buffer{})
+	inspectAllCmd.SetErr(&bytes.Buffer{})
+	inspectAllCmd.SetArgs([]string{"--config-file", configFile})
+
+	err := inspectAllCmd.Execute()
+	require.NoError(t, err)
+
+	assert.Equal(t, 6, inspector.Calls)
+	assert.LessOrEqual(t, inspector.MaxConcurrent, 2)
+}
+
+func TestInspectAllCommand_ConcurrencyInspectFromConfig(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "phantom-ecs.yaml")
+	// concurrency.inspectはbatch.max_concurrencyやconcurrency.scanより優先される
+	yamlContent := "profiles:\n  default:\n    region: us-east-1\n    default_cluster: prod\nbatch:\n  max_concurrency: 8\nconcurrency:\n  scan: 8\n  inspect: 1\n  batch: 8\n"
+	require.NoError(t, os.WriteFile(configFile, []byte(yamlContent), 0644))
+
+	inspector := &CountingInspector{Delay: 10 * time.Millisecond}
+	inspectAllCmd := cmd.NewInspectAllCommand(newInspectAllScanner("prod", 4), inspector)
+	inspectAllCmd.SetOut(&bytes.Buffer{})
+	inspectAllCmd.SetErr(&bytes.Buffer{})
+	inspectAllCmd.SetArgs([]string{"--config-file", configFile})
+
+	require.NoError(t, inspectAllCmd.Execute())
+
+	assert.Equal(t, 4, inspector.Calls)
+	assert.Equal(t, 1, inspector.MaxConcurrent)
+}
+
+func TestInspectAllCommand_InvalidConcurrency(t *testing.T) {
+	inspector := &CountingInspector{}
+	inspectAllCmd := cmd.NewInspectAllCommand(newInspectAllScanner("prod", 1), inspector)
+	inspectAllCmd.SetOut(&bytes.Buffer{})
+	inspectAllCmd.SetErr(&bytes.Buffer{})
+	inspectAllCmd.SetArgs([]string{"--cluster", "prod", "--concurrency", "0"})
+
+	err := inspectAllCmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "concurrency must be at least 1")
+	assert.Zero(t, inspector.Calls)
+}
+
+func TestInspectAllCommand_MultipleClusters(t *testing.T) {
+	newScanner := func() *scanner.Scanner {
+		return scanner.NewScanner(&FakeECSClient{Services: map[string][]types.Service{
+			"prod": {
+				fakeService("web", "ACTIVE", "FARGATE", 1, 1),
+				fakeService("api", "ACTIVE", "FARGATE", 1, 1),
+			},
+			// クラスターが異なれば同名のサービスも別に調査する
+			"staging": {fakeService("web", "ACTIVE", "FARGATE", 1, 1)},
+		}})
+	}
+	inspected := func(results []models.InspectionResult) []string {
+		var names []string
+		for _, result := range results {
+			names = append(names, result.Service.ClusterName+"/"+result.Service.ServiceName)
+		}
+		return names
+	}
+
+	t.Run("--clusterの複数指定", func(t *testing.T) {
+		inspector := &CountingInspector{}
+		inspectAllCmd := cmd.NewInspectAllCommand(newScanner(), inspector)
+		var buf bytes.Buffer
+		inspectAllCmd.SetOut(&buf)
+		inspectAllCmd.SetErr(&bytes.Buffer{})
+		inspectAllCmd.SetArgs([]string{"--cluster", "staging", "--cluster", "prod", "--output", "json"})
+
+		require.NoError(t, inspectAllCmd.Execute())
+
+		var results []models.InspectionResult
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &results))
+		assert.Equal(t, []string{"staging/web", "prod/web", "prod/api"}, inspected(results))
+		assert.Equal(t, 3, inspector.Calls)
+	})
+
+	t.Run("--all-clustersはクラスターごとにまとめて出力", func(t *testing.T) {
+		inspector := &CountingInspector{}
+		inspectAllCmd := cmd.NewInspectAllCommand(newScanner(), inspector)
+		var buf bytes.Buffer
+		inspectAllCmd.SetOut(&buf)
+		inspectAllCmd.SetErr(&bytes.Buffer{})
+		inspectAllCmd.SetArgs([]string{"--all-clusters", "--output", "json"})
+
+		require.NoError(t, inspectAllCmd.Execute())
+
+		var results []models.InspectionResult
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &results))
+		// クラスターの発見順は不定のため、クラスターごとに連続していることを確認
+		assert.ElementsMatch(t, []string{"prod/web", "prod/api", "staging/web"}, inspected(results))
+		clusterChanges := 0
+		for i := 1; i < len(results); i++ {
+			if results[i].Service.ClusterName != results[i-1].Service.ClusterName {
+				clusterChanges++
+			}
+		}
+		assert.Equal(t, 1, clusterChanges)
+	})
+
+	t.Run("同じクラスターの名前とARNは1つにまとめる", func(t *testing.T) {
+		inspector := &CountingInspector{}
+		inspectAllCmd := cmd.NewInspectAllCommand(newScanner(), inspector)
+		var buf bytes.Buffer
+		inspectAllCmd.SetOut(&buf)
+		inspectAllCmd.SetErr(&bytes.Buffer{})
+		inspectAllCmd.SetArgs([]string{"--cluster", "prod", "--cluster", "arn:aws:ecs:us-east-1:123456789012:cluster/prod"})
+
+		require.NoError(t, inspectAllCmd.Execute())
+
+		assert.Equal(t, 2, inspector.Calls)
+		assert.NotContains(t, buf.String(), "##### CLUSTER:")
+	})
+
+	t.Run("テーブル形式ではクラスターごとに見出しを表示", func(t *testing.T) {
+		inspectAllCmd := cmd.NewInspectAllCommand(newScanner(), &CountingInspector{})
+		var buf bytes.Buffer
+		inspectAllCmd.SetOut(&buf)
+		inspectAllCmd.SetErr(&bytes.Buffer{})
+		inspectAllCmd.SetArgs([]string{"--cluster", "prod,staging"})
+
+		require.NoError(t, inspectAllCmd.Execute())
+
+		output := buf.String()
+		assert.Equal(t, 1, strings.Count(output, "##### CLUSTER: prod #####"))
+		assert.Equal(t, 1, strings.Count(output, "##### CLUSTER: staging #####"))
+		assert.Less(t, strings.Index(output, "CLUSTER: prod"), strings.Index(output, "CLUSTER: staging"))
+	})
+
+	t.Run("--clusterと--all-clustersは同時に指定できない", func(t *testing.T) {
+		inspector := &CountingInspector{}
+		inspectAllCmd := cmd.NewInspectAllCommand(newScanner(), inspector)
+		inspectAllCmd.SetOut(&bytes.Buffer{})
+		inspectAllCmd.SetErr(&bytes.Buffer{})
+		inspectAllCmd.SetArgs([]string{"--cluster", "prod", "--all-clusters"})
+
+		require.Error(t, inspectAllCmd.Execute())
+		assert.Zero(t, inspector.Calls)
+	})
+}
+
+func TestInspectAllCommand_SortByRecommendations(t *testing.T) {
+	recommendations := map[string][]models.Recommendation{
+		"web": {{Title: "Scaling", Priority: "medium"}},
+		"api": {
+			{Title: "Rollout", Priority: "high"},
+			{Title: "Cost", Priority: "low"},
+			{Title: "Scaling", Priority: "medium"},
+		},
+		"worker": {
+			{Title: "Health Check", Priority: "medium"},
+			{Title: "Latest Tag", Priority: "medium"},
+		},
+		"batch": nil,
+	}
+	newInspector := func() *MockInspector {
+		inspector := &MockInspector{}
+		for name, recs := range recommendations {
+			inspector.On("InspectService", mock.Anything, name, "prod").Return(&models.InspectionResult{
+				Service:         models.ECSService{ServiceName: name, ClusterName: "prod", Status: "ACTIVE"},
+				Recommendations: recs,
+			}, nil)
+		}
+		return inspector
+	}
+	newScanner := func() *scanner.Scanner {
+		return scanner.NewScanner(&FakeECSClient{Services: map[string][]types.Service{
+			"prod": {
+				fakeService("web", "ACTIVE", "FARGATE", 1, 1),
+				fakeService("api", "ACTIVE", "FARGATE", 1, 1),
+				fakeService("batch", "ACTIVE", "FARGATE", 1, 1),
+				fakeService("worker", "ACTIVE", "FARGATE", 1, 1),
+			},
+		}})
+	}
+
+	t.Run("テーブル形式でサービスごとの件数を表示", func(t *testing.T) {
+		inspectAllCmd := cmd.NewInspectAllCommand(newScanner(), newInspector())
+		var buf bytes.Buffer
+		inspectAllCmd.SetOut(&buf)
+		inspectAllCmd.SetErr(&bytes.Buffer{})
+		inspectAllCmd.SetArgs([]string{"--cluster", "prod", "--sort-by", "recommendations"})
+
+		require.NoError(t, inspectAllCmd.Execute())
+
+		output := buf.String()
+		require.Contains(t, output, "=== RECOMMENDATION SUMMARY ===")
+		lines := strings.Split(output[strings.Index(output, "=== RECOMMENDATION SUMMARY ==="):], "\n")
+		require.GreaterOrEqual(t, len(lines), 7)
+		assert.Regexp(t, `^CLUSTER\s+SERVICE\s+HIGH\s+MEDIUM\s+LOW$`, lines[1])
+		// highの件数、mediumの件数、lowの件数の順で比較し、多い順に並ぶ
+		assert.Regexp(t, `^prod\s+api\s+1\s+1\s+1$`, lines[3])
+		assert.Regexp(t, `^prod\s+worker\s+0\s+2\s+0$`, lines[4])
+		assert.Regexp(t, `^prod\s+web\s+0\s+1\s+0$`, lines[5])
+		assert.Regexp(t, `^prod\s+batch\s+0\s+0\s+0$`, lines[6])
+	})
+
+	t.Run("JSON形式の結果も並び替える", func(t *testing.T) {
+		inspectAllCmd := cmd.NewInspectAllCommand(newScanner(), newInspector())
+		var buf bytes.Buffer
+		inspectAllCmd.SetOut(&buf)
+		inspectAllCmd.SetErr(&bytes.Buffer{})
+		inspectAllCmd.SetArgs([]string{"--cluster", "prod", "--sort-by", "recommendations", "--output", "json"})
+
+		require.NoError(t, inspectAllCmd.Execute())
+
+		var results []models.InspectionResult
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &results))
+		var names []string
+		for _, result := range results {
+			names = append(names, result.Service.ServiceName)
+		}
+		assert.Equal(t, []string{"api", "worker", "web", "batch"}, names)
+	})
+
+	t.Run("未指定時はスキャン順", func(t *testing.T) {
+		inspectAllCmd := cmd.NewInspectAllCommand(newScanner(), newInspector())
+		var buf bytes.Buffer
+		inspectAllCmd.SetOut(&buf)
+		inspectAllCmd.SetErr(&bytes.Buffer{})
+		inspectAllCmd.SetArgs([]string{"--cluster", "prod"})
+
+		require.NoError(t, inspectAllCmd.Execute())
+
+		output := buf.String()
+		lines := strings.Split(output[strings.Index(output, "=== RECOMMENDATION SUMMARY ==="):], "\n")
+		require.GreaterOrEqual(t, len(lines), 7)
+		assert.Regexp(t, `^prod\s+web\s+`, lines[3])
+		assert.Regexp(t, `^prod\s+api\s+`, lines[4])
+	})
+
+	t.Run("未知の並び替えキーは調査前にエラー", func(t *testing.T) {
+		inspector := &CountingInspector{}
+		inspectAllCmd := cmd.NewInspectAllCommand(newScanner(), inspector)
+		inspectAllCmd.SetOut(&bytes.Buffer{})
+		inspectAllCmd.SetErr(&bytes.Buffer{})
+		inspectAllCmd.SetArgs([]string{"--cluster", "prod", "--sort-by", "name"})
+
+		err := inspectAllCmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported sort key: name")
+		assert.Zero(t, inspector.Calls)
+	})
+}
+
+func TestInspectAllCommand_SkippedClusterWarning(t *testing.T) {
+	newScanner := func() *scanner.Scanner {
+		return scanner.NewScanner(&FakeECSClient{
+			Services: map[string][]types.Service{
+				"prod": {fakeService("web", "ACTIVE", "FARGATE", 1, 1)},
+				// クラスターの一覧には含まれるが、一覧の取得後に削除されたクラスター
+				"deleted": {},
+			},
+			ListServicesErrors: map[string]error{"deleted": &types.ClusterNotFoundException{Message: aws.String("Cluster not found.")}},
+		})
+	}
+
+	t.Run("--all-clustersで発見したクラスターは警告してスキップ", func(t *testing.T) {
+		inspector := &CountingInspector{}
+		inspectAllCmd := cmd.NewInspectAllCommand(newScanner(), inspector)
+		var stdout, stderr bytes.Buffer
+		inspectAllCmd.SetOut(&stdout)
+		inspectAllCmd.SetErr(&stderr)
+		inspectAllCmd.SetArgs([]string{"--all-clusters", "--output", "json"})
+
+		code := cmd.Run(inspectAllCmd)
+
+		assert.Equal(t, 0, code)
+		assert.Contains(t, stderr.String(), "Warning: skipped cluster deleted: cluster not found\n")
+		var results []models.InspectionResult
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &results))
+		require.Len(t, results, 1)
+		assert.Equal(t, "prod", results[0].Service.ClusterName)
+		assert.Equal(t, 1, inspector.Calls)
+	})
+
+	t.Run("--clusterで指定したクラスターが存在しなければエラー", func(t *testing.T) {
+		inspector := &CountingInspector{}
+		inspectAllCmd := cmd.NewInspectAllCommand(newScanner(), inspector)
+		var stdout, stderr bytes.Buffer
+		inspectAllCmd.SetOut(&stdout)
+		inspectAllCmd.SetErr(&stderr)
+		inspectAllCmd.SetArgs([]string{"--cluster", "deleted", "--cluster", "prod", "--output", "json"})
+
+		code := cmd.Run(inspectAllCmd)
+
+		assert.NotEqual(t, 0, code)
+		assert.Contains(t, stderr.String(), "ClusterNotFoundException")
+		assert.Equal(t, 0, inspector.Calls)
+	})
+}
+
+func TestInspectAllCommand_ClientFactory(t *testing.T) {
+	family := "web-task"
+	taskDefArn := "arn:aws:ecs:us-east-1:123456789012:task-definition/web-task:3"
+	web := fakeService("web", "ACTIVE", "FARGATE", 2, 2)
+	web.TaskDefinition = &taskDefArn
+	api := fakeService("api", "ACTIVE", "FARGATE", 1, 1)
+	api.TaskDefinition = &taskDefArn
+	factory := &FakeClientFactory{Clients: map[string]phantomaws.ECSClient{
+		"us-east-1": &FakeECSClient{
+			Services: map[string][]types.Service{"prod": {web, api}},
+			TaskDefinitions: map[string]*types.TaskDefinition{
+				taskDefArn: {TaskDefinitionArn: &taskDefArn, Family: &family, Revision: 3, Status: types.TaskDefinitionStatusActive},
+			},
+		},
+	}}
+
+	var buf bytes.Buffer
+	inspectAllCmd := cmd.NewInspectAllCommandWithClientFactory(factory)
+	inspectAllCmd.SetOut(&buf)
+	inspectAllCmd.SetErr(&bytes.Buffer{})
+	inspectAllCmd.SetArgs([]string{"--cluster", "prod", "--output", "json"})
+
+	require.NoError(t, inspectAllCmd.Execute())
+
+	var results []models.InspectionResult
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &results))
+	require.Len(t, results, 2)
+	names := []string{results[0].Service.ServiceName, results[1].Service.ServiceName}
+	assert.ElementsMatch(t, []string{"web", "api"}, names)
+}
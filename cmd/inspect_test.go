@@ -1,13 +1,26 @@
 package cmd_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
 	"github.com/dev-shimada/phantom-ecs/cmd"
+	phantomaws "github.com/dev-shimada/phantom-ecs/internal/aws"
+	"github.com/dev-shimada/phantom-ecs/internal/inspector"
 	"github.com/dev-shimada/phantom-ecs/internal/models"
+	"github.com/dev-shimada/phantom-ecs/internal/scanner"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockInspector はInspectorのモック
@@ -156,7 +169,7 @@ func TestInspectCommandHelp(t *testing.T) {
 	cmd := cmd.NewInspectCommand(mockInspector)
 
 	// コマンドの基本情報確認
-	assert.Equal(t, "inspect <service-name>", cmd.Use)
+	assert.Equal(t, "inspect <service-name|service-arn>", cmd.Use)
 	assert.NotEmpty(t, cmd.Short)
 	assert.NotEmpty(t, cmd.Long)
 	assert.NotEmpty(t, cmd.Example)
@@ -169,3 +182,845 @@ func TestInspectCommandArgs(t *testing.T) {
 	// 引数の検証確認
 	assert.NotNil(t, cmd.Args)
 }
+
+func TestInspectCommand_ClusterARNNormalization(t *testing.T) {
+	for _, clusterArg := range []string{"prod", "arn:aws:ecs:us-east-1:123456789012:cluster/prod"} {
+		t.Run(clusterArg, func(t *testing.T) {
+			mockInspector := &MockInspector{}
+			mockInspector.On("InspectService", mock.Anything, "web-service", "prod").Return(&models.InspectionResult{
+				Service: models.ECSService{ServiceName: "web-service", ClusterName: "prod"},
+			}, nil)
+
+			cmd := cmd.NewInspectCommand(mockInspector)
+			cmd.SetArgs([]string{"web-service", "--cluster", clusterArg, "--output", "json"})
+
+			err := cmd.Execute()
+			assert.NoError(t, err)
+			mockInspector.AssertExpectations(t)
+		})
+	}
+}
+
+func TestInspectCommand_DefaultClusterFromConfig(t *testing.T) {
+	tempDir := t.TempDir()
+	configFile := filepath.Join(tempDir, "phantom-ecs.yaml")
+	yamlContent := "profiles:\n  default:\n    region: us-east-1\n    default_cluster: config-cluster\n"
+	require.NoError(t, os.WriteFile(configFile, []byte(yamlContent), 0644))
+	overlayFile := filepath.Join(tempDir, "overlay.yaml")
+	require.NoError(t, os.WriteFile(overlayFile, []byte("profiles:\n  default:\n    default_cluster: overlay-cluster\n"), 0644))
+
+	tests := []struct {
+		name            string
+		args            []string
+		expectedCluster string
+	}{
+		{
+			name:            "フラグ未指定時は設定ファイルのdefault_clusterを使用",
+			args:            []string{"web-service", "--config-file", configFile, "--output", "json"},
+			expectedCluster: "config-cluster",
+		},
+		{
+			name:            "フラグ指定時はフラグを優先",
+			args:            []string{"web-service", "--config-file", configFile, "--cluster", "flag-cluster", "--output", "json"},
+			expectedCluster: "flag-cluster",
+		},
+		{
+			name:            "複数指定時は後のファイルを優先",
+			args:            []string{"web-service", "--config-file", configFile, "--config-file", overlayFile, "--output", "json"},
+			expectedCluster: "overlay-cluster",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockInspector := &MockInspector{}
+			mockInspector.On("InspectService", mock.Anything, "web-service", tt.expectedCluster).Return(&models.InspectionResult{
+				Service: models.ECSService{ServiceName: "web-service", ClusterName: tt.expectedCluster},
+			}, nil)
+
+			cmd := cmd.NewInspectCommand(mockInspector)
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			assert.NoError(t, err)
+			mockInspector.AssertExpectations(t)
+		})
+	}
+}
+
+func TestInspectCommand_NoClusterWithoutConfig(t *testing.T) {
+	mockInspector := &MockInspector{}
+
+	cmd := cmd.NewInspectCommand(mockInspector)
+	cmd.SetArgs([]string{"web-service"})
+
+	err := cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "cluster name is required")
+	mockInspector.AssertNotCalled(t, "InspectService", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestInspectCommand_Export(t *testing.T) {
+	mockInspector := &MockInspector{}
+	mockInspector.On("InspectService", mock.Anything, "web-service", "prod").Return(&models.InspectionResult{
+		Service:        models.ECSService{ServiceName: "web-service", ClusterName: "prod", DesiredCount: 1},
+		TaskDefinition: models.ECSTaskDefinition{Family: "web-task"},
+	}, nil)
+
+	var buf bytes.Buffer
+	cmd := cmd.NewInspectCommand(mockInspector)
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{"web-service", "--cluster", "prod", "--export", "terraform"})
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), `resource "aws_ecs_service" "web_service"`)
+	mockInspector.AssertExpectations(t)
+}
+
+func TestInspectCommand_CompareRevision(t *testing.T) {
+	current := models.ECSTaskDefinition{
+		Family:   "web-task",
+		Revision: 3,
+		CPU:      "256",
+		Containers: []models.ContainerDefinition{
+			{Name: "app", Image: "nginx:1.27", Essential: true},
+		},
+	}
+	previous := current
+	previous.Revision = 2
+	previous.Containers = []models.ContainerDefinition{
+		{Name: "app", Image: "nginx:1.25", Essential: true},
+	}
+
+	mockInspector := &MockInspectorForDeploy{}
+	mockInspector.On("InspectService", mock.Anything, "web-service", "prod").Return(&models.InspectionResult{
+		Service:        models.ECSService{ServiceName: "web-service", ClusterName: "prod"},
+		TaskDefinition: current,
+	}, nil)
+	mockInspector.On("AnalyzeTaskDefinition", mock.Anything, "web-task:2").Return(&previous, nil)
+
+	var buf bytes.Buffer
+	cmd := cmd.NewInspectCommand(mockInspector)
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{"web-service", "--cluster", "prod", "--compare-revision", "--output", "json"})
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	var result models.InspectionResult
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+	require.NotNil(t, result.RevisionComparison)
+	assert.Equal(t, 2, result.RevisionComparison.PreviousRevision)
+	assert.Equal(t, 3, result.RevisionComparison.CurrentRevision)
+	assert.Equal(t, []models.RevisionChange{
+		{Field: "containers.0.image", Previous: "nginx:1.25", Current: "nginx:1.27"},
+	}, result.RevisionComparison.Changes)
+	mockInspector.AssertExpectations(t)
+}
+
+func TestInspectCommand_CompareRevision_Table(t *testing.T) {
+	mockInspector := &MockInspectorForDeploy{}
+	mockInspector.On("InspectService", mock.Anything, "web-service", "prod").Return(&models.InspectionResult{
+		Service: models.ECSService{ServiceName: "web-service", ClusterName: "prod"},
+		TaskDefinition: models.ECSTaskDefinition{Family: "web-task", Revision: 5, Containers: []models.ContainerDefinition{
+			{Name: "app", Image: "app:v2"},
+		}},
+	}, nil)
+	mockInspector.On("AnalyzeTaskDefinition", mock.Anything, "web-task:4").Return(&models.ECSTaskDefinition{
+		Family: "web-task", Revision: 4, Containers: []models.ContainerDefinition{
+			{Name: "app", Image: "app:v1"},
+		},
+	}, nil)
+
+	var buf bytes.Buffer
+	cmd := cmd.NewInspectCommand(mockInspector)
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{"web-service", "--cluster", "prod", "--compare-revision"})
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "=== CHANGES FROM REVISION 4 ===")
+	assert.Contains(t, buf.String(), "app:v1")
+	assert.Contains(t, buf.String(), "app:v2")
+}
+
+func TestInspectCommand_CompareRevision_FirstRevision(t *testing.T) {
+	mockInspector := &MockInspectorForDeploy{}
+	mockInspector.On("InspectService", mock.Anything, "web-service", "prod").Return(&models.InspectionResult{
+		Service:        models.ECSService{ServiceName: "web-service", ClusterName: "prod"},
+		TaskDefinition: models.ECSTaskDefinition{Family: "web-task", Revision: 1},
+	}, nil)
+
+	var stdout, stderr bytes.Buffer
+	cmd := cmd.NewInspectCommand(mockInspector)
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs([]string{"web-service", "--cluster", "prod", "--compare-revision"})
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+	assert.Contains(t, stderr.String(), "No previous revision to compare")
+	assert.NotContains(t, stdout.String(), "CHANGES FROM REVISION")
+	mockInspector.AssertNotCalled(t, "AnalyzeTaskDefinition", mock.Anything, mock.Anything)
+}
+
+func TestInspectCommand_CompactJSON(t *testing.T) {
+	mockInspector := &MockInspector{}
+	mockInspector.On("InspectService", mock.Anything, "web-service", "prod").Return(&models.InspectionResult{
+		Service:        models.ECSService{ServiceName: "web-service", ClusterName: "prod"},
+		TaskDefinition: models.ECSTaskDefinition{Family: "web-task", Revision: 2},
+	}, nil)
+
+	t.Run("compact指定時は1行", func(t *testing.T) {
+		var buf bytes.Buffer
+		cmd := cmd.NewInspectCommand(mockInspector)
+		cmd.SetOut(&buf)
+		cmd.SetArgs([]string{"web-service", "--cluster", "prod", "--output", "json", "--compact"})
+
+		require.NoError(t, cmd.Execute())
+		assert.NotContains(t, strings.TrimSpace(buf.String()), "\n")
+		assert.True(t, json.Valid(buf.Bytes()))
+	})
+
+	t.Run("既定は整形済み", func(t *testing.T) {
+		var buf bytes.Buffer
+		cmd := cmd.NewInspectCommand(mockInspector)
+		cmd.SetOut(&buf)
+		cmd.SetArgs([]string{"web-service", "--cluster", "prod", "--output", "json"})
+
+		require.NoError(t, cmd.Execute())
+		assert.Contains(t, buf.String(), "\n  ")
+	})
+}
+
+func TestInspectCommand_NoSpinnerForNonTerminal(t *testing.T) {
+	mockInspector := &MockInspector{}
+	mockInspector.On("InspectService", mock.Anything, "web-service", "prod").Return(&models.InspectionResult{
+		Service:        models.ECSService{ServiceName: "web-service", ClusterName: "prod"},
+		TaskDefinition: models.ECSTaskDefinition{Family: "web-task", Revision: 2},
+	}, nil)
+
+	var stdout, stderr bytes.Buffer
+	cmd := cmd.NewInspectCommand(mockInspector)
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs([]string{"web-service", "--cluster", "prod"})
+
+	require.NoError(t, cmd.Execute())
+	assert.Empty(t, stderr.String())
+	assert.NotContains(t, stdout.String(), "Inspecting service...")
+}
+
+func TestInspectCommand_RecommendationPlugin(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script plugins are not supported on windows")
+	}
+	plugin := filepath.Join(t.TempDir(), "plugin.sh")
+	require.NoError(t, os.WriteFile(plugin, []byte(`#!/bin/sh
+cat >/dev/null
+echo '[{"category":"policy","title":"Missing owner tag","description":"d","priority":"low","action":"a"}]'
+`), 0755))
+
+	mockInspector := &MockInspector{}
+	mockInspector.On("InspectService", mock.Anything, "web-service", "prod").Return(&models.InspectionResult{
+		Service:         models.ECSService{ServiceName: "web-service", ClusterName: "prod"},
+		TaskDefinition:  models.ECSTaskDefinition{Family: "web-task", Revision: 2},
+		Recommendations: []models.Recommendation{{Category: "scaling", Title: "Consider Auto Scaling", Priority: "medium"}},
+	}, nil)
+
+	var buf bytes.Buffer
+	cmd := cmd.NewInspectCommand(mockInspector)
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{"web-service", "--cluster", "prod", "--output", "json", "--recommendation-plugin", plugin})
+
+	require.NoError(t, cmd.Execute())
+
+	var result models.InspectionResult
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+	require.Len(t, result.Recommendations, 2)
+	assert.Equal(t, "Consider Auto Scaling", result.Recommendations[0].Title)
+	assert.Equal(t, "Missing owner tag", result.Recommendations[1].Title)
+}
+
+func TestInspectCommand_RecommendationPluginReceivesMaskedResult(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script plugins are not supported on windows")
+	}
+	dir := t.TempDir()
+	input := filepath.Join(dir, "input.json")
+	plugin := filepath.Join(dir, "plugin.sh")
+	require.NoError(t, os.WriteFile(plugin, []byte(`#!/bin/sh
+cat >"`+input+`"
+echo '[]'
+`), 0755))
+
+	mockInspector := &MockInspector{}
+	mockInspector.On("InspectService", mock.Anything, "web-service", "prod").Return(&models.InspectionResult{
+		Service: models.ECSService{ServiceName: "web-service", ClusterName: "prod"},
+		TaskDefinition: models.ECSTaskDefinition{
+			Family:   "web-task",
+			Revision: 2,
+			Containers: []models.ContainerDefinition{{
+				Name:        "app",
+				Environment: []models.EnvironmentVariable{{Name: "DB_PASSWORD", Value: "hunter2"}},
+				Secrets:     []models.Secret{{Name: "API_KEY", ValueFrom: "arn:aws:secretsmanager:us-east-1:123456789012:secret:api"}},
+			}},
+		},
+	}, nil)
+
+	var buf bytes.Buffer
+	cmd := cmd.NewInspectCommand(mockInspector)
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{"web-service", "--cluster", "prod", "--output", "json", "--mask-secrets=false", "--recommendation-plugin", plugin})
+
+	require.NoError(t, cmd.Execute())
+
+	data, err := os.ReadFile(input)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "hunter2")
+	assert.NotContains(t, string(data), "secretsmanager")
+	assert.Contains(t, buf.String(), "hunter2")
+}
+
+func TestInspectCommand_IncludeTaskDefTags(t *testing.T) {
+	family := "web-task"
+	taskDefArn := "arn:aws:ecs:us-east-1:123456789012:task-definition/web-task:3"
+	service := fakeService("web-service", "ACTIVE", "FARGATE", 1, 1)
+	service.TaskDefinition = &taskDefArn
+	client := &FakeECSClient{
+		Services: map[string][]types.Service{"prod": {service}},
+		TaskDefinitions: map[string]*types.TaskDefinition{
+			taskDefArn: {TaskDefinitionArn: &taskDefArn, Family: &family, Revision: 3},
+		},
+		TaskDefinitionTags: map[string][]types.Tag{
+			taskDefArn: {{Key: aws.String("team"), Value: aws.String("web")}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantTags map[string]string
+	}{
+		{
+			name:     "未指定時はタグを取得しない",
+			args:     []string{"web-service", "--cluster", "prod", "--output", "json"},
+			wantTags: nil,
+		},
+		{
+			name:     "指定時はタグを取得",
+			args:     []string{"web-service", "--cluster", "prod", "--output", "json", "--include-taskdef-tags"},
+			wantTags: map[string]string{"team": "web"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			inspectCmd := cmd.NewInspectCommand(inspector.NewInspector(client))
+			inspectCmd.SetOut(&buf)
+			inspectCmd.SetErr(&bytes.Buffer{})
+			inspectCmd.SetArgs(tt.args)
+
+			require.NoError(t, inspectCmd.Execute())
+
+			var result models.InspectionResult
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+			assert.Equal(t, tt.wantTags, result.TaskDefinition.Tags)
+		})
+	}
+}
+
+func TestInspectCommand_IncludeTaskDefTags_Unsupported(t *testing.T) {
+	cmd := cmd.NewInspectCommand(&MockInspector{})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"web-service", "--cluster", "prod", "--include-taskdef-tags"})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not support fetching task definition tags")
+}
+
+func TestInspectCommand_ContainerPattern(t *testing.T) {
+	newInspector := func() *MockInspector {
+		mockInspector := &MockInspector{}
+		mockInspector.On("InspectService", mock.Anything, "web-service", "prod").Return(&models.InspectionResult{
+			Service: models.ECSService{ServiceName: "web-service", ClusterName: "prod"},
+			TaskDefinition: models.ECSTaskDefinition{
+				Family:   "web-task",
+				Revision: 2,
+				Containers: []models.ContainerDefinition{
+					{Name: "web-app"},
+					{Name: "web-proxy"},
+					{Name: "log-router"},
+				},
+			},
+		}, nil)
+		return mockInspector
+	}
+
+	t.Run("テーブル形式", func(t *testing.T) {
+		var buf bytes.Buffer
+		cmd := cmd.NewInspectCommand(newInspector())
+		cmd.SetOut(&buf)
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs([]string{"web-service", "--cluster", "prod", "--container-pattern", "web-*"})
+
+		require.NoError(t, cmd.Execute())
+		output := buf.String()
+		assert.Contains(t, output, "web-app")
+		assert.Contains(t, output, "web-proxy")
+		assert.NotContains(t, output, "log-router")
+		assert.Contains(t, output, `Showing 2 container(s) matching "web-*" (1 omitted)`)
+	})
+
+	t.Run("JSON形式", func(t *testing.T) {
+		var buf bytes.Buffer
+		cmd := cmd.NewInspectCommand(newInspector())
+		cmd.SetOut(&buf)
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs([]string{"web-service", "--cluster", "prod", "--container-pattern", "log-*", "--output", "json"})
+
+		require.NoError(t, cmd.Execute())
+		var result models.InspectionResult
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+		require.Len(t, result.TaskDefinition.Containers, 1)
+		assert.Equal(t, "log-router", result.TaskDefinition.Containers[0].Name)
+		assert.Equal(t, &models.ContainerFilter{Pattern: "log-*", Matched: 1, Omitted: 2}, result.ContainerFilter)
+	})
+
+	t.Run("不正なパターン", func(t *testing.T) {
+		mockInspector := &MockInspector{}
+		cmd := cmd.NewInspectCommand(mockInspector)
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs([]string{"web-service", "--cluster", "prod", "--container-pattern", "web-["})
+
+		err := cmd.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid container pattern")
+		mockInspector.AssertNotCalled(t, "InspectService", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestInspectCommand_SortRecommendations(t *testing.T) {
+	newMockInspector := func() *MockInspector {
+		mockInspector := &MockInspector{}
+		mockInspector.On("InspectService", mock.Anything, "web-service", "prod").Return(&models.InspectionResult{
+			Service:        models.ECSService{ServiceName: "web-service", ClusterName: "prod"},
+			TaskDefinition: models.ECSTaskDefinition{Family: "web-task", Revision: 2},
+			Recommendations: []models.Recommendation{
+				{Category: "scaling", Title: "Consider Auto Scaling", Priority: "low"},
+				{Category: "health", Title: "Service Health Issue", Priority: "high"},
+			},
+		}, nil)
+		return mockInspector
+	}
+
+	t.Run("優先度順にテーブル表示", func(t *testing.T) {
+		var buf bytes.Buffer
+		inspectCmd := cmd.NewInspectCommand(newMockInspector())
+		inspectCmd.SetOut(&buf)
+		inspectCmd.SetArgs([]string{"web-service", "--cluster", "prod", "--sort-recommendations", "priority"})
+
+		require.NoError(t, inspectCmd.Execute())
+
+		output := buf.String()
+		assert.Regexp(t, `#\s+PRIORITY\s+CATEGORY\s+TITLE\s+ACTION`, output)
+		assert.Regexp(t, `1\s+HIGH\s+health\s+Service Health Issue`, output)
+		assert.Regexp(t, `2\s+LOW\s+scaling\s+Consider Auto Scaling`, output)
+	})
+
+	t.Run("不正なキーはInspector呼び出し前に拒否", func(t *testing.T) {
+		mockInspector := &MockInspector{}
+		inspectCmd := cmd.NewInspectCommand(mockInspector)
+		inspectCmd.SetOut(&bytes.Buffer{})
+		inspectCmd.SetArgs([]string{"web-service", "--cluster", "prod", "--sort-recommendations", "title"})
+
+		err := inspectCmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported recommendation sort key")
+		mockInspector.AssertNotCalled(t, "InspectService", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestInspectCommand_FailIfUnhealthy(t *testing.T) {
+	tests := []struct {
+		name     string
+		service  models.ECSService
+		wantCode int
+	}{
+		{
+			name:     "健全なサービスは終了コード0",
+			service:  models.ECSService{ServiceName: "web-service", ClusterName: "prod", Status: "ACTIVE", DesiredCount: 2, RunningCount: 2},
+			wantCode: 0,
+		},
+		{
+			name:     "実行中タスク数が不足していれば非ゼロ",
+			service:  models.ECSService{ServiceName: "web-service", ClusterName: "prod", Status: "ACTIVE", DesiredCount: 2, RunningCount: 1},
+			wantCode: 3,
+		},
+		{
+			name:     "ACTIVEでなければ非ゼロ",
+			service:  models.ECSService{ServiceName: "web-service", ClusterName: "prod", Status: "DRAINING", DesiredCount: 2, RunningCount: 2},
+			wantCode: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockInspector := &MockInspector{}
+			mockInspector.On("InspectService", mock.Anything, "web-service", "prod").Return(&models.InspectionResult{
+				Service:        tt.service,
+				TaskDefinition: models.ECSTaskDefinition{Family: "web-task", Revision: 2},
+			}, nil)
+
+			var stdout, stderr bytes.Buffer
+			inspectCmd := cmd.NewInspectCommand(mockInspector)
+			inspectCmd.SetOut(&stdout)
+			inspectCmd.SetErr(&stderr)
+			inspectCmd.SetArgs([]string{"web-service", "--cluster", "prod", "--output", "json", "--fail-if-unhealthy"})
+
+			code := cmd.Run(inspectCmd)
+
+			assert.Equal(t, tt.wantCode, code)
+			// 失敗時も結果は出力される
+			var result map[string]interface{}
+			require.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+			if tt.wantCode != 0 {
+				assert.Contains(t, stderr.String(), "service web-service is unhealthy")
+				assert.Contains(t, stderr.String(), fmt.Sprintf("status=%s, running=%d, desired=%d",
+					tt.service.Status, tt.service.RunningCount, tt.service.DesiredCount))
+				assert.NotContains(t, stderr.String(), "Usage:")
+			}
+		})
+	}
+}
+
+func TestInspectCommand_FailOnRecommendation(t *testing.T) {
+	tests := []struct {
+		name            string
+		threshold       string
+		recommendations []models.Recommendation
+		wantCode        int
+		wantStderr      string
+	}{
+		{
+			name:      "優先度highのレコメンデーションがあれば非ゼロ",
+			threshold: "high",
+			recommendations: []models.Recommendation{
+				{Category: "scaling", Title: "Consider Auto Scaling", Priority: "medium"},
+				{Category: "deployment", Title: "Deployment Rollout Failed", Priority: "high"},
+			},
+			wantCode:   3,
+			wantStderr: "1 recommendations at or above high priority: [HIGH] Deployment Rollout Failed",
+		},
+		{
+			name:      "しきい値未満のレコメンデーションのみなら終了コード0",
+			threshold: "high",
+			recommendations: []models.Recommendation{
+				{Category: "scaling", Title: "Consider Auto Scaling", Priority: "medium"},
+				{Category: "cost", Title: "Use Spot", Priority: "low"},
+			},
+			wantCode: 0,
+		},
+		{
+			name:      "しきい値以上の優先度をすべて報告",
+			threshold: "MEDIUM",
+			recommendations: []models.Recommendation{
+				{Category: "scaling", Title: "Consider Auto Scaling", Priority: "medium"},
+				{Category: "cost", Title: "Use Spot", Priority: "low"},
+				{Category: "health", Title: "Service Health Issue", Priority: "high"},
+			},
+			wantCode:   3,
+			wantStderr: "2 recommendations at or above medium priority: [MEDIUM] Consider Auto Scaling, [HIGH] Service Health Issue",
+		},
+		{
+			name:      "レコメンデーションがなければ終了コード0",
+			threshold: "low",
+			wantCode:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockInspector := &MockInspector{}
+			mockInspector.On("InspectService", mock.Anything, "web-service", "prod").Return(&models.InspectionResult{
+				Service:         models.ECSService{ServiceName: "web-service", ClusterName: "prod", Status: "ACTIVE"},
+				TaskDefinition:  models.ECSTaskDefinition{Family: "web-task", Revision: 2},
+				Recommendations: tt.recommendations,
+			}, nil)
+
+			var stdout, stderr bytes.Buffer
+			inspectCmd := cmd.NewInspectCommand(mockInspector)
+			inspectCmd.SetOut(&stdout)
+			inspectCmd.SetErr(&stderr)
+			inspectCmd.SetArgs([]string{"web-service", "--cluster", "prod", "--output", "json", "--fail-on-recommendation", tt.threshold})
+
+			code := cmd.Run(inspectCmd)
+
+			assert.Equal(t, tt.wantCode, code)
+			// 失敗時も結果は出力される
+			var result map[string]interface{}
+			require.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+			if tt.wantCode != 0 {
+				assert.Contains(t, stderr.String(), tt.wantStderr)
+				assert.NotContains(t, stderr.String(), "Usage:")
+			} else {
+				assert.Empty(t, stderr.String())
+			}
+		})
+	}
+
+	t.Run("未知の優先度は調査前にエラー", func(t *testing.T) {
+		mockInspector := &MockInspector{}
+
+		var buf bytes.Buffer
+		inspectCmd := cmd.NewInspectCommand(mockInspector)
+		inspectCmd.SetOut(&buf)
+		inspectCmd.SetErr(&buf)
+		inspectCmd.SetArgs([]string{"web-service", "--cluster", "prod", "--fail-on-recommendation", "critical"})
+
+		err := inspectCmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported recommendation priority: critical")
+		mockInspector.AssertNotCalled(t, "InspectService", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestInspectCommand_OutputAlias(t *testing.T) {
+	mockInspector := &MockInspector{}
+	mockInspector.On("InspectService", mock.Anything, "web-service", "prod").Return(&models.InspectionResult{
+		Service:        models.ECSService{ServiceName: "web-service", ClusterName: "prod"},
+		TaskDefinition: models.ECSTaskDefinition{Family: "web-task", Revision: 2},
+	}, nil)
+
+	t.Run("短縮名でJSON出力", func(t *testing.T) {
+		var buf bytes.Buffer
+		inspectCmd := cmd.NewInspectCommand(mockInspector)
+		inspectCmd.SetOut(&buf)
+		inspectCmd.SetArgs([]string{"web-service", "--cluster", "prod", "-o", "j"})
+
+		require.NoError(t, inspectCmd.Execute())
+
+		var result map[string]interface{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+	})
+
+	t.Run("未知の短縮名はエラー", func(t *testing.T) {
+		inspectCmd := cmd.NewInspectCommand(mockInspector)
+		inspectCmd.SetOut(&bytes.Buffer{})
+		inspectCmd.SetArgs([]string{"web-service", "--cluster", "prod", "-o", "x"})
+
+		err := inspectCmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported output format: x. Supported formats: [json yaml table compact]")
+	})
+}
+
+func TestInspectCommand_JSONIndent(t *testing.T) {
+	mockInspector := &MockInspector{}
+	mockInspector.On("InspectService", mock.Anything, "web-service", "prod").Return(&models.InspectionResult{
+		Service:        models.ECSService{ServiceName: "web-service", ClusterName: "prod"},
+		TaskDefinition: models.ECSTaskDefinition{Family: "web-task", Revision: 2},
+	}, nil)
+
+	tests := []struct {
+		name   string
+		indent string
+		want   string
+	}{
+		{"4スペース", "4", "{\n    \"service\": {\n        \"service_name\""},
+		{"タブ", "tab", "{\n\t\"service\": {\n\t\t\"service_name\""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			cmd := cmd.NewInspectCommand(mockInspector)
+			cmd.SetOut(&buf)
+			cmd.SetArgs([]string{"web-service", "--cluster", "prod", "--output", "json", "--json-indent", tt.indent})
+
+			require.NoError(t, cmd.Execute())
+			assert.True(t, strings.HasPrefix(buf.String(), tt.want), buf.String())
+			assert.True(t, json.Valid(buf.Bytes()))
+		})
+	}
+
+	t.Run("不正な値は拒否", func(t *testing.T) {
+		cmd := cmd.NewInspectCommand(mockInspector)
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs([]string{"web-service", "--cluster", "prod", "--output", "json", "--json-indent", "wide"})
+
+		err := cmd.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "must be a number of spaces (0-8) or tab")
+	})
+}
+
+func TestInspectCommand_ServiceARN(t *testing.T) {
+	serviceARN := "arn:aws:ecs:us-west-2:123456789012:service/prod/web-service"
+	legacyARN := "arn:aws:ecs:us-west-2:123456789012:service/web-service"
+
+	tests := []struct {
+		name    string
+		args    []string
+		cluster string
+	}{
+		{name: "ARNからクラスターとサービスを取得", args: []string{serviceARN}, cluster: "prod"},
+		{name: "一致する--clusterは併用可能", args: []string{serviceARN, "--cluster", "arn:aws:ecs:us-west-2:123456789012:cluster/prod"}, cluster: "prod"},
+		{name: "旧形式のARNは--clusterを使用", args: []string{legacyARN, "--cluster", "staging"}, cluster: "staging"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockInspector := &MockInspector{}
+			mockInspector.On("InspectService", mock.Anything, "web-service", tt.cluster).Return(&models.InspectionResult{
+				Service: models.ECSService{ServiceName: "web-service", ClusterName: tt.cluster},
+			}, nil)
+
+			inspectCmd := cmd.NewInspectCommand(mockInspector)
+			inspectCmd.SetOut(&bytes.Buffer{})
+			inspectCmd.SetArgs(append(tt.args, "--output", "json"))
+
+			require.NoError(t, inspectCmd.Execute())
+			mockInspector.AssertExpectations(t)
+		})
+	}
+
+	errorTests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "ARNと異なる--clusterは拒否", args: []string{serviceARN, "--cluster", "staging"}, wantErr: `does not match the cluster "prod"`},
+		{name: "旧形式のARNで--cluster未指定", args: []string{legacyARN}, wantErr: "cluster name is required"},
+		{name: "サービス以外のARN", args: []string{"arn:aws:ecs:us-west-2:123456789012:cluster/prod"}, wantErr: "not an ECS service ARN"},
+	}
+
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockInspector := &MockInspector{}
+			inspectCmd := cmd.NewInspectCommand(mockInspector)
+			inspectCmd.SetOut(&bytes.Buffer{})
+			inspectCmd.SetErr(&bytes.Buffer{})
+			inspectCmd.SetArgs(tt.args)
+
+			err := inspectCmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			mockInspector.AssertNotCalled(t, "InspectService", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestInspectCommand_Prefix(t *testing.T) {
+	newScanner := func() *scanner.Scanner {
+		return scanner.NewScanner(&FakeECSClient{
+			Services: map[string][]types.Service{
+				"prod": {
+					fakeService("team-a-web", "ACTIVE", "FARGATE", 1, 1),
+					fakeService("team-b-web", "ACTIVE", "FARGATE", 1, 1),
+					fakeService("team-a-api", "ACTIVE", "FARGATE", 2, 2),
+					fakeService("legacy-team-a-batch", "ACTIVE", "EC2", 1, 1),
+				},
+			},
+		})
+	}
+
+	t.Run("名前がプレフィックスに一致するサービスのみ調査", func(t *testing.T) {
+		mockInspector := &MockInspector{}
+		for _, name := range []string{"team-a-web", "team-a-api"} {
+			mockInspector.On("InspectService", mock.Anything, name, "prod").Return(&models.InspectionResult{
+				Service: models.ECSService{ServiceName: name, ClusterName: "prod", Status: "ACTIVE"},
+			}, nil)
+		}
+
+		var buf bytes.Buffer
+		inspectCmd := cmd.NewInspectCommandWithScanner(mockInspector, newScanner())
+		inspectCmd.SetOut(&buf)
+		inspectCmd.SetErr(&bytes.Buffer{})
+		inspectCmd.SetArgs([]string{"--prefix", "team-a-", "--cluster", "prod", "--output", "json"})
+
+		require.NoError(t, inspectCmd.Execute())
+
+		var results []models.InspectionResult
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &results))
+		require.Len(t, results, 2)
+		// スキャンした順序で出力される
+		assert.Equal(t, "team-a-web", results[0].Service.ServiceName)
+		assert.Equal(t, "team-a-api", results[1].Service.ServiceName)
+		mockInspector.AssertExpectations(t)
+		mockInspector.AssertNotCalled(t, "InspectService", mock.Anything, "team-b-web", mock.Anything)
+		mockInspector.AssertNotCalled(t, "InspectService", mock.Anything, "legacy-team-a-batch", mock.Anything)
+	})
+
+	t.Run("一致するサービスがなければ空の結果", func(t *testing.T) {
+		mockInspector := &MockInspector{}
+
+		var buf bytes.Buffer
+		inspectCmd := cmd.NewInspectCommandWithScanner(mockInspector, newScanner())
+		inspectCmd.SetOut(&buf)
+		inspectCmd.SetErr(&bytes.Buffer{})
+		inspectCmd.SetArgs([]string{"--prefix", "team-c-", "--cluster", "prod", "--output", "json"})
+
+		require.NoError(t, inspectCmd.Execute())
+		assert.JSONEq(t, "[]", buf.String())
+		mockInspector.AssertNotCalled(t, "InspectService", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("サービス名や単一サービス用のフラグとは併用できない", func(t *testing.T) {
+		tests := []struct {
+			args        []string
+			expectedErr string
+		}{
+			{args: []string{"team-a-web", "--prefix", "team-a-", "--cluster", "prod"}, expectedErr: "cannot specify a service name together with --prefix"},
+			{args: []string{"--prefix", "team-a-", "--cluster", "prod", "--compare-revision"}, expectedErr: "--prefix cannot be combined with --compare-revision"},
+			{args: []string{"--prefix", "team-a-"}, expectedErr: "cluster name is required"},
+		}
+		for _, tt := range tests {
+			inspectCmd := cmd.NewInspectCommandWithScanner(&MockInspector{}, newScanner())
+			inspectCmd.SetOut(&bytes.Buffer{})
+			inspectCmd.SetErr(&bytes.Buffer{})
+			inspectCmd.SetArgs(tt.args)
+
+			err := inspectCmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedErr)
+		}
+	})
+}
+
+func TestInspectCommand_ClientFactory(t *testing.T) {
+	family := "web-task"
+	taskDefArn := "arn:aws:ecs:eu-west-1:123456789012:task-definition/web-task:3"
+	service := fakeService("web-service", "ACTIVE", "FARGATE", 2, 2)
+	service.TaskDefinition = &taskDefArn
+	factory := &FakeClientFactory{Clients: map[string]phantomaws.ECSClient{
+		"eu-west-1": &FakeECSClient{
+			Services: map[string][]types.Service{"prod": {service}},
+			TaskDefinitions: map[string]*types.TaskDefinition{
+				taskDefArn: {TaskDefinitionArn: &taskDefArn, Family: &family, Revision: 3, Status: types.TaskDefinitionStatusActive},
+			},
+		},
+	}}
+
+	var buf bytes.Buffer
+	inspectCmd := cmd.NewInspectCommandWithClientFactory(factory)
+	inspectCmd.SetOut(&buf)
+	inspectCmd.SetErr(&bytes.Buffer{})
+	inspectCmd.SetArgs([]string{"web-service", "--cluster", "prod", "--region", "eu-west-1", "--output", "json"})
+
+	require.NoError(t, inspectCmd.Execute())
+
+	var result models.InspectionResult
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+	assert.Equal(t, "web-service", result.Service.ServiceName)
+	assert.Equal(t, taskDefArn, result.TaskDefinition.TaskDefinitionArn)
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dev-shimada/phantom-ecs/internal/arn"
+	"github.com/dev-shimada/phantom-ecs/internal/aws"
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+	"github.com/dev-shimada/phantom-ecs/internal/scanner"
+	"github.com/dev-shimada/phantom-ecs/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// ContainerInstanceScannerInterface はコンテナインスタンス取得の操作を定義するインターフェース
+type ContainerInstanceScannerInterface interface {
+	ScanContainerInstances(ctx context.Context, clusterName string) ([]models.ContainerInstance, error)
+}
+
+// NewInstancesCommand はinstancesコマンドを作成
+func NewInstancesCommand(scannerImpl ContainerInstanceScannerInterface) *cobra.Command {
+	return newInstancesCommand(scannerImpl, aws.NewClientFactory())
+}
+
+// NewInstancesCommandWithClientFactory はAWSクライアントを指定したファクトリで作成するinstancesコマンドを作成
+func NewInstancesCommandWithClientFactory(clientFactory aws.ClientFactory) *cobra.Command {
+	return newInstancesCommand(nil, clientFactory)
+}
+
+// newInstancesCommand はinstancesコマンドを作成（Scannerがnilの場合はclientFactoryのクライアントを使用）
+func newInstancesCommand(scannerImpl ContainerInstanceScannerInterface, clientFactory aws.ClientFactory) *cobra.Command {
+	var clusterName string
+	var outputFormat string
+	var region string
+	var profile string
+	var configFiles []string
+	var configProfile string
+
+	cmd := &cobra.Command{
+		Use:   "instances",
+		Short: "EC2クラスターのコンテナインスタンス一覧を表示",
+		Long: `EC2起動タイプのクラスターに登録されているコンテナインスタンスの一覧を表示します。
+
+インスタンスID、状態、実行中のタスク数、
+残りのCPU/メモリを確認できます。`,
+		Example: `  # コンテナインスタンス一覧を表示
+  phantom-ecs instances --cluster my-cluster
+
+  # JSON形式で出力
+  phantom-ecs instances --cluster my-cluster --output json`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return applyConfigProfile(cmd, configFiles, configProfile)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runInstances(cmd, scannerImpl, clientFactory, clusterName, outputFormat, region, profile)
+		},
+	}
+
+	// ローカルフラグを定義
+	cmd.Flags().StringVarP(&clusterName, "cluster", "c", "", "クラスター名またはクラスターARN (必須)")
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "出力形式 (json|yaml|table)")
+	addCompactFlag(cmd)
+	cmd.Flags().StringVarP(&region, "region", "r", "us-east-1", "AWSリージョン")
+	cmd.Flags().StringVarP(&profile, "profile", "p", "", "AWSプロファイル")
+	addConfigFileFlags(cmd, &configFiles, &configProfile)
+
+	// 必須フラグを設定
+	cmd.MarkFlagRequired("cluster")
+
+	return cmd
+}
+
+// NewInstancesCommandWithDefaults はデフォルトのScannerでinstancesコマンドを作成
+func NewInstancesCommandWithDefaults() *cobra.Command {
+	return NewInstancesCommand(nil)
+}
+
+// runInstances はinstancesコマンドの実行ロジック
+func runInstances(cmd *cobra.Command, scannerImpl ContainerInstanceScannerInterface, clientFactory aws.ClientFactory, clusterName, outputFormat, region, profile string) error {
+	ctx := commandContext(cmd)
+
+	// 必須パラメータの検証
+	if clusterName == "" {
+		return fmt.Errorf("cluster name is required")
+	}
+	clusterName = arn.ClusterName(clusterName)
+
+	// 出力形式の検証
+	formatter := utils.NewFormatter()
+	if !formatter.ValidateFormat(outputFormat) {
+		return fmt.Errorf("unsupported output format: %s. Supported formats: %v",
+			outputFormat, formatter.GetSupportedFormats())
+	}
+	outputFormat = formatter.NormalizeFormat(outputFormat)
+
+	// Scannerがnilの場合（実際のAWS呼び出し用）は、AWS Scannerを作成
+	var scannerToUse ContainerInstanceScannerInterface
+	if scannerImpl != nil {
+		scannerToUse = scannerImpl
+	} else {
+		client, err := newECSClient(ctx, cmd, clientFactory, region, profile)
+		if err != nil {
+			return fmt.Errorf("failed to create AWS client: %w", err)
+		}
+		scannerToUse = scanner.NewScanner(client)
+	}
+
+	instances, err := scannerToUse.ScanContainerInstances(ctx, clusterName)
+	if err != nil {
+		return fmt.Errorf("failed to list container instances: %w", err)
+	}
+
+	// 結果をフォーマットして出力
+	output, err := formatter.FormatWithOptions(instances, utils.FormatOptions{
+		Format:      outputFormat,
+		PrettyPrint: prettyPrint(cmd),
+		Indent:      jsonIndent(cmd),
+		YAMLFlow:    yamlFlow(cmd),
+		Wide:        wide(cmd),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), output)
+	return nil
+}
@@ -0,0 +1,107 @@
+package cmd_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/dev-shimada/phantom-ecs/cmd"
+	phantomaws "github.com/dev-shimada/phantom-ecs/internal/aws"
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+	"github.com/dev-shimada/phantom-ecs/internal/scanner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeContainerInstance はテスト用のコンテナインスタンスを作成
+func fakeContainerInstance(id, status string, running, remainingCPU, remainingMemory int32) types.ContainerInstance {
+	return types.ContainerInstance{
+		ContainerInstanceArn: aws.String("arn:aws:ecs:us-east-1:123456789012:container-instance/ec2-cluster/" + id),
+		Ec2InstanceId:        aws.String(id),
+		Status:               aws.String(status),
+		RunningTasksCount:    running,
+		RegisteredResources: []types.Resource{
+			{Name: aws.String("CPU"), IntegerValue: 2048},
+			{Name: aws.String("MEMORY"), IntegerValue: 4096},
+		},
+		RemainingResources: []types.Resource{
+			{Name: aws.String("CPU"), IntegerValue: remainingCPU},
+			{Name: aws.String("MEMORY"), IntegerValue: remainingMemory},
+		},
+	}
+}
+
+func TestInstancesCommand(t *testing.T) {
+	client := &FakeECSClient{
+		ContainerInstances: map[string][]types.ContainerInstance{
+			"ec2-cluster": {
+				fakeContainerInstance("i-aaa", "ACTIVE", 2, 1024, 2048),
+				fakeContainerInstance("i-bbb", "DRAINING", 0, 2048, 4096),
+			},
+		},
+	}
+
+	t.Run("テーブル形式", func(t *testing.T) {
+		var buf bytes.Buffer
+		instancesCmd := cmd.NewInstancesCommand(scanner.NewScanner(client))
+		instancesCmd.SetOut(&buf)
+		instancesCmd.SetArgs([]string{"--cluster", "arn:aws:ecs:us-east-1:123456789012:cluster/ec2-cluster"})
+
+		err := instancesCmd.Execute()
+		require.NoError(t, err)
+
+		output := buf.String()
+		assert.Contains(t, output, "REMAINING CPU")
+		assert.Regexp(t, `i-aaa\s+ACTIVE\s+2\s+0\s+1024/2048\s+2048/4096`, output)
+		assert.Regexp(t, `i-bbb\s+DRAINING\s+0\s+0\s+2048/2048\s+4096/4096`, output)
+	})
+
+	t.Run("JSON形式", func(t *testing.T) {
+		var buf bytes.Buffer
+		instancesCmd := cmd.NewInstancesCommand(scanner.NewScanner(client))
+		instancesCmd.SetOut(&buf)
+		instancesCmd.SetArgs([]string{"--cluster", "ec2-cluster", "--output", "json"})
+
+		err := instancesCmd.Execute()
+		require.NoError(t, err)
+
+		var instances []models.ContainerInstance
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &instances))
+		require.Len(t, instances, 2)
+		assert.Equal(t, "i-aaa", instances[0].EC2InstanceID)
+		assert.Equal(t, int32(1024), instances[0].RemainingCPU)
+		assert.Equal(t, int32(2048), instances[0].RemainingMemory)
+		assert.Equal(t, "DRAINING", instances[1].Status)
+	})
+
+	t.Run("クラスター未指定エラー", func(t *testing.T) {
+		instancesCmd := cmd.NewInstancesCommand(scanner.NewScanner(client))
+		instancesCmd.SetOut(&bytes.Buffer{})
+		instancesCmd.SetErr(&bytes.Buffer{})
+		instancesCmd.SetArgs([]string{})
+
+		assert.Error(t, instancesCmd.Execute())
+	})
+}
+
+func TestInstancesCommand_ClientFactory(t *testing.T) {
+	factory := &FakeClientFactory{Clients: map[string]phantomaws.ECSClient{
+		"eu-west-1": &FakeECSClient{ContainerInstances: map[string][]types.ContainerInstance{
+			"ec2-cluster": {fakeContainerInstance("i-aaa", "ACTIVE", 2, 1024, 2048)},
+		}},
+	}}
+
+	var buf bytes.Buffer
+	instancesCmd := cmd.NewInstancesCommandWithClientFactory(factory)
+	instancesCmd.SetOut(&buf)
+	instancesCmd.SetArgs([]string{"--cluster", "ec2-cluster", "--region", "eu-west-1", "--output", "json"})
+
+	require.NoError(t, instancesCmd.Execute())
+
+	var instances []models.ContainerInstance
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &instances))
+	require.Len(t, instances, 1)
+	assert.Equal(t, "i-aaa", instances[0].EC2InstanceID)
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+	"github.com/dev-shimada/phantom-ecs/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// maskSecretsOptions は出力に含まれる機密情報をマスクするためのオプション
+type maskSecretsOptions struct {
+	enabled    bool
+	envPattern string
+}
+
+// addMaskSecretsFlags は --mask-secrets と --mask-env-pattern フラグを登録する
+func addMaskSecretsFlags(cmd *cobra.Command, options *maskSecretsOptions) {
+	cmd.Flags().BoolVar(&options.enabled, "mask-secrets", false, "シークレットの参照先と機密性の高い環境変数の値を *** でマスク (--output-file、--output-s3 では未指定時も有効)")
+	cmd.Flags().StringVar(&options.envPattern, "mask-env-pattern", utils.DefaultSensitiveEnvPattern, "値をマスクする環境変数名の正規表現")
+}
+
+// validate はマスク対象の環境変数名のパターンを検証する
+func (o maskSecretsOptions) validate() error {
+	if _, err := regexp.Compile(o.envPattern); err != nil {
+		return fmt.Errorf("invalid mask-env-pattern: %w", err)
+	}
+	return nil
+}
+
+// mask はマスク設定にかかわらず機密情報をマスクした結果を返す（外部プロセスに渡す場合に使用）
+func (o maskSecretsOptions) mask(result models.InspectionResult) (models.InspectionResult, error) {
+	pattern, err := regexp.Compile(o.envPattern)
+	if err != nil {
+		return result, fmt.Errorf("invalid mask-env-pattern: %w", err)
+	}
+	return utils.MaskInspectionResult(result, pattern), nil
+}
+
+// resolve は標準出力用と、ファイル・S3出力用の結果をそれぞれマスク設定に従って返す
+// --mask-secrets が明示されていない場合、標準出力はマスクせずファイル・S3出力のみマスクする
+func (o maskSecretsOptions) resolve(cmd *cobra.Command, result models.InspectionResult) (stdout, destination models.InspectionResult, err error) {
+	pattern, err := regexp.Compile(o.envPattern)
+	if err != nil {
+		return result, result, fmt.Errorf("invalid mask-env-pattern: %w", err)
+	}
+
+	masked := utils.MaskInspectionResult(result, pattern)
+	explicit := cmd.Flags().Changed("mask-secrets")
+
+	stdout = result
+	if explicit && o.enabled {
+		stdout = masked
+	}
+	destination = masked
+	if explicit && !o.enabled {
+		destination = result
+	}
+	return stdout, destination, nil
+}
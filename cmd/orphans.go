@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/dev-shimada/phantom-ecs/internal/aws"
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+	"github.com/dev-shimada/phantom-ecs/internal/scanner"
+	"github.com/dev-shimada/phantom-ecs/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// OrphanScannerInterface は未参照のタスク定義の検出に必要な操作を定義するインターフェース
+type OrphanScannerInterface interface {
+	ScannerInterface
+	TaskDefinitionScannerInterface
+}
+
+// NewOrphansCommand はorphansコマンドを作成
+func NewOrphansCommand(scannerImpl OrphanScannerInterface) *cobra.Command {
+	return newOrphansCommand(scannerImpl, aws.NewClientFactory())
+}
+
+// NewOrphansCommandWithClientFactory はAWSクライアントを指定したファクトリで作成するorphansコマンドを作成
+func NewOrphansCommandWithClientFactory(clientFactory aws.ClientFactory) *cobra.Command {
+	return newOrphansCommand(nil, clientFactory)
+}
+
+// newOrphansCommand はorphansコマンドを作成（Scannerがnilの場合はclientFactoryのクライアントを使用）
+func newOrphansCommand(scannerImpl OrphanScannerInterface, clientFactory aws.ClientFactory) *cobra.Command {
+	var outputFormat string
+	var region string
+	var profile string
+	var configFiles []string
+	var configProfile string
+	var all bool
+
+	cmd := &cobra.Command{
+		Use:   "orphans",
+		Short: "どのサービスからも参照されていないタスク定義ファミリーを表示",
+		Long: `ACTIVEなリビジョンを持つタスク定義ファミリーのうち、
+どのサービスからも参照されていないものを一覧表示します。
+
+すべてのクラスターのサービスを調べ、いずれかのリビジョンが参照されているファミリーは参照ありとみなします。
+削除済み（INACTIVE）のサービスは参照元として扱いません。`,
+		Example: `  # 参照されていないタスク定義ファミリーを表示
+  phantom-ecs orphans
+
+  # 参照されているファミリーも含めて参照の有無を表示
+  phantom-ecs orphans --all
+
+  # JSON形式で出力
+  phantom-ecs orphans --output json`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return applyConfigProfile(cmd, configFiles, configProfile)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runOrphans(cmd, scannerImpl, clientFactory, outputFormat, region, profile, all)
+		},
+	}
+
+	// ローカルフラグを定義
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "出力形式 (json|yaml|table)")
+	addCompactFlag(cmd)
+	cmd.Flags().StringVarP(&region, "region", "r", "us-east-1", "AWSリージョン")
+	cmd.Flags().StringVarP(&profile, "profile", "p", "", "AWSプロファイル")
+	addConfigFileFlags(cmd, &configFiles, &configProfile)
+	cmd.Flags().BoolVar(&all, "all", false, "参照されているファミリーも含めて表示")
+
+	return cmd
+}
+
+// NewOrphansCommandWithDefaults はデフォルトのScannerでorphansコマンドを作成
+func NewOrphansCommandWithDefaults() *cobra.Command {
+	return NewOrphansCommand(nil)
+}
+
+// runOrphans はorphansコマンドの実行ロジック
+func runOrphans(cmd *cobra.Command, scannerImpl OrphanScannerInterface, clientFactory aws.ClientFactory, outputFormat, region, profile string, all bool) error {
+	ctx := commandContext(cmd)
+
+	// 出力形式の検証
+	formatter := utils.NewFormatter()
+	if !formatter.ValidateFormat(outputFormat) {
+		return fmt.Errorf("unsupported output format: %s. Supported formats: %v",
+			outputFormat, formatter.GetSupportedFormats())
+	}
+	outputFormat = formatter.NormalizeFormat(outputFormat)
+
+	// Scannerがnilの場合（実際のAWS呼び出し用）は、AWS Scannerを作成
+	var scannerToUse OrphanScannerInterface
+	if scannerImpl != nil {
+		scannerToUse = scannerImpl
+	} else {
+		client, err := newECSClient(ctx, cmd, clientFactory, region, profile)
+		if err != nil {
+			return fmt.Errorf("failed to create AWS client: %w", err)
+		}
+		scannerToUse = scanner.NewScanner(client)
+	}
+
+	summaries, err := scannerToUse.ScanTaskDefinitions(ctx, "")
+	if err != nil {
+		return fmt.Errorf("failed to list task definitions: %w", err)
+	}
+
+	// すべてのクラスターのサービスから参照されているファミリーを確認
+	clusters, err := scannerToUse.DiscoverClusters(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to discover clusters: %w", err)
+	}
+	var services []models.ECSService
+	if len(clusters) > 0 {
+		services, err = scannerToUse.ScanServices(ctx, clusters)
+		if err != nil {
+			return fmt.Errorf("failed to scan services: %w", err)
+		}
+	}
+	marked := scanner.MarkReferencedFamilies(summaries, scanner.ExcludeInactive(services))
+
+	results := marked
+	if !all {
+		results = []models.TaskDefinitionSummary{}
+		for _, summary := range marked {
+			if !*summary.Referenced {
+				results = append(results, summary)
+			}
+		}
+	}
+
+	// 結果をフォーマットして出力
+	output, err := formatter.FormatWithOptions(results, utils.FormatOptions{
+		Format:      outputFormat,
+		PrettyPrint: prettyPrint(cmd),
+		Indent:      jsonIndent(cmd),
+		YAMLFlow:    yamlFlow(cmd),
+		Wide:        wide(cmd),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), output)
+	return nil
+}
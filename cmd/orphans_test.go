@@ -0,0 +1,119 @@
+package cmd_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/dev-shimada/phantom-ecs/cmd"
+	phantomaws "github.com/dev-shimada/phantom-ecs/internal/aws"
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+	"github.com/dev-shimada/phantom-ecs/internal/scanner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrphansCommand(t *testing.T) {
+	web := fakeService("web", "ACTIVE", "FARGATE", 2, 2)
+	web.TaskDefinition = aws.String("arn:aws:ecs:us-east-1:123456789012:task-definition/web-task:3")
+	deleted := fakeService("old-batch", "INACTIVE", "FARGATE", 0, 0)
+	deleted.TaskDefinition = aws.String("arn:aws:ecs:us-east-1:123456789012:task-definition/batch-task:1")
+
+	client := &FakeECSClient{
+		Services: map[string][]types.Service{"prod": {web, deleted}},
+		TaskDefinitionFamilies: map[string][]int{
+			"web-task":   {3, 4},
+			"batch-task": {1, 2},
+		},
+	}
+
+	t.Run("参照されていないファミリーのみ表示", func(t *testing.T) {
+		var buf bytes.Buffer
+		orphansCmd := cmd.NewOrphansCommand(scanner.NewScanner(client))
+		orphansCmd.SetOut(&buf)
+		orphansCmd.SetArgs([]string{"--output", "json"})
+
+		require.NoError(t, orphansCmd.Execute())
+
+		var summaries []models.TaskDefinitionSummary
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &summaries))
+		require.Len(t, summaries, 1)
+		assert.Equal(t, "batch-task", summaries[0].Family)
+		require.NotNil(t, summaries[0].Referenced)
+		assert.False(t, *summaries[0].Referenced)
+	})
+
+	t.Run("--allで参照の有無をテーブル表示", func(t *testing.T) {
+		var buf bytes.Buffer
+		orphansCmd := cmd.NewOrphansCommand(scanner.NewScanner(client))
+		orphansCmd.SetOut(&buf)
+		orphansCmd.SetArgs([]string{"--all"})
+
+		require.NoError(t, orphansCmd.Execute())
+
+		output := buf.String()
+		assert.Contains(t, output, "REFERENCED")
+		assert.Regexp(t, `batch-task\s+2\s+2\s+1,2\s+no\s*\n`, output)
+		assert.Regexp(t, `web-task\s+4\s+2\s+3,4\s+yes\s*\n`, output)
+	})
+}
+
+func TestOrphansCommand_PaginatedServices(t *testing.T) {
+	// ListServicesは1ページ最大10件のため、25件のサービスは3ページに分かれる
+	var services []types.Service
+	for i := 1; i <= 25; i++ {
+		service := fakeService(fmt.Sprintf("web-%d", i), "ACTIVE", "FARGATE", 1, 1)
+		service.TaskDefinition = aws.String("arn:aws:ecs:us-east-1:123456789012:task-definition/web-task:1")
+		services = append(services, service)
+	}
+	// 最後のページのサービスのみが参照するファミリー
+	services[24].TaskDefinition = aws.String("arn:aws:ecs:us-east-1:123456789012:task-definition/last-page-task:1")
+
+	client := &FakeECSClient{
+		Services: map[string][]types.Service{"prod": services},
+		TaskDefinitionFamilies: map[string][]int{
+			"web-task":       {1},
+			"last-page-task": {1},
+			"unused-task":    {1},
+		},
+	}
+
+	var buf bytes.Buffer
+	orphansCmd := cmd.NewOrphansCommand(scanner.NewScanner(client))
+	orphansCmd.SetOut(&buf)
+	orphansCmd.SetArgs([]string{"--output", "json"})
+
+	require.NoError(t, orphansCmd.Execute())
+
+	var summaries []models.TaskDefinitionSummary
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &summaries))
+	require.Len(t, summaries, 1)
+	assert.Equal(t, "unused-task", summaries[0].Family)
+	assert.Equal(t, 3, client.DescribeServicesCalls)
+}
+
+func TestOrphansCommand_ClientFactory(t *testing.T) {
+	web := fakeService("web", "ACTIVE", "FARGATE", 2, 2)
+	web.TaskDefinition = aws.String("arn:aws:ecs:eu-west-1:123456789012:task-definition/web-task:3")
+	factory := &FakeClientFactory{Clients: map[string]phantomaws.ECSClient{
+		"eu-west-1": &FakeECSClient{
+			Services:               map[string][]types.Service{"prod": {web}},
+			TaskDefinitionFamilies: map[string][]int{"web-task": {3}, "batch-task": {1}},
+		},
+	}}
+
+	var buf bytes.Buffer
+	orphansCmd := cmd.NewOrphansCommandWithClientFactory(factory)
+	orphansCmd.SetOut(&buf)
+	orphansCmd.SetArgs([]string{"--region", "eu-west-1", "--output", "json"})
+
+	require.NoError(t, orphansCmd.Execute())
+
+	var summaries []models.TaskDefinitionSummary
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &summaries))
+	require.Len(t, summaries, 1)
+	assert.Equal(t, "batch-task", summaries[0].Family)
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/dev-shimada/phantom-ecs/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// outputFileOptions は標準出力とは別形式で結果をファイルにも書き出すためのオプション
+type outputFileOptions struct {
+	path   string
+	format string
+	// style は標準出力と共通の整形オプション（--compact、--json-indent等）
+	style utils.FormatOptions
+}
+
+// addOutputFileFlags は --output-file と --output-file-format フラグを登録する
+func addOutputFileFlags(cmd *cobra.Command, options *outputFileOptions) {
+	cmd.Flags().StringVar(&options.path, "output-file", "", "結果を書き出すファイルのパス（標準出力への出力に加えて書き出す）")
+	cmd.Flags().StringVar(&options.format, "output-file-format", "json", "--output-file の出力形式 (json|yaml|table)")
+}
+
+// validate はファイル出力の形式を検証する
+func (o outputFileOptions) validate(formatter *utils.Formatter) error {
+	if o.path == "" {
+		return nil
+	}
+	if !formatter.ValidateFormat(o.format) {
+		return fmt.Errorf("unsupported output file format: %s. Supported formats: %v",
+			o.format, formatter.GetSupportedFormats())
+	}
+	return nil
+}
+
+// write はファイル出力が指定されている場合に、データを指定形式でフォーマットしてファイルに書き出す
+func (o outputFileOptions) write(formatter *utils.Formatter, data interface{}) error {
+	if o.path == "" {
+		return nil
+	}
+
+	options := o.style
+	options.Format = o.format
+	output, err := formatter.FormatWithOptions(data, options)
+	if err != nil {
+		return fmt.Errorf("failed to format output file: %w", err)
+	}
+
+	if err := os.WriteFile(o.path, []byte(output), 0644); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+	return nil
+}
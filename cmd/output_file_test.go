@@ -0,0 +1,222 @@
+package cmd_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dev-shimada/phantom-ecs/cmd"
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestScanCommand_OutputFile(t *testing.T) {
+	services := []models.ECSService{
+		{ServiceName: "web-service", ClusterName: "prod", Status: "ACTIVE", DesiredCount: 2, RunningCount: 2, LaunchType: "FARGATE"},
+	}
+
+	t.Run("標準出力はテーブル、ファイルはJSON", func(t *testing.T) {
+		mockScanner := &MockScanner{}
+		mockScanner.On("ScanServices", mock.Anything, []string{"prod"}).Return(services, nil)
+
+		outputFile := filepath.Join(t.TempDir(), "report.json")
+		scanCmd := cmd.NewScanCommand(mockScanner)
+		var buf bytes.Buffer
+		scanCmd.SetOut(&buf)
+		scanCmd.SetArgs([]string{"--cluster", "prod", "--output", "table", "--output-file", outputFile, "--output-file-format", "json"})
+
+		err := scanCmd.Execute()
+		require.NoError(t, err)
+
+		assert.Contains(t, buf.String(), "SERVICE NAME")
+		assert.Contains(t, buf.String(), "web-service")
+
+		data, err := os.ReadFile(outputFile)
+		require.NoError(t, err)
+		var written []models.ECSService
+		require.NoError(t, json.Unmarshal(data, &written))
+		assert.Equal(t, services[0].ServiceName, written[0].ServiceName)
+		assert.Equal(t, services[0].DesiredCount, written[0].DesiredCount)
+	})
+
+	t.Run("ファイル形式の既定値はJSON", func(t *testing.T) {
+		mockScanner := &MockScanner{}
+		mockScanner.On("ScanServices", mock.Anything, []string{"prod"}).Return(services, nil)
+
+		outputFile := filepath.Join(t.TempDir(), "report.json")
+		scanCmd := cmd.NewScanCommand(mockScanner)
+		scanCmd.SetOut(&bytes.Buffer{})
+		scanCmd.SetArgs([]string{"--cluster", "prod", "--output-file", outputFile})
+
+		require.NoError(t, scanCmd.Execute())
+
+		data, err := os.ReadFile(outputFile)
+		require.NoError(t, err)
+		assert.True(t, json.Valid(data))
+	})
+
+	t.Run("--compactはファイル出力にも適用される", func(t *testing.T) {
+		mockScanner := &MockScanner{}
+		mockScanner.On("ScanServices", mock.Anything, []string{"prod"}).Return(services, nil)
+
+		outputFile := filepath.Join(t.TempDir(), "report.json")
+		scanCmd := cmd.NewScanCommand(mockScanner)
+		scanCmd.SetOut(&bytes.Buffer{})
+		scanCmd.SetArgs([]string{"--cluster", "prod", "--compact", "--output-file", outputFile})
+
+		require.NoError(t, scanCmd.Execute())
+
+		data, err := os.ReadFile(outputFile)
+		require.NoError(t, err)
+		assert.True(t, json.Valid(data))
+		assert.NotContains(t, strings.TrimSpace(string(data)), "\n")
+	})
+
+	t.Run("--json-indentはファイル出力にも適用される", func(t *testing.T) {
+		mockScanner := &MockScanner{}
+		mockScanner.On("ScanServices", mock.Anything, []string{"prod"}).Return(services, nil)
+
+		outputFile := filepath.Join(t.TempDir(), "report.json")
+		scanCmd := cmd.NewScanCommand(mockScanner)
+		scanCmd.SetOut(&bytes.Buffer{})
+		scanCmd.SetArgs([]string{"--cluster", "prod", "--json-indent", "tab", "--output-file", outputFile})
+
+		require.NoError(t, scanCmd.Execute())
+
+		data, err := os.ReadFile(outputFile)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "\n\t{")
+	})
+
+	t.Run("不正なファイル形式はスキャン前にエラー", func(t *testing.T) {
+		mockScanner := &MockScanner{}
+
+		scanCmd := cmd.NewScanCommand(mockScanner)
+		scanCmd.SetOut(&bytes.Buffer{})
+		scanCmd.SetErr(&bytes.Buffer{})
+		scanCmd.SetArgs([]string{"--cluster", "prod", "--output-file", filepath.Join(t.TempDir(), "report.xml"), "--output-file-format", "xml"})
+
+		err := scanCmd.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported output file format")
+		mockScanner.AssertNotCalled(t, "ScanServices", mock.Anything, mock.Anything)
+	})
+}
+
+func TestInspectCommand_OutputFile(t *testing.T) {
+	mockInspector := &MockInspector{}
+	mockInspector.On("InspectService", mock.Anything, "web-service", "prod").Return(&models.InspectionResult{
+		Service:        models.ECSService{ServiceName: "web-service", ClusterName: "prod", Status: "ACTIVE"},
+		TaskDefinition: models.ECSTaskDefinition{Family: "web-task", Revision: 3},
+	}, nil)
+
+	outputFile := filepath.Join(t.TempDir(), "inspect.yaml")
+	inspectCmd := cmd.NewInspectCommand(mockInspector)
+	var buf bytes.Buffer
+	inspectCmd.SetOut(&buf)
+	inspectCmd.SetArgs([]string{"web-service", "--cluster", "prod", "--output-file", outputFile, "--output-file-format", "yaml"})
+
+	err := inspectCmd.Execute()
+	require.NoError(t, err)
+
+	assert.Contains(t, buf.String(), "=== TASK DEFINITION ===")
+
+	data, err := os.ReadFile(outputFile)
+	require.NoError(t, err)
+	var written models.InspectionResult
+	require.NoError(t, yaml.Unmarshal(data, &written))
+	assert.Equal(t, "web-task", written.TaskDefinition.Family)
+	assert.Equal(t, 3, written.TaskDefinition.Revision)
+}
+
+func TestScanCommand_InvalidOutputS3URI(t *testing.T) {
+	mockScanner := &MockScanner{}
+
+	cmd := cmd.NewScanCommand(mockScanner)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--output-s3", "my-bucket/report.json"})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must start with s3://")
+	mockScanner.AssertNotCalled(t, "DiscoverClusters", mock.Anything)
+}
+
+func TestInspectCommand_MaskSecrets(t *testing.T) {
+	secretArn := "arn:aws:secretsmanager:us-east-1:123456789012:secret:db-url"
+	newInspector := func() *MockInspector {
+		mockInspector := &MockInspector{}
+		mockInspector.On("InspectService", mock.Anything, "web-service", "prod").Return(&models.InspectionResult{
+			Service: models.ECSService{ServiceName: "web-service", ClusterName: "prod", Status: "ACTIVE"},
+			TaskDefinition: models.ECSTaskDefinition{
+				Family: "web-task",
+				Containers: []models.ContainerDefinition{{
+					Name:        "web",
+					Image:       "nginx:latest",
+					Environment: []models.EnvironmentVariable{{Name: "DB_PASSWORD", Value: "hunter2"}, {Name: "LOG_LEVEL", Value: "info"}},
+					Secrets:     []models.Secret{{Name: "DB_URL", ValueFrom: secretArn}},
+				}},
+			},
+		}, nil)
+		return mockInspector
+	}
+
+	run := func(t *testing.T, extraArgs ...string) (stdout, file string) {
+		outputFile := filepath.Join(t.TempDir(), "inspect.json")
+		inspectCmd := cmd.NewInspectCommand(newInspector())
+		var buf bytes.Buffer
+		inspectCmd.SetOut(&buf)
+		inspectCmd.SetArgs(append([]string{"web-service", "--cluster", "prod", "--output", "json", "--output-file", outputFile}, extraArgs...))
+
+		require.NoError(t, inspectCmd.Execute())
+		data, err := os.ReadFile(outputFile)
+		require.NoError(t, err)
+		return buf.String(), string(data)
+	}
+
+	t.Run("未指定時はファイル出力のみマスク", func(t *testing.T) {
+		stdout, file := run(t)
+
+		assert.Contains(t, stdout, "hunter2")
+		assert.Contains(t, stdout, secretArn)
+		assert.NotContains(t, file, "hunter2")
+		assert.NotContains(t, file, secretArn)
+		assert.Contains(t, file, `"value_from": "***"`)
+		// 機密でない値はマスクしない
+		assert.Contains(t, file, `"value": "info"`)
+		assert.Contains(t, file, "nginx:latest")
+	})
+
+	t.Run("明示的に有効化すると標準出力もマスク", func(t *testing.T) {
+		stdout, file := run(t, "--mask-secrets")
+
+		assert.NotContains(t, stdout, "hunter2")
+		assert.NotContains(t, stdout, secretArn)
+		assert.NotContains(t, file, "hunter2")
+	})
+
+	t.Run("明示的に無効化するとファイル出力もマスクしない", func(t *testing.T) {
+		_, file := run(t, "--mask-secrets=false")
+
+		assert.Contains(t, file, "hunter2")
+		assert.Contains(t, file, secretArn)
+	})
+
+	t.Run("不正なパターン", func(t *testing.T) {
+		inspectCmd := cmd.NewInspectCommand(newInspector())
+		inspectCmd.SetOut(&bytes.Buffer{})
+		inspectCmd.SetErr(&bytes.Buffer{})
+		inspectCmd.SetArgs([]string{"web-service", "--cluster", "prod", "--mask-env-pattern", "("})
+
+		err := inspectCmd.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid mask-env-pattern")
+	})
+}
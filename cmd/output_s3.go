@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dev-shimada/phantom-ecs/internal/aws"
+	"github.com/spf13/cobra"
+)
+
+// outputS3Options は標準出力と同じ内容をS3にもアップロードするためのオプション
+type outputS3Options struct {
+	uri      string
+	uploader aws.S3Uploader
+}
+
+// addOutputS3Flag は --output-s3 フラグを登録する
+func addOutputS3Flag(cmd *cobra.Command, options *outputS3Options) {
+	cmd.Flags().StringVar(&options.uri, "output-s3", "", "フォーマット済みの結果をアップロードするS3 URI (s3://bucket/key)")
+}
+
+// connect はS3出力が指定されている場合に、URIを検証して指定された設定のS3クライアントを作成する
+func (o *outputS3Options) connect(ctx context.Context, region, profile string, opts ...aws.Option) error {
+	if o.uri == "" {
+		return nil
+	}
+	if _, _, err := aws.ParseS3URI(o.uri); err != nil {
+		return err
+	}
+	if o.uploader != nil {
+		return nil
+	}
+
+	client, err := aws.NewS3Client(ctx, region, profile, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to create S3 client: %w", err)
+	}
+	o.uploader = client
+	return nil
+}
+
+// upload はS3出力が指定されている場合に、フォーマット済みの出力をアップロードする
+func (o outputS3Options) upload(ctx context.Context, outputFormat, output string) error {
+	if o.uri == "" {
+		return nil
+	}
+	return aws.UploadToS3(ctx, o.uploader, o.uri, []byte(output), contentTypeForFormat(outputFormat))
+}
+
+// contentTypeForFormat は出力形式に対応するContent-Typeを返す
+func contentTypeForFormat(outputFormat string) string {
+	switch outputFormat {
+	case "json":
+		return "application/json"
+	case "yaml":
+		return "application/yaml"
+	default:
+		return "text/plain; charset=utf-8"
+	}
+}
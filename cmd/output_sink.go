@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/dev-shimada/phantom-ecs/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// outputSink はコマンドの結果の出力先（標準出力、ファイル、S3）をまとめたio.Writer
+// コマンドごとに一度だけ解決し、フォーマット済みの結果はfmt.Printではなくこれに書き込む
+type outputSink struct {
+	stdout   io.Writer
+	file     outputFileOptions
+	s3       outputS3Options
+	s3Buffer bytes.Buffer
+}
+
+// newOutputSink は指定された標準出力、ファイル出力、S3出力から出力先を作成
+func newOutputSink(stdout io.Writer, file outputFileOptions, s3 outputS3Options) *outputSink {
+	return &outputSink{
+		stdout: stdout,
+		file:   file,
+		s3:     s3,
+	}
+}
+
+// resolveOutputSink はファイル出力の形式を検証し、S3出力が指定されていればクライアントを作成して出力先を解決する
+func resolveOutputSink(ctx context.Context, cmd *cobra.Command, formatter *utils.Formatter, region, profile string, file outputFileOptions, s3 outputS3Options) (*outputSink, error) {
+	if err := file.validate(formatter); err != nil {
+		return nil, err
+	}
+	file.style = utils.FormatOptions{
+		PrettyPrint: prettyPrint(cmd),
+		Indent:      jsonIndent(cmd),
+		YAMLFlow:    yamlFlow(cmd),
+		Wide:        wide(cmd),
+	}
+	options, err := awsClientOptions(cmd)
+	if err != nil {
+		return nil, err
+	}
+	if err := s3.connect(ctx, region, profile, options...); err != nil {
+		return nil, err
+	}
+	return newOutputSink(cmd.OutOrStdout(), file, s3), nil
+}
+
+// Write は標準出力に書き込み、S3出力が指定されている場合は同じ内容をアップロード用に保持する
+func (s *outputSink) Write(p []byte) (int, error) {
+	if s.uploadsToS3() {
+		s.s3Buffer.Write(p)
+	}
+	return s.stdout.Write(p)
+}
+
+// Stdout は標準出力のみに書き込むWriterを返す
+func (s *outputSink) Stdout() io.Writer {
+	return s.stdout
+}
+
+// Destination はS3出力のみに書き込むWriterを返す（マスク設定などで標準出力と内容が異なる場合に使用）
+func (s *outputSink) Destination() io.Writer {
+	if !s.uploadsToS3() {
+		return io.Discard
+	}
+	return &s.s3Buffer
+}
+
+// uploadsToS3 はS3出力が指定されているかどうかを返す
+func (s *outputSink) uploadsToS3() bool {
+	return s.s3.uri != ""
+}
+
+// WriteFile はファイル出力が指定されている場合に、データを指定形式でフォーマットしてファイルに書き出す
+func (s *outputSink) WriteFile(formatter *utils.Formatter, data interface{}) error {
+	return s.file.write(formatter, data)
+}
+
+// Flush はS3出力が指定されている場合に、保持している内容をアップロードしてバッファを空にする
+func (s *outputSink) Flush(ctx context.Context, contentFormat string) error {
+	if !s.uploadsToS3() {
+		return nil
+	}
+	content := s.s3Buffer.String()
+	s.s3Buffer.Reset()
+	return s.s3.upload(ctx, contentFormat, content)
+}
@@ -1,19 +1,26 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 
+	"github.com/dev-shimada/phantom-ecs/internal/aws"
 	"github.com/dev-shimada/phantom-ecs/internal/config"
+	"github.com/dev-shimada/phantom-ecs/internal/errors"
+	"github.com/dev-shimada/phantom-ecs/internal/logger"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
 var (
-	cfgFile      string
+	cfgFiles     []string
 	region       string
 	profile      string
 	outputFormat string
+	debugAWS     bool
+	useFIPS      bool
+	useDualStack bool
 )
 
 // Version はアプリケーションのバージョン
@@ -29,7 +36,17 @@ func NewRootCommand() *cobra.Command {
 主な機能:
 	 - ECSサービス一覧表示 (scan)
 	 - 特定サービスの詳細調査 (inspect)
+	 - クラスター内の全サービスの詳細調査 (inspect-all)
 	 - 同等サービスの自動作成 (deploy)
+	 - 既存サービスの更新 (update)
+	 - サービス集計情報の表示 (stats)
+	 - タスク定義ファイルとの差分検出 (diff)
+	 - クラスター間のサービス構成の比較 (diff-clusters)
+	 - EC2クラスターのコンテナインスタンス表示 (instances)
+	 - タスク定義ファミリーとリビジョンの一覧表示 (taskdefs)
+	 - どのサービスからも参照されていないタスク定義の検出 (orphans)
+	 - AWS認証情報の確認 (whoami)
+	 - 保存したサービス調査結果の一覧と差分の表示 (history)
 
 例:
 	 phantom-ecs scan --region us-east-1 --output json
@@ -59,39 +76,60 @@ func NewRootCommand() *cobra.Command {
 	}
 
 	// グローバルフラグを定義
-	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "設定ファイルパス (default: $HOME/.phantom-ecs.yaml)")
+	rootCmd.PersistentFlags().StringArrayVar(&cfgFiles, "config", nil, "設定ファイルパス (default: $HOME/.phantom-ecs.yaml、複数指定時は後のファイルを前のファイルに深くマージ)")
 	rootCmd.PersistentFlags().StringVarP(&region, "region", "r", "us-east-1", "AWSリージョン")
 	rootCmd.PersistentFlags().StringVarP(&profile, "profile", "p", "", "AWSプロファイル")
 	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "table", "出力形式 (json|yaml|table)")
+	rootCmd.PersistentFlags().BoolVar(&useFIPS, "use-fips-endpoint", false, "AWS APIの呼び出しにFIPSエンドポイントを使用 (設定ファイルのaws.use_fips_endpointでも指定可能)")
+	rootCmd.PersistentFlags().BoolVar(&useDualStack, "use-dualstack-endpoint", false, "AWS APIの呼び出しにデュアルスタック（IPv6）エンドポイントを使用 (設定ファイルのaws.use_dualstack_endpointでも指定可能)")
+	rootCmd.PersistentFlags().BoolVar(&debugAWS, "debug-aws", false, "AWS APIのリクエスト/レスポンスをデバッグログとして標準エラー出力に表示 (認証ヘッダーはマスク)")
 
 	// Viperでフラグをバインド
 	viper.BindPFlag("region", rootCmd.PersistentFlags().Lookup("region"))
 	viper.BindPFlag("profile", rootCmd.PersistentFlags().Lookup("profile"))
 	viper.BindPFlag("output", rootCmd.PersistentFlags().Lookup("output"))
+	viper.BindPFlag("aws.use_fips_endpoint", rootCmd.PersistentFlags().Lookup("use-fips-endpoint"))
+	viper.BindPFlag("aws.use_dualstack_endpoint", rootCmd.PersistentFlags().Lookup("use-dualstack-endpoint"))
 
 	// サブコマンドを追加
 	rootCmd.AddCommand(NewScanCommandWithDefaults())
 	rootCmd.AddCommand(NewInspectCommandWithDefaults())
+	rootCmd.AddCommand(NewInspectAllCommandWithDefaults())
 	rootCmd.AddCommand(NewDeployCommandWithDefaults())
+	rootCmd.AddCommand(NewUpdateCommandWithDefaults())
 	rootCmd.AddCommand(NewBatchCommand())
+	rootCmd.AddCommand(NewStatsCommandWithDefaults())
+	rootCmd.AddCommand(NewDiffCommandWithDefaults())
+	rootCmd.AddCommand(NewDiffClustersCommandWithDefaults())
+	rootCmd.AddCommand(NewInstancesCommandWithDefaults())
+	rootCmd.AddCommand(NewTaskDefsCommandWithDefaults())
+	rootCmd.AddCommand(NewOrphansCommandWithDefaults())
+	rootCmd.AddCommand(NewWhoamiCommandWithDefaults())
+	rootCmd.AddCommand(NewConfigCommand())
+	rootCmd.AddCommand(NewHistoryCommand())
 
 	return rootCmd
 }
 
-// Execute はルートコマンドを実行
+// Execute はルートコマンドを実行し、結果に応じた終了コードで終了
 func Execute() {
-	rootCmd := NewRootCommand()
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+	os.Exit(Run(NewRootCommand()))
+}
+
+// Run はコマンドを実行して終了コードを返す（終了コードの対応はerrors.ExitCodeを参照）
+func Run(command *cobra.Command) int {
+	err := command.Execute()
+	if err != nil {
+		fmt.Fprintln(command.ErrOrStderr(), err)
 	}
+	return errors.ExitCode(err)
 }
 
 // initConfig は設定を初期化
 func initConfig() error {
-	if cfgFile != "" {
-		// 設定ファイルが指定された場合
-		viper.SetConfigFile(cfgFile)
+	if len(cfgFiles) > 0 {
+		// 設定ファイルが指定された場合（2つ目以降は読み込み後にマージ）
+		viper.SetConfigFile(cfgFiles[0])
 	} else {
 		// デフォルトの設定ファイルを検索
 		home, err := os.UserHomeDir()
@@ -114,7 +152,23 @@ func initConfig() error {
 			return err
 		}
 	}
+	if len(cfgFiles) > 1 {
+		for _, file := range cfgFiles[1:] {
+			viper.SetConfigFile(file)
+			if err := viper.MergeInConfig(); err != nil {
+				return fmt.Errorf("failed to merge config file %s: %w", file, err)
+			}
+		}
+	}
 
+	// AWS SDKのHTTPクライアント設定を検証（クライアントの作成時にawsClientOptionsで適用）
+	httpOptions := httpOptionsFromViper()
+	if httpOptions.MaxConns < 0 {
+		return fmt.Errorf("aws.http.max_conns must not be negative: %d", httpOptions.MaxConns)
+	}
+	if httpOptions.Timeout < 0 {
+		return fmt.Errorf("aws.http.timeout must not be negative: %s", httpOptions.Timeout)
+	}
 	// 設定の検証
 	cfg := config.NewConfig(viper.GetString("region"), viper.GetString("profile"))
 	cfg.SetOutputFormat(viper.GetString("output"))
@@ -122,6 +176,42 @@ func initConfig() error {
 	return cfg.Validate()
 }
 
+// awsClientOptions は設定ファイル（--config）とルートコマンドのフラグから、AWSクライアントの作成時に適用する設定を返す
+func awsClientOptions(cmd *cobra.Command) ([]aws.Option, error) {
+	options := []aws.Option{
+		aws.WithHTTPOptions(httpOptionsFromViper()),
+		// 政府機関向けのワークロードやIPv6のみのVPC向けのエンドポイント
+		aws.WithFIPSEndpoint(viper.GetBool("aws.use_fips_endpoint")),
+		aws.WithDualStackEndpoint(viper.GetBool("aws.use_dualstack_endpoint")),
+	}
+	// --debug-aws指定時はAWS APIのリクエスト/レスポンスをデバッグログに出力
+	if debug, _ := cmd.Flags().GetBool("debug-aws"); debug {
+		log, err := logger.NewLogger(&logger.Config{Level: "debug", Format: "text", Output: cmd.ErrOrStderr()})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create AWS debug logger: %w", err)
+		}
+		options = append(options, aws.WithDebugLogger(log))
+	}
+	return options, nil
+}
+
+// newECSClient はawsClientOptionsの設定を適用し、ファクトリで一時的な障害を再試行するECSクライアントを作成
+func newECSClient(ctx context.Context, cmd *cobra.Command, clientFactory aws.ClientFactory, region, profile string) (aws.ECSClient, error) {
+	options, err := awsClientOptions(cmd)
+	if err != nil {
+		return nil, err
+	}
+	return clientFactory.NewClient(ctx, region, profile, options...)
+}
+
+// httpOptionsFromViper は設定ファイル（--config）とフラグから読み込んだAWS SDKのHTTPクライアント設定を返す
+func httpOptionsFromViper() aws.HTTPOptions {
+	return aws.HTTPOptions{
+		MaxConns: viper.GetInt("aws.http.max_conns"),
+		Timeout:  viper.GetDuration("aws.http.timeout"),
+	}
+}
+
 // GetConfig は現在の設定を取得
 func GetConfig() *config.Config {
 	cfg := config.NewConfig(viper.GetString("region"), viper.GetString("profile"))
@@ -2,9 +2,17 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
 
+	"github.com/dev-shimada/phantom-ecs/internal/arn"
 	"github.com/dev-shimada/phantom-ecs/internal/aws"
+	phantomerrors "github.com/dev-shimada/phantom-ecs/internal/errors"
+	"github.com/dev-shimada/phantom-ecs/internal/logger"
 	"github.com/dev-shimada/phantom-ecs/internal/models"
 	"github.com/dev-shimada/phantom-ecs/internal/scanner"
 	"github.com/dev-shimada/phantom-ecs/internal/utils"
@@ -15,13 +23,47 @@ import (
 type ScannerInterface interface {
 	ScanServices(ctx context.Context, clusterNames []string) ([]models.ECSService, error)
 	DiscoverClusters(ctx context.Context) ([]string, error)
+	EnrichTaskDefinitions(ctx context.Context, services []models.ECSService) []models.ECSService
 }
 
+// ConcurrentScanner はクラスターの同時スキャン数を設定できるScanner
+type ConcurrentScanner interface {
+	SetConcurrency(concurrency int)
+}
+
+// InactiveServiceScanner は名前またはARNを指定してINACTIVEのサービスを取得できるScanner
+type InactiveServiceScanner interface {
+	DescribeServices(ctx context.Context, clusterName string, serviceNames []string) ([]models.ECSService, error)
+}
+
+// BestEffortScanner はクラスターのスキャンに失敗しても残りのクラスターを続けてスキャンできるScanner
+type BestEffortScanner interface {
+	SetContinueOnError(continueOnError bool)
+}
+
+// WarningScanner はスキャンを失敗させずに続行した事象（スキップしたクラスター等）の警告を返せるScanner
+type WarningScanner interface {
+	Warnings() []string
+}
+
+// DefaultScanMaxResults はscanで取得するサービス数のデフォルト上限
+const DefaultScanMaxResults = 1000
+
 // NewScanCommand はscanコマンドを作成
 func NewScanCommand(scannerImpl ScannerInterface) *cobra.Command {
-	var outputFormat string
-	var region string
-	var profile string
+	return newScanCommand(scannerImpl, aws.NewClientFactory())
+}
+
+// NewScanCommandWithClientFactory はAWSクライアントを指定したファクトリで作成するscanコマンドを作成
+func NewScanCommandWithClientFactory(clientFactory aws.ClientFactory) *cobra.Command {
+	return newScanCommand(nil, clientFactory)
+}
+
+// newScanCommand はscanコマンドを作成（Scannerがnilの場合はclientFactoryのクライアントを使用）
+func newScanCommand(scannerImpl ScannerInterface, clientFactory aws.ClientFactory) *cobra.Command {
+	var options scanOptions
+	var configFiles []string
+	var configProfile string
 
 	cmd := &cobra.Command{
 		Use:   "scan",
@@ -30,7 +72,26 @@ func NewScanCommand(scannerImpl ScannerInterface) *cobra.Command {
 
 指定されたリージョンとプロファイルを使用して、
 利用可能なすべてのECSクラスター内のサービスをスキャンし、
-指定された形式で結果を出力します。`,
+指定された形式で結果を出力します。
+
+同時にスキャンするクラスターの数は --concurrency で指定でき、
+省略時は設定ファイルの concurrency.scan を使用します。
+
+デフォルトではいずれかのクラスターのスキャンに失敗した時点で中断します。
+--continue-on-error を指定すると失敗したクラスターを飛ばして残りをスキャンし、
+取得できたサービスを出力した上で失敗したクラスターとエラーを報告します。
+--cluster 未指定時に発見したクラスターがスキャン前に削除されていた場合はエラーにせずスキップし、
+タスク定義を取得できなかったサービスとともに警告として標準エラー出力に表示します
+（--cluster で指定したクラスターが存在しない場合はエラーになります）。
+
+ListServicesは削除済み（INACTIVE）のサービスを返さないため、--include-inactive では
+--inactive-service で指定したサービスをDescribeServicesで取得し、INACTIVEのものを結果に含めます。
+
+--group-by を指定するとサービスをクラスター・起動タイプ・ステータスごとに
+まとめ、グループごとのサービス数とタスク数を表示します。
+
+--fail-on-status を指定すると、結果を出力した上で指定したステータスの
+サービスがあれば非ゼロで終了します（監視・アラート用）。`,
 		Example: `  # デフォルト設定でサービス一覧を表示
   phantom-ecs scan
 
@@ -41,16 +102,85 @@ func NewScanCommand(scannerImpl ScannerInterface) *cobra.Command {
   phantom-ecs scan --output json
 
   # 特定のプロファイルを使用
-  phantom-ecs scan --profile production`,
+  phantom-ecs scan --profile production
+
+  # タスク定義の概要（CPU/メモリ）を含めて出力
+  phantom-ecs scan --task-definition-details --output json
+
+  # 削除済み（INACTIVE）のサービスも含めて表示
+  phantom-ecs scan --include-inactive --inactive-service old-api --inactive-service arn:aws:ecs:us-east-1:123456789012:service/prod/old-worker
+
+  # 特定のクラスターのみスキャン（名前またはARN）
+  phantom-ecs scan --cluster prod --cluster arn:aws:ecs:us-east-1:123456789012:cluster/staging
+
+  # サービスを取得せずにスキャン対象のみ確認
+  phantom-ecs scan --dry-run
+
+  # 10秒ごとに再スキャンして表示を更新（Ctrl-Cで終了）
+  phantom-ecs scan --watch --interval 10s
+
+  # サービス数の上限を無効化してスキャン
+  phantom-ecs scan --max-results 0
+
+  # 標準出力にはテーブル形式、ファイルにはJSON形式で出力
+  phantom-ecs scan --output table --output-file report.json --output-file-format json
+
+  # 権限のないクラスターがあっても他のクラスターのサービスを表示
+  phantom-ecs scan --continue-on-error
+
+  # クラスターごとにまとめて件数を表示
+  phantom-ecs scan --group-by cluster
+
+  # 起動タイプごとにまとめてJSON形式で出力
+  phantom-ecs scan --group-by launch-type --output json
+
+  # DRAININGのサービスがあれば非ゼロで終了
+  phantom-ecs scan --fail-on-status DRAINING
+
+  # テーブルに表示する列を指定（省略時は設定ファイルのcolumns）
+  phantom-ecs scan --fields service_name,cluster_name,running_count`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return applyConfigProfile(cmd, configFiles, configProfile)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runScan(cmd, scannerImpl, outputFormat, region, profile)
+			enhancedConfig, err := loadEnhancedConfig(configFiles, configProfile)
+			if err != nil {
+				return err
+			}
+			// --fields未指定時は設定ファイルのcolumnsを使用
+			if !cmd.Flags().Changed("fields") {
+				options.columns = enhancedConfig.Columns
+			}
+			if !cmd.Flags().Changed("concurrency") {
+				options.concurrency = enhancedConfig.Concurrency.Scan
+			}
+			// --inactive-serviceを指定した場合は--include-inactiveを省略できる
+			options.includeInactive = options.includeInactive || len(options.inactiveServices) > 0
+			return runScan(cmd, scannerImpl, clientFactory, options)
 		},
 	}
 
 	// ローカルフラグを定義
-	cmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "出力形式 (json|yaml|table)")
-	cmd.Flags().StringVarP(&region, "region", "r", "us-east-1", "AWSリージョン")
-	cmd.Flags().StringVarP(&profile, "profile", "p", "", "AWSプロファイル")
+	cmd.Flags().StringVarP(&options.outputFormat, "output", "o", "table", "出力形式 (json|yaml|table)")
+	addCompactFlag(cmd)
+	cmd.Flags().StringVarP(&options.region, "region", "r", "us-east-1", "AWSリージョン")
+	cmd.Flags().StringVarP(&options.profile, "profile", "p", "", "AWSプロファイル")
+	cmd.Flags().BoolVar(&options.withTaskDefinition, "task-definition-details", false, "タスク定義の概要を取得して出力に含める")
+	cmd.Flags().StringSliceVarP(&options.clusterNames, "cluster", "c", []string{}, "スキャン対象のクラスター名またはクラスターARN（省略時はすべてのクラスター）")
+	cmd.Flags().BoolVar(&options.includeInactive, "include-inactive", false, "--inactive-serviceで指定したINACTIVE状態のサービスも含めて表示")
+	cmd.Flags().StringSliceVar(&options.inactiveServices, "inactive-service", nil, "INACTIVE状態か確認するサービス名またはサービスARN (削除済みのサービスは一覧に含まれないため指定が必要、繰り返し指定可能)")
+	cmd.Flags().BoolVar(&options.dryRun, "dry-run", false, "サービスを取得せずにスキャン対象のリージョンとクラスターを表示")
+	cmd.Flags().BoolVar(&options.watch, "watch", false, "一定間隔で再スキャンして表示を更新 (JSON出力時は無効)")
+	cmd.Flags().DurationVar(&options.interval, "interval", 10*time.Second, "--watch 時の再スキャン間隔")
+	addOutputFileFlags(cmd, &options.outputFile)
+	addOutputS3Flag(cmd, &options.outputS3)
+	cmd.Flags().IntVar(&options.maxResults, "max-results", DefaultScanMaxResults, "スキャンするサービス数の上限。超えた場合は中断 (0で無制限)")
+	cmd.Flags().IntVar(&options.concurrency, "concurrency", 0, "同時にスキャンするクラスター数 (省略時は設定ファイルのconcurrency.scan)")
+	cmd.Flags().BoolVar(&options.continueOnError, "continue-on-error", false, "クラスターのスキャンに失敗しても残りのクラスターをスキャンし、取得できたサービスを出力した上で失敗を報告")
+	cmd.Flags().StringVar(&options.groupBy, "group-by", "", fmt.Sprintf("サービスをグループ化して集計 (%s|%s|%s)", scanner.GroupByCluster, scanner.GroupByLaunchType, scanner.GroupByStatus))
+	cmd.Flags().StringSliceVar(&options.failOnStatuses, "fail-on-status", nil, "指定したステータスのサービスがあれば結果を出力した上で非ゼロで終了 (繰り返し指定可能、例: DRAINING)")
+	cmd.Flags().StringSliceVar(&options.columns, "fields", nil, fmt.Sprintf("テーブルに表示する列 (%s、tableのみ)", strings.Join(utils.ServiceColumnNames(), "|")))
+	addConfigFileFlags(cmd, &configFiles, &configProfile)
 
 	return cmd
 }
@@ -60,15 +190,57 @@ func NewScanCommandWithDefaults() *cobra.Command {
 	return NewScanCommand(nil) // 実際の実装では適切なScannerを渡す
 }
 
+// scanOptions はscanコマンドのフラグで指定するスキャンの設定
+type scanOptions struct {
+	outputFormat       string
+	region             string
+	profile            string
+	clusterNames       []string
+	columns            []string
+	withTaskDefinition bool
+	includeInactive    bool
+	inactiveServices   []string
+	dryRun             bool
+	watch              bool
+	interval           time.Duration
+	maxResults         int
+	concurrency        int
+	continueOnError    bool
+	groupBy            string
+	failOnStatuses     []string
+	outputFile         outputFileOptions
+	outputS3           outputS3Options
+}
+
 // runScan はscanコマンドの実行ロジック
-func runScan(cmd *cobra.Command, scannerImpl ScannerInterface, outputFormat, region, profile string) error {
-	ctx := context.Background()
+func runScan(cmd *cobra.Command, scannerImpl ScannerInterface, clientFactory aws.ClientFactory, options scanOptions) error {
+	ctx := commandContext(cmd)
+
+	if options.concurrency < 1 {
+		return fmt.Errorf("concurrency must be at least 1: %d", options.concurrency)
+	}
 
 	// 出力形式の検証
 	formatter := utils.NewFormatter()
-	if !formatter.ValidateFormat(outputFormat) {
+	if !formatter.ValidateFormat(options.outputFormat) {
 		return fmt.Errorf("unsupported output format: %s. Supported formats: %v",
-			outputFormat, formatter.GetSupportedFormats())
+			options.outputFormat, formatter.GetSupportedFormats())
+	}
+	options.outputFormat = formatter.NormalizeFormat(options.outputFormat)
+	if err := utils.ValidateServiceColumns(options.columns); err != nil {
+		return err
+	}
+	if options.groupBy != "" {
+		if err := scanner.ValidateGroupBy(options.groupBy); err != nil {
+			return err
+		}
+	}
+	if options.includeInactive && len(options.inactiveServices) == 0 {
+		return fmt.Errorf("--include-inactive requires --inactive-service: ListServices does not return INACTIVE services")
+	}
+	sink, err := resolveOutputSink(ctx, cmd, formatter, options.region, options.profile, options.outputFile, options.outputS3)
+	if err != nil {
+		return err
 	}
 
 	// Scannerがnilの場合（実際のAWS呼び出し用）は、AWS Scannerを作成
@@ -77,39 +249,298 @@ func runScan(cmd *cobra.Command, scannerImpl ScannerInterface, outputFormat, reg
 		scannerToUse = scannerImpl
 	} else {
 		// 実際のAWS呼び出し用の実装
-		awsClient, err := aws.NewClient(ctx, region, profile)
+		client, err := newECSClient(ctx, cmd, clientFactory, options.region, options.profile)
 		if err != nil {
 			return fmt.Errorf("failed to create AWS client: %w", err)
 		}
-		scannerToUse = scanner.NewScanner(awsClient)
+		// 警告ログは出力結果と混ざらないよう標準エラー出力に書き出す
+		log, err := logger.NewLogger(&logger.Config{Level: "warn", Format: "text", Output: os.Stderr})
+		if err != nil {
+			return fmt.Errorf("failed to create logger: %w", err)
+		}
+		awsScanner := scanner.NewScannerWithLogger(client, log)
+		// 上限を超えた時点で残りのクラスターへのAPI呼び出しを行わずに中断する
+		awsScanner.SetMaxResults(options.maxResults)
+		scannerToUse = awsScanner
+	}
+	if concurrentScanner, ok := scannerToUse.(ConcurrentScanner); ok {
+		concurrentScanner.SetConcurrency(options.concurrency)
+	}
+	if bestEffortScanner, ok := scannerToUse.(BestEffortScanner); ok {
+		bestEffortScanner.SetContinueOnError(options.continueOnError)
+	}
+	if _, ok := scannerToUse.(InactiveServiceScanner); options.includeInactive && !ok {
+		return fmt.Errorf("scanner does not support describing inactive services")
 	}
 
-	// クラスターを発見
-	clusters, err := scannerToUse.DiscoverClusters(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to discover clusters: %w", err)
+	// JSON出力は連結すると不正なJSONになるためwatchを無効化
+	if options.watch && options.outputFormat == "json" {
+		fmt.Fprintln(cmd.ErrOrStderr(), "--watch is disabled for JSON output; scanning once")
+		options.watch = false
+	}
+
+	scanOnce := func(ctx context.Context) error {
+		return runScanOnce(ctx, cmd, scannerToUse, formatter, options, sink)
+	}
+
+	if !options.watch || options.dryRun {
+		return scanOnce(ctx)
+	}
+
+	if options.interval <= 0 {
+		return fmt.Errorf("interval must be greater than 0: %v", options.interval)
+	}
+	return watchScan(ctx, cmd, options.interval, func(ctx context.Context) error {
+		err := scanOnce(ctx)
+		// ベストエフォートモードでの一部のクラスターの失敗と、--fail-on-statusに一致したサービスは表示して監視を続ける
+		var partialErr *scanner.PartialScanError
+		var statusErr *phantomerrors.PhantomError
+		if errors.As(err, &partialErr) || (errors.As(err, &statusErr) && statusErr.Type == phantomerrors.ErrTypeValidation) {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %v\n", err)
+			return nil
+		}
+		return err
+	})
+}
+
+// runScanOnce はクラスターの決定からサービスのスキャン、出力までを1回実行
+func runScanOnce(ctx context.Context, cmd *cobra.Command, scannerToUse ScannerInterface, formatter *utils.Formatter, options scanOptions, sink *outputSink) error {
+	// クラスターを決定（指定がなければ発見）
+	var clusters []string
+	if len(options.clusterNames) > 0 {
+		for _, clusterName := range options.clusterNames {
+			clusters = append(clusters, arn.ClusterName(clusterName))
+		}
+	} else {
+		discovered, err := scannerToUse.DiscoverClusters(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to discover clusters: %w", err)
+		}
+		clusters = discovered
+	}
+
+	// ドライランの場合はサービスを取得せずにスキャン計画のみ表示
+	if options.dryRun {
+		printScanPlan(cmd, options.region, clusters, options.clusterNames, options.withTaskDefinition, options.inactiveServices)
+		return nil
 	}
 
 	if len(clusters) == 0 {
-		fmt.Println("No ECS clusters found in the specified region.")
+		fmt.Fprintln(cmd.OutOrStdout(), "No ECS clusters found in the specified region.")
 		return nil
 	}
 
 	// サービスをスキャン
+	// ベストエフォートモードでは一部のクラスターが失敗しても、取得できたサービスを出力してから失敗を報告する
 	services, err := scannerToUse.ScanServices(ctx, clusters)
-	if err != nil {
+	var partialErr *scanner.PartialScanError
+	if err != nil && !errors.As(err, &partialErr) {
 		return fmt.Errorf("failed to scan services: %w", err)
 	}
+	if err := scanner.CheckMaxResults(len(services), options.maxResults); err != nil {
+		return err
+	}
+
+	// デフォルトではINACTIVE状態のサービスを除外し、指定時は一覧に含まれないINACTIVEのサービスを追加
+	if options.includeInactive {
+		inactive, err := describeInactiveServices(ctx, scannerToUse.(InactiveServiceScanner), clusters, options.inactiveServices)
+		if err != nil {
+			return err
+		}
+		services = append(services, inactive...)
+	} else {
+		services = scanner.ExcludeInactive(services)
+	}
+
+	// タスク定義の概要を付与（個別の取得失敗はスキャンを中断しない）
+	if options.withTaskDefinition {
+		services = scannerToUse.EnrichTaskDefinitions(ctx, services)
+	}
+
+	// グループ化が指定された場合はグループごとの集計を出力
+	var data interface{} = services
+	if options.groupBy != "" {
+		groups, err := scanner.GroupServices(services, options.groupBy)
+		if err != nil {
+			return err
+		}
+		data = groups
+	}
 
 	// 結果をフォーマットして出力
-	output, err := formatter.FormatWithOptions(services, utils.FormatOptions{
-		Format:      outputFormat,
-		PrettyPrint: true,
+	output, err := formatter.FormatWithOptions(data, utils.FormatOptions{
+		Format:      options.outputFormat,
+		PrettyPrint: prettyPrint(cmd),
+		Indent:      jsonIndent(cmd),
+		YAMLFlow:    yamlFlow(cmd),
+		Wide:        wide(cmd),
+		Columns:     options.columns,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to format output: %w", err)
 	}
 
-	fmt.Print(output)
+	fmt.Fprint(sink, output)
+
+	// 標準出力と同じ内容をS3にもアップロード
+	if err := sink.Flush(ctx, options.outputFormat); err != nil {
+		return err
+	}
+
+	// 標準出力とは別形式でファイルにも書き出す
+	if err := sink.WriteFile(formatter, data); err != nil {
+		return err
+	}
+
+	// 警告は出力結果と混ざらないよう標準エラー出力に書き出す（終了コードには影響しない）
+	printScannerWarnings(cmd, scannerToUse)
+
+	if partialErr != nil {
+		return fmt.Errorf("failed to scan services: %w", partialErr)
+	}
+
+	// 結果を出力した上で、許可しないステータスのサービスがあれば失敗させる（アラート用）
+	if err := checkServiceStatuses(services, options.failOnStatuses); err != nil {
+		cmd.SilenceUsage = true
+		return err
+	}
 	return nil
 }
+
+// checkServiceStatuses はステータスがstatusesのいずれかに一致するサービスがあれば、該当するサービスを列挙したバリデーションエラーを返す
+// ステータスの比較は大文字小文字を区別しない
+func checkServiceStatuses(services []models.ECSService, statuses []string) error {
+	if len(statuses) == 0 {
+		return nil
+	}
+
+	var offending []string
+	for _, service := range services {
+		for _, status := range statuses {
+			if strings.EqualFold(service.Status, strings.TrimSpace(status)) {
+				offending = append(offending, fmt.Sprintf("%s/%s (%s)", service.ClusterName, service.ServiceName, service.Status))
+				break
+			}
+		}
+	}
+	if len(offending) == 0 {
+		return nil
+	}
+	return phantomerrors.NewValidationError(fmt.Sprintf("%d services have a disallowed status: %s",
+		len(offending), strings.Join(offending, ", ")), nil)
+}
+
+// printScannerWarnings はScannerが警告を返せる場合に、警告を標準エラー出力に書き出す
+func printScannerWarnings(cmd *cobra.Command, scannerToUse ScannerInterface) {
+	warningScanner, ok := scannerToUse.(WarningScanner)
+	if !ok {
+		return
+	}
+	for _, warning := range warningScanner.Warnings() {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %s\n", warning)
+	}
+}
+
+// describeInactiveServices は指定したサービスのうちINACTIVE状態のものを取得
+// ARNで指定したサービスはARNのクラスター、名前で指定したサービスはスキャン対象の各クラスターから取得する
+func describeInactiveServices(ctx context.Context, inactiveScanner InactiveServiceScanner, clusters, serviceNames []string) ([]models.ECSService, error) {
+	targets := make(map[string][]string)
+	var order []string
+	addTarget := func(cluster, service string) {
+		if _, ok := targets[cluster]; !ok {
+			order = append(order, cluster)
+		}
+		targets[cluster] = append(targets[cluster], service)
+	}
+	for _, nameOrARN := range serviceNames {
+		serviceName, clusterName, err := resolveServiceARN(nameOrARN, "")
+		if err != nil {
+			return nil, err
+		}
+		if clusterName != "" {
+			addTarget(clusterName, serviceName)
+			continue
+		}
+		for _, cluster := range clusters {
+			addTarget(cluster, serviceName)
+		}
+	}
+
+	inactive := []models.ECSService{}
+	seen := make(map[string]bool)
+	for _, cluster := range order {
+		services, err := inactiveScanner.DescribeServices(ctx, cluster, targets[cluster])
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe inactive services in cluster %s: %w", cluster, err)
+		}
+		for _, service := range services {
+			key := service.ClusterName + "/" + service.ServiceName
+			// ACTIVE・DRAININGのサービスはスキャン結果に含まれている
+			if service.Status != "INACTIVE" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			inactive = append(inactive, service)
+		}
+	}
+	return inactive, nil
+}
+
+// printScanPlan はドライラン時にスキャン対象のリージョン、クラスター、適用されるフィルターを表示
+func printScanPlan(cmd *cobra.Command, region string, clusters, clusterFilter []string, withTaskDefinition bool, inactiveServices []string) {
+	out := cmd.OutOrStdout()
+
+	fmt.Fprintln(out, "Scan plan (dry run):")
+	fmt.Fprintf(out, "  Region: %s\n", region)
+
+	fmt.Fprintf(out, "  Clusters (%d):\n", len(clusters))
+	for _, cluster := range clusters {
+		fmt.Fprintf(out, "    - %s\n", cluster)
+	}
+
+	fmt.Fprintln(out, "  Filters:")
+	if len(clusterFilter) > 0 {
+		fmt.Fprintf(out, "    cluster: %s\n", strings.Join(clusterFilter, ", "))
+	} else {
+		fmt.Fprintln(out, "    cluster: (all discovered)")
+	}
+	fmt.Fprintf(out, "    include-inactive: %t\n", len(inactiveServices) > 0)
+	if len(inactiveServices) > 0 {
+		fmt.Fprintf(out, "    inactive-service: %s\n", strings.Join(inactiveServices, ", "))
+	}
+	fmt.Fprintf(out, "    task-definition-details: %t\n", withTaskDefinition)
+}
+
+// clearScreen はカーソルを先頭に移動して画面を消去するエスケープシーケンス
+const clearScreen = "\033[H\033[2J"
+
+// watchScan は中断されるまで一定間隔でスキャンを繰り返し、表示を更新する
+func watchScan(ctx context.Context, cmd *cobra.Command, interval time.Duration, scanOnce func(context.Context) error) error {
+	// Ctrl-Cでループを終了
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	out := cmd.OutOrStdout()
+	for {
+		// 端末の場合のみ画面を消去して再描画
+		if utils.IsTerminal(out) {
+			fmt.Fprint(out, clearScreen)
+		}
+
+		if err := scanOnce(ctx); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
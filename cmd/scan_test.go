@@ -1,13 +1,27 @@
 package cmd_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
 	"github.com/dev-shimada/phantom-ecs/cmd"
+	phantomaws "github.com/dev-shimada/phantom-ecs/internal/aws"
+	"github.com/dev-shimada/phantom-ecs/internal/config"
 	"github.com/dev-shimada/phantom-ecs/internal/models"
+	"github.com/dev-shimada/phantom-ecs/internal/scanner"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockScanner はScannerのモック
@@ -25,6 +39,16 @@ func (m *MockScanner) DiscoverClusters(ctx context.Context) ([]string, error) {
 	return args.Get(0).([]string), args.Error(1)
 }
 
+func (m *MockScanner) DescribeServices(ctx context.Context, clusterName string, serviceNames []string) ([]models.ECSService, error) {
+	args := m.Called(ctx, clusterName, serviceNames)
+	return args.Get(0).([]models.ECSService), args.Error(1)
+}
+
+func (m *MockScanner) EnrichTaskDefinitions(ctx context.Context, services []models.ECSService) []models.ECSService {
+	args := m.Called(ctx, services)
+	return args.Get(0).([]models.ECSService)
+}
+
 func TestScanCommand(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -79,6 +103,25 @@ func TestScanCommand(t *testing.T) {
 				}, nil)
 			},
 		},
+		{
+			name:          "タスク定義の概要付きでスキャン",
+			args:          []string{"scan", "--task-definition-details", "--output", "json"},
+			expectedError: false,
+			setupMock: func(m *MockScanner) {
+				services := []models.ECSService{
+					{ServiceName: "web-service", ClusterName: "test-cluster", TaskDefinition: "web-task:1"},
+					{ServiceName: "api-service", ClusterName: "test-cluster", TaskDefinition: "api-task:1"},
+				}
+				m.On("DiscoverClusters", mock.Anything).Return([]string{"test-cluster"}, nil)
+				m.On("ScanServices", mock.Anything, []string{"test-cluster"}).Return(services, nil)
+				m.On("EnrichTaskDefinitions", mock.Anything, services).Return([]models.ECSService{
+					{ServiceName: "web-service", ClusterName: "test-cluster", TaskDefinition: "web-task:1",
+						TaskDefinitionDetails: &models.TaskDefinitionDetails{CPU: "256", Memory: "512"}},
+					{ServiceName: "api-service", ClusterName: "test-cluster", TaskDefinition: "api-task:1",
+						TaskDefinitionUnavailable: true},
+				})
+			},
+		},
 		{
 			name:          "無効な出力形式",
 			args:          []string{"scan", "--output", "invalid"},
@@ -129,3 +172,602 @@ func TestScanCommandHelp(t *testing.T) {
 	assert.NotEmpty(t, cmd.Long)
 	assert.NotEmpty(t, cmd.Example)
 }
+
+func TestScanCommand_IncludeInactive(t *testing.T) {
+	// ListServicesは削除済みのサービスを返さないため、スキャン結果にINACTIVEは含まれない
+	services := []models.ECSService{
+		{ServiceName: "active-service", ClusterName: "test-cluster", Status: "ACTIVE"},
+		{ServiceName: "draining-service", ClusterName: "test-cluster", Status: "DRAINING"},
+	}
+	described := []models.ECSService{
+		{ServiceName: "active-service", ClusterName: "test-cluster", Status: "ACTIVE"},
+		{ServiceName: "deleted-service", ClusterName: "test-cluster", Status: "INACTIVE"},
+	}
+
+	tests := []struct {
+		name          string
+		args          []string
+		setupMock     func(*MockScanner)
+		expectedNames []string
+	}{
+		{
+			name:          "デフォルトではINACTIVEを取得しない",
+			args:          []string{"--output", "json"},
+			setupMock:     func(m *MockScanner) {},
+			expectedNames: []string{"active-service", "draining-service"},
+		},
+		{
+			name: "--include-inactiveで指定したINACTIVEのサービスを含める",
+			args: []string{"--output", "json", "--include-inactive", "--inactive-service", "deleted-service", "--inactive-service", "active-service"},
+			setupMock: func(m *MockScanner) {
+				m.On("DescribeServices", mock.Anything, "test-cluster", []string{"deleted-service", "active-service"}).Return(described, nil)
+			},
+			expectedNames: []string{"active-service", "draining-service", "deleted-service"},
+		},
+		{
+			name: "サービスARNはARNのクラスターから取得",
+			args: []string{"--output", "json", "--inactive-service", "arn:aws:ecs:us-east-1:123456789012:service/old-cluster/deleted-service"},
+			setupMock: func(m *MockScanner) {
+				m.On("DescribeServices", mock.Anything, "old-cluster", []string{"deleted-service"}).Return([]models.ECSService{
+					{ServiceName: "deleted-service", ClusterName: "old-cluster", Status: "INACTIVE"},
+				}, nil)
+			},
+			expectedNames: []string{"active-service", "draining-service", "deleted-service"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockScanner := &MockScanner{}
+			mockScanner.On("DiscoverClusters", mock.Anything).Return([]string{"test-cluster"}, nil)
+			mockScanner.On("ScanServices", mock.Anything, []string{"test-cluster"}).Return(services, nil)
+			tt.setupMock(mockScanner)
+
+			var buf bytes.Buffer
+			scanCmd := cmd.NewScanCommand(mockScanner)
+			scanCmd.SetOut(&buf)
+			scanCmd.SetArgs(tt.args)
+
+			err := scanCmd.Execute()
+			require.NoError(t, err)
+
+			var result []models.ECSService
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+
+			var names []string
+			for _, service := range result {
+				names = append(names, service.ServiceName)
+			}
+			assert.Equal(t, tt.expectedNames, names)
+
+			mockScanner.AssertExpectations(t)
+		})
+	}
+}
+
+func TestScanCommand_IncludeInactiveRequiresServices(t *testing.T) {
+	mockScanner := &MockScanner{}
+
+	scanCmd := cmd.NewScanCommand(mockScanner)
+	scanCmd.SetOut(&bytes.Buffer{})
+	scanCmd.SetErr(&bytes.Buffer{})
+	scanCmd.SetArgs([]string{"--include-inactive"})
+
+	err := scanCmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--include-inactive requires --inactive-service")
+	mockScanner.AssertNotCalled(t, "ScanServices", mock.Anything, mock.Anything)
+}
+
+func TestScanCommand_Columns(t *testing.T) {
+	tempDir := t.TempDir()
+	configFile := filepath.Join(tempDir, "phantom-ecs.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte("profiles:\n  default:\n    columns: [service_name, cluster_name, running_count]\n"), 0644))
+
+	services := []models.ECSService{
+		{ServiceName: "web-service", ClusterName: "test-cluster", Status: "ACTIVE", TaskDefinition: "web-task:3", DesiredCount: 2, RunningCount: 2, LaunchType: "FARGATE"},
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{
+			name:     "設定ファイルのcolumnsの列のみ表示",
+			args:     []string{"--config-file", configFile},
+			expected: "SERVICE NAME  CLUSTER NAME  RUNNING COUNT\n-----------------------------------------\nweb-service   test-cluster  2\n",
+		},
+		{
+			name:     "--fieldsは設定ファイルより優先",
+			args:     []string{"--config-file", configFile, "--fields", "status,service_name"},
+			expected: "STATUS  SERVICE NAME\n--------------------\nACTIVE  web-service\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockScanner := &MockScanner{}
+			mockScanner.On("DiscoverClusters", mock.Anything).Return([]string{"test-cluster"}, nil)
+			mockScanner.On("ScanServices", mock.Anything, []string{"test-cluster"}).Return(services, nil)
+
+			var buf bytes.Buffer
+			scanCmd := cmd.NewScanCommand(mockScanner)
+			scanCmd.SetOut(&buf)
+			scanCmd.SetArgs(tt.args)
+
+			require.NoError(t, scanCmd.Execute())
+			assert.Equal(t, tt.expected, buf.String())
+		})
+	}
+
+	t.Run("未知の列名はスキャン前にエラー", func(t *testing.T) {
+		mockScanner := &MockScanner{}
+		scanCmd := cmd.NewScanCommand(mockScanner)
+		scanCmd.SetOut(&bytes.Buffer{})
+		scanCmd.SetArgs([]string{"--fields", "service_name,owner"})
+
+		err := scanCmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported column: owner")
+		mockScanner.AssertNotCalled(t, "DiscoverClusters", mock.Anything)
+	})
+}
+
+func TestScanCommand_ClusterARNNormalization(t *testing.T) {
+	for _, clusterArg := range []string{"prod", "arn:aws:ecs:us-east-1:123456789012:cluster/prod"} {
+		t.Run(clusterArg, func(t *testing.T) {
+			// FakeECSClientはListServicesInput.Clusterをキーにサービスを返す
+			client := &FakeECSClient{Services: map[string][]types.Service{
+				"prod": {fakeService("web", "ACTIVE", "FARGATE", 1, 1)},
+			}}
+
+			var buf bytes.Buffer
+			scanCmd := cmd.NewScanCommand(scanner.NewScanner(client))
+			scanCmd.SetOut(&buf)
+			scanCmd.SetArgs([]string{"--cluster", clusterArg, "--output", "json"})
+
+			err := scanCmd.Execute()
+			require.NoError(t, err)
+
+			var result []models.ECSService
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+			require.Len(t, result, 1)
+			assert.Equal(t, "web", result[0].ServiceName)
+			assert.Equal(t, "prod", result[0].ClusterName)
+		})
+	}
+}
+
+func TestScanCommand_DryRun(t *testing.T) {
+	mockScanner := &MockScanner{}
+	mockScanner.On("DiscoverClusters", mock.Anything).Return([]string{"prod-cluster", "staging-cluster"}, nil)
+
+	var buf bytes.Buffer
+	scanCmd := cmd.NewScanCommand(mockScanner)
+	scanCmd.SetOut(&buf)
+	scanCmd.SetArgs([]string{"--dry-run", "--region", "ap-northeast-1", "--include-inactive", "--inactive-service", "old-api"})
+
+	err := scanCmd.Execute()
+	require.NoError(t, err)
+
+	output := buf.String()
+	assert.Contains(t, output, "Region: ap-northeast-1")
+	assert.Contains(t, output, "- prod-cluster")
+	assert.Contains(t, output, "- staging-cluster")
+	assert.Contains(t, output, "include-inactive: true")
+	assert.Contains(t, output, "inactive-service: old-api")
+
+	mockScanner.AssertExpectations(t)
+	mockScanner.AssertNotCalled(t, "ScanServices", mock.Anything, mock.Anything)
+}
+
+func TestScanCommand_DryRunDoesNotDescribeServices(t *testing.T) {
+	client := &FakeECSClient{Services: map[string][]types.Service{
+		"prod": {fakeService("web", "ACTIVE", "FARGATE", 1, 1)},
+	}}
+
+	var buf bytes.Buffer
+	scanCmd := cmd.NewScanCommand(scanner.NewScanner(client))
+	scanCmd.SetOut(&buf)
+	scanCmd.SetArgs([]string{"--dry-run"})
+
+	err := scanCmd.Execute()
+	require.NoError(t, err)
+
+	assert.Contains(t, buf.String(), "- prod")
+	assert.Equal(t, 0, client.DescribeServicesCalls)
+}
+
+func TestScanCommand_Watch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// 3回目のスキャンでキャンセルしてループを終了させる
+	var iterations int
+	mockScanner := &MockScanner{}
+	mockScanner.On("DiscoverClusters", mock.Anything).Return([]string{"test-cluster"}, nil)
+	mockScanner.On("ScanServices", mock.Anything, []string{"test-cluster"}).Return([]models.ECSService{
+		{ServiceName: "web-service", ClusterName: "test-cluster", Status: "ACTIVE"},
+	}, nil).Run(func(args mock.Arguments) {
+		iterations++
+		if iterations == 3 {
+			cancel()
+		}
+	})
+
+	var buf bytes.Buffer
+	scanCmd := cmd.NewScanCommand(mockScanner)
+	scanCmd.SetOut(&buf)
+	scanCmd.SetArgs([]string{"--watch", "--interval", "10ms"})
+
+	done := make(chan error, 1)
+	go func() { done <- scanCmd.ExecuteContext(ctx) }()
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("watch loop did not exit after cancellation")
+	}
+
+	assert.GreaterOrEqual(t, iterations, 3)
+	assert.Equal(t, iterations, strings.Count(buf.String(), "web-service"))
+}
+
+func TestScanCommand_WatchDisabledForJSON(t *testing.T) {
+	mockScanner := &MockScanner{}
+	mockScanner.On("DiscoverClusters", mock.Anything).Return([]string{"test-cluster"}, nil)
+	mockScanner.On("ScanServices", mock.Anything, []string{"test-cluster"}).Return([]models.ECSService{}, nil)
+
+	var stderr bytes.Buffer
+	scanCmd := cmd.NewScanCommand(mockScanner)
+	scanCmd.SetOut(&bytes.Buffer{})
+	scanCmd.SetErr(&stderr)
+	scanCmd.SetArgs([]string{"--watch", "--interval", "10ms", "--output", "json"})
+
+	err := scanCmd.Execute()
+	require.NoError(t, err)
+
+	assert.Contains(t, stderr.String(), "--watch is disabled for JSON output")
+	mockScanner.AssertNumberOfCalls(t, "ScanServices", 1)
+}
+
+func TestScanCommand_MaxResults(t *testing.T) {
+	var services []types.Service
+	for i := 0; i < 5; i++ {
+		services = append(services, fakeService(fmt.Sprintf("service-%d", i), "ACTIVE", "FARGATE", 1, 1))
+	}
+	client := &FakeECSClient{Services: map[string][]types.Service{"prod": services}}
+
+	t.Run("上限を超えた場合は中断", func(t *testing.T) {
+		scanCmd := cmd.NewScanCommand(scanner.NewScanner(client))
+		var buf bytes.Buffer
+		scanCmd.SetOut(&buf)
+		scanCmd.SetErr(&bytes.Buffer{})
+		scanCmd.SetArgs([]string{"--max-results", "3"})
+
+		err := scanCmd.Execute()
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, scanner.ErrMaxResultsExceeded))
+		assert.Contains(t, err.Error(), "--max-results")
+		assert.NotContains(t, buf.String(), "service-0")
+	})
+
+	t.Run("0で無制限", func(t *testing.T) {
+		scanCmd := cmd.NewScanCommand(scanner.NewScanner(client))
+		var buf bytes.Buffer
+		scanCmd.SetOut(&buf)
+		scanCmd.SetArgs([]string{"--max-results", "0"})
+
+		err := scanCmd.Execute()
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "service-4")
+	})
+}
+
+func TestScanCommand_CompactJSON(t *testing.T) {
+	mockScanner := &MockScanner{}
+	mockScanner.On("DiscoverClusters", mock.Anything).Return([]string{"test-cluster"}, nil)
+	mockScanner.On("ScanServices", mock.Anything, []string{"test-cluster"}).Return([]models.ECSService{
+		{ServiceName: "web-service", ClusterName: "test-cluster", Status: "ACTIVE"},
+		{ServiceName: "api-service", ClusterName: "test-cluster", Status: "ACTIVE"},
+	}, nil)
+
+	var buf bytes.Buffer
+	cmd := cmd.NewScanCommand(mockScanner)
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{"--output", "json", "--compact"})
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	output := buf.String()
+	assert.NotContains(t, strings.TrimSpace(output), "\n")
+
+	var services []models.ECSService
+	require.NoError(t, json.Unmarshal([]byte(output), &services))
+	assert.Len(t, services, 2)
+}
+
+func TestScanCommand_YAMLFlow(t *testing.T) {
+	mockScanner := &MockScanner{}
+	mockScanner.On("DiscoverClusters", mock.Anything).Return([]string{"test-cluster"}, nil)
+	mockScanner.On("ScanServices", mock.Anything, []string{"test-cluster"}).Return([]models.ECSService{
+		{ServiceName: "web-service", ClusterName: "test-cluster", Status: "ACTIVE"},
+	}, nil)
+
+	var buf bytes.Buffer
+	scanCmd := cmd.NewScanCommand(mockScanner)
+	scanCmd.SetOut(&buf)
+	scanCmd.SetArgs([]string{"--output", "yaml", "--yaml-flow"})
+
+	require.NoError(t, scanCmd.Execute())
+	assert.True(t, strings.HasPrefix(buf.String(), "[{service_name: web-service, cluster_name: test-cluster, status: ACTIVE,"), buf.String())
+}
+
+// ConcurrencyRecordingScanner は設定されたクラスターの同時スキャン数を記録するScanner
+type ConcurrencyRecordingScanner struct {
+	*MockScanner
+	Concurrency int
+}
+
+func (s *ConcurrencyRecordingScanner) SetConcurrency(concurrency int) {
+	s.Concurrency = concurrency
+}
+
+func TestScanCommand_Concurrency(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "phantom-ecs.yaml")
+	yamlContent := "profiles:\n  default:\n    region: us-east-1\nbatch:\n  max_concurrency: 2\nconcurrency:\n  scan: 7\n  inspect: 5\n"
+	require.NoError(t, os.WriteFile(configFile, []byte(yamlContent), 0644))
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected int
+	}{
+		{name: "設定ファイルなしはデフォルト値", args: nil, expected: config.DefaultScanConcurrency},
+		{name: "設定ファイルのconcurrency.scan", args: []string{"--config-file", configFile}, expected: 7},
+		{name: "--concurrencyは設定ファイルより優先", args: []string{"--config-file", configFile, "--concurrency", "3"}, expected: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockScanner := &MockScanner{}
+			mockScanner.On("DiscoverClusters", mock.Anything).Return([]string{"test-cluster"}, nil)
+			mockScanner.On("ScanServices", mock.Anything, []string{"test-cluster"}).Return([]models.ECSService{}, nil)
+			recorder := &ConcurrencyRecordingScanner{MockScanner: mockScanner}
+
+			scanCmd := cmd.NewScanCommand(recorder)
+			scanCmd.SetOut(&bytes.Buffer{})
+			scanCmd.SetArgs(tt.args)
+
+			require.NoError(t, scanCmd.Execute())
+			assert.Equal(t, tt.expected, recorder.Concurrency)
+		})
+	}
+
+	t.Run("0以下はエラー", func(t *testing.T) {
+		scanCmd := cmd.NewScanCommand(&MockScanner{})
+		scanCmd.SetOut(&bytes.Buffer{})
+		scanCmd.SetErr(&bytes.Buffer{})
+		scanCmd.SetArgs([]string{"--concurrency", "0"})
+
+		err := scanCmd.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "concurrency must be at least 1")
+	})
+}
+
+func TestScanCommand_ContinueOnError(t *testing.T) {
+	newScanner := func() *scanner.Scanner {
+		return scanner.NewScanner(&FakeECSClient{
+			Services: map[string][]types.Service{
+				"prod":    {fakeService("web", "ACTIVE", "FARGATE", 2, 2)},
+				"staging": {fakeService("api", "ACTIVE", "FARGATE", 1, 1)},
+				"legacy":  {fakeService("batch", "ACTIVE", "EC2", 1, 1)},
+			},
+			ListServicesErrors: map[string]error{"staging": errors.New("AccessDeniedException: not authorized")},
+		})
+	}
+	args := []string{"--cluster", "prod,staging,legacy", "--output", "json"}
+
+	t.Run("失敗したクラスター以外のサービスを出力してエラーを報告", func(t *testing.T) {
+		var buf bytes.Buffer
+		scanCmd := cmd.NewScanCommand(newScanner())
+		scanCmd.SetOut(&buf)
+		scanCmd.SetErr(&bytes.Buffer{})
+		scanCmd.SetArgs(append(args, "--continue-on-error"))
+
+		err := scanCmd.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to scan 1 of 3 clusters")
+		assert.Contains(t, err.Error(), "staging: AccessDeniedException")
+
+		var services []models.ECSService
+		// エラー時に続けて表示される使用方法より前の出力を読み込む
+		require.NoError(t, json.NewDecoder(&buf).Decode(&services))
+		require.Len(t, services, 2)
+		assert.Equal(t, "prod", services[0].ClusterName)
+		assert.Equal(t, "legacy", services[1].ClusterName)
+	})
+
+	t.Run("デフォルトは最初のエラーで中断", func(t *testing.T) {
+		var buf bytes.Buffer
+		scanCmd := cmd.NewScanCommand(newScanner())
+		scanCmd.SetOut(&buf)
+		scanCmd.SetErr(&bytes.Buffer{})
+		scanCmd.SetArgs(args)
+
+		err := scanCmd.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "AccessDeniedException")
+		assert.NotContains(t, buf.String(), `"service_name"`)
+	})
+}
+
+func TestScanCommand_GroupBy(t *testing.T) {
+	newScanner := func() *scanner.Scanner {
+		return scanner.NewScanner(&FakeECSClient{
+			Services: map[string][]types.Service{
+				"prod":    {fakeService("web", "ACTIVE", "FARGATE", 2, 2), fakeService("worker", "ACTIVE", "EC2", 1, 1)},
+				"staging": {fakeService("api", "ACTIVE", "FARGATE", 1, 0)},
+			},
+		})
+	}
+
+	t.Run("テーブルはクラスターごとのセクションに分けて件数を表示", func(t *testing.T) {
+		var buf bytes.Buffer
+		scanCmd := cmd.NewScanCommand(newScanner())
+		scanCmd.SetOut(&buf)
+		scanCmd.SetArgs([]string{"--cluster", "prod,staging", "--group-by", "cluster"})
+
+		require.NoError(t, scanCmd.Execute())
+		output := buf.String()
+		assert.Contains(t, output, "=== CLUSTER: prod (2 services, 2 healthy, tasks 3/3) ===")
+		assert.Contains(t, output, "=== CLUSTER: staging (1 services, 0 healthy, tasks 0/1) ===")
+		assert.Less(t, strings.Index(output, "CLUSTER: prod"), strings.Index(output, "worker"))
+		assert.Less(t, strings.Index(output, "worker"), strings.Index(output, "CLUSTER: staging"))
+	})
+
+	t.Run("JSONはグループごとにネストして出力", func(t *testing.T) {
+		var buf bytes.Buffer
+		scanCmd := cmd.NewScanCommand(newScanner())
+		scanCmd.SetOut(&buf)
+		scanCmd.SetArgs([]string{"--cluster", "prod,staging", "--group-by", "launch-type", "--output", "json"})
+
+		require.NoError(t, scanCmd.Execute())
+		var groups models.ServiceGroups
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &groups))
+		assert.Equal(t, "launch-type", groups.GroupBy)
+		require.Len(t, groups.Groups, 2)
+		assert.Equal(t, "EC2", groups.Groups[0].Key)
+		assert.Equal(t, 1, groups.Groups[0].ServiceCount)
+		assert.Equal(t, "FARGATE", groups.Groups[1].Key)
+		assert.Equal(t, 2, groups.Groups[1].ServiceCount)
+		require.Len(t, groups.Groups[1].Services, 2)
+	})
+
+	t.Run("未対応のキーはエラー", func(t *testing.T) {
+		scanCmd := cmd.NewScanCommand(newScanner())
+		scanCmd.SetOut(&bytes.Buffer{})
+		scanCmd.SetErr(&bytes.Buffer{})
+		scanCmd.SetArgs([]string{"--group-by", "region"})
+
+		err := scanCmd.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported group-by key")
+	})
+}
+
+func TestScanCommand_FailOnStatus(t *testing.T) {
+	newScanner := func() *scanner.Scanner {
+		return scanner.NewScanner(&FakeECSClient{
+			Services: map[string][]types.Service{
+				"prod": {fakeService("web", "ACTIVE", "FARGATE", 2, 2), fakeService("legacy", "DRAINING", "EC2", 0, 1)},
+			},
+		})
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantCode int
+	}{
+		{name: "フラグなしは終了コード0", args: nil, wantCode: 0},
+		{name: "一致するサービスがあれば非ゼロ", args: []string{"--fail-on-status", "DRAINING"}, wantCode: 3},
+		{name: "大文字小文字を区別しない", args: []string{"--fail-on-status", "draining"}, wantCode: 3},
+		{name: "繰り返し指定", args: []string{"--fail-on-status", "PENDING", "--fail-on-status", "DRAINING"}, wantCode: 3},
+		{name: "一致するサービスがなければ終了コード0", args: []string{"--fail-on-status", "PENDING"}, wantCode: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			scanCmd := cmd.NewScanCommand(newScanner())
+			scanCmd.SetOut(&stdout)
+			scanCmd.SetErr(&stderr)
+			scanCmd.SetArgs(append([]string{"--cluster", "prod", "--output", "json"}, tt.args...))
+
+			code := cmd.Run(scanCmd)
+
+			assert.Equal(t, tt.wantCode, code)
+			// 失敗時も結果は出力される
+			var services []models.ECSService
+			require.NoError(t, json.Unmarshal(stdout.Bytes(), &services))
+			assert.Len(t, services, 2)
+			if tt.wantCode != 0 {
+				assert.Contains(t, stderr.String(), "1 services have a disallowed status: prod/legacy (DRAINING)")
+				assert.NotContains(t, stderr.String(), "Usage:")
+			}
+		})
+	}
+}
+
+func TestScanCommand_SkippedClusterWarning(t *testing.T) {
+	newScanner := func() *scanner.Scanner {
+		return scanner.NewScanner(&FakeECSClient{
+			Services: map[string][]types.Service{
+				"prod": {fakeService("web", "ACTIVE", "FARGATE", 2, 2)},
+				// クラスターの一覧には含まれるが、一覧の取得後に削除されたクラスター
+				"deleted": {},
+			},
+			ListServicesErrors: map[string]error{"deleted": &types.ClusterNotFoundException{Message: aws.String("Cluster not found.")}},
+		})
+	}
+
+	for _, concurrency := range []string{"1", "2"} {
+		t.Run("発見したクラスターは警告してスキップ concurrency="+concurrency, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			scanCmd := cmd.NewScanCommand(newScanner())
+			scanCmd.SetOut(&stdout)
+			scanCmd.SetErr(&stderr)
+			scanCmd.SetArgs([]string{"--output", "json", "--concurrency", concurrency})
+
+			code := cmd.Run(scanCmd)
+
+			// スキップしたクラスターは警告として標準エラー出力に表示し、コマンドは失敗させない
+			assert.Equal(t, 0, code)
+			assert.Equal(t, "Warning: skipped cluster deleted: cluster not found\n", stderr.String())
+			var services []models.ECSService
+			require.NoError(t, json.Unmarshal(stdout.Bytes(), &services))
+			require.Len(t, services, 1)
+			assert.Equal(t, "prod", services[0].ClusterName)
+		})
+	}
+
+	t.Run("指定したクラスターが存在しなければエラー", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		scanCmd := cmd.NewScanCommand(newScanner())
+		scanCmd.SetOut(&stdout)
+		scanCmd.SetErr(&stderr)
+		scanCmd.SetArgs([]string{"--cluster", "deleted,prod", "--output", "json"})
+
+		code := cmd.Run(scanCmd)
+
+		assert.NotEqual(t, 0, code)
+		assert.Contains(t, stderr.String(), "ClusterNotFoundException")
+		assert.NotContains(t, stderr.String(), "Warning:")
+	})
+}
+
+func TestScanCommand_ClientFactory(t *testing.T) {
+	factory := &FakeClientFactory{Clients: map[string]phantomaws.ECSClient{
+		"eu-west-1": &FakeECSClient{Services: map[string][]types.Service{"prod": {fakeService("web", "ACTIVE", "FARGATE", 2, 2)}}},
+	}}
+
+	var buf bytes.Buffer
+	scanCmd := cmd.NewScanCommandWithClientFactory(factory)
+	scanCmd.SetOut(&buf)
+	scanCmd.SetArgs([]string{"--region", "eu-west-1", "--output", "json"})
+
+	require.NoError(t, scanCmd.Execute())
+
+	// --regionのリージョンのクライアントでスキャンする
+	var services []models.ECSService
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &services))
+	require.Len(t, services, 1)
+	assert.Equal(t, "web", services[0].ServiceName)
+	assert.Equal(t, "prod", services[0].ClusterName)
+}
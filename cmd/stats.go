@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/dev-shimada/phantom-ecs/internal/aws"
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+	"github.com/dev-shimada/phantom-ecs/internal/scanner"
+	"github.com/dev-shimada/phantom-ecs/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// NewStatsCommand はstatsコマンドを作成
+func NewStatsCommand(clientFactory aws.ClientFactory) *cobra.Command {
+	var outputFormat string
+	var region string
+	var regions []string
+	var profile string
+	var configFiles []string
+	var configProfile string
+
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "ECSサービスの集計情報を表示",
+		Long: `ECSサービスの集計情報を表示します。
+
+指定されたリージョン内のすべてのクラスターをスキャンし、
+クラスター数、サービス数、健全/不健全なサービス数、
+起動タイプ別の内訳、タスク数の合計を表示します。`,
+		Example: `  # デフォルト設定で集計情報を表示
+  phantom-ecs stats
+
+  # 複数リージョンを集計
+  phantom-ecs stats --regions us-east-1,ap-northeast-1
+
+  # JSON形式で出力
+  phantom-ecs stats --output json`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return applyConfigProfile(cmd, configFiles, configProfile)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			targetRegions := regions
+			if len(targetRegions) == 0 {
+				targetRegions = []string{region}
+			}
+			return runStats(cmd, clientFactory, outputFormat, targetRegions, profile)
+		},
+	}
+
+	// ローカルフラグを定義
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "出力形式 (json|yaml|table)")
+	addCompactFlag(cmd)
+	cmd.Flags().StringVarP(&region, "region", "r", "us-east-1", "AWSリージョン")
+	cmd.Flags().StringSliceVar(&regions, "regions", []string{}, "集計対象のAWSリージョン（カンマ区切り、指定時は--regionより優先）")
+	cmd.Flags().StringVarP(&profile, "profile", "p", "", "AWSプロファイル")
+	addConfigFileFlags(cmd, &configFiles, &configProfile)
+
+	return cmd
+}
+
+// NewStatsCommandWithDefaults は実際のAWSクライアントを生成するファクトリでstatsコマンドを作成
+func NewStatsCommandWithDefaults() *cobra.Command {
+	return NewStatsCommand(aws.NewClientFactory())
+}
+
+// runStats はstatsコマンドの実行ロジック
+func runStats(cmd *cobra.Command, clientFactory aws.ClientFactory, outputFormat string, regions []string, profile string) error {
+	ctx := commandContext(cmd)
+
+	// 出力形式の検証
+	formatter := utils.NewFormatter()
+	if !formatter.ValidateFormat(outputFormat) {
+		return fmt.Errorf("unsupported output format: %s. Supported formats: %v",
+			outputFormat, formatter.GetSupportedFormats())
+	}
+	outputFormat = formatter.NormalizeFormat(outputFormat)
+
+	summary := models.NewScanSummary()
+
+	for _, region := range regions {
+		// リージョンごとにクライアントとScannerを作成
+		client, err := newECSClient(ctx, cmd, clientFactory, region, profile)
+		if err != nil {
+			return fmt.Errorf("failed to create AWS client for %s: %w", region, err)
+		}
+		scannerToUse := scanner.NewScanner(client)
+
+		// クラスターを発見
+		clusters, err := scannerToUse.DiscoverClusters(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to discover clusters in %s: %w", region, err)
+		}
+
+		// サービスをスキャン
+		var services []models.ECSService
+		if len(clusters) > 0 {
+			services, err = scannerToUse.ScanServices(ctx, clusters)
+			if err != nil {
+				return fmt.Errorf("failed to scan services in %s: %w", region, err)
+			}
+		}
+
+		summary.Add(region, len(clusters), services)
+	}
+
+	// 結果をフォーマットして出力
+	output, err := formatter.FormatWithOptions(*summary, utils.FormatOptions{
+		Format:      outputFormat,
+		PrettyPrint: prettyPrint(cmd),
+		Indent:      jsonIndent(cmd),
+		YAMLFlow:    yamlFlow(cmd),
+		Wide:        wide(cmd),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), output)
+	return nil
+}
@@ -0,0 +1,343 @@
+package cmd_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/dev-shimada/phantom-ecs/cmd"
+	phantomaws "github.com/dev-shimada/phantom-ecs/internal/aws"
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// FakeECSClient はクラスター名ごとのサービスを返すテスト用ECSクライアント
+type FakeECSClient struct {
+	Services           map[string][]types.Service
+	ContainerInstances map[string][]types.ContainerInstance
+	// TaskDefinitionFamilies はファミリー名ごとのACTIVEなリビジョン番号（登録順）
+	TaskDefinitionFamilies map[string][]int
+	TaskDefinitions        map[string]*types.TaskDefinition
+	// TaskDefinitionTags はタスク定義ごとのタグ（Include: TAGS 指定時のみ返す）
+	TaskDefinitionTags map[string][]types.Tag
+	// Clusters はDescribeClustersで返すクラスター（未指定のクラスターはEC2とFargateの両方を実行できるものとして返す）
+	Clusters map[string]types.Cluster
+	// ListServicesErrors はクラスターごとにListServicesで返すエラー
+	ListServicesErrors map[string]error
+
+	// DescribeServicesCallsはクラスターの並列スキャンで同時に更新されるためmuで保護する
+	mu                        sync.Mutex
+	DescribeServicesCalls     int
+	RegisteredTaskDefinitions []*ecs.RegisterTaskDefinitionInput
+	CreatedServices           []*ecs.CreateServiceInput
+	UpdatedServices           []*ecs.UpdateServiceInput
+}
+
+func (f *FakeECSClient) ListClusters(ctx context.Context, input *ecs.ListClustersInput) (*ecs.ListClustersOutput, error) {
+	output := &ecs.ListClustersOutput{}
+	for cluster := range f.Services {
+		output.ClusterArns = append(output.ClusterArns, "arn:aws:ecs:us-east-1:123456789012:cluster/"+cluster)
+	}
+	return output, nil
+}
+
+func (f *FakeECSClient) ListServices(ctx context.Context, input *ecs.ListServicesInput) (*ecs.ListServicesOutput, error) {
+	if err := f.ListServicesErrors[*input.Cluster]; err != nil {
+		return nil, err
+	}
+	// 実際のAPIと同様に1ページ最大10件で返す
+	services := f.Services[*input.Cluster]
+	start := 0
+	if input.NextToken != nil {
+		start, _ = strconv.Atoi(*input.NextToken)
+	}
+	end := min(start+fakeListServicesPageSize, len(services))
+	output := &ecs.ListServicesOutput{}
+	for _, service := range services[start:end] {
+		output.ServiceArns = append(output.ServiceArns, *service.ServiceName)
+	}
+	if end < len(services) {
+		output.NextToken = aws.String(strconv.Itoa(end))
+	}
+	return output, nil
+}
+
+// fakeListServicesPageSize はListServicesの1ページあたりの件数（実際のAPIのデフォルト）
+const fakeListServicesPageSize = 10
+
+func (f *FakeECSClient) DescribeServices(ctx context.Context, input *ecs.DescribeServicesInput) (*ecs.DescribeServicesOutput, error) {
+	f.mu.Lock()
+	f.DescribeServicesCalls++
+	f.mu.Unlock()
+	// 実際のAPIと同様に1回あたり最大10件で、指定されたサービスのみを返す
+	if len(input.Services) > fakeListServicesPageSize {
+		return nil, fmt.Errorf("InvalidParameterException: too many services: %d", len(input.Services))
+	}
+	output := &ecs.DescribeServicesOutput{}
+	for _, service := range f.Services[*input.Cluster] {
+		if slices.Contains(input.Services, *service.ServiceName) || (service.ServiceArn != nil && slices.Contains(input.Services, *service.ServiceArn)) {
+			output.Services = append(output.Services, service)
+		}
+	}
+	return output, nil
+}
+
+func (f *FakeECSClient) DescribeTaskDefinition(ctx context.Context, input *ecs.DescribeTaskDefinitionInput) (*ecs.DescribeTaskDefinitionOutput, error) {
+	output := &ecs.DescribeTaskDefinitionOutput{TaskDefinition: f.TaskDefinitions[*input.TaskDefinition]}
+	for _, field := range input.Include {
+		if field == types.TaskDefinitionFieldTags {
+			output.Tags = f.TaskDefinitionTags[*input.TaskDefinition]
+		}
+	}
+	return output, nil
+}
+
+func (f *FakeECSClient) CreateService(ctx context.Context, input *ecs.CreateServiceInput) (*ecs.CreateServiceOutput, error) {
+	f.CreatedServices = append(f.CreatedServices, input)
+	return &ecs.CreateServiceOutput{}, nil
+}
+
+func (f *FakeECSClient) UpdateService(ctx context.Context, input *ecs.UpdateServiceInput) (*ecs.UpdateServiceOutput, error) {
+	f.UpdatedServices = append(f.UpdatedServices, input)
+	return &ecs.UpdateServiceOutput{}, nil
+}
+
+func (f *FakeECSClient) RegisterTaskDefinition(ctx context.Context, input *ecs.RegisterTaskDefinitionInput) (*ecs.RegisterTaskDefinitionOutput, error) {
+	f.RegisteredTaskDefinitions = append(f.RegisteredTaskDefinitions, input)
+	taskDefArn := fmt.Sprintf("arn:aws:ecs:us-east-1:123456789012:task-definition/%s:1", *input.Family)
+	return &ecs.RegisterTaskDefinitionOutput{TaskDefinition: &types.TaskDefinition{TaskDefinitionArn: &taskDefArn}}, nil
+}
+
+func (f *FakeECSClient) DescribeClusters(ctx context.Context, input *ecs.DescribeClustersInput) (*ecs.DescribeClustersOutput, error) {
+	output := &ecs.DescribeClustersOutput{}
+	for _, name := range input.Clusters {
+		cluster, ok := f.Clusters[name]
+		if !ok {
+			cluster = types.Cluster{ClusterName: &name, RegisteredContainerInstancesCount: 1}
+		}
+		output.Clusters = append(output.Clusters, cluster)
+	}
+	return output, nil
+}
+
+func (f *FakeECSClient) ListContainerInstances(ctx context.Context, input *ecs.ListContainerInstancesInput) (*ecs.ListContainerInstancesOutput, error) {
+	output := &ecs.ListContainerInstancesOutput{}
+	for _, instance := range f.ContainerInstances[*input.Cluster] {
+		output.ContainerInstanceArns = append(output.ContainerInstanceArns, *instance.ContainerInstanceArn)
+	}
+	return output, nil
+}
+
+func (f *FakeECSClient) DescribeContainerInstances(ctx context.Context, input *ecs.DescribeContainerInstancesInput) (*ecs.DescribeContainerInstancesOutput, error) {
+	return &ecs.DescribeContainerInstancesOutput{ContainerInstances: f.ContainerInstances[*input.Cluster]}, nil
+}
+
+func (f *FakeECSClient) ListTaskDefinitionFamilies(ctx context.Context, input *ecs.ListTaskDefinitionFamiliesInput) (*ecs.ListTaskDefinitionFamiliesOutput, error) {
+	output := &ecs.ListTaskDefinitionFamiliesOutput{}
+	for family := range f.TaskDefinitionFamilies {
+		output.Families = append(output.Families, family)
+	}
+	sort.Strings(output.Families)
+	return output, nil
+}
+
+func (f *FakeECSClient) ListTaskDefinitions(ctx context.Context, input *ecs.ListTaskDefinitionsInput) (*ecs.ListTaskDefinitionsOutput, error) {
+	output := &ecs.ListTaskDefinitionsOutput{}
+	for _, revision := range f.TaskDefinitionFamilies[*input.FamilyPrefix] {
+		output.TaskDefinitionArns = append(output.TaskDefinitionArns,
+			fmt.Sprintf("arn:aws:ecs:us-east-1:123456789012:task-definition/%s:%d", *input.FamilyPrefix, revision))
+	}
+	return output, nil
+}
+
+// FakeClientFactory はリージョンごとにFakeECSClientを返すファクトリ
+type FakeClientFactory struct {
+	Clients map[string]phantomaws.ECSClient
+}
+
+func (f *FakeClientFactory) NewClient(ctx context.Context, region, profile string, opts ...phantomaws.Option) (phantomaws.ECSClient, error) {
+	client, ok := f.Clients[region]
+	if !ok {
+		return nil, fmt.Errorf("unexpected region: %s", region)
+	}
+	return client, nil
+}
+
+func fakeService(name, status, launchType string, desired, running int32) types.Service {
+	return types.Service{
+		ServiceName:  &name,
+		Status:       &status,
+		LaunchType:   types.LaunchType(launchType),
+		DesiredCount: desired,
+		RunningCount: running,
+	}
+}
+
+func TestStatsCommand_JSONOutput(t *testing.T) {
+	factory := &FakeClientFactory{
+		Clients: map[string]phantomaws.ECSClient{
+			"us-east-1": &FakeECSClient{Services: map[string][]types.Service{
+				"prod": {
+					fakeService("web", "ACTIVE", "FARGATE", 3, 3),
+					fakeService("api", "ACTIVE", "FARGATE", 2, 1),
+				},
+				"staging": {
+					fakeService("worker", "ACTIVE", "EC2", 1, 1),
+				},
+			}},
+			"ap-northeast-1": &FakeECSClient{Services: map[string][]types.Service{
+				"tokyo": {
+					fakeService("batch", "ACTIVE", "EC2", 2, 2),
+				},
+			}},
+		},
+	}
+
+	var buf bytes.Buffer
+	statsCmd := cmd.NewStatsCommand(factory)
+	statsCmd.SetOut(&buf)
+	statsCmd.SetArgs([]string{"--regions", "us-east-1,ap-northeast-1", "--output", "json"})
+
+	err := statsCmd.Execute()
+	require.NoError(t, err)
+
+	var summary models.ScanSummary
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &summary))
+
+	assert.Equal(t, []string{"us-east-1", "ap-northeast-1"}, summary.Regions)
+	assert.Equal(t, 3, summary.TotalClusters)
+	assert.Equal(t, 4, summary.TotalServices)
+	assert.Equal(t, 3, summary.HealthyServices)
+	assert.Equal(t, 1, summary.UnhealthyServices)
+	assert.Equal(t, 2, summary.LaunchTypes["FARGATE"])
+	assert.Equal(t, 2, summary.LaunchTypes["EC2"])
+	assert.Equal(t, int32(8), summary.TotalDesiredTasks)
+	assert.Equal(t, int32(7), summary.TotalRunningTasks)
+}
+
+func TestStatsCommand_TableOutput(t *testing.T) {
+	factory := &FakeClientFactory{
+		Clients: map[string]phantomaws.ECSClient{
+			"us-east-1": &FakeECSClient{Services: map[string][]types.Service{
+				"prod": {fakeService("web", "ACTIVE", "FARGATE", 2, 2)},
+			}},
+		},
+	}
+
+	var buf bytes.Buffer
+	statsCmd := cmd.NewStatsCommand(factory)
+	statsCmd.SetOut(&buf)
+	statsCmd.SetArgs([]string{})
+
+	err := statsCmd.Execute()
+	require.NoError(t, err)
+
+	assert.Contains(t, buf.String(), "Clusters: 1")
+	assert.Contains(t, buf.String(), "Services: 1")
+	assert.Contains(t, buf.String(), "Tasks (running/desired): 2/2")
+}
+
+func TestStatsCommand_ClientFactoryError(t *testing.T) {
+	factory := &FakeClientFactory{Clients: map[string]phantomaws.ECSClient{}}
+
+	statsCmd := cmd.NewStatsCommand(factory)
+	statsCmd.SetArgs([]string{"--region", "eu-west-1"})
+
+	err := statsCmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "eu-west-1")
+}
+
+// ProfileRecordingClientFactory はクライアント作成時のAWSプロファイルを記録するファクトリ
+type ProfileRecordingClientFactory struct {
+	FakeClientFactory
+	Profiles []string
+}
+
+func (f *ProfileRecordingClientFactory) NewClient(ctx context.Context, region, profile string, opts ...phantomaws.Option) (phantomaws.ECSClient, error) {
+	f.Profiles = append(f.Profiles, profile)
+	return f.FakeClientFactory.NewClient(ctx, region, profile, opts...)
+}
+
+func TestStatsCommand_ConfigProfile(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "phantom-ecs.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(`
+profiles:
+  default:
+    region: us-east-1
+  staging:
+    region: ap-northeast-1
+    output_format: json
+    aws_profile: stg-admin
+`), 0o600))
+	newFactory := func() *ProfileRecordingClientFactory {
+		return &ProfileRecordingClientFactory{FakeClientFactory: FakeClientFactory{Clients: map[string]phantomaws.ECSClient{
+			"us-east-1":      &FakeECSClient{Services: map[string][]types.Service{"virginia": {fakeService("web", "ACTIVE", "FARGATE", 1, 1)}}},
+			"ap-northeast-1": &FakeECSClient{Services: map[string][]types.Service{"tokyo": {fakeService("api", "ACTIVE", "FARGATE", 1, 1)}}},
+			"eu-west-1":      &FakeECSClient{Services: map[string][]types.Service{"ireland": {fakeService("batch", "ACTIVE", "EC2", 1, 1)}}},
+		}}}
+	}
+
+	t.Run("選択したプロファイルのリージョン・出力形式・AWSプロファイルを使用", func(t *testing.T) {
+		factory := newFactory()
+		var buf bytes.Buffer
+		statsCmd := cmd.NewStatsCommand(factory)
+		statsCmd.SetOut(&buf)
+		statsCmd.SetArgs([]string{"--config-file", configFile, "--config-profile", "staging"})
+
+		require.NoError(t, statsCmd.Execute())
+
+		var summary models.ScanSummary
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &summary))
+		assert.Equal(t, []string{"ap-northeast-1"}, summary.Regions)
+		assert.Equal(t, []string{"stg-admin"}, factory.Profiles)
+	})
+
+	t.Run("コマンドラインの指定はプロファイルより優先", func(t *testing.T) {
+		factory := newFactory()
+		var buf bytes.Buffer
+		statsCmd := cmd.NewStatsCommand(factory)
+		statsCmd.SetOut(&buf)
+		statsCmd.SetArgs([]string{"--config-file", configFile, "--config-profile", "staging", "--region", "eu-west-1", "--output", "table", "--profile", "ops"})
+
+		require.NoError(t, statsCmd.Execute())
+
+		assert.Contains(t, buf.String(), "Clusters: 1")
+		assert.Equal(t, []string{"ops"}, factory.Profiles)
+		assert.Equal(t, 1, factory.Clients["eu-west-1"].(*FakeECSClient).DescribeServicesCalls)
+	})
+
+	t.Run("存在しないプロファイルはAWSを呼び出す前にエラー", func(t *testing.T) {
+		factory := newFactory()
+		statsCmd := cmd.NewStatsCommand(factory)
+		statsCmd.SetOut(&bytes.Buffer{})
+		statsCmd.SetArgs([]string{"--config-file", configFile, "--config-profile", "missing"})
+
+		err := statsCmd.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to load config file")
+		assert.Empty(t, factory.Profiles)
+	})
+
+	t.Run("設定ファイルなしでのプロファイル指定はエラー", func(t *testing.T) {
+		statsCmd := cmd.NewStatsCommand(newFactory())
+		statsCmd.SetOut(&bytes.Buffer{})
+		statsCmd.SetArgs([]string{"--config-profile", "staging"})
+
+		err := statsCmd.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--config-profile requires --config-file")
+	})
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/dev-shimada/phantom-ecs/internal/arn"
+	"github.com/dev-shimada/phantom-ecs/internal/aws"
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// DefaultTailEventsInterval は inspect --tail-events のポーリング間隔のデフォルト値
+const DefaultTailEventsInterval = 5 * time.Second
+
+// ServiceEventsInterface はサービスイベントの取得操作を定義するインターフェース
+type ServiceEventsInterface interface {
+	GetServiceEvents(ctx context.Context, serviceName, clusterName string) ([]models.ServiceEvent, error)
+}
+
+// eventTailer は表示済みのイベントIDを記録し、新しいイベントのみを出力する
+type eventTailer struct {
+	out  io.Writer
+	seen map[string]bool
+}
+
+// newEventTailer は新しいeventTailerを作成
+func newEventTailer(out io.Writer) *eventTailer {
+	return &eventTailer{
+		out:  out,
+		seen: make(map[string]bool),
+	}
+}
+
+// printNew は未表示のイベントを時系列順に出力し、出力した件数を返す
+func (t *eventTailer) printNew(events []models.ServiceEvent) int {
+	printed := 0
+	for _, event := range events {
+		if t.seen[event.ID] {
+			continue
+		}
+		t.seen[event.ID] = true
+		fmt.Fprintf(t.out, "%s  %s\n", event.CreatedAt.Format(time.RFC3339), event.Message)
+		printed++
+	}
+	return printed
+}
+
+// tailServiceEvents は中断されるまで一定間隔でサービスイベントを取得し、新しいイベントを出力する
+func tailServiceEvents(ctx context.Context, out io.Writer, source ServiceEventsInterface, serviceName, clusterName string, interval time.Duration) error {
+	// Ctrl-Cでループを終了
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	tailer := newEventTailer(out)
+	for {
+		events, err := source.GetServiceEvents(ctx, serviceName, clusterName)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to get service events: %w", err)
+		}
+		tailer.printNew(events)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// runTailEvents は inspect --tail-events の実行ロジック
+func runTailEvents(cmd *cobra.Command, inspectorImpl InspectorInterface, clientFactory aws.ClientFactory, serviceName, clusterName, region, profile string, interval time.Duration) error {
+	ctx := commandContext(cmd)
+
+	// 必須パラメータの検証
+	if serviceName == "" {
+		return fmt.Errorf("service name is required")
+	}
+	if clusterName == "" {
+		return fmt.Errorf("cluster name is required")
+	}
+	if interval <= 0 {
+		return fmt.Errorf("tail-interval must be positive: %s", interval)
+	}
+	// クラスターARNが指定された場合はクラスター名に正規化
+	clusterName = arn.ClusterName(clusterName)
+
+	// Inspectorがnilの場合（実際のAWS呼び出し用）は、AWS Inspectorを作成
+	var inspectorToUse InspectorInterface
+	if inspectorImpl != nil {
+		inspectorToUse = inspectorImpl
+	} else {
+		client, err := newECSClient(ctx, cmd, clientFactory, region, profile)
+		if err != nil {
+			return fmt.Errorf("failed to create AWS client: %w", err)
+		}
+		inspectorToUse = newInspector(client)
+	}
+
+	source, ok := inspectorToUse.(ServiceEventsInterface)
+	if !ok {
+		return fmt.Errorf("inspector does not support tailing service events")
+	}
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "Tailing events for %s in %s (Ctrl-C to stop)\n", serviceName, clusterName)
+	return tailServiceEvents(ctx, cmd.OutOrStdout(), source, serviceName, clusterName, interval)
+}
@@ -0,0 +1,98 @@
+package cmd_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dev-shimada/phantom-ecs/cmd"
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// FakeEventsInspector はポーリングごとに用意したイベント一覧を順に返すInspectorのフェイク
+type FakeEventsInspector struct {
+	MockInspector
+	Polls  [][]models.ServiceEvent
+	Calls  int
+	OnPoll func()
+}
+
+func (f *FakeEventsInspector) GetServiceEvents(ctx context.Context, serviceName, clusterName string) ([]models.ServiceEvent, error) {
+	if f.OnPoll != nil {
+		f.OnPoll()
+	}
+	events := f.Polls[min(f.Calls, len(f.Polls)-1)]
+	f.Calls++
+	return events, nil
+}
+
+func serviceEvent(id, message string, minute int) models.ServiceEvent {
+	return models.ServiceEvent{
+		ID:        id,
+		CreatedAt: time.Date(2024, 1, 1, 10, minute, 0, 0, time.UTC),
+		Message:   message,
+	}
+}
+
+func TestInspectCommand_TailEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// ポーリングごとにイベントが追加される
+	polls := [][]models.ServiceEvent{
+		{serviceEvent("e1", "started 1 tasks", 0)},
+		{serviceEvent("e1", "started 1 tasks", 0), serviceEvent("e2", "registered 1 targets", 1)},
+		{serviceEvent("e1", "started 1 tasks", 0), serviceEvent("e2", "registered 1 targets", 1), serviceEvent("e3", "reached a steady state", 2)},
+	}
+
+	var buf bytes.Buffer
+	var outputs []string
+	fakeInspector := &FakeEventsInspector{Polls: polls}
+	fakeInspector.OnPoll = func() {
+		// 前回のポーリングで出力された内容を記録
+		outputs = append(outputs, buf.String())
+		buf.Reset()
+		if fakeInspector.Calls == len(polls)-1 {
+			cancel()
+		}
+	}
+
+	inspectCmd := cmd.NewInspectCommand(fakeInspector)
+	inspectCmd.SetOut(&buf)
+	inspectCmd.SetErr(&bytes.Buffer{})
+	inspectCmd.SetArgs([]string{"web-service", "--cluster", "test-cluster", "--tail-events", "--tail-interval", "10ms"})
+
+	done := make(chan error, 1)
+	go func() { done <- inspectCmd.ExecuteContext(ctx) }()
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("tail loop did not exit after cancellation")
+	}
+	outputs = append(outputs, buf.String())
+
+	// 各ポーリングでは新しいイベントのみが出力される
+	require.Len(t, outputs, len(polls)+1)
+	assert.Empty(t, outputs[0])
+	assert.Equal(t, "2024-01-01T10:00:00Z  started 1 tasks\n", outputs[1])
+	assert.Equal(t, "2024-01-01T10:01:00Z  registered 1 targets\n", outputs[2])
+	assert.Equal(t, "2024-01-01T10:02:00Z  reached a steady state\n", outputs[3])
+	assert.Equal(t, len(polls), fakeInspector.Calls)
+}
+
+func TestInspectCommand_TailEventsInvalidInterval(t *testing.T) {
+	inspectCmd := cmd.NewInspectCommand(&FakeEventsInspector{})
+	inspectCmd.SetOut(&bytes.Buffer{})
+	inspectCmd.SetErr(&bytes.Buffer{})
+	inspectCmd.SetArgs([]string{"web-service", "--cluster", "test-cluster", "--tail-events", "--tail-interval", "0s"})
+
+	err := inspectCmd.Execute()
+	require.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "tail-interval must be positive"))
+}
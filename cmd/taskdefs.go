@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dev-shimada/phantom-ecs/internal/aws"
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+	"github.com/dev-shimada/phantom-ecs/internal/scanner"
+	"github.com/dev-shimada/phantom-ecs/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// TaskDefinitionScannerInterface はタスク定義一覧取得の操作を定義するインターフェース
+type TaskDefinitionScannerInterface interface {
+	ScanTaskDefinitions(ctx context.Context, family string) ([]models.TaskDefinitionSummary, error)
+}
+
+// NewTaskDefsCommand はtaskdefsコマンドを作成
+func NewTaskDefsCommand(scannerImpl TaskDefinitionScannerInterface) *cobra.Command {
+	return newTaskDefsCommand(scannerImpl, aws.NewClientFactory())
+}
+
+// NewTaskDefsCommandWithClientFactory はAWSクライアントを指定したファクトリで作成するtaskdefsコマンドを作成
+func NewTaskDefsCommandWithClientFactory(clientFactory aws.ClientFactory) *cobra.Command {
+	return newTaskDefsCommand(nil, clientFactory)
+}
+
+// newTaskDefsCommand はtaskdefsコマンドを作成（Scannerがnilの場合はclientFactoryのクライアントを使用）
+func newTaskDefsCommand(scannerImpl TaskDefinitionScannerInterface, clientFactory aws.ClientFactory) *cobra.Command {
+	var family string
+	var outputFormat string
+	var region string
+	var profile string
+	var configFiles []string
+	var configProfile string
+
+	cmd := &cobra.Command{
+		Use:   "taskdefs",
+		Short: "タスク定義ファミリーとリビジョンの一覧を表示",
+		Long: `登録されているタスク定義ファミリーと、そのACTIVEなリビジョンの一覧を表示します。
+
+ファミリーごとに最新リビジョンとACTIVEなリビジョン数を確認できます。`,
+		Example: `  # すべてのタスク定義ファミリーを表示
+  phantom-ecs taskdefs
+
+  # 特定のファミリーのリビジョンのみを表示
+  phantom-ecs taskdefs --family web-task
+
+  # JSON形式で出力
+  phantom-ecs taskdefs --output json`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return applyConfigProfile(cmd, configFiles, configProfile)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTaskDefs(cmd, scannerImpl, clientFactory, family, outputFormat, region, profile)
+		},
+	}
+
+	// ローカルフラグを定義
+	cmd.Flags().StringVar(&family, "family", "", "対象のタスク定義ファミリー (省略時はすべてのファミリー)")
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "出力形式 (json|yaml|table)")
+	addCompactFlag(cmd)
+	cmd.Flags().StringVarP(&region, "region", "r", "us-east-1", "AWSリージョン")
+	cmd.Flags().StringVarP(&profile, "profile", "p", "", "AWSプロファイル")
+	addConfigFileFlags(cmd, &configFiles, &configProfile)
+
+	return cmd
+}
+
+// NewTaskDefsCommandWithDefaults はデフォルトのScannerでtaskdefsコマンドを作成
+func NewTaskDefsCommandWithDefaults() *cobra.Command {
+	return NewTaskDefsCommand(nil)
+}
+
+// runTaskDefs はtaskdefsコマンドの実行ロジック
+func runTaskDefs(cmd *cobra.Command, scannerImpl TaskDefinitionScannerInterface, clientFactory aws.ClientFactory, family, outputFormat, region, profile string) error {
+	ctx := commandContext(cmd)
+
+	// 出力形式の検証
+	formatter := utils.NewFormatter()
+	if !formatter.ValidateFormat(outputFormat) {
+		return fmt.Errorf("unsupported output format: %s. Supported formats: %v",
+			outputFormat, formatter.GetSupportedFormats())
+	}
+	outputFormat = formatter.NormalizeFormat(outputFormat)
+
+	// Scannerがnilの場合（実際のAWS呼び出し用）は、AWS Scannerを作成
+	var scannerToUse TaskDefinitionScannerInterface
+	if scannerImpl != nil {
+		scannerToUse = scannerImpl
+	} else {
+		client, err := newECSClient(ctx, cmd, clientFactory, region, profile)
+		if err != nil {
+			return fmt.Errorf("failed to create AWS client: %w", err)
+		}
+		scannerToUse = scanner.NewScanner(client)
+	}
+
+	summaries, err := scannerToUse.ScanTaskDefinitions(ctx, family)
+	if err != nil {
+		return fmt.Errorf("failed to list task definitions: %w", err)
+	}
+
+	// 結果をフォーマットして出力
+	output, err := formatter.FormatWithOptions(summaries, utils.FormatOptions{
+		Format:      outputFormat,
+		PrettyPrint: prettyPrint(cmd),
+		Indent:      jsonIndent(cmd),
+		YAMLFlow:    yamlFlow(cmd),
+		Wide:        wide(cmd),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), output)
+	return nil
+}
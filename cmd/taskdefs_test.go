@@ -0,0 +1,84 @@
+package cmd_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/dev-shimada/phantom-ecs/cmd"
+	phantomaws "github.com/dev-shimada/phantom-ecs/internal/aws"
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+	"github.com/dev-shimada/phantom-ecs/internal/scanner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskDefsCommand(t *testing.T) {
+	client := &FakeECSClient{
+		TaskDefinitionFamilies: map[string][]int{
+			"web-task": {3, 4, 7},
+			"api-task": {1},
+		},
+	}
+
+	t.Run("テーブル形式", func(t *testing.T) {
+		var buf bytes.Buffer
+		taskDefsCmd := cmd.NewTaskDefsCommand(scanner.NewScanner(client))
+		taskDefsCmd.SetOut(&buf)
+		taskDefsCmd.SetArgs([]string{})
+
+		err := taskDefsCmd.Execute()
+		require.NoError(t, err)
+
+		output := buf.String()
+		assert.Contains(t, output, "FAMILY")
+		assert.Regexp(t, `api-task\s+1\s+1\s+1\s*\n`, output)
+		assert.Regexp(t, `web-task\s+7\s+3\s+3,4,7\s*\n`, output)
+	})
+
+	t.Run("ファミリー指定", func(t *testing.T) {
+		var buf bytes.Buffer
+		taskDefsCmd := cmd.NewTaskDefsCommand(scanner.NewScanner(client))
+		taskDefsCmd.SetOut(&buf)
+		taskDefsCmd.SetArgs([]string{"--family", "web-task", "--output", "json"})
+
+		err := taskDefsCmd.Execute()
+		require.NoError(t, err)
+
+		var summaries []models.TaskDefinitionSummary
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &summaries))
+		require.Len(t, summaries, 1)
+		assert.Equal(t, "web-task", summaries[0].Family)
+		assert.Equal(t, []int{3, 4, 7}, summaries[0].Revisions)
+		assert.Equal(t, 7, summaries[0].LatestRevision)
+	})
+
+	t.Run("該当なし", func(t *testing.T) {
+		var buf bytes.Buffer
+		taskDefsCmd := cmd.NewTaskDefsCommand(scanner.NewScanner(client))
+		taskDefsCmd.SetOut(&buf)
+		taskDefsCmd.SetArgs([]string{"--family", "missing-task"})
+
+		err := taskDefsCmd.Execute()
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "No task definitions found.")
+	})
+}
+
+func TestTaskDefsCommand_ClientFactory(t *testing.T) {
+	factory := &FakeClientFactory{Clients: map[string]phantomaws.ECSClient{
+		"eu-west-1": &FakeECSClient{TaskDefinitionFamilies: map[string][]int{"web-task": {3, 4}}},
+	}}
+
+	var buf bytes.Buffer
+	taskDefsCmd := cmd.NewTaskDefsCommandWithClientFactory(factory)
+	taskDefsCmd.SetOut(&buf)
+	taskDefsCmd.SetArgs([]string{"--region", "eu-west-1", "--output", "json"})
+
+	require.NoError(t, taskDefsCmd.Execute())
+
+	var summaries []models.TaskDefinitionSummary
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &summaries))
+	require.Len(t, summaries, 1)
+	assert.Equal(t, "web-task", summaries[0].Family)
+}
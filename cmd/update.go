@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dev-shimada/phantom-ecs/internal/arn"
+	"github.com/dev-shimada/phantom-ecs/internal/aws"
+	"github.com/dev-shimada/phantom-ecs/internal/deployer"
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+	"github.com/dev-shimada/phantom-ecs/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// UpdaterInterface は既存サービスの更新操作を定義するインターフェース
+type UpdaterInterface interface {
+	UpdateService(ctx context.Context, update models.ServiceUpdate) (*models.DeploymentResult, error)
+}
+
+// UpdatePlannerInterface は稼働中のサービスと比較して更新内容を求める操作を定義するインターフェース
+type UpdatePlannerInterface interface {
+	PlanServiceUpdate(ctx context.Context, update models.ServiceUpdate) (*models.ServiceUpdatePlan, error)
+}
+
+// NewUpdateCommand はupdateコマンドを作成
+func NewUpdateCommand(updaterImpl UpdaterInterface) *cobra.Command {
+	return newUpdateCommand(updaterImpl, aws.NewClientFactory())
+}
+
+// NewUpdateCommandWithClientFactory はAWSクライアントを指定したファクトリで作成するupdateコマンドを作成
+func NewUpdateCommandWithClientFactory(clientFactory aws.ClientFactory) *cobra.Command {
+	return newUpdateCommand(nil, clientFactory)
+}
+
+// newUpdateCommand はupdateコマンドを作成（Updaterがnilの場合はclientFactoryのクライアントを使用）
+func newUpdateCommand(updaterImpl UpdaterInterface, clientFactory aws.ClientFactory) *cobra.Command {
+	var clusterName string
+	var taskDefinition string
+	var desiredCount int32
+	var forceNewDeployment bool
+	var dryRun bool
+	var outputFormat string
+	var region string
+	var profile string
+	var configFiles []string
+	var configProfile string
+
+	cmd := &cobra.Command{
+		Use:   "update <service-name>",
+		Short: "既存のECSサービスを更新",
+		Long: `既存のECSサービスのタスク定義や希望タスク数を更新します。
+
+--force-new-deployment を指定すると、設定に変更がなくても
+新しいデプロイを開始します（移動したイメージタグの再取得など）。
+
+--dry-run を指定すると、稼働中のサービスと比較して
+変更される項目のみを表示し、サービスは更新しません。
+
+更新前に確認を求めます。CIなど標準入力が端末でない環境では
+--yes を指定してください。`,
+		Example: `  # タスク定義を更新
+  phantom-ecs update my-service --cluster my-cluster --task-definition my-task:5
+
+  # 希望タスク数を変更
+  phantom-ecs update my-service --cluster my-cluster --desired-count 3
+
+  # 設定を変えずに新しいデプロイを強制
+  phantom-ecs update my-service --cluster my-cluster --force-new-deployment
+
+  # 確認を省略して更新（CI向け）
+  phantom-ecs update my-service --cluster my-cluster --desired-count 3 --yes
+
+  # 更新せずに現在のサービスからの変更点を確認
+  phantom-ecs update my-service --cluster my-cluster --task-definition my-task:5 --desired-count 3 --dry-run`,
+		Args: cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return applyConfigProfile(cmd, configFiles, configProfile)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			serviceName := args[0]
+			clusterName, err := resolveCluster(clusterName, configFiles, configProfile)
+			if err != nil {
+				return err
+			}
+
+			update := models.ServiceUpdate{
+				ServiceName:        serviceName,
+				ClusterName:        clusterName,
+				TaskDefinition:     taskDefinition,
+				ForceNewDeployment: forceNewDeployment,
+			}
+			if cmd.Flags().Changed("desired-count") {
+				update.DesiredCount = &desiredCount
+			}
+			return runUpdate(cmd, updaterImpl, clientFactory, update, dryRun, outputFormat, region, profile)
+		},
+	}
+
+	// ローカルフラグを定義
+	cmd.Flags().StringVarP(&clusterName, "cluster", "c", "", "クラスター名またはクラスターARN (省略時は設定ファイルのdefault_cluster)")
+	cmd.Flags().StringVar(&taskDefinition, "task-definition", "", "新しいタスク定義 (family:revision またはARN)")
+	cmd.Flags().Int32Var(&desiredCount, "desired-count", 0, "新しい希望タスク数")
+	cmd.Flags().BoolVar(&forceNewDeployment, "force-new-deployment", false, "設定の変更がなくても新しいデプロイを開始")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "サービスを更新せず、稼働中のサービスから変更される項目のみを表示")
+	addYesFlag(cmd)
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "出力形式 (json|yaml|table)")
+	addCompactFlag(cmd)
+	cmd.Flags().StringVarP(&region, "region", "r", "us-east-1", "AWSリージョン")
+	cmd.Flags().StringVarP(&profile, "profile", "p", "", "AWSプロファイル")
+	addConfigFileFlags(cmd, &configFiles, &configProfile)
+
+	return cmd
+}
+
+// NewUpdateCommandWithDefaults はデフォルトのDeployerでupdateコマンドを作成
+func NewUpdateCommandWithDefaults() *cobra.Command {
+	return NewUpdateCommand(nil)
+}
+
+// runUpdate はupdateコマンドの実行ロジック
+func runUpdate(cmd *cobra.Command, updaterImpl UpdaterInterface, clientFactory aws.ClientFactory, update models.ServiceUpdate, dryRun bool, outputFormat, region, profile string) error {
+	ctx := commandContext(cmd)
+
+	// 必須パラメータの検証
+	if update.ServiceName == "" {
+		return fmt.Errorf("service name is required")
+	}
+	if update.ClusterName == "" {
+		return fmt.Errorf("cluster name is required")
+	}
+	if update.TaskDefinition == "" && update.DesiredCount == nil && !update.ForceNewDeployment {
+		return fmt.Errorf("nothing to update: specify --task-definition, --desired-count or --force-new-deployment")
+	}
+	if update.DesiredCount != nil && *update.DesiredCount < 0 {
+		return fmt.Errorf("desired-count must not be negative: %d", *update.DesiredCount)
+	}
+	// クラスターARNが指定された場合はクラスター名に正規化
+	update.ClusterName = arn.ClusterName(update.ClusterName)
+
+	// 出力形式の検証
+	formatter := utils.NewFormatter()
+	if !formatter.ValidateFormat(outputFormat) {
+		return fmt.Errorf("unsupported output format: %s. Supported formats: %v",
+			outputFormat, formatter.GetSupportedFormats())
+	}
+	outputFormat = formatter.NormalizeFormat(outputFormat)
+
+	// Updaterがnilの場合（実際のAWS呼び出し用）は、AWS実装を作成
+	var updaterToUse UpdaterInterface
+	if updaterImpl != nil {
+		updaterToUse = updaterImpl
+	} else {
+		client, err := newECSClient(ctx, cmd, clientFactory, region, profile)
+		if err != nil {
+			return fmt.Errorf("failed to create AWS client: %w", err)
+		}
+		updaterToUse = deployer.NewDeployer(client)
+	}
+
+	// ドライランの場合は稼働中のサービスと比較した変更点のみを表示
+	if dryRun {
+		return runUpdatePlan(ctx, cmd, updaterToUse, formatter, update, outputFormat)
+	}
+
+	// 実際に更新する場合は実行前に確認（--yesで省略）
+	if err := confirmAction(cmd, fmt.Sprintf("Update service %s in cluster %s?", update.ServiceName, update.ClusterName)); err != nil {
+		return err
+	}
+
+	result, err := updaterToUse.UpdateService(ctx, update)
+	if err != nil {
+		return fmt.Errorf("failed to update service: %w", err)
+	}
+
+	// 結果をフォーマットして出力
+	output, err := formatter.FormatWithOptions(*result, utils.FormatOptions{
+		Format:      outputFormat,
+		PrettyPrint: prettyPrint(cmd),
+		Indent:      jsonIndent(cmd),
+		YAMLFlow:    yamlFlow(cmd),
+		Wide:        wide(cmd),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), output)
+	return nil
+}
+
+// runUpdatePlan は稼働中のサービスと比較した更新内容を出力
+func runUpdatePlan(ctx context.Context, cmd *cobra.Command, updaterToUse UpdaterInterface, formatter *utils.Formatter, update models.ServiceUpdate, outputFormat string) error {
+	planner, ok := updaterToUse.(UpdatePlannerInterface)
+	if !ok {
+		return fmt.Errorf("updater does not support dry-run")
+	}
+
+	plan, err := planner.PlanServiceUpdate(ctx, update)
+	if err != nil {
+		return fmt.Errorf("failed to plan service update: %w", err)
+	}
+
+	output, err := formatter.FormatWithOptions(*plan, utils.FormatOptions{
+		Format:      outputFormat,
+		PrettyPrint: prettyPrint(cmd),
+		Indent:      jsonIndent(cmd),
+		YAMLFlow:    yamlFlow(cmd),
+		Wide:        wide(cmd),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), output)
+	return nil
+}
@@ -0,0 +1,205 @@
+package cmd_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/dev-shimada/phantom-ecs/cmd"
+	"github.com/dev-shimada/phantom-ecs/internal/deployer"
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockUpdater はUpdaterのモック
+type MockUpdater struct {
+	mock.Mock
+}
+
+func (m *MockUpdater) UpdateService(ctx context.Context, update models.ServiceUpdate) (*models.DeploymentResult, error) {
+	args := m.Called(ctx, update)
+	return args.Get(0).(*models.DeploymentResult), args.Error(1)
+}
+
+func TestUpdateCommand_ForceNewDeployment(t *testing.T) {
+	mockUpdater := &MockUpdater{}
+	mockUpdater.On("UpdateService", mock.Anything, models.ServiceUpdate{
+		ServiceName:        "web-service",
+		ClusterName:        "prod",
+		ForceNewDeployment: true,
+	}).Return(&models.DeploymentResult{ServiceName: "web-service", ClusterName: "prod", Success: true}, nil)
+
+	var buf bytes.Buffer
+	cmd := cmd.NewUpdateCommand(mockUpdater)
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{"web-service", "--cluster", "arn:aws:ecs:us-east-1:123456789012:cluster/prod", "--force-new-deployment", "--yes"})
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "web-service")
+	mockUpdater.AssertExpectations(t)
+}
+
+func TestUpdateCommand_TaskDefinitionAndDesiredCount(t *testing.T) {
+	desiredCount := int32(0)
+	mockUpdater := &MockUpdater{}
+	mockUpdater.On("UpdateService", mock.Anything, models.ServiceUpdate{
+		ServiceName:    "web-service",
+		ClusterName:    "prod",
+		TaskDefinition: "web-task:4",
+		DesiredCount:   &desiredCount,
+	}).Return(&models.DeploymentResult{ServiceName: "web-service", ClusterName: "prod", Success: true}, nil)
+
+	cmd := cmd.NewUpdateCommand(mockUpdater)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{"web-service", "--cluster", "prod", "--task-definition", "web-task:4", "--desired-count", "0", "--yes"})
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+	mockUpdater.AssertExpectations(t)
+}
+
+func TestUpdateCommand_NothingToUpdate(t *testing.T) {
+	mockUpdater := &MockUpdater{}
+
+	cmd := cmd.NewUpdateCommand(mockUpdater)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"web-service", "--cluster", "prod"})
+
+	err := cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "nothing to update")
+	mockUpdater.AssertNotCalled(t, "UpdateService", mock.Anything, mock.Anything)
+}
+
+func TestUpdateCommand_DryRun(t *testing.T) {
+	family := "web-task"
+	currentArn := "arn:aws:ecs:us-east-1:123456789012:task-definition/web-task:3"
+	newArn := "arn:aws:ecs:us-east-1:123456789012:task-definition/web-task:4"
+	service := fakeService("web-service", "ACTIVE", "FARGATE", 2, 2)
+	service.TaskDefinition = &currentArn
+
+	newClient := func() *FakeECSClient {
+		return &FakeECSClient{
+			Services: map[string][]types.Service{"prod": {service}},
+			TaskDefinitions: map[string]*types.TaskDefinition{
+				"web-task:3": {TaskDefinitionArn: &currentArn, Family: &family, Revision: 3},
+				"web-task:4": {TaskDefinitionArn: &newArn, Family: &family, Revision: 4},
+			},
+		}
+	}
+
+	t.Run("現在の設定と同じ場合は変更なし", func(t *testing.T) {
+		client := newClient()
+		var buf bytes.Buffer
+		cmd := cmd.NewUpdateCommand(deployer.NewDeployer(client))
+		cmd.SetOut(&buf)
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs([]string{"web-service", "--cluster", "prod", "--task-definition", "web-task:3", "--desired-count", "2", "--dry-run"})
+
+		require.NoError(t, cmd.Execute())
+		assert.Contains(t, buf.String(), "No changes.")
+		assert.Empty(t, client.UpdatedServices)
+	})
+
+	t.Run("変更される項目のみを表示", func(t *testing.T) {
+		client := newClient()
+		var buf bytes.Buffer
+		cmd := cmd.NewUpdateCommand(deployer.NewDeployer(client))
+		cmd.SetOut(&buf)
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs([]string{"web-service", "--cluster", "prod", "--task-definition", "web-task:4", "--desired-count", "2", "--dry-run", "--output", "json"})
+
+		require.NoError(t, cmd.Execute())
+		var plan models.ServiceUpdatePlan
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &plan))
+		assert.Equal(t, []models.FieldDifference{
+			{Field: "task_definition", Live: currentArn, Expected: newArn},
+		}, plan.Changes)
+		assert.Empty(t, client.UpdatedServices)
+	})
+
+	t.Run("テーブル形式で差分を表示", func(t *testing.T) {
+		var buf bytes.Buffer
+		cmd := cmd.NewUpdateCommand(deployer.NewDeployer(newClient()))
+		cmd.SetOut(&buf)
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs([]string{"web-service", "--cluster", "prod", "--desired-count", "5", "--dry-run"})
+
+		require.NoError(t, cmd.Execute())
+		assert.Contains(t, buf.String(), "=== CHANGES (1) ===")
+		assert.Regexp(t, `desired_count\s+2\s+5\s*\n`, buf.String())
+		assert.NotContains(t, buf.String(), "task_definition")
+	})
+}
+
+func TestUpdateCommand_DryRunUnsupported(t *testing.T) {
+	mockUpdater := &MockUpdater{}
+
+	cmd := cmd.NewUpdateCommand(mockUpdater)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"web-service", "--cluster", "prod", "--desired-count", "1", "--dry-run"})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not support dry-run")
+	mockUpdater.AssertNotCalled(t, "UpdateService", mock.Anything, mock.Anything)
+}
+
+func TestUpdateCommand_Confirmation(t *testing.T) {
+	t.Run("入力でyを承認すると更新する", func(t *testing.T) {
+		mockUpdater := &MockUpdater{}
+		mockUpdater.On("UpdateService", mock.Anything, mock.Anything).
+			Return(&models.DeploymentResult{ServiceName: "web-service", ClusterName: "prod", Success: true}, nil)
+
+		var stderr bytes.Buffer
+		updateCmd := cmd.NewUpdateCommand(mockUpdater)
+		updateCmd.SetIn(bytes.NewBufferString("y\n"))
+		updateCmd.SetOut(&bytes.Buffer{})
+		updateCmd.SetErr(&stderr)
+		updateCmd.SetArgs([]string{"web-service", "--cluster", "prod", "--desired-count", "3"})
+
+		require.NoError(t, updateCmd.Execute())
+		assert.Contains(t, stderr.String(), "Update service web-service in cluster prod? [y/N]: ")
+		mockUpdater.AssertExpectations(t)
+	})
+
+	t.Run("拒否またはEOFの場合は更新しない", func(t *testing.T) {
+		for _, input := range []string{"n\n", ""} {
+			mockUpdater := &MockUpdater{}
+
+			updateCmd := cmd.NewUpdateCommand(mockUpdater)
+			updateCmd.SetIn(bytes.NewBufferString(input))
+			updateCmd.SetOut(&bytes.Buffer{})
+			updateCmd.SetErr(&bytes.Buffer{})
+			updateCmd.SetArgs([]string{"web-service", "--cluster", "prod", "--desired-count", "3"})
+
+			err := updateCmd.Execute()
+			assert.ErrorIs(t, err, cmd.ErrCancelled)
+			mockUpdater.AssertNotCalled(t, "UpdateService", mock.Anything, mock.Anything)
+		}
+	})
+
+	t.Run("--yesの場合は確認しない", func(t *testing.T) {
+		mockUpdater := &MockUpdater{}
+		mockUpdater.On("UpdateService", mock.Anything, mock.Anything).
+			Return(&models.DeploymentResult{ServiceName: "web-service", ClusterName: "prod", Success: true}, nil)
+
+		var stderr bytes.Buffer
+		updateCmd := cmd.NewUpdateCommand(mockUpdater)
+		updateCmd.SetIn(bytes.NewBufferString(""))
+		updateCmd.SetOut(&bytes.Buffer{})
+		updateCmd.SetErr(&stderr)
+		updateCmd.SetArgs([]string{"web-service", "--cluster", "prod", "--desired-count", "3", "--yes"})
+
+		require.NoError(t, updateCmd.Execute())
+		assert.NotContains(t, stderr.String(), "[y/N]")
+		mockUpdater.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/dev-shimada/phantom-ecs/internal/aws"
+	"github.com/dev-shimada/phantom-ecs/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// NewWhoamiCommand はwhoamiコマンドを作成
+func NewWhoamiCommand(stsClient aws.STSClient) *cobra.Command {
+	var outputFormat string
+	var region string
+	var profile string
+	var configFiles []string
+	var configProfile string
+
+	cmd := &cobra.Command{
+		Use:     "whoami",
+		Aliases: []string{"check-auth"},
+		Short:   "AWS認証情報を確認",
+		Long: `AWS認証情報が有効かどうかを確認します。
+
+STS GetCallerIdentityを呼び出し、アカウントID、ARN、
+使用するリージョンを表示します。認証情報が無効な場合はエラーになります。`,
+		Example: `  # デフォルトの認証情報を確認
+  phantom-ecs whoami
+
+  # 特定のプロファイルとリージョンの認証情報を確認
+  phantom-ecs whoami --profile production --region ap-northeast-1`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return applyConfigProfile(cmd, configFiles, configProfile)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWhoami(cmd, stsClient, outputFormat, region, profile)
+		},
+	}
+
+	// ローカルフラグを定義
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "出力形式 (json|yaml|table)")
+	addCompactFlag(cmd)
+	cmd.Flags().StringVarP(&region, "region", "r", "us-east-1", "AWSリージョン")
+	cmd.Flags().StringVarP(&profile, "profile", "p", "", "AWSプロファイル")
+	addConfigFileFlags(cmd, &configFiles, &configProfile)
+
+	return cmd
+}
+
+// NewWhoamiCommandWithDefaults はデフォルトのSTSクライアントでwhoamiコマンドを作成
+func NewWhoamiCommandWithDefaults() *cobra.Command {
+	return NewWhoamiCommand(nil)
+}
+
+// runWhoami はwhoamiコマンドの実行ロジック
+func runWhoami(cmd *cobra.Command, stsClient aws.STSClient, outputFormat, region, profile string) error {
+	ctx := commandContext(cmd)
+
+	// 出力形式の検証
+	formatter := utils.NewFormatter()
+	if !formatter.ValidateFormat(outputFormat) {
+		return fmt.Errorf("unsupported output format: %s. Supported formats: %v",
+			outputFormat, formatter.GetSupportedFormats())
+	}
+	outputFormat = formatter.NormalizeFormat(outputFormat)
+
+	// STSクライアントがnilの場合（実際のAWS呼び出し用）は、設定から解決したリージョンで作成
+	clientToUse := stsClient
+	if clientToUse == nil {
+		options, err := awsClientOptions(cmd)
+		if err != nil {
+			return err
+		}
+		client, err := aws.NewSTSClient(ctx, region, profile, options...)
+		if err != nil {
+			return fmt.Errorf("failed to load AWS configuration: %w", err)
+		}
+		clientToUse = client
+		region = client.Options().Region
+	}
+
+	identity, err := aws.GetCallerIdentity(ctx, clientToUse, region)
+	if err != nil {
+		if profile != "" {
+			return fmt.Errorf("invalid AWS credentials for profile %s: %w", profile, err)
+		}
+		return fmt.Errorf("invalid AWS credentials: %w", err)
+	}
+
+	// 結果をフォーマットして出力
+	output, err := formatter.FormatWithOptions(*identity, utils.FormatOptions{
+		Format:      outputFormat,
+		PrettyPrint: prettyPrint(cmd),
+		Indent:      jsonIndent(cmd),
+		YAMLFlow:    yamlFlow(cmd),
+		Wide:        wide(cmd),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), output)
+	return nil
+}
@@ -0,0 +1,85 @@
+package cmd_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go"
+	"github.com/dev-shimada/phantom-ecs/cmd"
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// FakeSTSClient は固定の呼び出し元IDを返すテスト用STSクライアント
+type FakeSTSClient struct {
+	Output *sts.GetCallerIdentityOutput
+	Err    error
+}
+
+func (f *FakeSTSClient) GetCallerIdentity(ctx context.Context, params *sts.GetCallerIdentityInput, optFns ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	return f.Output, nil
+}
+
+func TestWhoamiCommand(t *testing.T) {
+	client := &FakeSTSClient{Output: &sts.GetCallerIdentityOutput{
+		Account: aws.String("123456789012"),
+		Arn:     aws.String("arn:aws:iam::123456789012:user/deployer"),
+		UserId:  aws.String("AIDAEXAMPLE"),
+	}}
+
+	t.Run("テーブル形式", func(t *testing.T) {
+		var buf bytes.Buffer
+		whoamiCmd := cmd.NewWhoamiCommand(client)
+		whoamiCmd.SetOut(&buf)
+		whoamiCmd.SetArgs([]string{"--region", "ap-northeast-1"})
+
+		err := whoamiCmd.Execute()
+		require.NoError(t, err)
+
+		output := buf.String()
+		assert.Contains(t, output, "Account: 123456789012")
+		assert.Contains(t, output, "ARN: arn:aws:iam::123456789012:user/deployer")
+		assert.Contains(t, output, "Region: ap-northeast-1")
+	})
+
+	t.Run("JSON形式", func(t *testing.T) {
+		var buf bytes.Buffer
+		whoamiCmd := cmd.NewWhoamiCommand(client)
+		whoamiCmd.SetOut(&buf)
+		whoamiCmd.SetArgs([]string{"--output", "json"})
+
+		err := whoamiCmd.Execute()
+		require.NoError(t, err)
+
+		var identity models.CallerIdentity
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &identity))
+		assert.Equal(t, models.CallerIdentity{
+			Account: "123456789012",
+			ARN:     "arn:aws:iam::123456789012:user/deployer",
+			UserID:  "AIDAEXAMPLE",
+			Region:  "us-east-1",
+		}, identity)
+	})
+}
+
+func TestWhoamiCommand_InvalidCredentials(t *testing.T) {
+	client := &FakeSTSClient{Err: &smithy.GenericAPIError{Code: "InvalidClientTokenId", Message: "The security token included in the request is invalid."}}
+
+	whoamiCmd := cmd.NewWhoamiCommand(client)
+	whoamiCmd.SetOut(&bytes.Buffer{})
+	whoamiCmd.SetErr(&bytes.Buffer{})
+	whoamiCmd.SetArgs([]string{"--profile", "expired"})
+
+	err := whoamiCmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid AWS credentials for profile expired")
+	assert.Contains(t, err.Error(), "InvalidClientTokenId")
+}
@@ -0,0 +1,105 @@
+package arn
+
+import (
+	"fmt"
+	"strings"
+)
+
+const arnPrefix = "arn:"
+
+// ARN はAWSリソースのARNを分解した構造体
+// 形式: arn:partition:service:region:account:resource-type/resource-name
+type ARN struct {
+	Partition    string
+	Service      string
+	Region       string
+	AccountID    string
+	ResourceType string
+	ResourceName string
+}
+
+// Parse はARN文字列を解析してARN構造体を返す
+func Parse(arn string) (ARN, error) {
+	if !strings.HasPrefix(arn, arnPrefix) {
+		return ARN{}, fmt.Errorf("invalid ARN: %q does not start with %q", arn, arnPrefix)
+	}
+
+	sections := strings.SplitN(arn, ":", 6)
+	if len(sections) != 6 {
+		return ARN{}, fmt.Errorf("invalid ARN: %q has not enough sections", arn)
+	}
+
+	parsed := ARN{
+		Partition: sections[1],
+		Service:   sections[2],
+		Region:    sections[3],
+		AccountID: sections[4],
+	}
+	if parsed.Partition == "" || parsed.Service == "" {
+		return ARN{}, fmt.Errorf("invalid ARN: %q is missing partition or service", arn)
+	}
+
+	// リソース部分は "type/name" または "type:name" 形式
+	resource := sections[5]
+	if idx := strings.IndexAny(resource, "/:"); idx >= 0 {
+		parsed.ResourceType = resource[:idx]
+		parsed.ResourceName = resource[idx+1:]
+	} else {
+		parsed.ResourceName = resource
+	}
+	if parsed.ResourceName == "" {
+		return ARN{}, fmt.Errorf("invalid ARN: %q is missing resource name", arn)
+	}
+
+	return parsed, nil
+}
+
+// IsARN は文字列がARN形式かどうかを判定
+func IsARN(s string) bool {
+	return strings.HasPrefix(s, arnPrefix)
+}
+
+// String はARNを文字列形式に戻す
+func (a ARN) String() string {
+	resource := a.ResourceName
+	if a.ResourceType != "" {
+		resource = a.ResourceType + "/" + a.ResourceName
+	}
+	return strings.Join([]string{"arn", a.Partition, a.Service, a.Region, a.AccountID, resource}, ":")
+}
+
+// ClusterName はクラスター名またはクラスターARNを受け取りクラスター名を返す
+// クラスターARNとして解析できない値はそのまま返す
+func ClusterName(nameOrARN string) string {
+	if !IsARN(nameOrARN) {
+		return nameOrARN
+	}
+
+	parsed, err := Parse(nameOrARN)
+	if err != nil || parsed.ResourceType != "cluster" {
+		return nameOrARN
+	}
+	return parsed.ResourceName
+}
+
+// ParseServiceARN はサービスARNからクラスター名とサービス名を取り出す
+// 新形式 (service/cluster-name/service-name) と旧形式 (service/service-name) に対応し、旧形式ではクラスター名は空になる
+func ParseServiceARN(serviceARN string) (clusterName, serviceName string, err error) {
+	parsed, err := Parse(serviceARN)
+	if err != nil {
+		return "", "", err
+	}
+	if parsed.Service != "ecs" || parsed.ResourceType != "service" {
+		return "", "", fmt.Errorf("invalid service ARN: %q is not an ECS service ARN", serviceARN)
+	}
+
+	parts := strings.Split(parsed.ResourceName, "/")
+	switch {
+	case len(parts) == 1:
+		return "", parts[0], nil
+	case len(parts) == 2 && parts[0] != "" && parts[1] != "":
+		return parts[0], parts[1], nil
+	default:
+		return "", "", fmt.Errorf("invalid service ARN: %q has a malformed resource %q", serviceARN, parsed.ResourceName)
+	}
+}
@@ -0,0 +1,140 @@
+package arn_test
+
+import (
+	"testing"
+
+	"github.com/dev-shimada/phantom-ecs/internal/arn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected arn.ARN
+	}{
+		{
+			name:  "クラスターARN",
+			input: "arn:aws:ecs:us-east-1:123456789012:cluster/prod-cluster",
+			expected: arn.ARN{
+				Partition:    "aws",
+				Service:      "ecs",
+				Region:       "us-east-1",
+				AccountID:    "123456789012",
+				ResourceType: "cluster",
+				ResourceName: "prod-cluster",
+			},
+		},
+		{
+			name:  "サービスARN（クラスター名を含む形式）",
+			input: "arn:aws:ecs:ap-northeast-1:123456789012:service/prod-cluster/web-service",
+			expected: arn.ARN{
+				Partition:    "aws",
+				Service:      "ecs",
+				Region:       "ap-northeast-1",
+				AccountID:    "123456789012",
+				ResourceType: "service",
+				ResourceName: "prod-cluster/web-service",
+			},
+		},
+		{
+			name:  "タスク定義ARN",
+			input: "arn:aws-cn:ecs:cn-north-1:123456789012:task-definition/web-task:5",
+			expected: arn.ARN{
+				Partition:    "aws-cn",
+				Service:      "ecs",
+				Region:       "cn-north-1",
+				AccountID:    "123456789012",
+				ResourceType: "task-definition",
+				ResourceName: "web-task:5",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := arn.Parse(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, parsed)
+			assert.Equal(t, tt.input, parsed.String())
+		})
+	}
+}
+
+func TestParse_Malformed(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "空文字列", input: ""},
+		{name: "ARNではない名前", input: "prod-cluster"},
+		{name: "セクション不足", input: "arn:aws:ecs:us-east-1"},
+		{name: "サービスが空", input: "arn:aws::us-east-1:123456789012:cluster/prod"},
+		{name: "リソース名が空", input: "arn:aws:ecs:us-east-1:123456789012:cluster/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := arn.Parse(tt.input)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "invalid ARN")
+		})
+	}
+}
+
+func TestClusterName(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "クラスター名", input: "prod", expected: "prod"},
+		{name: "クラスターARN", input: "arn:aws:ecs:us-east-1:123456789012:cluster/prod", expected: "prod"},
+		{name: "クラスター以外のARNはそのまま", input: "arn:aws:ecs:us-east-1:123456789012:service/prod/web", expected: "arn:aws:ecs:us-east-1:123456789012:service/prod/web"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, arn.ClusterName(tt.input))
+		})
+	}
+}
+
+func TestParseServiceARN(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           string
+		expectedCluster string
+		expectedService string
+	}{
+		{name: "新形式", input: "arn:aws:ecs:us-east-1:123456789012:service/prod/web", expectedCluster: "prod", expectedService: "web"},
+		{name: "旧形式はクラスターなし", input: "arn:aws:ecs:us-east-1:123456789012:service/web", expectedCluster: "", expectedService: "web"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cluster, service, err := arn.ParseServiceARN(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedCluster, cluster)
+			assert.Equal(t, tt.expectedService, service)
+		})
+	}
+}
+
+func TestParseServiceARN_Invalid(t *testing.T) {
+	inputs := []string{
+		"web",
+		"arn:aws:ecs:us-east-1:123456789012:cluster/prod",
+		"arn:aws:s3:::bucket/service",
+		"arn:aws:ecs:us-east-1:123456789012:service/prod/web/extra",
+		"arn:aws:ecs:us-east-1:123456789012:service//web",
+	}
+
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			_, _, err := arn.ParseServiceARN(input)
+			assert.Error(t, err)
+		})
+	}
+}
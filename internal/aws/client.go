@@ -15,27 +15,8 @@ type Client struct {
 }
 
 // NewClient 新しいAWSクライアントを作成
-func NewClient(ctx context.Context, region, profile string) (*Client, error) {
-	// デフォルトリージョンの設定
-	if region == "" {
-		region = "us-east-1"
-	}
-
-	// AWS設定の読み込み
-	var cfg aws.Config
-	var err error
-
-	if profile != "" {
-		cfg, err = config.LoadDefaultConfig(ctx,
-			config.WithRegion(region),
-			config.WithSharedConfigProfile(profile),
-		)
-	} else {
-		cfg, err = config.LoadDefaultConfig(ctx,
-			config.WithRegion(region),
-		)
-	}
-
+func NewClient(ctx context.Context, region, profile string, opts ...Option) (*Client, error) {
+	cfg, err := loadConfig(ctx, region, profile, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -45,10 +26,47 @@ func NewClient(ctx context.Context, region, profile string) (*Client, error) {
 
 	return &Client{
 		ecsClient: ecsClient,
-		region:    region,
+		region:    cfg.Region,
 	}, nil
 }
 
+// loadConfig はリージョンとプロファイルを指定し、クライアントの設定を適用してAWS設定を読み込む
+func loadConfig(ctx context.Context, region, profile string, opts []Option) (aws.Config, error) {
+	clientOptions := newClientOptions(opts)
+
+	// デフォルトリージョンの設定
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	options := []func(*config.LoadOptions) error{
+		config.WithRegion(region),
+	}
+	if profile != "" {
+		options = append(options, config.WithSharedConfigProfile(profile))
+	}
+	// 高い同時実行数でも接続プールが枯渇しないよう、設定されたHTTP設定を適用
+	if !clientOptions.httpOptions.IsZero() {
+		options = append(options, config.WithHTTPClient(NewHTTPClient(clientOptions.httpOptions)))
+	}
+	// 政府機関向けのワークロードなどでFIPS 140-2準拠のエンドポイントを使用
+	if clientOptions.useFIPSEndpoint {
+		options = append(options, config.WithUseFIPSEndpoint(aws.FIPSEndpointStateEnabled))
+	}
+	// IPv6のみのVPCからも接続できるようデュアルスタックのエンドポイントを使用
+	if clientOptions.useDualStackEndpoint {
+		options = append(options, config.WithUseDualStackEndpoint(aws.DualStackEndpointStateEnabled))
+	}
+	// --debug-aws指定時はリクエスト/レスポンスを機密ヘッダーをマスクしてデバッグログに出力
+	if clientOptions.debugLogger != nil {
+		options = append(options,
+			config.WithClientLogMode(DebugLogMode),
+			config.WithLogger(&debugLogAdapter{logger: clientOptions.debugLogger}),
+		)
+	}
+	return config.LoadDefaultConfig(ctx, options...)
+}
+
 // GetECSClient ECSクライアントを取得
 func (c *Client) GetECSClient() *ecs.Client {
 	return c.ecsClient
@@ -80,6 +98,30 @@ func (c *Client) CreateService(ctx context.Context, input *ecs.CreateServiceInpu
 	return c.ecsClient.CreateService(ctx, input)
 }
 
+func (c *Client) UpdateService(ctx context.Context, input *ecs.UpdateServiceInput) (*ecs.UpdateServiceOutput, error) {
+	return c.ecsClient.UpdateService(ctx, input)
+}
+
+func (c *Client) DescribeClusters(ctx context.Context, input *ecs.DescribeClustersInput) (*ecs.DescribeClustersOutput, error) {
+	return c.ecsClient.DescribeClusters(ctx, input)
+}
+
+func (c *Client) ListContainerInstances(ctx context.Context, input *ecs.ListContainerInstancesInput) (*ecs.ListContainerInstancesOutput, error) {
+	return c.ecsClient.ListContainerInstances(ctx, input)
+}
+
+func (c *Client) DescribeContainerInstances(ctx context.Context, input *ecs.DescribeContainerInstancesInput) (*ecs.DescribeContainerInstancesOutput, error) {
+	return c.ecsClient.DescribeContainerInstances(ctx, input)
+}
+
+func (c *Client) ListTaskDefinitionFamilies(ctx context.Context, input *ecs.ListTaskDefinitionFamiliesInput) (*ecs.ListTaskDefinitionFamiliesOutput, error) {
+	return c.ecsClient.ListTaskDefinitionFamilies(ctx, input)
+}
+
+func (c *Client) ListTaskDefinitions(ctx context.Context, input *ecs.ListTaskDefinitionsInput) (*ecs.ListTaskDefinitionsOutput, error) {
+	return c.ecsClient.ListTaskDefinitions(ctx, input)
+}
+
 func (c *Client) RegisterTaskDefinition(ctx context.Context, input *ecs.RegisterTaskDefinitionInput) (*ecs.RegisterTaskDefinitionOutput, error) {
 	return c.ecsClient.RegisterTaskDefinition(ctx, input)
 }
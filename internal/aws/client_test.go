@@ -1,10 +1,19 @@
 package aws_test
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"testing"
+	"time"
 
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
 	"github.com/dev-shimada/phantom-ecs/internal/aws"
+	"github.com/dev-shimada/phantom-ecs/internal/logger"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -88,3 +97,115 @@ func TestClient_GetRegion(t *testing.T) {
 		})
 	}
 }
+
+func TestDefaultClientFactory_NewClient(t *testing.T) {
+	factory := aws.NewClientFactory()
+
+	client, err := factory.NewClient(context.Background(), "us-west-2", "")
+	require.NoError(t, err)
+	assert.NotNil(t, client)
+
+	client, err = factory.NewClient(context.Background(), "us-west-2", "nonexistent-profile")
+	assert.Error(t, err)
+	assert.Nil(t, client)
+}
+
+func TestNewClient_HTTPOptions(t *testing.T) {
+	client, err := aws.NewClient(context.Background(), "us-east-1", "",
+		aws.WithHTTPOptions(aws.HTTPOptions{MaxConns: 64, Timeout: 15 * time.Second}))
+	require.NoError(t, err)
+
+	httpClient, ok := client.GetECSClient().Options().HTTPClient.(*awshttp.BuildableClient)
+	require.True(t, ok, "unexpected HTTP client type: %T", client.GetECSClient().Options().HTTPClient)
+	assert.Equal(t, 64, httpClient.GetTransport().MaxIdleConnsPerHost)
+	assert.GreaterOrEqual(t, httpClient.GetTransport().MaxIdleConns, 64)
+	assert.Equal(t, 15*time.Second, httpClient.GetTimeout())
+}
+
+func TestNewClient_UseFIPSEndpoint(t *testing.T) {
+	client, err := aws.NewClient(context.Background(), "us-gov-west-1", "")
+	require.NoError(t, err)
+	assert.NotEqual(t, awssdk.FIPSEndpointStateEnabled, client.GetECSClient().Options().EndpointOptions.UseFIPSEndpoint)
+
+	client, err = aws.NewClient(context.Background(), "us-gov-west-1", "", aws.WithFIPSEndpoint(true))
+	require.NoError(t, err)
+	assert.Equal(t, awssdk.FIPSEndpointStateEnabled, client.GetECSClient().Options().EndpointOptions.UseFIPSEndpoint)
+}
+
+func TestNewClient_UseDualStackEndpoint(t *testing.T) {
+	client, err := aws.NewClient(context.Background(), "us-east-1", "")
+	require.NoError(t, err)
+	assert.NotEqual(t, awssdk.DualStackEndpointStateEnabled, client.GetECSClient().Options().EndpointOptions.UseDualStackEndpoint)
+
+	client, err = aws.NewClient(context.Background(), "us-east-1", "", aws.WithDualStackEndpoint(true))
+	require.NoError(t, err)
+	assert.Equal(t, awssdk.DualStackEndpointStateEnabled, client.GetECSClient().Options().EndpointOptions.UseDualStackEndpoint)
+}
+
+func TestNewHTTPClient_ZeroOptionsKeepSDKDefaults(t *testing.T) {
+	defaults := awshttp.NewBuildableClient()
+
+	httpClient := aws.NewHTTPClient(aws.HTTPOptions{})
+
+	assert.Equal(t, defaults.GetTransport().MaxIdleConnsPerHost, httpClient.GetTransport().MaxIdleConnsPerHost)
+	assert.Equal(t, defaults.GetTimeout(), httpClient.GetTimeout())
+}
+
+func TestNewClient_DebugLogger(t *testing.T) {
+	errStop := errors.New("stop before sending request")
+	requestLogger := func(client *aws.Client) *smithyhttp.RequestResponseLogger {
+		var found *smithyhttp.RequestResponseLogger
+		_, err := client.GetECSClient().ListClusters(context.Background(), &ecs.ListClustersInput{}, func(o *ecs.Options) {
+			o.APIOptions = append(o.APIOptions, func(stack *middleware.Stack) error {
+				if m, ok := stack.Deserialize.Get("RequestResponseLogger"); ok {
+					found, _ = m.(*smithyhttp.RequestResponseLogger)
+				}
+				return errStop
+			})
+		})
+		require.ErrorIs(t, err, errStop)
+		return found
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		client, err := aws.NewClient(context.Background(), "us-east-1", "")
+		require.NoError(t, err)
+
+		m := requestLogger(client)
+		if m != nil {
+			assert.False(t, m.LogRequest || m.LogResponseWithBody)
+		}
+	})
+
+	t.Run("enabled with debug logger", func(t *testing.T) {
+		log, err := logger.NewLogger(&logger.Config{Level: "debug", Format: "text", Output: &bytes.Buffer{}})
+		require.NoError(t, err)
+		client, err := aws.NewClient(context.Background(), "us-east-1", "", aws.WithDebugLogger(log))
+		require.NoError(t, err)
+
+		assert.Equal(t, aws.DebugLogMode, client.GetECSClient().Options().ClientLogMode)
+		m := requestLogger(client)
+		require.NotNil(t, m)
+		assert.True(t, m.LogRequest)
+		assert.True(t, m.LogResponseWithBody)
+	})
+}
+
+func TestRedactSensitiveHeaders(t *testing.T) {
+	dump := "POST /?X-Amz-Credential=AKIA%2F20240101&X-Amz-Signature=abcdef&Action=List HTTP/1.1\r\n" +
+		"Host: ecs.us-east-1.amazonaws.com\r\n" +
+		"Authorization: AWS4-HMAC-SHA256 Credential=AKIA/20240101, Signature=abcdef\r\n" +
+		"x-amz-security-token: secret-token\r\n" +
+		"X-Amz-Target: AmazonEC2ContainerServiceV20141113.ListClusters\r\n"
+
+	redacted := aws.RedactSensitiveHeaders(dump)
+
+	assert.NotContains(t, redacted, "AKIA")
+	assert.NotContains(t, redacted, "abcdef")
+	assert.NotContains(t, redacted, "secret-token")
+	assert.Contains(t, redacted, "Authorization: [REDACTED]\r\n")
+	assert.Contains(t, redacted, "x-amz-security-token: [REDACTED]\r\n")
+	assert.Contains(t, redacted, "X-Amz-Credential=[REDACTED]&X-Amz-Signature=[REDACTED]&Action=List")
+	assert.Contains(t, redacted, "Host: ecs.us-east-1.amazonaws.com\r\n")
+	assert.Contains(t, redacted, "X-Amz-Target: AmazonEC2ContainerServiceV20141113.ListClusters\r\n")
+}
@@ -0,0 +1,57 @@
+package aws
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/smithy-go/logging"
+	"github.com/dev-shimada/phantom-ecs/internal/logger"
+)
+
+// DebugLogMode は--debug-aws指定時にSDKへ設定するリクエスト/レスポンスのログ出力モード
+// リクエストは本文なし（認証情報を含み得るため）、レスポンスは本文ありで出力する
+const DebugLogMode = aws.LogRequest | aws.LogResponseWithBody
+
+// redactedValue はマスクした値の代わりに出力する文字列
+const redactedValue = "[REDACTED]"
+
+// sensitiveHeaders はログ出力時に値をマスクするHTTPヘッダー（小文字）
+var sensitiveHeaders = map[string]bool{
+	"authorization":        true,
+	"proxy-authorization":  true,
+	"x-amz-security-token": true,
+	"cookie":               true,
+	"set-cookie":           true,
+}
+
+// sensitiveQueryPattern は署名付きURLのクエリに含まれる認証情報にマッチする
+var sensitiveQueryPattern = regexp.MustCompile(`(?i)(X-Amz-(?:Signature|Credential|Security-Token)=)[^&\s]*`)
+
+// debugLogAdapter はSDKのログ出力をロガーのデバッグレベルに中継する
+type debugLogAdapter struct {
+	logger logger.Logger
+}
+
+// Logf はlogging.Loggerの実装で、機密情報をマスクしてデバッグレベルで出力する
+func (a *debugLogAdapter) Logf(classification logging.Classification, format string, v ...interface{}) {
+	a.logger.Debug(RedactSensitiveHeaders(fmt.Sprintf(format, v...)))
+}
+
+// RedactSensitiveHeaders はHTTPメッセージのダンプから認証ヘッダーと署名クエリの値をマスクする
+func RedactSensitiveHeaders(dump string) string {
+	lines := strings.Split(dump, "\n")
+	for i, line := range lines {
+		name, _, found := strings.Cut(line, ":")
+		if found && sensitiveHeaders[strings.ToLower(strings.TrimSpace(name))] {
+			lines[i] = name + ": " + redactedValue
+			if strings.HasSuffix(line, "\r") {
+				lines[i] += "\r"
+			}
+			continue
+		}
+		lines[i] = sensitiveQueryPattern.ReplaceAllString(line, "${1}"+redactedValue)
+	}
+	return strings.Join(lines, "\n")
+}
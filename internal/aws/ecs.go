@@ -30,7 +30,7 @@ func (e *ECSService) GetClient() *Client {
 	return e.client
 }
 
-// ListServices 指定されたクラスターのサービス一覧を取得
+// ListServices 指定されたクラスターのサービス一覧を全ページ取得
 func (e *ECSService) ListServices(ctx context.Context, clusterName string) ([]string, error) {
 	ecsClient := e.client.GetECSClient()
 
@@ -39,14 +39,15 @@ func (e *ECSService) ListServices(ctx context.Context, clusterName string) ([]st
 		input.Cluster = &clusterName
 	}
 
-	output, err := ecsClient.ListServices(ctx, input)
-	if err != nil {
-		return nil, err
-	}
-
+	// 1ページ最大10件のため、最後のページまで取得する
 	var services []string
-	for _, arn := range output.ServiceArns {
-		services = append(services, arn)
+	paginator := ecs.NewListServicesPaginator(ecsClient, input)
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		services = append(services, output.ServiceArns...)
 	}
 
 	return services, nil
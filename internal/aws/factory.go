@@ -0,0 +1,27 @@
+package aws
+
+import (
+	"context"
+)
+
+// ClientFactory はリージョン・プロファイルごとにECSクライアントを生成するインターフェース
+type ClientFactory interface {
+	NewClient(ctx context.Context, region, profile string, opts ...Option) (ECSClient, error)
+}
+
+// DefaultClientFactory は実際のAWSクライアントを生成するClientFactoryの実装
+type DefaultClientFactory struct{}
+
+// NewClientFactory は新しいDefaultClientFactoryを作成
+func NewClientFactory() *DefaultClientFactory {
+	return &DefaultClientFactory{}
+}
+
+// NewClient は指定されたリージョンとプロファイルと設定で、一時的な障害を再試行するAWSクライアントを作成
+func (f *DefaultClientFactory) NewClient(ctx context.Context, region, profile string, opts ...Option) (ECSClient, error) {
+	client, err := NewClient(ctx, region, profile, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return NewRetryingClient(client), nil
+}
@@ -0,0 +1,39 @@
+package aws
+
+import (
+	"net/http"
+	"time"
+
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+)
+
+// HTTPOptions はAWS SDKが使用するHTTPクライアントの調整項目（0の場合はSDKのデフォルト値）
+type HTTPOptions struct {
+	// MaxConns はホストごとに保持するアイドル接続数の上限
+	MaxConns int
+	// Timeout はリクエスト全体のタイムアウト
+	Timeout time.Duration
+}
+
+// IsZero は調整項目が指定されていないかどうかを判定
+func (o HTTPOptions) IsZero() bool {
+	return o.MaxConns == 0 && o.Timeout == 0
+}
+
+// NewHTTPClient は指定された設定を適用したHTTPクライアントを作成
+func NewHTTPClient(options HTTPOptions) *awshttp.BuildableClient {
+	client := awshttp.NewBuildableClient()
+	if options.MaxConns > 0 {
+		client = client.WithTransportOptions(func(transport *http.Transport) {
+			transport.MaxIdleConnsPerHost = options.MaxConns
+			// 全体の上限がホストごとの上限より小さいと接続が再利用されないため合わせて引き上げる
+			if transport.MaxIdleConns < options.MaxConns {
+				transport.MaxIdleConns = options.MaxConns
+			}
+		})
+	}
+	if options.Timeout > 0 {
+		client = client.WithTimeout(options.Timeout)
+	}
+	return client
+}
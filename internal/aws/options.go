@@ -0,0 +1,53 @@
+package aws
+
+import (
+	"github.com/dev-shimada/phantom-ecs/internal/logger"
+)
+
+// Option はクライアントの作成時に適用する設定
+type Option func(*clientOptions)
+
+// clientOptions はクライアントの作成時に適用する設定の集合
+type clientOptions struct {
+	httpOptions          HTTPOptions
+	useFIPSEndpoint      bool
+	useDualStackEndpoint bool
+	debugLogger          logger.Logger
+}
+
+// newClientOptions は指定された設定を順に適用した結果を返す
+func newClientOptions(opts []Option) clientOptions {
+	var options clientOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// WithHTTPOptions はAWS SDKが使用するHTTPクライアントの設定を指定
+func WithHTTPOptions(httpOptions HTTPOptions) Option {
+	return func(o *clientOptions) {
+		o.httpOptions = httpOptions
+	}
+}
+
+// WithFIPSEndpoint はFIPSエンドポイントを使用するかどうかを指定
+func WithFIPSEndpoint(enabled bool) Option {
+	return func(o *clientOptions) {
+		o.useFIPSEndpoint = enabled
+	}
+}
+
+// WithDualStackEndpoint はデュアルスタック（IPv6）エンドポイントを使用するかどうかを指定
+func WithDualStackEndpoint(enabled bool) Option {
+	return func(o *clientOptions) {
+		o.useDualStackEndpoint = enabled
+	}
+}
+
+// WithDebugLogger はリクエスト/レスポンスをデバッグレベルで出力するロガーを指定（nilの場合は出力しない）
+func WithDebugLogger(log logger.Logger) Option {
+	return func(o *clientOptions) {
+		o.debugLogger = log
+	}
+}
@@ -0,0 +1,158 @@
+package aws
+
+import (
+	"context"
+	"time"
+
+	"github.com/avast/retry-go/v4"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/dev-shimada/phantom-ecs/internal/errors"
+	"github.com/dev-shimada/phantom-ecs/internal/scanner"
+)
+
+const (
+	// DefaultRetryAttempts はRetryingClientのデフォルトの試行回数（初回を含む）
+	DefaultRetryAttempts = 3
+	// DefaultRetryDelay はRetryingClientのデフォルトの初回待機時間
+	DefaultRetryDelay = 500 * time.Millisecond
+)
+
+// ECSClient はRetryingClientがラップするECS操作のインターフェース
+type ECSClient interface {
+	scanner.ECSClient
+	UpdateService(ctx context.Context, input *ecs.UpdateServiceInput) (*ecs.UpdateServiceOutput, error)
+	DescribeClusters(ctx context.Context, input *ecs.DescribeClustersInput) (*ecs.DescribeClustersOutput, error)
+}
+
+// RetryingClient は一時的な障害で失敗したECS API呼び出しをバックオフ付きで再試行するデコレーター
+// 再試行するのは参照系のAPIのみで、リソースを作成・変更するAPIは再試行しない
+// （タイムアウト等で失敗したように見えても実際には成功している場合があり、
+// 再試行するとタスク定義のリビジョンが重複して登録されたり、サービスの作成が失敗したりするため）
+type RetryingClient struct {
+	client   ECSClient
+	attempts uint
+	delay    time.Duration
+}
+
+// NewRetryingClient はデフォルト設定でRetryingClientを作成
+func NewRetryingClient(client ECSClient) *RetryingClient {
+	return NewRetryingClientWithOptions(client, DefaultRetryAttempts, DefaultRetryDelay)
+}
+
+// NewRetryingClientWithOptions は試行回数と初回待機時間を指定してRetryingClientを作成
+func NewRetryingClientWithOptions(client ECSClient, attempts uint, delay time.Duration) *RetryingClient {
+	// retry-goでは0回が無制限を意味するため、最低1回に補正
+	if attempts == 0 {
+		attempts = 1
+	}
+	return &RetryingClient{
+		client:   client,
+		attempts: attempts,
+		delay:    delay,
+	}
+}
+
+// ListClusters はListClustersを再試行付きで呼び出す
+func (r *RetryingClient) ListClusters(ctx context.Context, input *ecs.ListClustersInput) (*ecs.ListClustersOutput, error) {
+	return withRetry(ctx, r, func() (*ecs.ListClustersOutput, error) {
+		return r.client.ListClusters(ctx, input)
+	})
+}
+
+// ListServices はListServicesを再試行付きで呼び出す
+func (r *RetryingClient) ListServices(ctx context.Context, input *ecs.ListServicesInput) (*ecs.ListServicesOutput, error) {
+	return withRetry(ctx, r, func() (*ecs.ListServicesOutput, error) {
+		return r.client.ListServices(ctx, input)
+	})
+}
+
+// DescribeServices はDescribeServicesを再試行付きで呼び出す
+func (r *RetryingClient) DescribeServices(ctx context.Context, input *ecs.DescribeServicesInput) (*ecs.DescribeServicesOutput, error) {
+	return withRetry(ctx, r, func() (*ecs.DescribeServicesOutput, error) {
+		return r.client.DescribeServices(ctx, input)
+	})
+}
+
+// DescribeTaskDefinition はDescribeTaskDefinitionを再試行付きで呼び出す
+func (r *RetryingClient) DescribeTaskDefinition(ctx context.Context, input *ecs.DescribeTaskDefinitionInput) (*ecs.DescribeTaskDefinitionOutput, error) {
+	return withRetry(ctx, r, func() (*ecs.DescribeTaskDefinitionOutput, error) {
+		return r.client.DescribeTaskDefinition(ctx, input)
+	})
+}
+
+// CreateService はCreateServiceを再試行せずに呼び出す（べき等でないため）
+func (r *RetryingClient) CreateService(ctx context.Context, input *ecs.CreateServiceInput) (*ecs.CreateServiceOutput, error) {
+	return withoutRetry(func() (*ecs.CreateServiceOutput, error) {
+		return r.client.CreateService(ctx, input)
+	})
+}
+
+// UpdateService はUpdateServiceを再試行せずに呼び出す（べき等でないため）
+func (r *RetryingClient) UpdateService(ctx context.Context, input *ecs.UpdateServiceInput) (*ecs.UpdateServiceOutput, error) {
+	return withoutRetry(func() (*ecs.UpdateServiceOutput, error) {
+		return r.client.UpdateService(ctx, input)
+	})
+}
+
+// DescribeClusters はDescribeClustersを再試行付きで呼び出す
+func (r *RetryingClient) DescribeClusters(ctx context.Context, input *ecs.DescribeClustersInput) (*ecs.DescribeClustersOutput, error) {
+	return withRetry(ctx, r, func() (*ecs.DescribeClustersOutput, error) {
+		return r.client.DescribeClusters(ctx, input)
+	})
+}
+
+// ListContainerInstances はListContainerInstancesを再試行付きで呼び出す
+func (r *RetryingClient) ListContainerInstances(ctx context.Context, input *ecs.ListContainerInstancesInput) (*ecs.ListContainerInstancesOutput, error) {
+	return withRetry(ctx, r, func() (*ecs.ListContainerInstancesOutput, error) {
+		return r.client.ListContainerInstances(ctx, input)
+	})
+}
+
+// DescribeContainerInstances はDescribeContainerInstancesを再試行付きで呼び出す
+func (r *RetryingClient) DescribeContainerInstances(ctx context.Context, input *ecs.DescribeContainerInstancesInput) (*ecs.DescribeContainerInstancesOutput, error) {
+	return withRetry(ctx, r, func() (*ecs.DescribeContainerInstancesOutput, error) {
+		return r.client.DescribeContainerInstances(ctx, input)
+	})
+}
+
+// ListTaskDefinitionFamilies はListTaskDefinitionFamiliesを再試行付きで呼び出す
+func (r *RetryingClient) ListTaskDefinitionFamilies(ctx context.Context, input *ecs.ListTaskDefinitionFamiliesInput) (*ecs.ListTaskDefinitionFamiliesOutput, error) {
+	return withRetry(ctx, r, func() (*ecs.ListTaskDefinitionFamiliesOutput, error) {
+		return r.client.ListTaskDefinitionFamilies(ctx, input)
+	})
+}
+
+// ListTaskDefinitions はListTaskDefinitionsを再試行付きで呼び出す
+func (r *RetryingClient) ListTaskDefinitions(ctx context.Context, input *ecs.ListTaskDefinitionsInput) (*ecs.ListTaskDefinitionsOutput, error) {
+	return withRetry(ctx, r, func() (*ecs.ListTaskDefinitionsOutput, error) {
+		return r.client.ListTaskDefinitions(ctx, input)
+	})
+}
+
+// RegisterTaskDefinition はRegisterTaskDefinitionを再試行せずに呼び出す（べき等でないため）
+func (r *RetryingClient) RegisterTaskDefinition(ctx context.Context, input *ecs.RegisterTaskDefinitionInput) (*ecs.RegisterTaskDefinitionOutput, error) {
+	return withoutRetry(func() (*ecs.RegisterTaskDefinitionOutput, error) {
+		return r.client.RegisterTaskDefinition(ctx, input)
+	})
+}
+
+// withRetry は一時的なエラーの場合のみ指数バックオフで再試行する
+// 権限不足のエラーは不足しているIAM権限を示すエラーに変換する
+func withRetry[T any](ctx context.Context, r *RetryingClient, call func() (T, error)) (T, error) {
+	result, err := retry.DoWithData(
+		call,
+		retry.Context(ctx),
+		retry.Attempts(r.attempts),
+		retry.Delay(r.delay),
+		retry.DelayType(retry.BackOffDelay),
+		retry.RetryIf(errors.IsTransient),
+		retry.LastErrorOnly(true),
+	)
+	return result, errors.WrapAccessDenied(err)
+}
+
+// withoutRetry は再試行せずに呼び出し、権限不足のエラーのみ不足しているIAM権限を示すエラーに変換する
+func withoutRetry[T any](call func() (T, error)) (T, error) {
+	result, err := call()
+	return result, errors.WrapAccessDenied(err)
+}
@@ -0,0 +1,235 @@
+package aws_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/smithy-go"
+	"github.com/dev-shimada/phantom-ecs/internal/aws"
+	phantomerrors "github.com/dev-shimada/phantom-ecs/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// FlakyECSClient は指定回数だけエラーを返した後に成功するテスト用ECSクライアント
+type FlakyECSClient struct {
+	failures int
+	err      error
+	calls    int
+}
+
+func (f *FlakyECSClient) fail() error {
+	f.calls++
+	if f.calls <= f.failures {
+		return f.err
+	}
+	return nil
+}
+
+func (f *FlakyECSClient) ListClusters(ctx context.Context, input *ecs.ListClustersInput) (*ecs.ListClustersOutput, error) {
+	if err := f.fail(); err != nil {
+		return nil, err
+	}
+	return &ecs.ListClustersOutput{ClusterArns: []string{"arn:aws:ecs:us-east-1:123456789012:cluster/prod"}}, nil
+}
+
+func (f *FlakyECSClient) ListServices(ctx context.Context, input *ecs.ListServicesInput) (*ecs.ListServicesOutput, error) {
+	if err := f.fail(); err != nil {
+		return nil, err
+	}
+	return &ecs.ListServicesOutput{}, nil
+}
+
+func (f *FlakyECSClient) DescribeServices(ctx context.Context, input *ecs.DescribeServicesInput) (*ecs.DescribeServicesOutput, error) {
+	if err := f.fail(); err != nil {
+		return nil, err
+	}
+	return &ecs.DescribeServicesOutput{}, nil
+}
+
+func (f *FlakyECSClient) DescribeTaskDefinition(ctx context.Context, input *ecs.DescribeTaskDefinitionInput) (*ecs.DescribeTaskDefinitionOutput, error) {
+	if err := f.fail(); err != nil {
+		return nil, err
+	}
+	return &ecs.DescribeTaskDefinitionOutput{}, nil
+}
+
+func (f *FlakyECSClient) CreateService(ctx context.Context, input *ecs.CreateServiceInput) (*ecs.CreateServiceOutput, error) {
+	if err := f.fail(); err != nil {
+		return nil, err
+	}
+	return &ecs.CreateServiceOutput{}, nil
+}
+
+func (f *FlakyECSClient) UpdateService(ctx context.Context, input *ecs.UpdateServiceInput) (*ecs.UpdateServiceOutput, error) {
+	if err := f.fail(); err != nil {
+		return nil, err
+	}
+	return &ecs.UpdateServiceOutput{}, nil
+}
+
+func (f *FlakyECSClient) DescribeClusters(ctx context.Context, input *ecs.DescribeClustersInput) (*ecs.DescribeClustersOutput, error) {
+	if err := f.fail(); err != nil {
+		return nil, err
+	}
+	return &ecs.DescribeClustersOutput{}, nil
+}
+
+func (f *FlakyECSClient) RegisterTaskDefinition(ctx context.Context, input *ecs.RegisterTaskDefinitionInput) (*ecs.RegisterTaskDefinitionOutput, error) {
+	if err := f.fail(); err != nil {
+		return nil, err
+	}
+	return &ecs.RegisterTaskDefinitionOutput{}, nil
+}
+
+func (f *FlakyECSClient) ListContainerInstances(ctx context.Context, input *ecs.ListContainerInstancesInput) (*ecs.ListContainerInstancesOutput, error) {
+	if err := f.fail(); err != nil {
+		return nil, err
+	}
+	return &ecs.ListContainerInstancesOutput{}, nil
+}
+
+func (f *FlakyECSClient) DescribeContainerInstances(ctx context.Context, input *ecs.DescribeContainerInstancesInput) (*ecs.DescribeContainerInstancesOutput, error) {
+	if err := f.fail(); err != nil {
+		return nil, err
+	}
+	return &ecs.DescribeContainerInstancesOutput{}, nil
+}
+
+func (f *FlakyECSClient) ListTaskDefinitionFamilies(ctx context.Context, input *ecs.ListTaskDefinitionFamiliesInput) (*ecs.ListTaskDefinitionFamiliesOutput, error) {
+	if err := f.fail(); err != nil {
+		return nil, err
+	}
+	return &ecs.ListTaskDefinitionFamiliesOutput{}, nil
+}
+
+func (f *FlakyECSClient) ListTaskDefinitions(ctx context.Context, input *ecs.ListTaskDefinitionsInput) (*ecs.ListTaskDefinitionsOutput, error) {
+	if err := f.fail(); err != nil {
+		return nil, err
+	}
+	return &ecs.ListTaskDefinitionsOutput{}, nil
+}
+
+func TestRetryingClient_RetriesTransientErrors(t *testing.T) {
+	throttled := &smithy.GenericAPIError{Code: "ThrottlingException", Message: "Rate exceeded"}
+	flaky := &FlakyECSClient{failures: 2, err: throttled}
+	client := aws.NewRetryingClientWithOptions(flaky, 3, time.Millisecond)
+
+	output, err := client.ListClusters(context.Background(), &ecs.ListClustersInput{})
+
+	require.NoError(t, err)
+	assert.Len(t, output.ClusterArns, 1)
+	assert.Equal(t, 3, flaky.calls)
+}
+
+func TestRetryingClient_GivesUpAfterAttempts(t *testing.T) {
+	throttled := &smithy.GenericAPIError{Code: "ThrottlingException", Message: "Rate exceeded"}
+	flaky := &FlakyECSClient{failures: 5, err: throttled}
+	client := aws.NewRetryingClientWithOptions(flaky, 3, time.Millisecond)
+
+	_, err := client.DescribeServices(context.Background(), &ecs.DescribeServicesInput{})
+
+	assert.Error(t, err)
+	assert.True(t, errors.As(err, new(smithy.APIError)))
+	assert.Equal(t, 3, flaky.calls)
+}
+
+func TestRetryingClient_DoesNotRetryMutations(t *testing.T) {
+	// 実際には成功していても応答がタイムアウトする場合があるため、作成・変更系のAPIは一時的なエラーでも再試行しない
+	timeout := &smithy.GenericAPIError{Code: "RequestTimeout", Message: "timed out"}
+	ctx := context.Background()
+
+	calls := map[string]func(client *aws.RetryingClient) error{
+		"CreateService": func(client *aws.RetryingClient) error {
+			_, err := client.CreateService(ctx, &ecs.CreateServiceInput{})
+			return err
+		},
+		"UpdateService": func(client *aws.RetryingClient) error {
+			_, err := client.UpdateService(ctx, &ecs.UpdateServiceInput{})
+			return err
+		},
+		"RegisterTaskDefinition": func(client *aws.RetryingClient) error {
+			_, err := client.RegisterTaskDefinition(ctx, &ecs.RegisterTaskDefinitionInput{})
+			return err
+		},
+	}
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			flaky := &FlakyECSClient{failures: 1, err: timeout}
+
+			err := call(aws.NewRetryingClientWithOptions(flaky, 3, time.Millisecond))
+
+			require.Error(t, err)
+			assert.Equal(t, 1, flaky.calls)
+		})
+	}
+
+	t.Run("権限不足は必要なIAM権限を示すエラーに変換", func(t *testing.T) {
+		denied := &smithy.GenericAPIError{Code: "AccessDeniedException", Message: "User is not authorized to perform: ecs:CreateService"}
+		flaky := &FlakyECSClient{failures: 1, err: denied}
+
+		err := calls["CreateService"](aws.NewRetryingClientWithOptions(flaky, 3, time.Millisecond))
+
+		assert.Contains(t, err.Error(), "ecs:CreateService")
+	})
+}
+
+func TestRetryingClient_CancelledContext(t *testing.T) {
+	flaky := &FlakyECSClient{}
+	client := aws.NewRetryingClientWithOptions(flaky, 3, time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.ListServices(ctx, &ecs.ListServicesInput{})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 0, flaky.calls)
+}
+
+func TestRetryingClient_StopsWaitingWhenContextExpires(t *testing.T) {
+	throttled := &smithy.GenericAPIError{Code: "ThrottlingException", Message: "Rate exceeded"}
+	flaky := &FlakyECSClient{failures: 5, err: throttled}
+	client := aws.NewRetryingClientWithOptions(flaky, 3, time.Minute)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.ListClusters(ctx, &ecs.ListClustersInput{})
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, 1, flaky.calls)
+}
+
+func TestRetryingClient_DoesNotRetryNonTransientErrors(t *testing.T) {
+	denied := &smithy.GenericAPIError{Code: "AccessDeniedException", Message: "not authorized"}
+	flaky := &FlakyECSClient{failures: 2, err: denied}
+	client := aws.NewRetryingClientWithOptions(flaky, 3, time.Millisecond)
+
+	_, err := client.DescribeTaskDefinition(context.Background(), &ecs.DescribeTaskDefinitionInput{})
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, flaky.calls)
+}
+
+func TestRetryingClient_ReportsMissingPermission(t *testing.T) {
+	denied := &smithy.OperationError{
+		ServiceID:     "ECS",
+		OperationName: "ListServices",
+		Err:           &smithy.GenericAPIError{Code: "AccessDeniedException", Message: "not authorized"},
+	}
+	flaky := &FlakyECSClient{failures: 1, err: denied}
+	client := aws.NewRetryingClientWithOptions(flaky, 3, time.Millisecond)
+
+	_, err := client.ListServices(context.Background(), &ecs.ListServicesInput{})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ecs:ListServices")
+	assert.Equal(t, phantomerrors.ErrTypeAWS, phantomerrors.ClassifyAWSError(err))
+	assert.Equal(t, 1, flaky.calls)
+}
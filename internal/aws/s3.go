@@ -0,0 +1,57 @@
+package aws
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/dev-shimada/phantom-ecs/internal/errors"
+)
+
+// S3Uploader はS3へのオブジェクトアップロード操作のインターフェース
+type S3Uploader interface {
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+}
+
+// NewS3Client はECSクライアントと同じリージョン・プロファイルの設定でS3クライアントを作成
+func NewS3Client(ctx context.Context, region, profile string, opts ...Option) (*s3.Client, error) {
+	cfg, err := loadConfig(ctx, region, profile, opts)
+	if err != nil {
+		return nil, err
+	}
+	return s3.NewFromConfig(cfg), nil
+}
+
+// ParseS3URI は s3://bucket/key 形式のURIをバケット名とキーに分解
+func ParseS3URI(uri string) (bucket, key string, err error) {
+	path, ok := strings.CutPrefix(uri, "s3://")
+	if !ok {
+		return "", "", fmt.Errorf("invalid S3 URI %q: must start with s3://", uri)
+	}
+	bucket, key, _ = strings.Cut(path, "/")
+	if bucket == "" || key == "" || strings.HasSuffix(key, "/") {
+		return "", "", fmt.Errorf("invalid S3 URI %q: expected s3://bucket/key", uri)
+	}
+	return bucket, key, nil
+}
+
+// UploadToS3 は内容をS3 URIで指定したオブジェクトとしてアップロード
+func UploadToS3(ctx context.Context, uploader S3Uploader, uri string, content []byte, contentType string) error {
+	bucket, key, err := ParseS3URI(uri)
+	if err != nil {
+		return err
+	}
+
+	_, err = uploader.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      &bucket,
+		Key:         &key,
+		Body:        bytes.NewReader(content),
+		ContentType: &contentType,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload to %s: %w", uri, errors.WrapAccessDenied(err))
+	}
+	return nil
+}
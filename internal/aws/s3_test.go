@@ -0,0 +1,89 @@
+package aws_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/dev-shimada/phantom-ecs/internal/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// FakeS3Uploader はアップロードされたオブジェクトを記録するテスト用S3Uploader
+type FakeS3Uploader struct {
+	bucket      string
+	key         string
+	contentType string
+	body        []byte
+	err         error
+}
+
+func (f *FakeS3Uploader) PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.bucket = *params.Bucket
+	f.key = *params.Key
+	f.contentType = *params.ContentType
+	body, err := io.ReadAll(params.Body)
+	if err != nil {
+		return nil, err
+	}
+	f.body = body
+	return &s3.PutObjectOutput{}, nil
+}
+
+func TestUploadToS3(t *testing.T) {
+	uploader := &FakeS3Uploader{}
+
+	err := aws.UploadToS3(context.Background(), uploader, "s3://ci-reports/phantom-ecs/scan.json", []byte(`[{"service_name":"web"}]`), "application/json")
+
+	require.NoError(t, err)
+	assert.Equal(t, "ci-reports", uploader.bucket)
+	assert.Equal(t, "phantom-ecs/scan.json", uploader.key)
+	assert.Equal(t, "application/json", uploader.contentType)
+	assert.Equal(t, `[{"service_name":"web"}]`, string(uploader.body))
+}
+
+func TestUploadToS3_Error(t *testing.T) {
+	uploader := &FakeS3Uploader{err: errors.New("AccessDenied")}
+
+	err := aws.UploadToS3(context.Background(), uploader, "s3://ci-reports/scan.json", []byte("{}"), "application/json")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "s3://ci-reports/scan.json")
+	assert.Contains(t, err.Error(), "AccessDenied")
+}
+
+func TestParseS3URI(t *testing.T) {
+	tests := []struct {
+		uri            string
+		expectedBucket string
+		expectedKey    string
+		expectError    bool
+	}{
+		{uri: "s3://bucket/key.json", expectedBucket: "bucket", expectedKey: "key.json"},
+		{uri: "s3://bucket/path/to/report.yaml", expectedBucket: "bucket", expectedKey: "path/to/report.yaml"},
+		{uri: "https://bucket/key.json", expectError: true},
+		{uri: "s3://bucket", expectError: true},
+		{uri: "s3://bucket/", expectError: true},
+		{uri: "s3://bucket/reports/", expectError: true},
+		{uri: "s3:///key.json", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.uri, func(t *testing.T) {
+			bucket, key, err := aws.ParseS3URI(tt.uri)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedBucket, bucket)
+			assert.Equal(t, tt.expectedKey, key)
+		})
+	}
+}
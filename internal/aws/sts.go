@@ -0,0 +1,43 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/dev-shimada/phantom-ecs/internal/errors"
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+)
+
+// STSClient はSTSの呼び出し元ID取得操作のインターフェース
+type STSClient interface {
+	GetCallerIdentity(ctx context.Context, params *sts.GetCallerIdentityInput, optFns ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error)
+}
+
+// NewSTSClient はECSクライアントと同じリージョン・プロファイルの設定でSTSクライアントを作成
+func NewSTSClient(ctx context.Context, region, profile string, opts ...Option) (*sts.Client, error) {
+	cfg, err := loadConfig(ctx, region, profile, opts)
+	if err != nil {
+		return nil, err
+	}
+	return sts.NewFromConfig(cfg), nil
+}
+
+// GetCallerIdentity は認証情報の呼び出し元IDを取得し、使用するリージョンとあわせて返す
+func GetCallerIdentity(ctx context.Context, client STSClient, region string) (*models.CallerIdentity, error) {
+	output, err := client.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return nil, errors.WrapAccessDenied(err)
+	}
+
+	identity := &models.CallerIdentity{Region: region}
+	if output.Account != nil {
+		identity.Account = *output.Account
+	}
+	if output.Arn != nil {
+		identity.ARN = *output.Arn
+	}
+	if output.UserId != nil {
+		identity.UserID = *output.UserId
+	}
+	return identity, nil
+}
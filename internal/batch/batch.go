@@ -2,11 +2,15 @@ package batch
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"sync"
 	"time"
 
 	"github.com/avast/retry-go/v4"
+	"github.com/dev-shimada/phantom-ecs/internal/utils"
 	"github.com/schollz/progressbar/v3"
 )
 
@@ -18,8 +22,12 @@ type Config struct {
 	RetryAttempts int
 	// RetryDelay はリトライ間隔
 	RetryDelay time.Duration
-	// ShowProgress はプログレスバーの表示フラグ
+	// ShowProgress はプログレスバーの表示フラグ（出力先が端末でない場合は無視される）
 	ShowProgress bool
+	// ForceProgress は出力先が端末でなくてもプログレスバーを表示するフラグ
+	ForceProgress bool
+	// Sequential は指定された順序どおりに1件ずつ処理するフラグ（MaxConcurrencyは無視される）
+	Sequential bool
 }
 
 // Processor はバッチ処理で実行される処理のインターフェース
@@ -33,20 +41,37 @@ type ProcessResult struct {
 	Success     bool
 	Error       error
 	Duration    time.Duration
+	RetryCount  int
 }
 
 // BatchProcessor はバッチ処理を管理する
 type BatchProcessor struct {
 	config    *Config
 	processor Processor
+	output    io.Writer
 }
 
 // NewBatchProcessor は新しいバッチプロセッサを作成する
 func NewBatchProcessor(config *Config, processor Processor) *BatchProcessor {
+	return NewBatchProcessorWithOutput(config, processor, os.Stdout)
+}
+
+// NewBatchProcessorWithOutput はプログレスバーの出力先を指定してバッチプロセッサを作成する
+func NewBatchProcessorWithOutput(config *Config, processor Processor, output io.Writer) *BatchProcessor {
 	return &BatchProcessor{
 		config:    config,
 		processor: processor,
+		output:    output,
+	}
+}
+
+// shouldShowProgress はプログレスバーを表示するかどうかを判定する
+// CIなど出力先が端末でない環境ではログが崩れるため、ForceProgressが指定されない限り表示しない
+func (bp *BatchProcessor) shouldShowProgress() bool {
+	if bp.config.ForceProgress {
+		return true
 	}
+	return bp.config.ShowProgress && utils.IsTerminal(bp.output)
 }
 
 // ProcessServices は複数のサービスを並列処理する
@@ -55,8 +80,9 @@ func (bp *BatchProcessor) ProcessServices(ctx context.Context, services []string
 
 	// プログレスバーの設定
 	var bar *progressbar.ProgressBar
-	if bp.config.ShowProgress {
+	if bp.shouldShowProgress() {
 		bar = progressbar.NewOptions(len(services),
+			progressbar.OptionSetWriter(bp.output),
 			progressbar.OptionSetDescription("Processing services..."),
 			progressbar.OptionSetWidth(15),
 			progressbar.OptionShowCount(),
@@ -71,6 +97,20 @@ func (bp *BatchProcessor) ProcessServices(ctx context.Context, services []string
 		)
 	}
 
+	// 順次処理モードの場合は指定された順序どおりに処理
+	if bp.config.Sequential {
+		for i, service := range services {
+			results[i] = bp.processServiceWithRetry(ctx, service)
+			if bar != nil {
+				bar.Add(1)
+			}
+		}
+		if bar != nil {
+			bar.Finish()
+		}
+		return results, nil
+	}
+
 	// セマフォで同時実行数を制限
 	semaphore := make(chan struct{}, bp.config.MaxConcurrency)
 	var wg sync.WaitGroup
@@ -106,8 +146,10 @@ func (bp *BatchProcessor) ProcessServices(ctx context.Context, services []string
 // processServiceWithRetry はリトライ機能付きでサービスを処理する
 func (bp *BatchProcessor) processServiceWithRetry(ctx context.Context, serviceName string) *ProcessResult {
 	start := time.Now()
+	maxAttempts := uint(bp.config.RetryAttempts + 1) // 初回 + リトライ回数
 
 	var lastErr error
+	var retryCount int
 	err := retry.Do(
 		func() error {
 			err := bp.processor.Process(ctx, serviceName)
@@ -117,11 +159,14 @@ func (bp *BatchProcessor) processServiceWithRetry(ctx context.Context, serviceNa
 			}
 			return nil
 		},
-		retry.Attempts(uint(bp.config.RetryAttempts+1)), // 初回 + リトライ回数
+		retry.Attempts(maxAttempts),
 		retry.Delay(bp.config.RetryDelay),
 		retry.Context(ctx),
 		retry.OnRetry(func(n uint, err error) {
-			// リトライ時のログ（必要に応じて）
+			// 最終試行の失敗時にも呼ばれるため、次の試行がある場合のみカウント
+			if n+1 < maxAttempts {
+				retryCount++
+			}
 		}),
 	)
 
@@ -133,6 +178,7 @@ func (bp *BatchProcessor) processServiceWithRetry(ctx context.Context, serviceNa
 			Success:     false,
 			Error:       lastErr,
 			Duration:    duration,
+			RetryCount:  retryCount,
 		}
 	}
 
@@ -141,6 +187,7 @@ func (bp *BatchProcessor) processServiceWithRetry(ctx context.Context, serviceNa
 		Success:     true,
 		Error:       nil,
 		Duration:    duration,
+		RetryCount:  retryCount,
 	}
 }
 
@@ -169,6 +216,7 @@ type Statistics struct {
 	FailedCount     int
 	TotalDuration   time.Duration
 	AverageDuration time.Duration
+	TotalRetries    int
 	FailedServices  []string
 }
 
@@ -182,6 +230,7 @@ func CalculateStatistics(results []*ProcessResult) *Statistics {
 	var totalDuration time.Duration
 	for _, result := range results {
 		totalDuration += result.Duration
+		stats.TotalRetries += result.RetryCount
 
 		if result.Success {
 			stats.SuccessfulCount++
@@ -199,6 +248,27 @@ func CalculateStatistics(results []*ProcessResult) *Statistics {
 	return stats
 }
 
+// MarshalJSON は統計情報をJSONに変換する（処理時間はログと同じ文字列形式で出力）
+func (s *Statistics) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		TotalServices   int      `json:"total_services"`
+		SuccessfulCount int      `json:"successful_count"`
+		FailedCount     int      `json:"failed_count"`
+		TotalDuration   string   `json:"total_duration"`
+		AverageDuration string   `json:"average_duration"`
+		TotalRetries    int      `json:"total_retries"`
+		FailedServices  []string `json:"failed_services"`
+	}{
+		TotalServices:   s.TotalServices,
+		SuccessfulCount: s.SuccessfulCount,
+		FailedCount:     s.FailedCount,
+		TotalDuration:   s.TotalDuration.String(),
+		AverageDuration: s.AverageDuration.String(),
+		TotalRetries:    s.TotalRetries,
+		FailedServices:  s.FailedServices,
+	})
+}
+
 // PrintStatistics は統計情報を表示する
 func (s *Statistics) PrintStatistics() {
 	fmt.Printf("\n=== バッチ処理統計 ===\n")
@@ -207,6 +277,7 @@ func (s *Statistics) PrintStatistics() {
 	fmt.Printf("失敗: %d\n", s.FailedCount)
 	fmt.Printf("総処理時間: %v\n", s.TotalDuration)
 	fmt.Printf("平均処理時間: %v\n", s.AverageDuration)
+	fmt.Printf("総リトライ回数: %d\n", s.TotalRetries)
 
 	if len(s.FailedServices) > 0 {
 		fmt.Printf("\n失敗したサービス:\n")
@@ -1,11 +1,13 @@
 package batch
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"testing"
 	"time"
 
+	"github.com/dev-shimada/phantom-ecs/internal/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -187,6 +189,38 @@ func TestProcessServices_ContextCancellation(t *testing.T) {
 	processor.AssertExpectations(t)
 }
 
+func TestProcessServices_RetryCount(t *testing.T) {
+	config := &Config{
+		MaxConcurrency: 1,
+		RetryAttempts:  3,
+		RetryDelay:     time.Millisecond * 10,
+	}
+
+	processor := &MockProcessor{}
+	services := []string{"service1", "service2"}
+
+	// service1は3回目で成功、service2は全て失敗
+	processor.On("Process", mock.Anything, "service1").Return(errors.New("一時的な失敗")).Times(2)
+	processor.On("Process", mock.Anything, "service1").Return(nil).Once()
+	processor.On("Process", mock.Anything, "service2").Return(errors.New("恒久的な失敗")).Times(4)
+
+	batchProcessor := NewBatchProcessor(config, processor)
+	ctx := context.Background()
+
+	results, err := batchProcessor.ProcessServices(ctx, services)
+
+	require.NoError(t, err)
+	assert.True(t, results[0].Success)
+	assert.Equal(t, 2, results[0].RetryCount)
+	assert.False(t, results[1].Success)
+	assert.Equal(t, 3, results[1].RetryCount)
+
+	stats := CalculateStatistics(results)
+	assert.Equal(t, 5, stats.TotalRetries)
+
+	processor.AssertExpectations(t)
+}
+
 func TestGetDefaultConfig(t *testing.T) {
 	config := GetDefaultConfig()
 
@@ -223,3 +257,118 @@ func TestProcessResult(t *testing.T) {
 	assert.Error(t, failureResult.Error)
 	assert.Equal(t, time.Millisecond*500, failureResult.Duration)
 }
+
+func TestProcessServices_ProgressBarSuppressedWhenNotTerminal(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       *Config
+		expectOutput bool
+	}{
+		{
+			name:         "端末でない場合はShowProgressが有効でも表示しない",
+			config:       &Config{MaxConcurrency: 1, ShowProgress: true},
+			expectOutput: false,
+		},
+		{
+			name:         "ForceProgress指定時は端末でなくても表示する",
+			config:       &Config{MaxConcurrency: 1, ShowProgress: true, ForceProgress: true},
+			expectOutput: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor := &MockProcessor{}
+			processor.On("Process", mock.Anything, "service1").Return(nil)
+
+			var buf bytes.Buffer
+			batchProcessor := NewBatchProcessorWithOutput(tt.config, processor, &buf)
+
+			_, err := batchProcessor.ProcessServices(context.Background(), []string{"service1"})
+
+			require.NoError(t, err)
+			if tt.expectOutput {
+				assert.Contains(t, buf.String(), "Processing services...")
+			} else {
+				assert.Empty(t, buf.String())
+			}
+		})
+	}
+}
+
+func TestOrderByTag(t *testing.T) {
+	described := []models.ECSService{
+		{ServiceName: "frontend", Tags: map[string]string{"deploy-order": "2"}},
+		{ServiceName: "database", Tags: map[string]string{"deploy-order": "0"}},
+		{ServiceName: "api", Tags: map[string]string{"deploy-order": "1"}},
+		{ServiceName: "worker"},
+	}
+
+	ordered, err := OrderByTag([]string{"worker", "frontend", "api", "database"}, described, "deploy-order")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"database", "api", "frontend", "worker"}, ordered)
+}
+
+func TestOrderByTag_Errors(t *testing.T) {
+	described := []models.ECSService{
+		{ServiceName: "api", Tags: map[string]string{"deploy-order": "first"}},
+	}
+
+	_, err := OrderByTag([]string{"api"}, described, "deploy-order")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not an integer")
+
+	_, err = OrderByTag([]string{"missing"}, described, "deploy-order")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "service not found")
+}
+
+func TestProcessServices_SequentialFollowsTagOrder(t *testing.T) {
+	described := []models.ECSService{
+		{ServiceName: "frontend", Tags: map[string]string{"deploy-order": "2"}},
+		{ServiceName: "database", Tags: map[string]string{"deploy-order": "0"}},
+		{ServiceName: "api", Tags: map[string]string{"deploy-order": "1"}},
+	}
+	ordered, err := OrderByTag([]string{"frontend", "api", "database"}, described, "deploy-order")
+	require.NoError(t, err)
+
+	var processed []string
+	processor := ProcessorFunc(func(ctx context.Context, service string) error {
+		processed = append(processed, service)
+		return nil
+	})
+
+	config := &Config{MaxConcurrency: 3, Sequential: true}
+	batchProcessor := NewBatchProcessor(config, processor)
+
+	results, err := batchProcessor.ProcessServices(context.Background(), ordered)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"database", "api", "frontend"}, processed)
+	for i, result := range results {
+		assert.Equal(t, ordered[i], result.ServiceName)
+		assert.True(t, result.Success)
+	}
+}
+
+func TestFormatPrometheus(t *testing.T) {
+	stats := &Statistics{
+		TotalServices:   3,
+		SuccessfulCount: 2,
+		FailedCount:     1,
+		AverageDuration: 250 * time.Millisecond,
+		TotalRetries:    4,
+		FailedServices:  []string{"service3"},
+	}
+
+	output := FormatPrometheus(stats, 1500*time.Millisecond)
+
+	assert.Contains(t, output, "# TYPE phantom_ecs_batch_success_total counter\nphantom_ecs_batch_success_total 2\n")
+	assert.Contains(t, output, "phantom_ecs_batch_services_total 3\n")
+	assert.Contains(t, output, "phantom_ecs_batch_failure_total 1\n")
+	assert.Contains(t, output, "phantom_ecs_batch_retries_total 4\n")
+	assert.Contains(t, output, "# TYPE phantom_ecs_batch_duration_seconds gauge\nphantom_ecs_batch_duration_seconds 1.5\n")
+	assert.Contains(t, output, "phantom_ecs_batch_service_duration_average_seconds 0.25\n")
+	assert.Contains(t, output, "# HELP phantom_ecs_batch_success_total ")
+}
@@ -0,0 +1,41 @@
+package batch
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// metricsPrefix はPrometheusメトリクス名の接頭辞
+const metricsPrefix = "phantom_ecs_batch_"
+
+// prometheusMetric はエクスポジション形式で出力する単一のメトリクス
+type prometheusMetric struct {
+	name       string
+	help       string
+	metricType string
+	value      float64
+}
+
+// FormatPrometheus は統計情報をPrometheusのテキストエクスポジション形式に変換する
+// durationはバッチ処理全体の経過時間（各サービスの処理時間の合計ではない）
+func FormatPrometheus(stats *Statistics, duration time.Duration) string {
+	metrics := []prometheusMetric{
+		{"services_total", "Number of services processed by the batch.", "gauge", float64(stats.TotalServices)},
+		{"success_total", "Number of services processed successfully.", "counter", float64(stats.SuccessfulCount)},
+		{"failure_total", "Number of services that failed after all retries.", "counter", float64(stats.FailedCount)},
+		{"retries_total", "Number of retries across all services.", "counter", float64(stats.TotalRetries)},
+		{"duration_seconds", "Wall-clock duration of the batch in seconds.", "gauge", duration.Seconds()},
+		{"service_duration_average_seconds", "Average processing duration per service in seconds.", "gauge", stats.AverageDuration.Seconds()},
+	}
+
+	var output strings.Builder
+	for _, metric := range metrics {
+		name := metricsPrefix + metric.name
+		fmt.Fprintf(&output, "# HELP %s %s\n", name, metric.help)
+		fmt.Fprintf(&output, "# TYPE %s %s\n", name, metric.metricType)
+		fmt.Fprintf(&output, "%s %s\n", name, strconv.FormatFloat(metric.value, 'g', -1, 64))
+	}
+	return output.String()
+}
@@ -0,0 +1,56 @@
+package batch
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+)
+
+// OrderByTag は処理対象のサービスを指定したタグの整数値の昇順に並び替える
+// タグを持たないサービスはタグ付きのサービスの後に、指定された順序のまま並ぶ
+func OrderByTag(services []string, described []models.ECSService, tagKey string) ([]string, error) {
+	tagsByService := make(map[string]map[string]string, len(described))
+	for _, service := range described {
+		tagsByService[service.ServiceName] = service.Tags
+	}
+
+	type orderedService struct {
+		name   string
+		order  int
+		tagged bool
+	}
+
+	ordered := make([]orderedService, 0, len(services))
+	for _, name := range services {
+		tags, found := tagsByService[name]
+		if !found {
+			return nil, fmt.Errorf("service not found: %s", name)
+		}
+
+		entry := orderedService{name: name}
+		if value, ok := tags[tagKey]; ok {
+			order, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s tag value for service %s: %q is not an integer", tagKey, name, value)
+			}
+			entry.order = order
+			entry.tagged = true
+		}
+		ordered = append(ordered, entry)
+	}
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].tagged != ordered[j].tagged {
+			return ordered[i].tagged
+		}
+		return ordered[i].order < ordered[j].order
+	})
+
+	result := make([]string, len(ordered))
+	for i, entry := range ordered {
+		result[i] = entry.name
+	}
+	return result, nil
+}
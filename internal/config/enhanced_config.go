@@ -1,19 +1,25 @@
 package config
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
 	"time"
 
+	"github.com/dev-shimada/phantom-ecs/internal/utils"
 	"gopkg.in/yaml.v3"
 )
 
 // EnhancedConfig は拡張された設定構造体
 type EnhancedConfig struct {
-	Config  `yaml:",inline"`
-	Logging LoggingConfig `yaml:"logging"`
-	Batch   BatchConfig   `yaml:"batch"`
+	Config         `yaml:",inline"`
+	DefaultCluster string            `yaml:"default_cluster"`
+	Columns        []string          `yaml:"columns,omitempty"`
+	Logging        LoggingConfig     `yaml:"logging"`
+	Batch          BatchConfig       `yaml:"batch"`
+	Concurrency    ConcurrencyConfig `yaml:"concurrency"`
 }
 
 // LoggingConfig はロギング設定
@@ -24,6 +30,13 @@ type LoggingConfig struct {
 	MaxSize    int    `yaml:"max_size"`
 	MaxAge     int    `yaml:"max_age"`
 	MaxBackups int    `yaml:"max_backups"`
+	// Compress はローテーションしたファイルを圧縮するかどうか（未指定時は圧縮する）
+	Compress *bool `yaml:"compress,omitempty"`
+}
+
+// CompressEnabled はローテーションしたログファイルを圧縮するかどうかを返す
+func (l LoggingConfig) CompressEnabled() bool {
+	return l.Compress == nil || *l.Compress
 }
 
 // BatchConfig はバッチ処理設定
@@ -34,30 +47,80 @@ type BatchConfig struct {
 	ShowProgress   bool          `yaml:"show_progress"`
 }
 
+// ConcurrencyConfig は処理ごとの同時実行数の設定
+// BatchとInspectの未指定時は従来のbatch.max_concurrencyを使用する
+type ConcurrencyConfig struct {
+	// Scan はscanで同時にスキャンするクラスター数
+	Scan int `yaml:"scan"`
+	// Inspect はinspect-allで同時に調査するサービス数
+	Inspect int `yaml:"inspect"`
+	// Batch はbatchで同時に処理するサービス数
+	Batch int `yaml:"batch"`
+}
+
+// DefaultScanConcurrency はscanで同時にスキャンするクラスター数のデフォルト値
+const DefaultScanConcurrency = 4
+
+// UnmarshalYAML はretry_delayをフラグや環境変数と同じ形式 (例: 500ms, 2s) で解析する
+// 単位のない数値はナノ秒として解釈されてしまうためエラーとする
+func (b *BatchConfig) UnmarshalYAML(value *yaml.Node) error {
+	for i := 0; i+1 < len(value.Content); i += 2 {
+		key, val := value.Content[i], value.Content[i+1]
+		if key.Value == "retry_delay" && val.Tag == "!!int" {
+			return fmt.Errorf("retry_delay には単位付きの値を指定してください (例: 500ms, 2s): %s", val.Value)
+		}
+	}
+
+	type plain BatchConfig
+	return value.Decode((*plain)(b))
+}
+
 // ProfileConfig はプロファイル別設定
 type ProfileConfig struct {
-	Region       string `yaml:"region"`
-	OutputFormat string `yaml:"output_format"`
-	AWSProfile   string `yaml:"aws_profile"`
+	Region         string `yaml:"region"`
+	OutputFormat   string `yaml:"output_format"`
+	AWSProfile     string `yaml:"aws_profile"`
+	DefaultCluster string `yaml:"default_cluster,omitempty"`
+	// Columnsはサービス一覧のテーブルに表示する列（--fields未指定時に使用）
+	Columns []string `yaml:"columns,omitempty"`
+	// Logging、Batch、Concurrencyはトップレベルの設定のうち指定したキーのみを上書きする
+	Logging     yaml.Node `yaml:"logging,omitempty"`
+	Batch       yaml.Node `yaml:"batch,omitempty"`
+	Concurrency yaml.Node `yaml:"concurrency,omitempty"`
 }
 
 // FileConfig はYAMLファイルの構造
 type FileConfig struct {
-	Profiles map[string]ProfileConfig `yaml:"profiles"`
-	Logging  LoggingConfig            `yaml:"logging"`
-	Batch    BatchConfig              `yaml:"batch"`
+	Profiles    map[string]ProfileConfig `yaml:"profiles"`
+	Logging     LoggingConfig            `yaml:"logging"`
+	Batch       BatchConfig              `yaml:"batch"`
+	Concurrency ConcurrencyConfig        `yaml:"concurrency"`
 }
 
 // LoadFromFile はYAMLファイルから設定を読み込む
 func LoadFromFile(filename, profileName string) (*EnhancedConfig, error) {
-	data, err := os.ReadFile(filename)
-	if err != nil {
-		return nil, fmt.Errorf("設定ファイルの読み込みに失敗しました: %w", err)
-	}
+	return LoadFromFiles([]string{filename}, profileName)
+}
 
-	var fileConfig FileConfig
-	if err := yaml.Unmarshal(data, &fileConfig); err != nil {
-		return nil, fmt.Errorf("YAML解析に失敗しました: %w", err)
+// LoadFromFileWithTimeout はファイルの読み込みにタイムアウトを設けて設定を読み込む
+func LoadFromFileWithTimeout(filename, profileName string, timeout time.Duration) (*EnhancedConfig, error) {
+	return LoadFromFilesWithTimeout([]string{filename}, profileName, timeout)
+}
+
+// LoadFromFiles は複数のYAMLファイルを指定順に深くマージしてから設定を読み込む
+// 後のファイルの値が優先され、マッピングはキーごとに再帰的にマージ、スカラーとシーケンスは置き換える
+// 読み込みのタイムアウトはPHANTOM_ECS_CONFIG_READ_TIMEOUTで指定できる（未指定時はタイムアウトなし）
+func LoadFromFiles(filenames []string, profileName string) (*EnhancedConfig, error) {
+	return LoadFromFilesWithTimeout(filenames, profileName, configReadTimeout())
+}
+
+// LoadFromFilesWithTimeout はファイルごとの読み込みにタイムアウトを設けて、複数のYAMLファイルから設定を読み込む
+// NFSなどのネットワークファイルシステムで読み込みが応答しない場合に、処理を止めずにエラーを返すために使用する
+// timeoutが0以下の場合はタイムアウトなしで読み込む
+func LoadFromFilesWithTimeout(filenames []string, profileName string, timeout time.Duration) (*EnhancedConfig, error) {
+	fileConfig, err := loadMergedFileConfig(filenames, timeout)
+	if err != nil {
+		return nil, err
 	}
 
 	profile, exists := fileConfig.Profiles[profileName]
@@ -71,8 +134,32 @@ func LoadFromFile(filename, profileName string) (*EnhancedConfig, error) {
 			Profile:      profile.AWSProfile,
 			OutputFormat: profile.OutputFormat,
 		},
-		Logging: fileConfig.Logging,
-		Batch:   fileConfig.Batch,
+		DefaultCluster: profile.DefaultCluster,
+		Columns:        profile.Columns,
+		Logging:        fileConfig.Logging,
+		Batch:          fileConfig.Batch,
+		Concurrency:    fileConfig.Concurrency,
+	}
+
+	if err := utils.ValidateServiceColumns(profile.Columns); err != nil {
+		return nil, fmt.Errorf("プロファイル '%s' のcolumns設定が不正です: %w", profileName, err)
+	}
+
+	// プロファイル別のlogging/batch/concurrencyでトップレベルの設定を上書き
+	if !profile.Logging.IsZero() {
+		if err := profile.Logging.Decode(&config.Logging); err != nil {
+			return nil, fmt.Errorf("プロファイル '%s' のlogging設定の解析に失敗しました: %w", profileName, err)
+		}
+	}
+	if !profile.Batch.IsZero() {
+		if err := profile.Batch.Decode(&config.Batch); err != nil {
+			return nil, fmt.Errorf("プロファイル '%s' のbatch設定の解析に失敗しました: %w", profileName, err)
+		}
+	}
+	if !profile.Concurrency.IsZero() {
+		if err := profile.Concurrency.Decode(&config.Concurrency); err != nil {
+			return nil, fmt.Errorf("プロファイル '%s' のconcurrency設定の解析に失敗しました: %w", profileName, err)
+		}
 	}
 
 	// デフォルト値の設定
@@ -81,6 +168,125 @@ func LoadFromFile(filename, profileName string) (*EnhancedConfig, error) {
 	return config, nil
 }
 
+// LoadProfile は複数のYAMLファイルを深くマージし、指定したプロファイルの設定をデフォルト値で補完せずに返す
+func LoadProfile(filenames []string, profileName string) (*ProfileConfig, error) {
+	fileConfig, err := loadMergedFileConfig(filenames, configReadTimeout())
+	if err != nil {
+		return nil, err
+	}
+
+	profile, exists := fileConfig.Profiles[profileName]
+	if !exists {
+		return nil, fmt.Errorf("プロファイル '%s' が見つかりません", profileName)
+	}
+	return &profile, nil
+}
+
+// loadMergedFileConfig は複数のYAMLファイルを深くマージしてFileConfigに変換する
+func loadMergedFileConfig(filenames []string, timeout time.Duration) (*FileConfig, error) {
+	var merged *yaml.Node
+	for _, filename := range filenames {
+		data, err := readConfigFile(filename, timeout)
+		if err != nil {
+			return nil, fmt.Errorf("設定ファイルの読み込みに失敗しました: %w", err)
+		}
+
+		var document yaml.Node
+		if err := yaml.Unmarshal(data, &document); err != nil {
+			return nil, fmt.Errorf("YAML解析に失敗しました: %w", err)
+		}
+		// 空のファイルはマージ対象にしない
+		if len(document.Content) == 0 {
+			continue
+		}
+		root := document.Content[0]
+		if root.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("YAML解析に失敗しました: %s のトップレベルはマッピングである必要があります", filename)
+		}
+		merged = mergeYAMLNodes(merged, root)
+	}
+
+	var fileConfig FileConfig
+	if merged != nil {
+		if err := merged.Decode(&fileConfig); err != nil {
+			return nil, fmt.Errorf("YAML解析に失敗しました: %w", err)
+		}
+	}
+	return &fileConfig, nil
+}
+
+// openConfigFile は設定ファイルを開く（テストで差し替え可能）
+var openConfigFile = func(filename string) (io.ReadCloser, error) {
+	return os.Open(filename)
+}
+
+// configReadTimeout は環境変数PHANTOM_ECS_CONFIG_READ_TIMEOUTで指定された読み込みのタイムアウトを返す
+func configReadTimeout() time.Duration {
+	return getEnvDuration("PHANTOM_ECS_CONFIG_READ_TIMEOUT")
+}
+
+// readConfigFile は設定ファイルを読み込み、timeoutを超えた場合はエラーを返す
+// 読み込み自体は中断できないため、タイムアウト後も応答しない読み込みのgoroutineは残る
+func readConfigFile(filename string, timeout time.Duration) ([]byte, error) {
+	if timeout <= 0 {
+		return os.ReadFile(filename)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	type readResult struct {
+		data []byte
+		err  error
+	}
+	// タイムアウト後に結果を送信してもブロックしないようバッファを持たせる
+	results := make(chan readResult, 1)
+	go func() {
+		file, err := openConfigFile(filename)
+		if err != nil {
+			results <- readResult{err: err}
+			return
+		}
+		defer file.Close()
+
+		data, err := io.ReadAll(file)
+		results <- readResult{data: data, err: err}
+	}()
+
+	select {
+	case result := <-results:
+		return result.data, result.err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("%s の読み込みが %s 以内に完了しませんでした: %w", filename, timeout, ctx.Err())
+	}
+}
+
+// mergeYAMLNodes はoverlayをbaseに深くマージしたノードを返す（base、overlayは変更しない）
+// 両方がマッピングの場合のみキーごとに再帰的にマージし、それ以外はoverlayで置き換える
+func mergeYAMLNodes(base, overlay *yaml.Node) *yaml.Node {
+	if base == nil || base.Kind != yaml.MappingNode || overlay.Kind != yaml.MappingNode {
+		return overlay
+	}
+
+	merged := *base
+	merged.Content = append([]*yaml.Node{}, base.Content...)
+	for i := 0; i+1 < len(overlay.Content); i += 2 {
+		key, value := overlay.Content[i], overlay.Content[i+1]
+		found := false
+		for j := 0; j+1 < len(merged.Content); j += 2 {
+			if merged.Content[j].Value == key.Value {
+				merged.Content[j+1] = mergeYAMLNodes(merged.Content[j+1], value)
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged.Content = append(merged.Content, key, value)
+		}
+	}
+	return &merged
+}
+
 // NewEnhancedConfigFromEnvironment は環境変数から拡張設定を作成する
 func NewEnhancedConfigFromEnvironment() *EnhancedConfig {
 	config := &EnhancedConfig{
@@ -96,6 +302,7 @@ func NewEnhancedConfigFromEnvironment() *EnhancedConfig {
 			MaxSize:    getEnvIntOrDefault("PHANTOM_ECS_LOG_MAX_SIZE", 100),
 			MaxAge:     getEnvIntOrDefault("PHANTOM_ECS_LOG_MAX_AGE", 30),
 			MaxBackups: getEnvIntOrDefault("PHANTOM_ECS_LOG_MAX_BACKUPS", 10),
+			Compress:   boolPtr(getEnvBoolOrDefault("PHANTOM_ECS_LOG_COMPRESS", true)),
 		},
 		Batch: BatchConfig{
 			MaxConcurrency: getEnvIntOrDefault("PHANTOM_ECS_BATCH_MAX_CONCURRENCY", 3),
@@ -104,6 +311,11 @@ func NewEnhancedConfigFromEnvironment() *EnhancedConfig {
 			ShowProgress:   getEnvBoolOrDefault("PHANTOM_ECS_BATCH_SHOW_PROGRESS", true),
 		},
 	}
+	config.Concurrency = ConcurrencyConfig{
+		Scan:    getEnvIntOrDefault("PHANTOM_ECS_CONCURRENCY_SCAN", DefaultScanConcurrency),
+		Inspect: getEnvIntOrDefault("PHANTOM_ECS_CONCURRENCY_INSPECT", config.Batch.MaxConcurrency),
+		Batch:   getEnvIntOrDefault("PHANTOM_ECS_CONCURRENCY_BATCH", config.Batch.MaxConcurrency),
+	}
 
 	return config
 }
@@ -123,6 +335,7 @@ func GetDefaultEnhancedConfig() *EnhancedConfig {
 			MaxSize:    100,
 			MaxAge:     30,
 			MaxBackups: 10,
+			Compress:   boolPtr(true),
 		},
 		Batch: BatchConfig{
 			MaxConcurrency: 3,
@@ -130,6 +343,11 @@ func GetDefaultEnhancedConfig() *EnhancedConfig {
 			RetryDelay:     time.Second * 2,
 			ShowProgress:   true,
 		},
+		Concurrency: ConcurrencyConfig{
+			Scan:    DefaultScanConcurrency,
+			Inspect: 3,
+			Batch:   3,
+		},
 	}
 }
 
@@ -156,6 +374,9 @@ func (c *EnhancedConfig) setDefaults() {
 	if c.Logging.MaxBackups == 0 {
 		c.Logging.MaxBackups = 10
 	}
+	if c.Logging.Compress == nil {
+		c.Logging.Compress = boolPtr(true)
+	}
 	if c.Batch.MaxConcurrency == 0 {
 		c.Batch.MaxConcurrency = 3
 	}
@@ -165,6 +386,15 @@ func (c *EnhancedConfig) setDefaults() {
 	if c.Batch.RetryDelay == 0 {
 		c.Batch.RetryDelay = time.Second * 2
 	}
+	if c.Concurrency.Scan == 0 {
+		c.Concurrency.Scan = DefaultScanConcurrency
+	}
+	if c.Concurrency.Inspect == 0 {
+		c.Concurrency.Inspect = c.Batch.MaxConcurrency
+	}
+	if c.Concurrency.Batch == 0 {
+		c.Concurrency.Batch = c.Batch.MaxConcurrency
+	}
 }
 
 // Validate は拡張設定を検証する
@@ -199,10 +429,30 @@ func (c *EnhancedConfig) Validate() error {
 	if c.Batch.RetryAttempts < 0 {
 		return fmt.Errorf("リトライ回数は0以上である必要があります")
 	}
+	if c.Batch.RetryDelay < 0 {
+		return fmt.Errorf("リトライ間隔は0以上である必要があります")
+	}
+
+	// 処理ごとの同時実行数の検証（0は未指定としてデフォルト値を使用）
+	if c.Concurrency.Scan < 0 || c.Concurrency.Inspect < 0 || c.Concurrency.Batch < 0 {
+		return fmt.Errorf("concurrencyの同時実行数は0以上である必要があります (scan: %d, inspect: %d, batch: %d)",
+			c.Concurrency.Scan, c.Concurrency.Inspect, c.Concurrency.Batch)
+	}
 
 	return nil
 }
 
+// Warnings はエラーではないが注意が必要な設定を返す
+func (c *EnhancedConfig) Warnings() []string {
+	var warnings []string
+
+	if c.Batch.RetryAttempts > 0 && c.Batch.RetryDelay == 0 {
+		warnings = append(warnings, "リトライ間隔が0のため、リトライが待機なしで即座に実行されます")
+	}
+
+	return warnings
+}
+
 // MergeWithEnvironment は環境変数で設定を上書きする
 func (c *EnhancedConfig) MergeWithEnvironment() {
 	if region := os.Getenv("PHANTOM_ECS_REGION"); region != "" {
@@ -232,11 +482,18 @@ func (c *EnhancedConfig) MergeWithEnvironment() {
 	if maxBackups := getEnvInt("PHANTOM_ECS_LOG_MAX_BACKUPS"); maxBackups > 0 {
 		c.Logging.MaxBackups = maxBackups
 	}
+	if compress := getEnvBool("PHANTOM_ECS_LOG_COMPRESS"); compress != nil {
+		c.Logging.Compress = compress
+	}
 	if maxConcurrency := getEnvInt("PHANTOM_ECS_BATCH_MAX_CONCURRENCY"); maxConcurrency > 0 {
 		c.Batch.MaxConcurrency = maxConcurrency
+		c.Concurrency.Batch = maxConcurrency
 	}
-	if retryAttempts := getEnvInt("PHANTOM_ECS_BATCH_RETRY_ATTEMPTS"); retryAttempts >= 0 {
-		c.Batch.RetryAttempts = retryAttempts
+	// 0（リトライなし）も指定できるよう、未設定の場合のみ上書きしない
+	if value := os.Getenv("PHANTOM_ECS_BATCH_RETRY_ATTEMPTS"); value != "" {
+		if retryAttempts, err := strconv.Atoi(value); err == nil && retryAttempts >= 0 {
+			c.Batch.RetryAttempts = retryAttempts
+		}
 	}
 	if retryDelay := getEnvDuration("PHANTOM_ECS_BATCH_RETRY_DELAY"); retryDelay > 0 {
 		c.Batch.RetryDelay = retryDelay
@@ -244,6 +501,15 @@ func (c *EnhancedConfig) MergeWithEnvironment() {
 	if showProgress := getEnvBool("PHANTOM_ECS_BATCH_SHOW_PROGRESS"); showProgress != nil {
 		c.Batch.ShowProgress = *showProgress
 	}
+	if scan := getEnvInt("PHANTOM_ECS_CONCURRENCY_SCAN"); scan > 0 {
+		c.Concurrency.Scan = scan
+	}
+	if inspect := getEnvInt("PHANTOM_ECS_CONCURRENCY_INSPECT"); inspect > 0 {
+		c.Concurrency.Inspect = inspect
+	}
+	if batch := getEnvInt("PHANTOM_ECS_CONCURRENCY_BATCH"); batch > 0 {
+		c.Concurrency.Batch = batch
+	}
 }
 
 // SaveToFile は設定をYAMLファイルに保存する
@@ -251,13 +517,15 @@ func (c *EnhancedConfig) SaveToFile(filename string) error {
 	fileConfig := FileConfig{
 		Profiles: map[string]ProfileConfig{
 			"default": {
-				Region:       c.Region,
-				OutputFormat: c.OutputFormat,
-				AWSProfile:   c.Profile,
+				Region:         c.Region,
+				OutputFormat:   c.OutputFormat,
+				AWSProfile:     c.Profile,
+				DefaultCluster: c.DefaultCluster,
 			},
 		},
-		Logging: c.Logging,
-		Batch:   c.Batch,
+		Logging:     c.Logging,
+		Batch:       c.Batch,
+		Concurrency: c.Concurrency,
 	}
 
 	data, err := yaml.Marshal(&fileConfig)
@@ -313,6 +581,10 @@ func getEnvBool(key string) *bool {
 	return nil
 }
 
+func boolPtr(value bool) *bool {
+	return &value
+}
+
 func getEnvDurationOrDefault(key string, defaultValue time.Duration) time.Duration {
 	if value := getEnvDuration(key); value != 0 {
 		return value
@@ -1,9 +1,12 @@
 package config
 
 import (
+	"context"
+	"io"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -251,6 +254,110 @@ func TestMergeWithEnvironment(t *testing.T) {
 	assert.Equal(t, "debug", config.Logging.Level)
 }
 
+func TestLoggingConfig_Compress(t *testing.T) {
+	t.Run("未指定時は圧縮する", func(t *testing.T) {
+		configFile := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(configFile, []byte("profiles:\n  default:\n    region: us-east-1\nlogging:\n  level: info\n"), 0o600))
+
+		config, err := LoadFromFile(configFile, "default")
+		require.NoError(t, err)
+		assert.True(t, config.Logging.CompressEnabled())
+	})
+
+	t.Run("設定ファイルで無効化", func(t *testing.T) {
+		configFile := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(configFile, []byte("profiles:\n  default:\n    region: us-east-1\nlogging:\n  compress: false\n"), 0o600))
+
+		config, err := LoadFromFile(configFile, "default")
+		require.NoError(t, err)
+		assert.False(t, config.Logging.CompressEnabled())
+	})
+
+	t.Run("環境変数で上書き", func(t *testing.T) {
+		t.Setenv("PHANTOM_ECS_LOG_COMPRESS", "false")
+
+		assert.False(t, NewEnhancedConfigFromEnvironment().Logging.CompressEnabled())
+
+		config := GetDefaultEnhancedConfig()
+		config.MergeWithEnvironment()
+		assert.False(t, config.Logging.CompressEnabled())
+	})
+}
+
+// blockingReader はunblockが閉じられるまで読み込みを返さないReader（応答しないNFS上のファイルを想定）
+type blockingReader struct {
+	unblock chan struct{}
+}
+
+func (r *blockingReader) Read(p []byte) (int, error) {
+	<-r.unblock
+	return 0, io.EOF
+}
+
+func (r *blockingReader) Close() error {
+	return nil
+}
+
+func TestLoadFromFileWithTimeout(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte("profiles:\n  default:\n    region: ap-northeast-1\n"), 0o600))
+
+	t.Run("時間内に読み込めれば設定を返す", func(t *testing.T) {
+		config, err := LoadFromFileWithTimeout(configFile, "default", time.Second)
+		require.NoError(t, err)
+		assert.Equal(t, "ap-northeast-1", config.Region)
+	})
+
+	t.Run("読み込みが応答しない場合はタイムアウトでエラー", func(t *testing.T) {
+		reader := &blockingReader{unblock: make(chan struct{})}
+		defer close(reader.unblock)
+
+		original := openConfigFile
+		openConfigFile = func(filename string) (io.ReadCloser, error) {
+			return reader, nil
+		}
+		defer func() { openConfigFile = original }()
+
+		start := time.Now()
+		_, err := LoadFromFileWithTimeout(configFile, "default", 50*time.Millisecond)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Contains(t, err.Error(), "設定ファイルの読み込みに失敗しました")
+		assert.Less(t, time.Since(start), 5*time.Second)
+	})
+
+	t.Run("環境変数でLoadFromFileにタイムアウトを指定", func(t *testing.T) {
+		reader := &blockingReader{unblock: make(chan struct{})}
+		defer close(reader.unblock)
+
+		original := openConfigFile
+		openConfigFile = func(filename string) (io.ReadCloser, error) {
+			return reader, nil
+		}
+		defer func() { openConfigFile = original }()
+		t.Setenv("PHANTOM_ECS_CONFIG_READ_TIMEOUT", "50ms")
+
+		_, err := LoadFromFile(configFile, "default")
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestMergeWithEnvironment_RetryAttempts(t *testing.T) {
+	t.Run("未設定の場合は上書きしない", func(t *testing.T) {
+		config := GetDefaultEnhancedConfig()
+		config.MergeWithEnvironment()
+		assert.Equal(t, 3, config.Batch.RetryAttempts)
+	})
+
+	t.Run("0を指定するとリトライしない", func(t *testing.T) {
+		t.Setenv("PHANTOM_ECS_BATCH_RETRY_ATTEMPTS", "0")
+
+		config := GetDefaultEnhancedConfig()
+		config.MergeWithEnvironment()
+		assert.Equal(t, 0, config.Batch.RetryAttempts)
+	})
+}
+
 func TestSaveToFile(t *testing.T) {
 	tempDir := t.TempDir()
 	configFile := filepath.Join(tempDir, "test-config.yaml")
@@ -272,3 +379,401 @@ func TestSaveToFile(t *testing.T) {
 	assert.Equal(t, "ap-northeast-1", loadedConfig.Region)
 	assert.Equal(t, "yaml", loadedConfig.OutputFormat)
 }
+
+func TestEnhancedConfig_RetryDelayValidation(t *testing.T) {
+	tests := []struct {
+		name          string
+		retryAttempts int
+		retryDelay    time.Duration
+		expectError   bool
+		expectWarning bool
+	}{
+		{
+			name:          "正のリトライ間隔",
+			retryAttempts: 3,
+			retryDelay:    500 * time.Millisecond,
+		},
+		{
+			name:          "負のリトライ間隔",
+			retryAttempts: 3,
+			retryDelay:    -time.Second,
+			expectError:   true,
+		},
+		{
+			name:          "リトライありで間隔0は警告",
+			retryAttempts: 3,
+			retryDelay:    0,
+			expectWarning: true,
+		},
+		{
+			name:          "リトライなしで間隔0は警告なし",
+			retryAttempts: 0,
+			retryDelay:    0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := GetDefaultEnhancedConfig()
+			config.Batch.RetryAttempts = tt.retryAttempts
+			config.Batch.RetryDelay = tt.retryDelay
+
+			err := config.Validate()
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "リトライ間隔は0以上である必要があります")
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if tt.expectWarning {
+				assert.NotEmpty(t, config.Warnings())
+			} else {
+				assert.Empty(t, config.Warnings())
+			}
+		})
+	}
+}
+
+func TestLoadFromYAMLFile_RetryDelayFormats(t *testing.T) {
+	tests := []struct {
+		name        string
+		retryDelay  string
+		expected    time.Duration
+		expectError bool
+	}{
+		{name: "ミリ秒", retryDelay: "500ms", expected: 500 * time.Millisecond},
+		{name: "秒", retryDelay: "2s", expected: 2 * time.Second},
+		{name: "単位なしの数値", retryDelay: "2", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configFile := filepath.Join(t.TempDir(), "phantom-ecs.yaml")
+			yamlContent := "profiles:\n  default:\n    region: us-east-1\nbatch:\n  retry_delay: " + tt.retryDelay + "\n"
+			require.NoError(t, os.WriteFile(configFile, []byte(yamlContent), 0644))
+
+			config, err := LoadFromFile(configFile, "default")
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "retry_delay")
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, config.Batch.RetryDelay)
+		})
+	}
+}
+
+func TestLoadFromYAMLFile_DefaultCluster(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "phantom-ecs.yaml")
+	yamlContent := `
+profiles:
+  default:
+    region: us-east-1
+  production:
+    region: ap-northeast-1
+    default_cluster: prod-cluster
+`
+	require.NoError(t, os.WriteFile(configFile, []byte(yamlContent), 0644))
+
+	config, err := LoadFromFile(configFile, "production")
+	require.NoError(t, err)
+	assert.Equal(t, "prod-cluster", config.DefaultCluster)
+
+	config, err = LoadFromFile(configFile, "default")
+	require.NoError(t, err)
+	assert.Empty(t, config.DefaultCluster)
+}
+
+func TestLoadFromYAMLFile_ProfileOverrides(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "phantom-ecs.yaml")
+	yamlContent := `
+profiles:
+  default:
+    region: us-east-1
+  production:
+    region: ap-northeast-1
+    logging:
+      level: warn
+    batch:
+      max_concurrency: 10
+      show_progress: false
+
+logging:
+  level: info
+  format: json
+
+batch:
+  max_concurrency: 3
+  retry_delay: 5s
+  show_progress: true
+`
+	require.NoError(t, os.WriteFile(configFile, []byte(yamlContent), 0644))
+
+	config, err := LoadFromFile(configFile, "production")
+	require.NoError(t, err)
+	assert.Equal(t, "warn", config.Logging.Level)
+	assert.Equal(t, 10, config.Batch.MaxConcurrency)
+	assert.False(t, config.Batch.ShowProgress)
+	// 上書きしていないキーはトップレベルの設定を引き継ぐ
+	assert.Equal(t, "json", config.Logging.Format)
+	assert.Equal(t, 5*time.Second, config.Batch.RetryDelay)
+
+	config, err = LoadFromFile(configFile, "default")
+	require.NoError(t, err)
+	assert.Equal(t, "info", config.Logging.Level)
+	assert.Equal(t, 3, config.Batch.MaxConcurrency)
+	assert.True(t, config.Batch.ShowProgress)
+}
+
+func TestLoadFromYAMLFile_ProfileOverrideInvalidRetryDelay(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "phantom-ecs.yaml")
+	yamlContent := `
+profiles:
+  production:
+    batch:
+      retry_delay: 500
+`
+	require.NoError(t, os.WriteFile(configFile, []byte(yamlContent), 0644))
+
+	_, err := LoadFromFile(configFile, "production")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "production")
+}
+
+func TestLoadFromFiles_OverlayMerge(t *testing.T) {
+	tempDir := t.TempDir()
+	baseFile := filepath.Join(tempDir, "base.yaml")
+	overlayFile := filepath.Join(tempDir, "overlay.yaml")
+
+	baseContent := `
+profiles:
+  production:
+    region: ap-northeast-1
+    output_format: json
+    aws_profile: prod
+    default_cluster: prod-cluster
+    batch:
+      show_progress: false
+
+logging:
+  level: warn
+  format: json
+
+batch:
+  max_concurrency: 7
+  retry_delay: 5s
+  show_progress: true
+`
+	overlayContent := `
+profiles:
+  production:
+    region: us-west-2
+`
+	require.NoError(t, os.WriteFile(baseFile, []byte(baseContent), 0644))
+	require.NoError(t, os.WriteFile(overlayFile, []byte(overlayContent), 0644))
+
+	config, err := LoadFromFiles([]string{baseFile, overlayFile}, "production")
+	require.NoError(t, err)
+
+	// オーバーレイで指定したキーのみ上書き
+	assert.Equal(t, "us-west-2", config.Region)
+	// それ以外はベースの設定を引き継ぐ
+	assert.Equal(t, "json", config.OutputFormat)
+	assert.Equal(t, "prod", config.Profile)
+	assert.Equal(t, "prod-cluster", config.DefaultCluster)
+	assert.Equal(t, "warn", config.Logging.Level)
+	assert.Equal(t, "json", config.Logging.Format)
+	assert.Equal(t, 7, config.Batch.MaxConcurrency)
+	assert.Equal(t, 5*time.Second, config.Batch.RetryDelay)
+	assert.False(t, config.Batch.ShowProgress)
+
+	// 順序を入れ替えるとベースが優先される
+	config, err = LoadFromFiles([]string{overlayFile, baseFile}, "production")
+	require.NoError(t, err)
+	assert.Equal(t, "ap-northeast-1", config.Region)
+}
+
+func TestLoadFromFiles_OverlayAddsProfileAndReplacesValues(t *testing.T) {
+	tempDir := t.TempDir()
+	baseFile := filepath.Join(tempDir, "base.yaml")
+	overlayFile := filepath.Join(tempDir, "overlay.yaml")
+
+	require.NoError(t, os.WriteFile(baseFile, []byte(`
+profiles:
+  default:
+    region: us-east-1
+batch:
+  max_concurrency: 3
+`), 0644))
+	require.NoError(t, os.WriteFile(overlayFile, []byte(`
+profiles:
+  staging:
+    region: eu-west-1
+batch:
+  max_concurrency: 9
+`), 0644))
+
+	config, err := LoadFromFiles([]string{baseFile, overlayFile}, "default")
+	require.NoError(t, err)
+	assert.Equal(t, "us-east-1", config.Region)
+	assert.Equal(t, 9, config.Batch.MaxConcurrency)
+
+	config, err = LoadFromFiles([]string{baseFile, overlayFile}, "staging")
+	require.NoError(t, err)
+	assert.Equal(t, "eu-west-1", config.Region)
+}
+
+func TestLoadFromFiles_Errors(t *testing.T) {
+	tempDir := t.TempDir()
+	baseFile := filepath.Join(tempDir, "base.yaml")
+	require.NoError(t, os.WriteFile(baseFile, []byte("profiles:\n  default:\n    region: us-east-1\n"), 0644))
+
+	t.Run("存在しないファイル", func(t *testing.T) {
+		_, err := LoadFromFiles([]string{baseFile, filepath.Join(tempDir, "missing.yaml")}, "default")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "設定ファイルの読み込みに失敗しました")
+	})
+
+	t.Run("オーバーレイの単位のないretry_delay", func(t *testing.T) {
+		overlayFile := filepath.Join(tempDir, "invalid-delay.yaml")
+		require.NoError(t, os.WriteFile(overlayFile, []byte("batch:\n  retry_delay: 500\n"), 0644))
+
+		_, err := LoadFromFiles([]string{baseFile, overlayFile}, "default")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "retry_delay")
+	})
+
+	t.Run("トップレベルがマッピングでない", func(t *testing.T) {
+		overlayFile := filepath.Join(tempDir, "list.yaml")
+		require.NoError(t, os.WriteFile(overlayFile, []byte("- region\n"), 0644))
+
+		_, err := LoadFromFiles([]string{baseFile, overlayFile}, "default")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "トップレベルはマッピングである必要があります")
+	})
+}
+
+func TestLoadFromFile_Columns(t *testing.T) {
+	tempDir := t.TempDir()
+
+	t.Run("プロファイルのcolumnsを読み込む", func(t *testing.T) {
+		configFile := filepath.Join(tempDir, "columns.yaml")
+		require.NoError(t, os.WriteFile(configFile, []byte(`
+profiles:
+  default:
+    columns: [service_name, cluster_name, running_count]
+  production:
+    region: us-west-2
+`), 0644))
+
+		config, err := LoadFromFile(configFile, "default")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"service_name", "cluster_name", "running_count"}, config.Columns)
+
+		config, err = LoadFromFile(configFile, "production")
+		require.NoError(t, err)
+		assert.Empty(t, config.Columns)
+	})
+
+	t.Run("未知の列名はエラー", func(t *testing.T) {
+		configFile := filepath.Join(tempDir, "invalid-columns.yaml")
+		require.NoError(t, os.WriteFile(configFile, []byte("profiles:\n  default:\n    columns: [service_name, owner]\n"), 0644))
+
+		_, err := LoadFromFile(configFile, "default")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "columns設定が不正です")
+		assert.Contains(t, err.Error(), "unsupported column: owner")
+	})
+}
+
+func TestLoadFromFile_Concurrency(t *testing.T) {
+	tempDir := t.TempDir()
+
+	t.Run("処理ごとのキーを個別に読み込む", func(t *testing.T) {
+		configFile := filepath.Join(tempDir, "concurrency.yaml")
+		require.NoError(t, os.WriteFile(configFile, []byte(`
+profiles:
+  default:
+    region: us-east-1
+  production:
+    concurrency:
+      inspect: 8
+batch:
+  max_concurrency: 2
+concurrency:
+  scan: 6
+  inspect: 5
+  batch: 4
+`), 0644))
+
+		config, err := LoadFromFile(configFile, "default")
+		require.NoError(t, err)
+		assert.Equal(t, ConcurrencyConfig{Scan: 6, Inspect: 5, Batch: 4}, config.Concurrency)
+
+		// プロファイルでは指定したキーのみ上書きする
+		config, err = LoadFromFile(configFile, "production")
+		require.NoError(t, err)
+		assert.Equal(t, ConcurrencyConfig{Scan: 6, Inspect: 8, Batch: 4}, config.Concurrency)
+	})
+
+	t.Run("未指定時のデフォルト値", func(t *testing.T) {
+		configFile := filepath.Join(tempDir, "legacy.yaml")
+		require.NoError(t, os.WriteFile(configFile, []byte("profiles:\n  default:\n    region: us-east-1\nbatch:\n  max_concurrency: 7\n"), 0644))
+
+		config, err := LoadFromFile(configFile, "default")
+		require.NoError(t, err)
+		// inspectとbatchは従来のbatch.max_concurrencyを引き継ぐ
+		assert.Equal(t, ConcurrencyConfig{Scan: DefaultScanConcurrency, Inspect: 7, Batch: 7}, config.Concurrency)
+	})
+
+	t.Run("環境変数での上書き", func(t *testing.T) {
+		t.Setenv("PHANTOM_ECS_CONCURRENCY_SCAN", "9")
+		t.Setenv("PHANTOM_ECS_CONCURRENCY_BATCH", "1")
+
+		config := GetDefaultEnhancedConfig()
+		config.MergeWithEnvironment()
+		assert.Equal(t, ConcurrencyConfig{Scan: 9, Inspect: 3, Batch: 1}, config.Concurrency)
+	})
+
+	t.Run("負の値はエラー", func(t *testing.T) {
+		config := GetDefaultEnhancedConfig()
+		config.Concurrency.Inspect = -1
+		err := config.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "inspect: -1")
+	})
+}
+
+func TestLoadProfile(t *testing.T) {
+	tempDir := t.TempDir()
+	configFile := filepath.Join(tempDir, "profiles.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(`
+profiles:
+  default:
+    region: us-east-1
+  staging:
+    region: ap-northeast-1
+    output_format: json
+    aws_profile: stg-admin
+  minimal:
+    default_cluster: minimal-cluster
+`), 0644))
+
+	profile, err := LoadProfile([]string{configFile}, "staging")
+	require.NoError(t, err)
+	assert.Equal(t, "ap-northeast-1", profile.Region)
+	assert.Equal(t, "json", profile.OutputFormat)
+	assert.Equal(t, "stg-admin", profile.AWSProfile)
+
+	// 未指定の項目はデフォルト値で補完しない
+	profile, err = LoadProfile([]string{configFile}, "minimal")
+	require.NoError(t, err)
+	assert.Empty(t, profile.Region)
+	assert.Empty(t, profile.OutputFormat)
+
+	_, err = LoadProfile([]string{configFile}, "missing")
+	assert.Error(t, err)
+}
@@ -0,0 +1,88 @@
+package deployer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/dev-shimada/phantom-ecs/internal/errors"
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+)
+
+// ClusterDescriber はデプロイ先クラスターのキャパシティを取得できるクライアントのインターフェース
+// クライアントが実装している場合のみ、デプロイ前にクラスターとタスク定義の互換性を確認する
+type ClusterDescriber interface {
+	DescribeClusters(ctx context.Context, input *ecs.DescribeClustersInput) (*ecs.DescribeClustersOutput, error)
+}
+
+// fargateCapacityProviders はFargateでタスクを実行するキャパシティプロバイダー
+var fargateCapacityProviders = map[string]bool{
+	"FARGATE":      true,
+	"FARGATE_SPOT": true,
+}
+
+// CheckClusterCompatibility はデプロイ先クラスターがタスクを起動できるキャパシティを持つかを確認する
+// 起動タイプが指定されていればその起動タイプ、未指定ならタスク定義のrequiresCompatibilitiesのいずれかをクラスターが
+// サポートしている必要がある（コンテナインスタンスのないクラスターではEC2のタスクを起動できない等）
+// FARGATE起動タイプはキャパシティプロバイダーの設定に関わらずどのクラスターでも起動できる
+func CheckClusterCompatibility(ctx context.Context, client ClusterDescriber, taskDefinition models.ECSTaskDefinition, launchType, targetCluster string) error {
+	output, err := client.DescribeClusters(ctx, &ecs.DescribeClustersInput{
+		Clusters: []string{targetCluster},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to describe target cluster %s: %w", targetCluster, err)
+	}
+	if len(output.Clusters) == 0 || output.Clusters[0].ClusterName == nil {
+		return errors.NewValidationError(fmt.Sprintf("target cluster not found: %s", targetCluster), nil)
+	}
+	cluster := output.Clusters[0]
+
+	supported := clusterLaunchTypes(cluster.CapacityProviders, cluster.RegisteredContainerInstancesCount)
+
+	required := taskDefinition.RequiresAttributes
+	if launchType != "" {
+		required = []string{launchType}
+	}
+	// 起動タイプもrequiresCompatibilitiesも未指定の場合は判定できないため互換とみなす
+	if len(required) == 0 {
+		return nil
+	}
+	for _, compatibility := range required {
+		if supported[models.LaunchType(strings.ToUpper(compatibility))] {
+			return nil
+		}
+	}
+
+	return errors.NewValidationError(fmt.Sprintf("target cluster %s cannot run task definition %s: requires %s, but the cluster only has capacity for %s",
+		targetCluster, taskDefinition.Family, strings.Join(required, " or "), formatLaunchTypes(supported)), nil)
+}
+
+// clusterLaunchTypes はクラスターのキャパシティプロバイダーと登録済みのコンテナインスタンスから起動できる起動タイプを返す
+// FARGATE起動タイプはクラスターのキャパシティプロバイダーに関わらず起動できるため常に許可する
+// FARGATE・FARGATE_SPOT以外のキャパシティプロバイダー（Auto Scalingグループ）はEC2として扱う
+func clusterLaunchTypes(capacityProviders []string, registeredContainerInstances int32) map[models.LaunchType]bool {
+	supported := map[models.LaunchType]bool{models.LaunchTypeFargate: true}
+	for _, provider := range capacityProviders {
+		if !fargateCapacityProviders[provider] {
+			supported[models.LaunchTypeEC2] = true
+		}
+	}
+	// 登録済みのコンテナインスタンスはEC2とECS Anywhere（EXTERNAL）を区別できないため両方を許可する
+	if registeredContainerInstances > 0 {
+		supported[models.LaunchTypeEC2] = true
+		supported[models.LaunchTypeExternal] = true
+	}
+	return supported
+}
+
+// formatLaunchTypes はクラスターがサポートする起動タイプをエラーメッセージ用に整形する
+func formatLaunchTypes(supported map[models.LaunchType]bool) string {
+	var launchTypes []string
+	for _, launchType := range []models.LaunchType{models.LaunchTypeEC2, models.LaunchTypeFargate} {
+		if supported[launchType] {
+			launchTypes = append(launchTypes, string(launchType))
+		}
+	}
+	return strings.Join(launchTypes, ", ")
+}
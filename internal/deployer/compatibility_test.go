@@ -0,0 +1,197 @@
+package deployer_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/aws/smithy-go"
+	"github.com/dev-shimada/phantom-ecs/internal/deployer"
+	phantomerrors "github.com/dev-shimada/phantom-ecs/internal/errors"
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// ClusterAwareMockECSClient はDescribeClustersも実装するECSクライアントのモック
+type ClusterAwareMockECSClient struct {
+	MockECSClient
+}
+
+func (m *ClusterAwareMockECSClient) DescribeClusters(ctx context.Context, input *ecs.DescribeClustersInput) (*ecs.DescribeClustersOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*ecs.DescribeClustersOutput), args.Error(1)
+}
+
+// describeClustersOutput は指定したキャパシティのクラスターを返すDescribeClustersの結果を作成
+func describeClustersOutput(name string, capacityProviders []string, registeredInstances int32) *ecs.DescribeClustersOutput {
+	return &ecs.DescribeClustersOutput{
+		Clusters: []types.Cluster{{
+			ClusterName:                       &name,
+			CapacityProviders:                 capacityProviders,
+			RegisteredContainerInstancesCount: registeredInstances,
+		}},
+	}
+}
+
+func TestCheckClusterCompatibility(t *testing.T) {
+	tests := []struct {
+		name                string
+		requires            []string
+		launchType          string
+		capacityProviders   []string
+		registeredInstances int32
+		expectedErr         string
+	}{
+		{
+			name:                "FargateのタスクはEC2のキャパシティのみのクラスターでも起動できる",
+			requires:            []string{"FARGATE"},
+			launchType:          "FARGATE",
+			capacityProviders:   []string{"ec2-asg-provider"},
+			registeredInstances: 3,
+		},
+		{
+			name:              "FargateのタスクをFARGATE_SPOTのクラスターへ",
+			requires:          []string{"FARGATE"},
+			launchType:        "FARGATE",
+			capacityProviders: []string{"FARGATE_SPOT"},
+		},
+		{
+			name:       "キャパシティプロバイダー未設定のクラスターでもFargateは起動できる",
+			requires:   []string{"FARGATE"},
+			launchType: "FARGATE",
+		},
+		{
+			name:        "EC2のタスクをコンテナインスタンスのないFargateクラスターへ",
+			requires:    []string{"EC2"},
+			launchType:  "EC2",
+			expectedErr: "requires EC2, but the cluster only has capacity for FARGATE",
+		},
+		{
+			name:                "EC2のタスクをコンテナインスタンスのあるクラスターへ",
+			requires:            []string{"EC2"},
+			launchType:          "EC2",
+			registeredInstances: 2,
+		},
+		{
+			name:              "起動タイプ未指定ならrequiresCompatibilitiesのいずれかを起動できればよい",
+			requires:          []string{"EC2", "FARGATE"},
+			capacityProviders: []string{"FARGATE"},
+		},
+		{
+			name:              "起動タイプ未指定でrequiresCompatibilitiesのいずれも起動できない",
+			requires:          []string{"EC2", "EXTERNAL"},
+			capacityProviders: []string{"FARGATE"},
+			expectedErr:       "requires EC2 or EXTERNAL",
+		},
+		{
+			name:              "起動タイプもrequiresCompatibilitiesも未指定なら判定しない",
+			capacityProviders: []string{"FARGATE"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockClient := new(ClusterAwareMockECSClient)
+			mockClient.On("DescribeClusters", ctx, &ecs.DescribeClustersInput{Clusters: []string{"ec2-cluster"}}).
+				Return(describeClustersOutput("ec2-cluster", tt.capacityProviders, tt.registeredInstances), nil)
+
+			err := deployer.CheckClusterCompatibility(ctx, mockClient, models.ECSTaskDefinition{
+				Family:             "web-task",
+				RequiresAttributes: tt.requires,
+			}, tt.launchType, "ec2-cluster")
+
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedErr)
+			var phantomErr *phantomerrors.PhantomError
+			require.ErrorAs(t, err, &phantomErr)
+			assert.Equal(t, phantomerrors.ErrTypeValidation, phantomErr.Type)
+		})
+	}
+}
+
+func TestCheckClusterCompatibility_ClusterNotFound(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(ClusterAwareMockECSClient)
+	reason := "MISSING"
+	mockClient.On("DescribeClusters", ctx, mock.Anything).Return(&ecs.DescribeClustersOutput{
+		Failures: []types.Failure{{Reason: &reason}},
+	}, nil)
+
+	err := deployer.CheckClusterCompatibility(ctx, mockClient, models.ECSTaskDefinition{Family: "web-task"}, "FARGATE", "missing-cluster")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "target cluster not found: missing-cluster")
+}
+
+func TestDeployer_DeployService_IncompatibleTargetCluster(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(ClusterAwareMockECSClient)
+	mockClient.On("DescribeClusters", ctx, &ecs.DescribeClustersInput{Clusters: []string{"fargate-cluster"}}).
+		Return(describeClustersOutput("fargate-cluster", []string{"FARGATE"}, 0), nil)
+
+	inspectionResult := &models.InspectionResult{
+		Service: models.ECSService{
+			ServiceName: "web-service",
+			ClusterName: "source-cluster",
+			LaunchType:  "EC2",
+			Status:      "ACTIVE",
+		},
+		TaskDefinition: models.ECSTaskDefinition{
+			Family:             "web-task",
+			NetworkMode:        "bridge",
+			Status:             "ACTIVE",
+			RequiresAttributes: []string{"EC2"},
+		},
+	}
+
+	for _, dryRun := range []bool{true, false} {
+		result, err := deployer.NewDeployer(mockClient).DeployService(ctx, inspectionResult, "fargate-cluster", "web-service", dryRun)
+
+		require.Error(t, err)
+		assert.True(t, strings.HasSuffix(err.Error(), "requires EC2, but the cluster only has capacity for FARGATE"))
+		assert.False(t, result.Success)
+	}
+	// 互換性がなければタスク定義の登録もサービスの作成も行わない
+	mockClient.AssertNotCalled(t, "RegisterTaskDefinition", mock.Anything, mock.Anything)
+	mockClient.AssertNotCalled(t, "CreateService", mock.Anything, mock.Anything)
+}
+
+func TestDeployer_DeployService_DescribeClustersAccessDenied(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(ClusterAwareMockECSClient)
+	mockClient.On("DescribeClusters", ctx, mock.Anything).
+		Return((*ecs.DescribeClustersOutput)(nil), &smithy.GenericAPIError{Code: "AccessDeniedException", Message: "not authorized to perform: ecs:DescribeClusters"})
+
+	inspectionResult := &models.InspectionResult{
+		Service: models.ECSService{
+			ServiceName: "web-service",
+			ClusterName: "source-cluster",
+			LaunchType:  "FARGATE",
+			Status:      "ACTIVE",
+		},
+		TaskDefinition: models.ECSTaskDefinition{
+			Family:             "web-task",
+			NetworkMode:        "awsvpc",
+			Status:             "ACTIVE",
+			RequiresAttributes: []string{"FARGATE"},
+		},
+		NetworkConfig: &models.NetworkConfig{Subnets: []string{"subnet-12345"}},
+	}
+
+	// 権限がなくてもdry runは失敗せず、確認をスキップしたことを警告する
+	result, err := deployer.NewDeployer(mockClient).DeployService(ctx, inspectionResult, "target-cluster", "web-service", true)
+
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	require.Len(t, result.Warnings, 1)
+	assert.Contains(t, result.Warnings[0], "skipped target cluster capacity check for target-cluster")
+}
@@ -3,9 +3,13 @@ package deployer
 import (
 	"context"
 	"fmt"
+	"os"
+	"strconv"
 
 	"github.com/aws/aws-sdk-go-v2/service/ecs"
 	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/dev-shimada/phantom-ecs/internal/errors"
+	"github.com/dev-shimada/phantom-ecs/internal/logger"
 	"github.com/dev-shimada/phantom-ecs/internal/models"
 )
 
@@ -17,6 +21,7 @@ type ECSClient interface {
 	DescribeTaskDefinition(ctx context.Context, input *ecs.DescribeTaskDefinitionInput) (*ecs.DescribeTaskDefinitionOutput, error)
 	CreateService(ctx context.Context, input *ecs.CreateServiceInput) (*ecs.CreateServiceOutput, error)
 	RegisterTaskDefinition(ctx context.Context, input *ecs.RegisterTaskDefinitionInput) (*ecs.RegisterTaskDefinitionOutput, error)
+	UpdateService(ctx context.Context, input *ecs.UpdateServiceInput) (*ecs.UpdateServiceOutput, error)
 }
 
 // DeploymentCustomization はmodelsパッケージから取得
@@ -25,19 +30,96 @@ type DeploymentCustomization = models.DeploymentCustomization
 // Deployer はECSサービスのデプロイを行う
 type Deployer struct {
 	client ECSClient
+	logger logger.Logger
 }
 
-// NewDeployer は新しいDeployerインスタンスを作成
+// NewDeployer は新しいDeployerインスタンスを作成（監査ログは出力しない）
 func NewDeployer(client ECSClient) *Deployer {
+	return NewDeployerWithLogger(client, logger.NewNoopLogger())
+}
+
+// NewDeployerWithLogger は監査ログを出力するロガー付きのDeployerインスタンスを作成
+func NewDeployerWithLogger(client ECSClient, log logger.Logger) *Deployer {
+	if log == nil {
+		log = logger.NewNoopLogger()
+	}
 	return &Deployer{
 		client: client,
+		logger: log,
 	}
 }
 
-// DeployService は指定されたサービスをデプロイする
+// DeployService は指定されたサービスをデプロイし、完了時に監査ログを1行出力する
 func (d *Deployer) DeployService(ctx context.Context, inspectionResult *models.InspectionResult, targetCluster, newServiceName string, dryRun bool) (*models.DeploymentResult, error) {
+	result, err := d.deployService(ctx, inspectionResult, nil, targetCluster, newServiceName, dryRun)
+	d.logAudit(inspectionResult, result, dryRun, err)
+	return result, err
+}
+
+// DeployServiceWithTaskDefinition はソースのタスク定義を複製せず、指定されたタスク定義を登録してサービスをデプロイし、完了時に監査ログを1行出力する
+// サービスの設定（希望タスク数、起動タイプ、ネットワーク設定）はインスペクション結果から引き継ぐ
+func (d *Deployer) DeployServiceWithTaskDefinition(ctx context.Context, inspectionResult *models.InspectionResult, taskDefinition *ecs.RegisterTaskDefinitionInput, targetCluster, newServiceName string, dryRun bool) (*models.DeploymentResult, error) {
+	result, err := d.deployService(ctx, inspectionResult, taskDefinition, targetCluster, newServiceName, dryRun)
+	d.logAudit(inspectionResult, result, dryRun, err)
+	return result, err
+}
+
+// logAudit はデプロイ操作の監査ログを構造化して出力する
+func (d *Deployer) logAudit(inspectionResult *models.InspectionResult, result *models.DeploymentResult, dryRun bool, err error) {
+	fields := map[string]interface{}{
+		"audit":               true,
+		"operation":           "deploy",
+		"source_service":      inspectionResult.Service.ServiceName,
+		"source_cluster":      inspectionResult.Service.ClusterName,
+		"service":             result.ServiceName,
+		"cluster":             result.ClusterName,
+		"task_definition_arn": result.TaskDefinitionArn,
+		"dry_run":             dryRun,
+		"success":             result.Success,
+		"actor":               auditActor(),
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+	}
+
+	d.logger.WithFields(fields).Info("デプロイ操作の監査ログ")
+}
+
+// auditActor は監査ログに記録する実行者を環境変数から取得する
+// PHANTOM_ECS_ACTOR が未設定の場合はOSのユーザー名を使用する
+func auditActor() string {
+	for _, key := range []string{"PHANTOM_ECS_ACTOR", "USER", "USERNAME"} {
+		if actor := os.Getenv(key); actor != "" {
+			return actor
+		}
+	}
+	return "unknown"
+}
+
+// deployService はデプロイ処理の本体（taskDefinitionがnilの場合はソースのタスク定義を複製する）
+func (d *Deployer) deployService(ctx context.Context, inspectionResult *models.InspectionResult, taskDefinition *ecs.RegisterTaskDefinitionInput, targetCluster, newServiceName string, dryRun bool) (*models.DeploymentResult, error) {
+	// 指定されたタスク定義のネットワークモードと互換性要件で検証する
+	if taskDefinition != nil {
+		if err := ValidateTaskDefinitionInput(taskDefinition); err != nil {
+			return &models.DeploymentResult{
+				ServiceName: newServiceName,
+				ClusterName: targetCluster,
+				Success:     false,
+				DryRun:      dryRun,
+				Error:       err.Error(),
+			}, err
+		}
+		withTaskDef := *inspectionResult
+		withTaskDef.TaskDefinition = TaskDefinitionSummary(taskDefinition)
+		inspectionResult = &withTaskDef
+	}
+
 	// バリデーション
+	var warnings []string
 	err := d.ValidateDeployment(inspectionResult, targetCluster, newServiceName)
+	if err == nil {
+		warnings, err = d.checkTargetCluster(ctx, inspectionResult, targetCluster)
+	}
 	if err != nil {
 		return &models.DeploymentResult{
 			ServiceName: newServiceName,
@@ -45,6 +127,7 @@ func (d *Deployer) DeployService(ctx context.Context, inspectionResult *models.I
 			Success:     false,
 			DryRun:      dryRun,
 			Error:       err.Error(),
+			Warnings:    warnings,
 		}, err
 	}
 
@@ -52,7 +135,17 @@ func (d *Deployer) DeployService(ctx context.Context, inspectionResult *models.I
 
 	// Dry runの場合は実行せずに予定操作を返す
 	if dryRun {
-		operations = append(operations, fmt.Sprintf("Register task definition: %s-copy", inspectionResult.TaskDefinition.Family))
+		if taskDefinition != nil {
+			operations = append(operations, fmt.Sprintf("Register task definition: %s (from file)", inspectionResult.TaskDefinition.Family))
+		} else {
+			operations = append(operations, fmt.Sprintf("Register task definition: %s-copy", inspectionResult.TaskDefinition.Family))
+			// 環境変数ファイルは参照のみ複製するため、デプロイ先から読み込めるかの確認を促す
+			for _, container := range inspectionResult.TaskDefinition.Containers {
+				for _, file := range container.EnvironmentFiles {
+					operations = append(operations, fmt.Sprintf("Keep environment file for container %s: %s (the task execution role must be able to read it)", container.Name, file.Value))
+				}
+			}
+		}
 		operations = append(operations, fmt.Sprintf("Create service: %s in cluster %s", newServiceName, targetCluster))
 
 		return &models.DeploymentResult{
@@ -61,19 +154,35 @@ func (d *Deployer) DeployService(ctx context.Context, inspectionResult *models.I
 			Success:     true,
 			DryRun:      true,
 			Operations:  operations,
+			Warnings:    warnings,
 		}, nil
 	}
 
-	// タスク定義を複製
-	newTaskDefFamily := fmt.Sprintf("%s-copy", inspectionResult.TaskDefinition.Family)
-	taskDefArn, err := d.CloneTaskDefinition(ctx, inspectionResult.TaskDefinition, newTaskDefFamily)
-	if err != nil {
-		return &models.DeploymentResult{
-			ServiceName: newServiceName,
-			ClusterName: targetCluster,
-			Success:     false,
-			Error:       fmt.Sprintf("failed to clone task definition: %v", err),
-		}, err
+	// 指定されたタスク定義を登録、または元のタスク定義を複製
+	var taskDefArn string
+	if taskDefinition != nil {
+		taskDefArn, err = d.registerTaskDefinition(ctx, taskDefinition)
+		if err != nil {
+			return &models.DeploymentResult{
+				ServiceName: newServiceName,
+				ClusterName: targetCluster,
+				Success:     false,
+				Error:       fmt.Sprintf("failed to register task definition: %v", err),
+				Warnings:    warnings,
+			}, err
+		}
+	} else {
+		newTaskDefFamily := fmt.Sprintf("%s-copy", inspectionResult.TaskDefinition.Family)
+		taskDefArn, err = d.CloneTaskDefinition(ctx, inspectionResult.TaskDefinition, newTaskDefFamily)
+		if err != nil {
+			return &models.DeploymentResult{
+				ServiceName: newServiceName,
+				ClusterName: targetCluster,
+				Success:     false,
+				Error:       fmt.Sprintf("failed to clone task definition: %v", err),
+				Warnings:    warnings,
+			}, err
+		}
 	}
 
 	// サービスを作成
@@ -85,6 +194,7 @@ func (d *Deployer) DeployService(ctx context.Context, inspectionResult *models.I
 			TaskDefinitionArn: taskDefArn,
 			Success:           false,
 			Error:             fmt.Sprintf("failed to create service: %v", err),
+			Warnings:          warnings,
 		}, err
 	}
 
@@ -94,25 +204,60 @@ func (d *Deployer) DeployService(ctx context.Context, inspectionResult *models.I
 		TaskDefinitionArn: taskDefArn,
 		Success:           true,
 		DryRun:            false,
+		Warnings:          warnings,
 	}, nil
 }
 
+// checkTargetCluster はクライアントがクラスター情報を取得できる場合のみ、デプロイ先クラスターでタスクを起動できるかを確認する（dry runでも確認する）
+// ecs:DescribeClusters の権限がない場合は確認をスキップし、デプロイを失敗させずに警告を返す
+func (d *Deployer) checkTargetCluster(ctx context.Context, inspectionResult *models.InspectionResult, targetCluster string) ([]string, error) {
+	describer, ok := d.client.(ClusterDescriber)
+	if !ok {
+		return nil, nil
+	}
+	err := CheckClusterCompatibility(ctx, describer, inspectionResult.TaskDefinition, inspectionResult.Service.LaunchType, targetCluster)
+	if errors.IsAccessDenied(err) {
+		return []string{fmt.Sprintf("skipped target cluster capacity check for %s: %v", targetCluster, err)}, nil
+	}
+	return nil, err
+}
+
 // CloneTaskDefinition はタスク定義を複製する
 func (d *Deployer) CloneTaskDefinition(ctx context.Context, sourceTaskDef models.ECSTaskDefinition, newFamily string) (string, error) {
 	// タスク定義登録用の入力を作成
 	input := &ecs.RegisterTaskDefinitionInput{
 		Family:                  &newFamily,
-		Cpu:                     &sourceTaskDef.CPU,
-		Memory:                  &sourceTaskDef.Memory,
 		NetworkMode:             types.NetworkMode(sourceTaskDef.NetworkMode),
 		RequiresCompatibilities: []types.Compatibility{},
-		ContainerDefinitions: []types.ContainerDefinition{
-			// 基本的なコンテナ定義（実際の実装では元のタスク定義から取得）
+		ContainerDefinitions:    buildContainerDefinitions(sourceTaskDef.Containers),
+		Volumes:                 buildVolumes(sourceTaskDef.Volumes),
+		ProxyConfiguration:      buildProxyConfiguration(sourceTaskDef.ProxyConfiguration),
+	}
+
+	// タスクレベルのCPU・メモリはコンテナレベルのみで指定されたタスク定義（EC2で一般的）では未指定のままにする
+	if sourceTaskDef.CPU != "" {
+		input.Cpu = stringPtr(sourceTaskDef.CPU)
+	}
+	if sourceTaskDef.Memory != "" {
+		input.Memory = stringPtr(sourceTaskDef.Memory)
+	}
+
+	// シークレットや環境変数ファイルの取得に必要なタスク実行ロールと、アプリケーションのタスクロールを引き継ぐ
+	if sourceTaskDef.ExecutionRoleArn != "" {
+		input.ExecutionRoleArn = stringPtr(sourceTaskDef.ExecutionRoleArn)
+	}
+	if sourceTaskDef.TaskRoleArn != "" {
+		input.TaskRoleArn = stringPtr(sourceTaskDef.TaskRoleArn)
+	}
+
+	// 元のタスク定義からコンテナ定義を取得できない場合は基本的なコンテナ定義を使用
+	if len(input.ContainerDefinitions) == 0 {
+		input.ContainerDefinitions = []types.ContainerDefinition{
 			{
 				Name:  stringPtr("app"),
 				Image: stringPtr("nginx:latest"),
 			},
-		},
+		}
 	}
 
 	// 互換性要件を変換
@@ -121,12 +266,17 @@ func (d *Deployer) CloneTaskDefinition(ctx context.Context, sourceTaskDef models
 	}
 
 	// タスク定義を登録
+	return d.registerTaskDefinition(ctx, input)
+}
+
+// registerTaskDefinition はタスク定義を登録し、登録されたタスク定義のARNを返す
+func (d *Deployer) registerTaskDefinition(ctx context.Context, input *ecs.RegisterTaskDefinitionInput) (string, error) {
 	output, err := d.client.RegisterTaskDefinition(ctx, input)
 	if err != nil {
 		return "", err
 	}
 
-	if output.TaskDefinition.TaskDefinitionArn != nil {
+	if output.TaskDefinition != nil && output.TaskDefinition.TaskDefinitionArn != nil {
 		return *output.TaskDefinition.TaskDefinitionArn, nil
 	}
 
@@ -136,11 +286,17 @@ func (d *Deployer) CloneTaskDefinition(ctx context.Context, sourceTaskDef models
 // createService はサービスを作成する
 func (d *Deployer) createService(ctx context.Context, inspectionResult *models.InspectionResult, targetCluster, serviceName, taskDefArn string) error {
 	input := &ecs.CreateServiceInput{
-		ServiceName:    &serviceName,
-		Cluster:        &targetCluster,
-		TaskDefinition: &taskDefArn,
-		DesiredCount:   &inspectionResult.Service.DesiredCount,
-		LaunchType:     types.LaunchType(inspectionResult.Service.LaunchType),
+		ServiceName:        &serviceName,
+		Cluster:            &targetCluster,
+		TaskDefinition:     &taskDefArn,
+		DesiredCount:       &inspectionResult.Service.DesiredCount,
+		LaunchType:         types.LaunchType(inspectionResult.Service.LaunchType),
+		SchedulingStrategy: types.SchedulingStrategy(inspectionResult.Service.SchedulingStrategy),
+	}
+
+	// デーモンサービスはインスタンスごとにタスクを配置するため希望タスク数を指定しない
+	if inspectionResult.Service.IsDaemon() {
+		input.DesiredCount = nil
 	}
 
 	// ネットワーク設定があれば追加
@@ -163,6 +319,104 @@ func (d *Deployer) createService(ctx context.Context, inspectionResult *models.I
 	return err
 }
 
+// UpdateService は既存サービスのタスク定義、希望タスク数を更新する
+// ForceNewDeploymentが指定された場合は設定変更がなくても新しいデプロイを開始する
+func (d *Deployer) UpdateService(ctx context.Context, update models.ServiceUpdate) (*models.DeploymentResult, error) {
+	input := &ecs.UpdateServiceInput{
+		Service:            stringPtr(update.ServiceName),
+		Cluster:            stringPtr(update.ClusterName),
+		DesiredCount:       update.DesiredCount,
+		ForceNewDeployment: update.ForceNewDeployment,
+	}
+	if update.TaskDefinition != "" {
+		input.TaskDefinition = stringPtr(update.TaskDefinition)
+	}
+
+	output, err := d.client.UpdateService(ctx, input)
+	if err != nil {
+		return &models.DeploymentResult{
+			ServiceName:       update.ServiceName,
+			ClusterName:       update.ClusterName,
+			TaskDefinitionArn: update.TaskDefinition,
+			Success:           false,
+			Error:             fmt.Sprintf("failed to update service: %v", err),
+		}, err
+	}
+
+	result := &models.DeploymentResult{
+		ServiceName:       update.ServiceName,
+		ClusterName:       update.ClusterName,
+		TaskDefinitionArn: update.TaskDefinition,
+		Success:           true,
+		Operations:        []string{fmt.Sprintf("Update service: %s in cluster %s", update.ServiceName, update.ClusterName)},
+	}
+	if output.Service != nil && output.Service.TaskDefinition != nil {
+		result.TaskDefinitionArn = *output.Service.TaskDefinition
+	}
+	if update.ForceNewDeployment {
+		result.Operations = append(result.Operations, "Force new deployment")
+	}
+
+	return result, nil
+}
+
+// PlanServiceUpdate は稼働中のサービスと比較して、更新によって変わる項目のみを返す
+func (d *Deployer) PlanServiceUpdate(ctx context.Context, update models.ServiceUpdate) (*models.ServiceUpdatePlan, error) {
+	output, err := d.client.DescribeServices(ctx, &ecs.DescribeServicesInput{
+		Cluster:  stringPtr(update.ClusterName),
+		Services: []string{update.ServiceName},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe service: %w", err)
+	}
+	if len(output.Services) == 0 {
+		return nil, fmt.Errorf("service not found: %s", update.ServiceName)
+	}
+	current := output.Services[0]
+
+	plan := &models.ServiceUpdatePlan{
+		ServiceName:        update.ServiceName,
+		ClusterName:        update.ClusterName,
+		Changes:            []models.FieldDifference{},
+		ForceNewDeployment: update.ForceNewDeployment,
+	}
+
+	if update.TaskDefinition != "" {
+		// family:revision やファミリー名のみの指定もARNに解決して比較
+		described, err := d.client.DescribeTaskDefinition(ctx, &ecs.DescribeTaskDefinitionInput{
+			TaskDefinition: stringPtr(update.TaskDefinition),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe task definition %s: %w", update.TaskDefinition, err)
+		}
+		newArn := update.TaskDefinition
+		if described.TaskDefinition != nil && described.TaskDefinition.TaskDefinitionArn != nil {
+			newArn = *described.TaskDefinition.TaskDefinitionArn
+		}
+		currentArn := ""
+		if current.TaskDefinition != nil {
+			currentArn = *current.TaskDefinition
+		}
+		if newArn != currentArn {
+			plan.Changes = append(plan.Changes, models.FieldDifference{
+				Field:    "task_definition",
+				Live:     currentArn,
+				Expected: newArn,
+			})
+		}
+	}
+
+	if update.DesiredCount != nil && *update.DesiredCount != current.DesiredCount {
+		plan.Changes = append(plan.Changes, models.FieldDifference{
+			Field:    "desired_count",
+			Live:     strconv.Itoa(int(current.DesiredCount)),
+			Expected: strconv.Itoa(int(*update.DesiredCount)),
+		})
+	}
+
+	return plan, nil
+}
+
 // CustomizeService はサービス設定をカスタマイズする
 func (d *Deployer) CustomizeService(sourceService models.ECSService, customization DeploymentCustomization) models.ECSService {
 	result := sourceService
@@ -217,10 +471,196 @@ func (d *Deployer) ValidateDeployment(inspectionResult *models.InspectionResult,
 		return fmt.Errorf("cannot deploy to the same service name in the same cluster")
 	}
 
+	// 起動タイプはタスク定義のrequiresCompatibilitiesに含まれている必要がある（FARGATE専用のタスク定義をEC2で起動する等は不可）
+	launchType := models.LaunchType(inspectionResult.Service.LaunchType)
+	if !launchType.IsCompatibleWith(inspectionResult.TaskDefinition.RequiresAttributes) {
+		return fmt.Errorf("launch type %s is not compatible with the task definition's requires compatibilities %v",
+			launchType, inspectionResult.TaskDefinition.RequiresAttributes)
+	}
+
+	// awsvpcモードではサブネットの指定が必須（未指定だとCreateServiceで失敗する）
+	if inspectionResult.TaskDefinition.NetworkMode == "awsvpc" {
+		if inspectionResult.NetworkConfig == nil || len(inspectionResult.NetworkConfig.Subnets) == 0 {
+			return fmt.Errorf("awsvpc network mode requires at least one subnet in the network configuration")
+		}
+	}
+
 	return nil
 }
 
+// buildContainerDefinitions はモデルのコンテナ定義をAWSのコンテナ定義に変換
+func buildContainerDefinitions(containers []models.ContainerDefinition) []types.ContainerDefinition {
+	var result []types.ContainerDefinition
+
+	for _, container := range containers {
+		def := types.ContainerDefinition{
+			Name:      stringPtr(container.Name),
+			Image:     stringPtr(container.Image),
+			Essential: boolPtr(container.Essential),
+		}
+
+		// コンテナレベルのリソース指定（0は未指定）
+		def.Cpu = container.CPU
+		if container.Memory != 0 {
+			def.Memory = int32Ptr(container.Memory)
+		}
+		if container.MemoryReservation != 0 {
+			def.MemoryReservation = int32Ptr(container.MemoryReservation)
+		}
+
+		for _, pm := range container.PortMappings {
+			portMapping := types.PortMapping{
+				ContainerPort: int32Ptr(pm.ContainerPort),
+				Protocol:      types.TransportProtocol(pm.Protocol),
+			}
+			if pm.Name != "" {
+				portMapping.Name = stringPtr(pm.Name)
+			}
+			if pm.HostPort != 0 {
+				portMapping.HostPort = int32Ptr(pm.HostPort)
+			}
+			def.PortMappings = append(def.PortMappings, portMapping)
+		}
+
+		for _, ulimit := range container.Ulimits {
+			def.Ulimits = append(def.Ulimits, types.Ulimit{
+				Name:      types.UlimitName(ulimit.Name),
+				SoftLimit: ulimit.SoftLimit,
+				HardLimit: ulimit.HardLimit,
+			})
+		}
+
+		for _, mp := range container.MountPoints {
+			def.MountPoints = append(def.MountPoints, types.MountPoint{
+				SourceVolume:  stringPtr(mp.SourceVolume),
+				ContainerPath: stringPtr(mp.ContainerPath),
+				ReadOnly:      boolPtr(mp.ReadOnly),
+			})
+		}
+
+		for _, vf := range container.VolumesFrom {
+			def.VolumesFrom = append(def.VolumesFrom, types.VolumeFrom{
+				SourceContainer: stringPtr(vf.SourceContainer),
+				ReadOnly:        boolPtr(vf.ReadOnly),
+			})
+		}
+
+		for _, dep := range container.DependsOn {
+			def.DependsOn = append(def.DependsOn, types.ContainerDependency{
+				ContainerName: stringPtr(dep.ContainerName),
+				Condition:     types.ContainerCondition(dep.Condition),
+			})
+		}
+
+		def.EntryPoint = container.EntryPoint
+		def.Command = container.Command
+		if container.WorkingDirectory != "" {
+			def.WorkingDirectory = stringPtr(container.WorkingDirectory)
+		}
+
+		for _, env := range container.Environment {
+			def.Environment = append(def.Environment, types.KeyValuePair{
+				Name:  stringPtr(env.Name),
+				Value: stringPtr(env.Value),
+			})
+		}
+
+		for _, secret := range container.Secrets {
+			def.Secrets = append(def.Secrets, types.Secret{
+				Name:      stringPtr(secret.Name),
+				ValueFrom: stringPtr(secret.ValueFrom),
+			})
+		}
+
+		for _, file := range container.EnvironmentFiles {
+			def.EnvironmentFiles = append(def.EnvironmentFiles, types.EnvironmentFile{
+				Type:  types.EnvironmentFileType(file.Type),
+				Value: stringPtr(file.Value),
+			})
+		}
+
+		if hc := container.HealthCheck; hc != nil {
+			def.HealthCheck = &types.HealthCheck{Command: hc.Command}
+			if hc.Interval != 0 {
+				def.HealthCheck.Interval = int32Ptr(hc.Interval)
+			}
+			if hc.Timeout != 0 {
+				def.HealthCheck.Timeout = int32Ptr(hc.Timeout)
+			}
+			if hc.Retries != 0 {
+				def.HealthCheck.Retries = int32Ptr(hc.Retries)
+			}
+			if hc.StartPeriod != 0 {
+				def.HealthCheck.StartPeriod = int32Ptr(hc.StartPeriod)
+			}
+		}
+
+		result = append(result, def)
+	}
+
+	return result
+}
+
+// buildVolumes はモデルのボリューム定義をAWSのボリューム定義に変換
+func buildVolumes(volumes []models.Volume) []types.Volume {
+	var result []types.Volume
+
+	for _, volume := range volumes {
+		v := types.Volume{
+			Name: stringPtr(volume.Name),
+		}
+
+		if volume.HostSourcePath != "" {
+			v.Host = &types.HostVolumeProperties{
+				SourcePath: stringPtr(volume.HostSourcePath),
+			}
+		}
+
+		if volume.EFSFileSystemID != "" {
+			v.EfsVolumeConfiguration = &types.EFSVolumeConfiguration{
+				FileSystemId: stringPtr(volume.EFSFileSystemID),
+			}
+			if volume.EFSRootDirectory != "" {
+				v.EfsVolumeConfiguration.RootDirectory = stringPtr(volume.EFSRootDirectory)
+			}
+		}
+
+		result = append(result, v)
+	}
+
+	return result
+}
+
+// buildProxyConfiguration はモデルのプロキシ設定をAWSのプロキシ設定に変換
+func buildProxyConfiguration(proxy *models.ProxyConfiguration) *types.ProxyConfiguration {
+	if proxy == nil {
+		return nil
+	}
+
+	result := &types.ProxyConfiguration{
+		Type:          types.ProxyConfigurationType(proxy.Type),
+		ContainerName: stringPtr(proxy.ContainerName),
+	}
+
+	for _, property := range proxy.Properties {
+		result.Properties = append(result.Properties, types.KeyValuePair{
+			Name:  stringPtr(property.Name),
+			Value: stringPtr(property.Value),
+		})
+	}
+
+	return result
+}
+
 // ヘルパー関数
 func stringPtr(s string) *string {
 	return &s
 }
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
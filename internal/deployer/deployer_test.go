@@ -1,15 +1,22 @@
 package deployer_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/service/ecs"
 	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
 	"github.com/dev-shimada/phantom-ecs/internal/deployer"
+	"github.com/dev-shimada/phantom-ecs/internal/logger"
 	"github.com/dev-shimada/phantom-ecs/internal/models"
+	"github.com/dev-shimada/phantom-ecs/internal/models/modeltest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockECSClient はECSクライアントのモック
@@ -42,6 +49,11 @@ func (m *MockECSClient) CreateService(ctx context.Context, input *ecs.CreateServ
 	return args.Get(0).(*ecs.CreateServiceOutput), args.Error(1)
 }
 
+func (m *MockECSClient) UpdateService(ctx context.Context, input *ecs.UpdateServiceInput) (*ecs.UpdateServiceOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*ecs.UpdateServiceOutput), args.Error(1)
+}
+
 func (m *MockECSClient) RegisterTaskDefinition(ctx context.Context, input *ecs.RegisterTaskDefinitionInput) (*ecs.RegisterTaskDefinitionOutput, error) {
 	args := m.Called(ctx, input)
 	return args.Get(0).(*ecs.RegisterTaskDefinitionOutput), args.Error(1)
@@ -145,6 +157,10 @@ func TestDeployer_DeployService_DryRun(t *testing.T) {
 			NetworkMode: "awsvpc",
 			Status:      "ACTIVE",
 		},
+		NetworkConfig: &models.NetworkConfig{
+			Subnets:        []string{"subnet-12345"},
+			SecurityGroups: []string{"sg-12345"},
+		},
 	}
 
 	targetCluster := "target-cluster"
@@ -242,67 +258,776 @@ func TestDeployer_CustomizeService_BasicCustomization(t *testing.T) {
 func TestDeployer_ValidateDeployment_Success(t *testing.T) {
 	deployer := &deployer.Deployer{}
 
-	inspectionResult := &models.InspectionResult{
-		Service: models.ECSService{
-			ServiceName: "web-service",
-			Status:      "ACTIVE",
+	inspectionResult := modeltest.NewInspectionResult(
+		modeltest.NewService("web-service"),
+		modeltest.NewTaskDefinition("web-task"),
+	)
+
+	err := deployer.ValidateDeployment(inspectionResult, "target-cluster", "web-service-copy")
+
+	assert.NoError(t, err)
+}
+
+func TestDeployer_ValidateDeployment_InvalidSource(t *testing.T) {
+	deployer := &deployer.Deployer{}
+
+	inspectionResult := modeltest.NewInspectionResult(
+		modeltest.NewService("web-service", modeltest.WithStatus("INACTIVE")), // 無効なステータス
+		modeltest.NewTaskDefinition("web-task"),
+	)
+
+	err := deployer.ValidateDeployment(inspectionResult, "target-cluster", "web-service-copy")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "source service is not active")
+}
+
+func TestDeployer_ValidateDeployment_EmptyTargetCluster(t *testing.T) {
+	deployer := &deployer.Deployer{}
+
+	inspectionResult := modeltest.NewInspectionResult(
+		modeltest.NewService("web-service"),
+		modeltest.NewTaskDefinition("web-task"),
+	)
+
+	err := deployer.ValidateDeployment(inspectionResult, "", "web-service-copy")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "target cluster name cannot be empty")
+}
+
+func TestDeployer_ValidateDeployment_NetworkConfiguration(t *testing.T) {
+	deployer := &deployer.Deployer{}
+
+	newInspectionResult := func(networkMode string, opts ...modeltest.InspectionResultOption) *models.InspectionResult {
+		return modeltest.NewInspectionResult(
+			modeltest.NewService("web-service"),
+			modeltest.NewTaskDefinition("web-task", modeltest.WithNetworkMode(networkMode)),
+			opts...,
+		)
+	}
+
+	t.Run("awsvpcでサブネットあり", func(t *testing.T) {
+		result := newInspectionResult("awsvpc")
+
+		assert.NoError(t, deployer.ValidateDeployment(result, "target-cluster", "web-service-copy"))
+	})
+
+	t.Run("awsvpcでサブネットなし", func(t *testing.T) {
+		for _, networkConfig := range []*models.NetworkConfig{nil, {SecurityGroups: []string{"sg-12345"}}} {
+			result := newInspectionResult("awsvpc", modeltest.WithNetworkConfig(networkConfig))
+			err := deployer.ValidateDeployment(result, "target-cluster", "web-service-copy")
+
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "awsvpc network mode requires at least one subnet")
+		}
+	})
+
+	t.Run("bridgeではサブネット不要", func(t *testing.T) {
+		assert.NoError(t, deployer.ValidateDeployment(newInspectionResult("bridge"), "target-cluster", "web-service-copy"))
+	})
+}
+
+func TestDeployer_CloneTaskDefinition_PreservesContainerSettings(t *testing.T) {
+	mockClient := new(MockECSClient)
+	deployer := deployer.NewDeployer(mockClient)
+
+	ctx := context.Background()
+
+	sourceTaskDef := models.ECSTaskDefinition{
+		Family:      "web-task",
+		Revision:    1,
+		CPU:         "256",
+		Memory:      "512",
+		NetworkMode: "awsvpc",
+		Status:      "ACTIVE",
+		Containers: []models.ContainerDefinition{
+			{
+				Name:      "web",
+				Image:     "nginx:1.25",
+				Essential: true,
+				PortMappings: []models.PortMapping{
+					{ContainerPort: 80, HostPort: 80, Protocol: "tcp"},
+				},
+				Ulimits: []models.Ulimit{
+					{Name: "nofile", SoftLimit: 65536, HardLimit: 65536},
+				},
+				MountPoints: []models.MountPoint{
+					{SourceVolume: "data", ContainerPath: "/data", ReadOnly: true},
+				},
+				VolumesFrom: []models.VolumeFrom{
+					{SourceContainer: "sidecar", ReadOnly: true},
+				},
+				DependsOn: []models.ContainerDependency{
+					{ContainerName: "sidecar", Condition: "HEALTHY"},
+				},
+				EntryPoint:       []string{"/docker-entrypoint.sh"},
+				Command:          []string{"nginx", "-g", "daemon off;"},
+				WorkingDirectory: "/usr/share/nginx",
+				EnvironmentFiles: []models.EnvironmentFile{
+					{Value: "arn:aws:s3:::config-bucket/web.env", Type: "s3"},
+				},
+				HealthCheck: &models.HealthCheck{
+					Command:  []string{"CMD-SHELL", "curl -f http://localhost/ || exit 1"},
+					Interval: 30,
+					Retries:  3,
+				},
+			},
+			{
+				Name:  "sidecar",
+				Image: "envoy:v1.29",
+			},
 		},
-		TaskDefinition: models.ECSTaskDefinition{
-			Family: "web-task",
-			Status: "ACTIVE",
+		Volumes: []models.Volume{
+			{Name: "data", HostSourcePath: "/mnt/data"},
 		},
 	}
 
-	targetCluster := "target-cluster"
-	newServiceName := "web-service-copy"
+	var captured *ecs.RegisterTaskDefinitionInput
+	mockClient.On("RegisterTaskDefinition", ctx, mock.AnythingOfType("*ecs.RegisterTaskDefinitionInput")).Run(func(args mock.Arguments) {
+		captured = args.Get(1).(*ecs.RegisterTaskDefinitionInput)
+	}).Return(
+		&ecs.RegisterTaskDefinitionOutput{
+			TaskDefinition: &types.TaskDefinition{
+				TaskDefinitionArn: func() *string { s := "arn:aws:ecs:us-west-2:123456789012:task-definition/web-task-copy:1"; return &s }(),
+			},
+		}, nil)
 
-	err := deployer.ValidateDeployment(inspectionResult, targetCluster, newServiceName)
+	_, err := deployer.CloneTaskDefinition(ctx, sourceTaskDef, "web-task-copy")
 
 	assert.NoError(t, err)
+	assert.Len(t, captured.ContainerDefinitions, 2)
+
+	container := captured.ContainerDefinitions[0]
+	assert.Equal(t, "web", *container.Name)
+	assert.Equal(t, "nginx:1.25", *container.Image)
+	assert.True(t, *container.Essential)
+	assert.Len(t, container.PortMappings, 1)
+	assert.Equal(t, int32(80), *container.PortMappings[0].ContainerPort)
+	assert.Equal(t, int32(80), *container.PortMappings[0].HostPort)
+	assert.Equal(t, types.TransportProtocolTcp, container.PortMappings[0].Protocol)
+	assert.Equal(t, types.UlimitNameNofile, container.Ulimits[0].Name)
+	assert.Equal(t, int32(65536), container.Ulimits[0].SoftLimit)
+	assert.Equal(t, "/data", *container.MountPoints[0].ContainerPath)
+	assert.Equal(t, "sidecar", *container.VolumesFrom[0].SourceContainer)
+	assert.Len(t, container.DependsOn, 1)
+	assert.Equal(t, "sidecar", *container.DependsOn[0].ContainerName)
+	assert.Equal(t, types.ContainerConditionHealthy, container.DependsOn[0].Condition)
+	assert.Equal(t, []string{"/docker-entrypoint.sh"}, container.EntryPoint)
+	assert.Equal(t, []string{"nginx", "-g", "daemon off;"}, container.Command)
+	assert.Equal(t, "/usr/share/nginx", *container.WorkingDirectory)
+	require.Len(t, container.EnvironmentFiles, 1)
+	assert.Equal(t, "arn:aws:s3:::config-bucket/web.env", *container.EnvironmentFiles[0].Value)
+	assert.Equal(t, types.EnvironmentFileTypeS3, container.EnvironmentFiles[0].Type)
+	require.NotNil(t, container.HealthCheck)
+	assert.Equal(t, []string{"CMD-SHELL", "curl -f http://localhost/ || exit 1"}, container.HealthCheck.Command)
+	assert.Equal(t, int32(30), *container.HealthCheck.Interval)
+	assert.Equal(t, int32(3), *container.HealthCheck.Retries)
+	assert.Nil(t, container.HealthCheck.Timeout)
+
+	// 指定のないコンテナには設定しない
+	sidecar := captured.ContainerDefinitions[1]
+	assert.Empty(t, sidecar.DependsOn)
+	assert.Nil(t, sidecar.Command)
+	assert.Nil(t, sidecar.WorkingDirectory)
+	assert.Empty(t, sidecar.EnvironmentFiles)
+	assert.Nil(t, sidecar.HealthCheck)
+
+	assert.Len(t, captured.Volumes, 1)
+	assert.Equal(t, "data", *captured.Volumes[0].Name)
+	assert.Equal(t, "/mnt/data", *captured.Volumes[0].Host.SourcePath)
+
+	mockClient.AssertExpectations(t)
 }
 
-func TestDeployer_ValidateDeployment_InvalidSource(t *testing.T) {
-	deployer := &deployer.Deployer{}
+func TestDeployer_CloneTaskDefinition_ContainerLevelResourcesOnly(t *testing.T) {
+	mockClient := new(MockECSClient)
+	deployer := deployer.NewDeployer(mockClient)
 
-	inspectionResult := &models.InspectionResult{
-		Service: models.ECSService{
-			ServiceName: "web-service",
-			Status:      "INACTIVE", // 無効なステータス
+	ctx := context.Background()
+
+	// EC2で一般的な、タスクレベルのCPU・メモリがなくコンテナレベルでのみ指定されたタスク定義
+	sourceTaskDef := models.ECSTaskDefinition{
+		Family:      "worker-task",
+		NetworkMode: "bridge",
+		Containers: []models.ContainerDefinition{
+			{Name: "worker", Image: "worker:1.0", Essential: true, CPU: 128, Memory: 512, MemoryReservation: 256},
+			{Name: "log-router", Image: "fluent-bit:2.2", MemoryReservation: 64},
 		},
-		TaskDefinition: models.ECSTaskDefinition{
-			Family: "web-task",
-			Status: "ACTIVE",
+	}
+
+	var captured *ecs.RegisterTaskDefinitionInput
+	mockClient.On("RegisterTaskDefinition", ctx, mock.AnythingOfType("*ecs.RegisterTaskDefinitionInput")).Run(func(args mock.Arguments) {
+		captured = args.Get(1).(*ecs.RegisterTaskDefinitionInput)
+	}).Return(
+		&ecs.RegisterTaskDefinitionOutput{
+			TaskDefinition: &types.TaskDefinition{
+				TaskDefinitionArn: func() *string {
+					s := "arn:aws:ecs:us-west-2:123456789012:task-definition/worker-task-copy:1"
+					return &s
+				}(),
+			},
+		}, nil)
+
+	_, err := deployer.CloneTaskDefinition(ctx, sourceTaskDef, "worker-task-copy")
+
+	require.NoError(t, err)
+	assert.Nil(t, captured.Cpu)
+	assert.Nil(t, captured.Memory)
+	require.Len(t, captured.ContainerDefinitions, 2)
+
+	worker := captured.ContainerDefinitions[0]
+	assert.Equal(t, int32(128), worker.Cpu)
+	require.NotNil(t, worker.Memory)
+	assert.Equal(t, int32(512), *worker.Memory)
+	require.NotNil(t, worker.MemoryReservation)
+	assert.Equal(t, int32(256), *worker.MemoryReservation)
+
+	logRouter := captured.ContainerDefinitions[1]
+	assert.Equal(t, int32(0), logRouter.Cpu)
+	assert.Nil(t, logRouter.Memory)
+	require.NotNil(t, logRouter.MemoryReservation)
+	assert.Equal(t, int32(64), *logRouter.MemoryReservation)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestDeployer_CloneTaskDefinition_PreservesTaskRoles(t *testing.T) {
+	mockClient := new(MockECSClient)
+	deployer := deployer.NewDeployer(mockClient)
+
+	ctx := context.Background()
+
+	// 環境変数ファイルとシークレットはタスク実行ロールで取得するため、ロールも複製する
+	sourceTaskDef := models.ECSTaskDefinition{
+		Family:           "web-task",
+		CPU:              "256",
+		Memory:           "512",
+		NetworkMode:      "awsvpc",
+		ExecutionRoleArn: "arn:aws:iam::123456789012:role/ecsTaskExecutionRole",
+		TaskRoleArn:      "arn:aws:iam::123456789012:role/web-task-role",
+		Containers: []models.ContainerDefinition{
+			{
+				Name:             "web",
+				Image:            "nginx:1.25",
+				EnvironmentFiles: []models.EnvironmentFile{{Value: "arn:aws:s3:::config-bucket/web.env", Type: "s3"}},
+				Secrets:          []models.Secret{{Name: "DB_PASSWORD", ValueFrom: "arn:aws:secretsmanager:us-west-2:123456789012:secret:db"}},
+			},
 		},
 	}
 
-	targetCluster := "target-cluster"
-	newServiceName := "web-service-copy"
+	var captured *ecs.RegisterTaskDefinitionInput
+	mockClient.On("RegisterTaskDefinition", ctx, mock.AnythingOfType("*ecs.RegisterTaskDefinitionInput")).Run(func(args mock.Arguments) {
+		captured = args.Get(1).(*ecs.RegisterTaskDefinitionInput)
+	}).Return(
+		&ecs.RegisterTaskDefinitionOutput{
+			TaskDefinition: &types.TaskDefinition{
+				TaskDefinitionArn: func() *string { s := "arn:aws:ecs:us-west-2:123456789012:task-definition/web-task-copy:1"; return &s }(),
+			},
+		}, nil)
 
-	err := deployer.ValidateDeployment(inspectionResult, targetCluster, newServiceName)
+	_, err := deployer.CloneTaskDefinition(ctx, sourceTaskDef, "web-task-copy")
 
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "source service is not active")
+	require.NoError(t, err)
+	require.NotNil(t, captured.ExecutionRoleArn)
+	assert.Equal(t, "arn:aws:iam::123456789012:role/ecsTaskExecutionRole", *captured.ExecutionRoleArn)
+	require.NotNil(t, captured.TaskRoleArn)
+	assert.Equal(t, "arn:aws:iam::123456789012:role/web-task-role", *captured.TaskRoleArn)
+	require.Len(t, captured.ContainerDefinitions, 1)
+	require.Len(t, captured.ContainerDefinitions[0].EnvironmentFiles, 1)
+	assert.Equal(t, "arn:aws:s3:::config-bucket/web.env", *captured.ContainerDefinitions[0].EnvironmentFiles[0].Value)
+	require.Len(t, captured.ContainerDefinitions[0].Secrets, 1)
+	assert.Equal(t, "DB_PASSWORD", *captured.ContainerDefinitions[0].Secrets[0].Name)
+
+	mockClient.AssertExpectations(t)
 }
 
-func TestDeployer_ValidateDeployment_EmptyTargetCluster(t *testing.T) {
-	deployer := &deployer.Deployer{}
+func TestDeployer_CloneTaskDefinition_PreservesProxyConfiguration(t *testing.T) {
+	mockClient := new(MockECSClient)
+	deployer := deployer.NewDeployer(mockClient)
+
+	ctx := context.Background()
+
+	newSourceTaskDef := func(proxy *models.ProxyConfiguration) models.ECSTaskDefinition {
+		return models.ECSTaskDefinition{
+			Family:      "mesh-task",
+			Revision:    1,
+			CPU:         "256",
+			Memory:      "512",
+			NetworkMode: "awsvpc",
+			Status:      "ACTIVE",
+			Containers: []models.ContainerDefinition{
+				{Name: "app", Image: "app:1.0", Essential: true},
+				{Name: "envoy", Image: "envoy:v1.29", Essential: true},
+			},
+			ProxyConfiguration: proxy,
+		}
+	}
+
+	var captured []*ecs.RegisterTaskDefinitionInput
+	mockClient.On("RegisterTaskDefinition", ctx, mock.AnythingOfType("*ecs.RegisterTaskDefinitionInput")).Run(func(args mock.Arguments) {
+		captured = append(captured, args.Get(1).(*ecs.RegisterTaskDefinitionInput))
+	}).Return(
+		&ecs.RegisterTaskDefinitionOutput{
+			TaskDefinition: &types.TaskDefinition{
+				TaskDefinitionArn: func() *string { s := "arn:aws:ecs:us-west-2:123456789012:task-definition/mesh-task-copy:1"; return &s }(),
+			},
+		}, nil)
+
+	_, err := deployer.CloneTaskDefinition(ctx, newSourceTaskDef(&models.ProxyConfiguration{
+		Type:          "APPMESH",
+		ContainerName: "envoy",
+		Properties: []models.ProxyProperty{
+			{Name: "AppPorts", Value: "8080"},
+			{Name: "EgressIgnoredIPs", Value: "169.254.170.2,169.254.169.254"},
+		},
+	}), "mesh-task-copy")
+	require.NoError(t, err)
+
+	_, err = deployer.CloneTaskDefinition(ctx, newSourceTaskDef(nil), "mesh-task-copy")
+	require.NoError(t, err)
+
+	require.Len(t, captured, 2)
+	proxy := captured[0].ProxyConfiguration
+	require.NotNil(t, proxy)
+	assert.Equal(t, types.ProxyConfigurationTypeAppmesh, proxy.Type)
+	assert.Equal(t, "envoy", *proxy.ContainerName)
+	require.Len(t, proxy.Properties, 2)
+	assert.Equal(t, "AppPorts", *proxy.Properties[0].Name)
+	assert.Equal(t, "8080", *proxy.Properties[0].Value)
+	assert.Equal(t, "EgressIgnoredIPs", *proxy.Properties[1].Name)
+	assert.Equal(t, "169.254.170.2,169.254.169.254", *proxy.Properties[1].Value)
+
+	// プロキシ設定のないタスク定義には設定しない
+	assert.Nil(t, captured[1].ProxyConfiguration)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestDeployer_DeployService_DaemonService(t *testing.T) {
+	ctx := context.Background()
+
+	newInspectionResult := func(schedulingStrategy string) *models.InspectionResult {
+		return &models.InspectionResult{
+			Service: models.ECSService{
+				ServiceName:        "log-agent",
+				ClusterName:        "source-cluster",
+				TaskDefinition:     "log-agent:1",
+				DesiredCount:       3,
+				LaunchType:         "EC2",
+				SchedulingStrategy: schedulingStrategy,
+				Status:             "ACTIVE",
+			},
+			TaskDefinition: models.ECSTaskDefinition{
+				Family:      "log-agent",
+				Revision:    1,
+				NetworkMode: "bridge",
+				Status:      "ACTIVE",
+			},
+		}
+	}
+
+	deploy := func(t *testing.T, result *models.InspectionResult) *ecs.CreateServiceInput {
+		mockClient := new(MockECSClient)
+		mockClient.On("RegisterTaskDefinition", ctx, mock.Anything).Return(
+			&ecs.RegisterTaskDefinitionOutput{
+				TaskDefinition: &types.TaskDefinition{
+					TaskDefinitionArn: func() *string { s := "arn:aws:ecs:us-west-2:123456789012:task-definition/log-agent-copy:1"; return &s }(),
+				},
+			}, nil)
+		var captured *ecs.CreateServiceInput
+		mockClient.On("CreateService", ctx, mock.AnythingOfType("*ecs.CreateServiceInput")).Run(func(args mock.Arguments) {
+			captured = args.Get(1).(*ecs.CreateServiceInput)
+		}).Return(&ecs.CreateServiceOutput{}, nil)
+
+		_, err := deployer.NewDeployer(mockClient).DeployService(ctx, result, "target-cluster", "log-agent", false)
+		require.NoError(t, err)
+		require.NotNil(t, captured)
+		return captured
+	}
+
+	t.Run("DAEMONは希望タスク数なしで複製", func(t *testing.T) {
+		input := deploy(t, newInspectionResult("DAEMON"))
+
+		assert.Equal(t, types.SchedulingStrategyDaemon, input.SchedulingStrategy)
+		assert.Nil(t, input.DesiredCount)
+	})
+
+	t.Run("REPLICAは希望タスク数を引き継ぐ", func(t *testing.T) {
+		input := deploy(t, newInspectionResult("REPLICA"))
+
+		assert.Equal(t, types.SchedulingStrategyReplica, input.SchedulingStrategy)
+		require.NotNil(t, input.DesiredCount)
+		assert.Equal(t, int32(3), *input.DesiredCount)
+	})
+}
+
+// newCapturingLogger はJSON形式のログをバッファに書き出すロガーを作成
+func newCapturingLogger(t *testing.T, buf *bytes.Buffer) logger.Logger {
+	log, err := logger.NewLogger(&logger.Config{Level: "info", Format: "json", Output: buf})
+	require.NoError(t, err)
+	return log
+}
+
+// decodeAuditEntries はバッファ内の監査ログを読み取る
+func decodeAuditEntries(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	var entries []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		if entry["audit"] == true {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+func TestDeployer_DeployService_AuditLog(t *testing.T) {
+	t.Setenv("PHANTOM_ECS_ACTOR", "alice")
 
 	inspectionResult := &models.InspectionResult{
 		Service: models.ECSService{
 			ServiceName: "web-service",
+			ClusterName: "source-cluster",
 			Status:      "ACTIVE",
+			LaunchType:  "FARGATE",
 		},
-		TaskDefinition: models.ECSTaskDefinition{
-			Family: "web-task",
-			Status: "ACTIVE",
-		},
+		TaskDefinition: models.ECSTaskDefinition{Family: "web-task", Status: "ACTIVE"},
 	}
+	taskDefArn := "arn:aws:ecs:us-west-2:123456789012:task-definition/web-task-copy:1"
 
-	targetCluster := ""
-	newServiceName := "web-service-copy"
+	t.Run("デプロイ成功", func(t *testing.T) {
+		mockClient := new(MockECSClient)
+		mockClient.On("RegisterTaskDefinition", mock.Anything, mock.Anything).Return(
+			&ecs.RegisterTaskDefinitionOutput{TaskDefinition: &types.TaskDefinition{TaskDefinitionArn: &taskDefArn}}, nil)
+		mockClient.On("CreateService", mock.Anything, mock.Anything).Return(&ecs.CreateServiceOutput{}, nil)
 
-	err := deployer.ValidateDeployment(inspectionResult, targetCluster, newServiceName)
+		var buf bytes.Buffer
+		d := deployer.NewDeployerWithLogger(mockClient, newCapturingLogger(t, &buf))
 
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "target cluster name cannot be empty")
+		_, err := d.DeployService(context.Background(), inspectionResult, "target-cluster", "web-service-copy", false)
+		require.NoError(t, err)
+
+		entries := decodeAuditEntries(t, &buf)
+		require.Len(t, entries, 1)
+		entry := entries[0]
+		assert.Equal(t, "deploy", entry["operation"])
+		assert.Equal(t, "web-service-copy", entry["service"])
+		assert.Equal(t, "target-cluster", entry["cluster"])
+		assert.Equal(t, "web-service", entry["source_service"])
+		assert.Equal(t, "source-cluster", entry["source_cluster"])
+		assert.Equal(t, taskDefArn, entry["task_definition_arn"])
+		assert.Equal(t, false, entry["dry_run"])
+		assert.Equal(t, true, entry["success"])
+		assert.Equal(t, "alice", entry["actor"])
+		assert.NotContains(t, entry, "error")
+	})
+
+	t.Run("ドライラン", func(t *testing.T) {
+		var buf bytes.Buffer
+		d := deployer.NewDeployerWithLogger(new(MockECSClient), newCapturingLogger(t, &buf))
+
+		_, err := d.DeployService(context.Background(), inspectionResult, "target-cluster", "web-service-copy", true)
+		require.NoError(t, err)
+
+		entries := decodeAuditEntries(t, &buf)
+		require.Len(t, entries, 1)
+		assert.Equal(t, true, entries[0]["dry_run"])
+		assert.Equal(t, true, entries[0]["success"])
+	})
+
+	t.Run("デプロイ失敗", func(t *testing.T) {
+		mockClient := new(MockECSClient)
+		mockClient.On("RegisterTaskDefinition", mock.Anything, mock.Anything).Return(
+			(*ecs.RegisterTaskDefinitionOutput)(nil), errors.New("AccessDeniedException"))
+
+		var buf bytes.Buffer
+		d := deployer.NewDeployerWithLogger(mockClient, newCapturingLogger(t, &buf))
+
+		_, err := d.DeployService(context.Background(), inspectionResult, "target-cluster", "web-service-copy", false)
+		require.Error(t, err)
+
+		entries := decodeAuditEntries(t, &buf)
+		require.Len(t, entries, 1)
+		assert.Equal(t, false, entries[0]["success"])
+		assert.Contains(t, entries[0]["error"], "AccessDeniedException")
+		assert.Equal(t, "alice", entries[0]["actor"])
+	})
+}
+
+func TestDeployer_UpdateService(t *testing.T) {
+	t.Run("設定変更なしで強制デプロイ", func(t *testing.T) {
+		mockClient := new(MockECSClient)
+		mockClient.On("UpdateService", mock.Anything, mock.MatchedBy(func(input *ecs.UpdateServiceInput) bool {
+			return *input.Service == "web-service" &&
+				*input.Cluster == "prod" &&
+				input.ForceNewDeployment &&
+				input.TaskDefinition == nil &&
+				input.DesiredCount == nil
+		})).Return(&ecs.UpdateServiceOutput{Service: &types.Service{
+			TaskDefinition: func() *string { s := "arn:aws:ecs:us-east-1:123456789012:task-definition/web-task:3"; return &s }(),
+		}}, nil)
+
+		d := deployer.NewDeployer(mockClient)
+		result, err := d.UpdateService(context.Background(), models.ServiceUpdate{
+			ServiceName:        "web-service",
+			ClusterName:        "prod",
+			ForceNewDeployment: true,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, result.Success)
+		assert.Equal(t, "arn:aws:ecs:us-east-1:123456789012:task-definition/web-task:3", result.TaskDefinitionArn)
+		assert.Contains(t, result.Operations, "Force new deployment")
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("タスク定義の更新は強制デプロイと独立", func(t *testing.T) {
+		mockClient := new(MockECSClient)
+		mockClient.On("UpdateService", mock.Anything, mock.MatchedBy(func(input *ecs.UpdateServiceInput) bool {
+			return *input.TaskDefinition == "web-task:4" && *input.DesiredCount == 2 && !input.ForceNewDeployment
+		})).Return(&ecs.UpdateServiceOutput{}, nil)
+
+		desiredCount := int32(2)
+		d := deployer.NewDeployer(mockClient)
+		result, err := d.UpdateService(context.Background(), models.ServiceUpdate{
+			ServiceName:    "web-service",
+			ClusterName:    "prod",
+			TaskDefinition: "web-task:4",
+			DesiredCount:   &desiredCount,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "web-task:4", result.TaskDefinitionArn)
+		assert.NotContains(t, result.Operations, "Force new deployment")
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("更新失敗", func(t *testing.T) {
+		mockClient := new(MockECSClient)
+		mockClient.On("UpdateService", mock.Anything, mock.Anything).Return(
+			(*ecs.UpdateServiceOutput)(nil), errors.New("ServiceNotFoundException"))
+
+		d := deployer.NewDeployer(mockClient)
+		result, err := d.UpdateService(context.Background(), models.ServiceUpdate{
+			ServiceName:        "web-service",
+			ClusterName:        "prod",
+			ForceNewDeployment: true,
+		})
+
+		require.Error(t, err)
+		assert.False(t, result.Success)
+		assert.Contains(t, result.Error, "ServiceNotFoundException")
+	})
+}
+
+func TestDeployer_PlanServiceUpdate(t *testing.T) {
+	currentArn := "arn:aws:ecs:us-east-1:123456789012:task-definition/web-task:3"
+	newArn := "arn:aws:ecs:us-east-1:123456789012:task-definition/web-task:4"
+
+	newMockClient := func() *MockECSClient {
+		mockClient := new(MockECSClient)
+		mockClient.On("DescribeServices", mock.Anything, mock.Anything).Return(&ecs.DescribeServicesOutput{
+			Services: []types.Service{{
+				TaskDefinition: &currentArn,
+				DesiredCount:   2,
+			}},
+		}, nil)
+		mockClient.On("DescribeTaskDefinition", mock.Anything, mock.MatchedBy(func(input *ecs.DescribeTaskDefinitionInput) bool {
+			return *input.TaskDefinition == "web-task:3"
+		})).Return(&ecs.DescribeTaskDefinitionOutput{TaskDefinition: &types.TaskDefinition{TaskDefinitionArn: &currentArn}}, nil)
+		mockClient.On("DescribeTaskDefinition", mock.Anything, mock.MatchedBy(func(input *ecs.DescribeTaskDefinitionInput) bool {
+			return *input.TaskDefinition == "web-task:4"
+		})).Return(&ecs.DescribeTaskDefinitionOutput{TaskDefinition: &types.TaskDefinition{TaskDefinitionArn: &newArn}}, nil)
+		return mockClient
+	}
+
+	t.Run("現在の設定と同じ場合は変更なし", func(t *testing.T) {
+		mockClient := newMockClient()
+		desiredCount := int32(2)
+		d := deployer.NewDeployer(mockClient)
+		plan, err := d.PlanServiceUpdate(context.Background(), models.ServiceUpdate{
+			ServiceName:    "web-service",
+			ClusterName:    "prod",
+			TaskDefinition: "web-task:3",
+			DesiredCount:   &desiredCount,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, plan.HasChanges())
+		assert.Empty(t, plan.Changes)
+		mockClient.AssertNotCalled(t, "UpdateService", mock.Anything, mock.Anything)
+	})
+
+	t.Run("変更される項目のみを列挙", func(t *testing.T) {
+		mockClient := newMockClient()
+		desiredCount := int32(2)
+		d := deployer.NewDeployer(mockClient)
+		plan, err := d.PlanServiceUpdate(context.Background(), models.ServiceUpdate{
+			ServiceName:    "web-service",
+			ClusterName:    "prod",
+			TaskDefinition: "web-task:4",
+			DesiredCount:   &desiredCount,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, plan.HasChanges())
+		assert.Equal(t, []models.FieldDifference{
+			{Field: "task_definition", Live: currentArn, Expected: newArn},
+		}, plan.Changes)
+	})
+
+	t.Run("強制デプロイは変更として扱う", func(t *testing.T) {
+		d := deployer.NewDeployer(newMockClient())
+		plan, err := d.PlanServiceUpdate(context.Background(), models.ServiceUpdate{
+			ServiceName:        "web-service",
+			ClusterName:        "prod",
+			ForceNewDeployment: true,
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, plan.Changes)
+		assert.True(t, plan.HasChanges())
+	})
+
+	t.Run("サービスが存在しない", func(t *testing.T) {
+		mockClient := new(MockECSClient)
+		mockClient.On("DescribeServices", mock.Anything, mock.Anything).Return(&ecs.DescribeServicesOutput{}, nil)
+
+		d := deployer.NewDeployer(mockClient)
+		_, err := d.PlanServiceUpdate(context.Background(), models.ServiceUpdate{ServiceName: "missing", ClusterName: "prod"})
+
+		assert.ErrorContains(t, err, "service not found: missing")
+	})
+}
+
+func TestDeployer_ValidateDeployment_LaunchTypeCompatibility(t *testing.T) {
+	deployer := &deployer.Deployer{}
+
+	newCustomizedResult := func(launchType string, compatibilities ...string) *models.InspectionResult {
+		service := deployer.CustomizeService(modeltest.NewService("web-service"), models.DeploymentCustomization{
+			LaunchType: launchType,
+		})
+		return modeltest.NewInspectionResult(
+			service,
+			modeltest.NewTaskDefinition("web-task", modeltest.WithRequiresCompatibilities(compatibilities...)),
+		)
+	}
+
+	t.Run("FARGATE専用のタスク定義をEC2でデプロイ", func(t *testing.T) {
+		err := deployer.ValidateDeployment(newCustomizedResult("EC2", "FARGATE"), "target-cluster", "web-service-copy")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "launch type EC2 is not compatible")
+		assert.Contains(t, err.Error(), "[FARGATE]")
+	})
+
+	t.Run("両対応のタスク定義をEC2でデプロイ", func(t *testing.T) {
+		err := deployer.ValidateDeployment(newCustomizedResult("EC2", "EC2", "FARGATE"), "target-cluster", "web-service-copy")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("requiresCompatibilities未指定では検証しない", func(t *testing.T) {
+		err := deployer.ValidateDeployment(newCustomizedResult("EC2"), "target-cluster", "web-service-copy")
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestDeployer_DeployServiceWithTaskDefinition(t *testing.T) {
+	mockClient := new(MockECSClient)
+	d := deployer.NewDeployer(mockClient)
+	ctx := context.Background()
+
+	inspectionResult := modeltest.NewInspectionResult(
+		modeltest.NewService("web-service", modeltest.WithCluster("source-cluster")),
+		modeltest.NewTaskDefinition("web-task"),
+	)
+	taskDefinition, err := deployer.ParseTaskDefinition([]byte(`{
+  "family": "web-from-file",
+  "networkMode": "awsvpc",
+  "requiresCompatibilities": ["FARGATE"],
+  "containerDefinitions": [{"name": "app", "image": "nginx:1.27"}]
+}`))
+	require.NoError(t, err)
+
+	taskDefArn := "arn:aws:ecs:us-east-1:123456789012:task-definition/web-from-file:1"
+	mockClient.On("RegisterTaskDefinition", ctx, taskDefinition).Return(
+		&ecs.RegisterTaskDefinitionOutput{TaskDefinition: &types.TaskDefinition{TaskDefinitionArn: &taskDefArn}}, nil)
+	mockClient.On("CreateService", ctx, mock.MatchedBy(func(input *ecs.CreateServiceInput) bool {
+		return *input.TaskDefinition == taskDefArn && *input.ServiceName == "web-service"
+	})).Return(&ecs.CreateServiceOutput{}, nil)
+
+	result, err := d.DeployServiceWithTaskDefinition(ctx, inspectionResult, taskDefinition, "target-cluster", "web-service", false)
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, taskDefArn, result.TaskDefinitionArn)
+
+	// ソースのタスク定義は複製されず、ファイルの定義がそのまま登録される
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNumberOfCalls(t, "RegisterTaskDefinition", 1)
+}
+
+func TestDeployer_DeployServiceWithTaskDefinition_ValidatesFileTaskDefinition(t *testing.T) {
+	mockClient := new(MockECSClient)
+	d := deployer.NewDeployer(mockClient)
+
+	// ソースはbridgeだがファイルのタスク定義はawsvpcのため、サブネットがないと拒否される
+	inspectionResult := modeltest.NewInspectionResult(
+		modeltest.NewService("web-service", modeltest.WithCluster("source-cluster"), modeltest.WithLaunchType("EC2")),
+		modeltest.NewTaskDefinition("web-task", modeltest.WithNetworkMode("bridge")),
+	)
+	taskDefinition, err := deployer.ParseTaskDefinition([]byte(`{
+  "family": "web-from-file",
+  "networkMode": "awsvpc",
+  "containerDefinitions": [{"name": "app", "image": "nginx:1.27"}]
+}`))
+	require.NoError(t, err)
+
+	result, err := d.DeployServiceWithTaskDefinition(context.Background(), inspectionResult, taskDefinition, "target-cluster", "web-service", true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "awsvpc network mode requires at least one subnet")
+	assert.False(t, result.Success)
+
+	mockClient.AssertNotCalled(t, "RegisterTaskDefinition")
+}
+
+func TestDeployer_DeployServiceWithTaskDefinition_DryRun(t *testing.T) {
+	mockClient := new(MockECSClient)
+	d := deployer.NewDeployer(mockClient)
+
+	inspectionResult := modeltest.NewInspectionResult(
+		modeltest.NewService("web-service", modeltest.WithCluster("source-cluster")),
+		modeltest.NewTaskDefinition("web-task"),
+	)
+	taskDefinition, err := deployer.ParseTaskDefinition([]byte(`{"family": "web-from-file", "networkMode": "awsvpc", "containerDefinitions": [{"name": "app", "image": "nginx"}]}`))
+	require.NoError(t, err)
+
+	result, err := d.DeployServiceWithTaskDefinition(context.Background(), inspectionResult, taskDefinition, "target-cluster", "web-service", true)
+	require.NoError(t, err)
+	assert.Contains(t, result.Operations, "Register task definition: web-from-file (from file)")
+
+	mockClient.AssertNotCalled(t, "RegisterTaskDefinition")
+	mockClient.AssertNotCalled(t, "CreateService")
+}
+
+func TestDeployer_DeployService_DryRunEnvironmentFiles(t *testing.T) {
+	mockClient := new(MockECSClient)
+	d := deployer.NewDeployer(mockClient)
+
+	inspectionResult := modeltest.NewInspectionResult(
+		modeltest.NewService("web-service", modeltest.WithCluster("source-cluster")),
+		modeltest.NewTaskDefinition("web-task", modeltest.WithContainers(models.ContainerDefinition{
+			Name:             "app",
+			Image:            "nginx:1.25",
+			EnvironmentFiles: []models.EnvironmentFile{{Value: "arn:aws:s3:::config-bucket/app.env", Type: "s3"}},
+		})),
+	)
+
+	result, err := d.DeployService(context.Background(), inspectionResult, "target-cluster", "web-service", true)
+	require.NoError(t, err)
+	assert.Contains(t, result.Operations,
+		"Keep environment file for container app: arn:aws:s3:::config-bucket/app.env (the task execution role must be able to read it)")
+	mockClient.AssertNotCalled(t, "RegisterTaskDefinition")
 }
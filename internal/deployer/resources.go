@@ -0,0 +1,102 @@
+package deployer
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// cpuUnitsPerVCPU は1 vCPUあたりのCPUユニット数
+const cpuUnitsPerVCPU = 1024
+
+// mebibytesPerGigabyte は1GBあたりのMiB数
+const mebibytesPerGigabyte = 1024
+
+// NormalizeResources はCPU・メモリの指定をECSが要求する数値文字列に変換する
+// CPUは "256"（ユニット）または "0.25 vCPU"、メモリは "512"（MiB）、"512MB" または "0.5GB" の形式を受け付ける
+// 空文字列は未指定として空文字列のまま返す
+func NormalizeResources(cpu, memory string) (string, string, error) {
+	normalizedCPU, err := normalizeCPU(cpu)
+	if err != nil {
+		return "", "", err
+	}
+	normalizedMemory, err := normalizeMemory(memory)
+	if err != nil {
+		return "", "", err
+	}
+	return normalizedCPU, normalizedMemory, nil
+}
+
+// normalizeCPU はCPU指定をCPUユニットの数値文字列に変換
+func normalizeCPU(cpu string) (string, error) {
+	value := strings.TrimSpace(cpu)
+	if value == "" {
+		return "", nil
+	}
+
+	multiplier := 1.0
+	if number, ok := trimUnitSuffix(value, "vcpu"); ok {
+		value = number
+		multiplier = cpuUnitsPerVCPU
+	}
+
+	units, err := parseResourceValue(value, multiplier)
+	if err != nil {
+		return "", fmt.Errorf("invalid cpu %q: %w", cpu, err)
+	}
+	return strconv.Itoa(units), nil
+}
+
+// normalizeMemory はメモリ指定をMiBの数値文字列に変換
+func normalizeMemory(memory string) (string, error) {
+	value := strings.TrimSpace(memory)
+	if value == "" {
+		return "", nil
+	}
+
+	multiplier := 1.0
+	for _, unit := range []string{"gib", "gb"} {
+		if number, ok := trimUnitSuffix(value, unit); ok {
+			value = number
+			multiplier = mebibytesPerGigabyte
+			break
+		}
+	}
+	for _, unit := range []string{"mib", "mb"} {
+		if number, ok := trimUnitSuffix(value, unit); ok {
+			value = number
+			break
+		}
+	}
+
+	mebibytes, err := parseResourceValue(value, multiplier)
+	if err != nil {
+		return "", fmt.Errorf("invalid memory %q: %w", memory, err)
+	}
+	return strconv.Itoa(mebibytes), nil
+}
+
+// trimUnitSuffix は大文字小文字を区別せずに単位の接尾辞を取り除く
+func trimUnitSuffix(value, unit string) (string, bool) {
+	if len(value) < len(unit) || !strings.EqualFold(value[len(value)-len(unit):], unit) {
+		return value, false
+	}
+	return strings.TrimSpace(value[:len(value)-len(unit)]), true
+}
+
+// parseResourceValue は数値に倍率を掛けて正の整数に変換（整数にならない場合はエラー）
+func parseResourceValue(value string, multiplier float64) (int, error) {
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("not a number")
+	}
+	result := number * multiplier
+	if result <= 0 || math.IsInf(result, 0) || math.IsNaN(result) {
+		return 0, fmt.Errorf("must be a positive value")
+	}
+	if result != math.Trunc(result) {
+		return 0, fmt.Errorf("does not convert to a whole number")
+	}
+	return int(result), nil
+}
@@ -0,0 +1,58 @@
+package deployer_test
+
+import (
+	"testing"
+
+	"github.com/dev-shimada/phantom-ecs/internal/deployer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeResources(t *testing.T) {
+	tests := []struct {
+		name           string
+		cpu            string
+		memory         string
+		expectedCPU    string
+		expectedMemory string
+	}{
+		{name: "数値はそのまま", cpu: "256", memory: "512", expectedCPU: "256", expectedMemory: "512"},
+		{name: "vCPUとGB", cpu: "0.25 vCPU", memory: "1GB", expectedCPU: "256", expectedMemory: "1024"},
+		{name: "大文字小文字と空白を無視", cpu: "2vcpu", memory: "0.5 gb", expectedCPU: "2048", expectedMemory: "512"},
+		{name: "MBとGiB", cpu: "1 vCPU", memory: "2GiB", expectedCPU: "1024", expectedMemory: "2048"},
+		{name: "MB指定", cpu: "", memory: "768MB", expectedCPU: "", expectedMemory: "768"},
+		{name: "未指定", cpu: "", memory: "", expectedCPU: "", expectedMemory: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpu, memory, err := deployer.NormalizeResources(tt.cpu, tt.memory)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedCPU, cpu)
+			assert.Equal(t, tt.expectedMemory, memory)
+		})
+	}
+}
+
+func TestNormalizeResources_Invalid(t *testing.T) {
+	tests := []struct {
+		name        string
+		cpu         string
+		memory      string
+		expectedErr string
+	}{
+		{name: "数値でないCPU", cpu: "quarter", expectedErr: `invalid cpu "quarter"`},
+		{name: "未知の単位", memory: "1TB", expectedErr: `invalid memory "1TB"`},
+		{name: "負の値", cpu: "-256", expectedErr: "must be a positive value"},
+		{name: "ゼロ", memory: "0GB", expectedErr: "must be a positive value"},
+		{name: "整数にならない", cpu: "0.1 vCPU", expectedErr: "does not convert to a whole number"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := deployer.NormalizeResources(tt.cpu, tt.memory)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedErr)
+		})
+	}
+}
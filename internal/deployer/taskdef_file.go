@@ -0,0 +1,90 @@
+package deployer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+)
+
+// LoadTaskDefinitionFile はタスク定義ファイルを読み込み、RegisterTaskDefinitionの入力として検証する
+func LoadTaskDefinitionFile(path string) (*ecs.RegisterTaskDefinitionInput, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read task definition file: %w", err)
+	}
+
+	input, err := ParseTaskDefinition(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid task definition file %s: %w", path, err)
+	}
+	return input, nil
+}
+
+// ParseTaskDefinition は `aws ecs register-task-definition --cli-input-json` と同じ形式のJSONを解析して検証する
+// フィールド名の誤りを見逃さないよう、入力に存在しないフィールドはエラーとする
+func ParseTaskDefinition(data []byte) (*ecs.RegisterTaskDefinitionInput, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var input ecs.RegisterTaskDefinitionInput
+	if err := decoder.Decode(&input); err != nil {
+		return nil, fmt.Errorf("failed to parse task definition JSON: %w", err)
+	}
+	if err := ValidateTaskDefinitionInput(&input); err != nil {
+		return nil, err
+	}
+	return &input, nil
+}
+
+// ValidateTaskDefinitionInput はタスク定義の登録に最低限必要な項目を検証する
+func ValidateTaskDefinitionInput(input *ecs.RegisterTaskDefinitionInput) error {
+	if aws.ToString(input.Family) == "" {
+		return fmt.Errorf("task definition family is required")
+	}
+	if len(input.ContainerDefinitions) == 0 {
+		return fmt.Errorf("task definition must have at least one container definition")
+	}
+
+	names := make(map[string]bool, len(input.ContainerDefinitions))
+	for i, container := range input.ContainerDefinitions {
+		name := aws.ToString(container.Name)
+		if name == "" {
+			return fmt.Errorf("container definition %d: name is required", i)
+		}
+		if aws.ToString(container.Image) == "" {
+			return fmt.Errorf("container definition %s: image is required", name)
+		}
+		if names[name] {
+			return fmt.Errorf("duplicate container name: %s", name)
+		}
+		names[name] = true
+	}
+	return nil
+}
+
+// TaskDefinitionSummary は登録予定のタスク定義から、デプロイの検証と出力に使うモデルを作成する
+// 登録後のタスク定義として扱うためステータスはACTIVEとする
+func TaskDefinitionSummary(input *ecs.RegisterTaskDefinitionInput) models.ECSTaskDefinition {
+	taskDef := models.ECSTaskDefinition{
+		Family:      aws.ToString(input.Family),
+		Status:      "ACTIVE",
+		CPU:         aws.ToString(input.Cpu),
+		Memory:      aws.ToString(input.Memory),
+		NetworkMode: string(input.NetworkMode),
+	}
+	for _, compatibility := range input.RequiresCompatibilities {
+		taskDef.RequiresAttributes = append(taskDef.RequiresAttributes, string(compatibility))
+	}
+	for _, container := range input.ContainerDefinitions {
+		taskDef.Containers = append(taskDef.Containers, models.ContainerDefinition{
+			Name:  aws.ToString(container.Name),
+			Image: aws.ToString(container.Image),
+		})
+	}
+	return taskDef
+}
@@ -0,0 +1,83 @@
+package deployer_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/dev-shimada/phantom-ecs/internal/deployer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadTaskDefinitionFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "taskdef.json")
+	content := `{
+  "family": "web-from-file",
+  "networkMode": "awsvpc",
+  "requiresCompatibilities": ["FARGATE"],
+  "cpu": "512",
+  "memory": "1024",
+  "containerDefinitions": [
+    {
+      "name": "app",
+      "image": "nginx:1.27",
+      "essential": true,
+      "portMappings": [{"containerPort": 80, "protocol": "tcp"}]
+    }
+  ]
+}`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	input, err := deployer.LoadTaskDefinitionFile(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, "web-from-file", *input.Family)
+	assert.Equal(t, types.NetworkModeAwsvpc, input.NetworkMode)
+	assert.Equal(t, []types.Compatibility{types.CompatibilityFargate}, input.RequiresCompatibilities)
+	assert.Equal(t, "512", *input.Cpu)
+	require.Len(t, input.ContainerDefinitions, 1)
+	assert.Equal(t, "app", *input.ContainerDefinitions[0].Name)
+	assert.Equal(t, "nginx:1.27", *input.ContainerDefinitions[0].Image)
+	require.Len(t, input.ContainerDefinitions[0].PortMappings, 1)
+	assert.Equal(t, int32(80), *input.ContainerDefinitions[0].PortMappings[0].ContainerPort)
+
+	summary := deployer.TaskDefinitionSummary(input)
+	assert.Equal(t, "web-from-file", summary.Family)
+	assert.Equal(t, "ACTIVE", summary.Status)
+	assert.Equal(t, []string{"FARGATE"}, summary.RequiresAttributes)
+}
+
+func TestLoadTaskDefinitionFile_Invalid(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		expectedErr string
+	}{
+		{name: "JSONでない", content: `family: web`, expectedErr: "failed to parse task definition JSON"},
+		{name: "未知のフィールド", content: `{"family": "web", "containers": []}`, expectedErr: "unknown field"},
+		{name: "ファミリーなし", content: `{"containerDefinitions": [{"name": "app", "image": "nginx"}]}`, expectedErr: "family is required"},
+		{name: "コンテナなし", content: `{"family": "web"}`, expectedErr: "at least one container definition"},
+		{name: "コンテナ名なし", content: `{"family": "web", "containerDefinitions": [{"image": "nginx"}]}`, expectedErr: "name is required"},
+		{name: "イメージなし", content: `{"family": "web", "containerDefinitions": [{"name": "app"}]}`, expectedErr: "container definition app: image is required"},
+		{name: "コンテナ名の重複", content: `{"family": "web", "containerDefinitions": [{"name": "app", "image": "nginx"}, {"name": "app", "image": "envoy"}]}`, expectedErr: "duplicate container name: app"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "taskdef.json")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+
+			_, err := deployer.LoadTaskDefinitionFile(path)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedErr)
+		})
+	}
+}
+
+func TestLoadTaskDefinitionFile_NotFound(t *testing.T) {
+	_, err := deployer.LoadTaskDefinitionFile(filepath.Join(t.TempDir(), "missing.json"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read task definition file")
+}
@@ -0,0 +1,94 @@
+package differ
+
+import (
+	"sort"
+
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+)
+
+// SetDiff は2つの文字列集合を比較し、aのみ、bのみ、両方に含まれる要素をそれぞれ昇順で返す
+func SetDiff(a, b []string) (onlyA, onlyB, both []string) {
+	inA := make(map[string]struct{}, len(a))
+	for _, value := range a {
+		inA[value] = struct{}{}
+	}
+	inB := make(map[string]struct{}, len(b))
+	for _, value := range b {
+		inB[value] = struct{}{}
+	}
+
+	onlyA, onlyB, both = []string{}, []string{}, []string{}
+	for value := range inA {
+		if _, ok := inB[value]; ok {
+			both = append(both, value)
+		} else {
+			onlyA = append(onlyA, value)
+		}
+	}
+	for value := range inB {
+		if _, ok := inA[value]; !ok {
+			onlyB = append(onlyB, value)
+		}
+	}
+
+	sort.Strings(onlyA)
+	sort.Strings(onlyB)
+	sort.Strings(both)
+	return onlyA, onlyB, both
+}
+
+// CompareClusterServices は2つのクラスターのサービス一覧を比較し、追加・削除・共通のサービスを返す
+func CompareClusterServices(fromCluster, toCluster string, from, to []models.ECSService) models.ClusterServicesDiff {
+	fromByName := servicesByName(from)
+	toByName := servicesByName(to)
+
+	removed, added, common := SetDiff(serviceNames(from), serviceNames(to))
+
+	diff := models.ClusterServicesDiff{
+		FromCluster: fromCluster,
+		ToCluster:   toCluster,
+		Added:       added,
+		Removed:     removed,
+		Common:      make([]models.CommonService, 0, len(common)),
+	}
+	for _, name := range common {
+		fromHealth := serviceHealth(fromByName[name])
+		toHealth := serviceHealth(toByName[name])
+		diff.Common = append(diff.Common, models.CommonService{
+			ServiceName:   name,
+			From:          fromHealth,
+			To:            toHealth,
+			HealthDiffers: fromHealth.Healthy != toHealth.Healthy,
+		})
+	}
+
+	return diff
+}
+
+// serviceNames はサービス名の一覧を返す
+func serviceNames(services []models.ECSService) []string {
+	names := make([]string, 0, len(services))
+	for _, service := range services {
+		names = append(names, service.ServiceName)
+	}
+	return names
+}
+
+// servicesByName はサービス名をキーとしたマップを返す
+func servicesByName(services []models.ECSService) map[string]models.ECSService {
+	byName := make(map[string]models.ECSService, len(services))
+	for _, service := range services {
+		byName[service.ServiceName] = service
+	}
+	return byName
+}
+
+// serviceHealth はサービスのヘルス状態を返す
+func serviceHealth(service models.ECSService) models.ServiceHealth {
+	return models.ServiceHealth{
+		Status:       service.Status,
+		DesiredCount: service.DesiredCount,
+		RunningCount: service.RunningCount,
+		Healthy:      service.IsHealthy(),
+	}
+}
@@ -0,0 +1,59 @@
+package differ_test
+
+import (
+	"testing"
+
+	"github.com/dev-shimada/phantom-ecs/internal/differ"
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetDiff(t *testing.T) {
+	onlyA, onlyB, both := differ.SetDiff(
+		[]string{"web", "api", "worker", "api"},
+		[]string{"api", "batch", "web"},
+	)
+
+	assert.Equal(t, []string{"worker"}, onlyA)
+	assert.Equal(t, []string{"batch"}, onlyB)
+	assert.Equal(t, []string{"api", "web"}, both)
+}
+
+func TestSetDiff_Empty(t *testing.T) {
+	onlyA, onlyB, both := differ.SetDiff(nil, nil)
+
+	assert.Empty(t, onlyA)
+	assert.Empty(t, onlyB)
+	assert.Empty(t, both)
+}
+
+func TestCompareClusterServices(t *testing.T) {
+	from := []models.ECSService{
+		{ServiceName: "web", Status: "ACTIVE", DesiredCount: 2, RunningCount: 2},
+		{ServiceName: "api", Status: "ACTIVE", DesiredCount: 2, RunningCount: 2},
+		{ServiceName: "legacy", Status: "ACTIVE", DesiredCount: 1, RunningCount: 1},
+	}
+	to := []models.ECSService{
+		{ServiceName: "web", Status: "ACTIVE", DesiredCount: 2, RunningCount: 2},
+		{ServiceName: "api", Status: "ACTIVE", DesiredCount: 2, RunningCount: 0},
+		{ServiceName: "worker", Status: "ACTIVE", DesiredCount: 1, RunningCount: 1},
+	}
+
+	diff := differ.CompareClusterServices("old", "new", from, to)
+
+	assert.Equal(t, "old", diff.FromCluster)
+	assert.Equal(t, "new", diff.ToCluster)
+	assert.Equal(t, []string{"worker"}, diff.Added)
+	assert.Equal(t, []string{"legacy"}, diff.Removed)
+	require.Len(t, diff.Common, 2)
+
+	assert.Equal(t, "api", diff.Common[0].ServiceName)
+	assert.True(t, diff.Common[0].HealthDiffers)
+	assert.True(t, diff.Common[0].From.Healthy)
+	assert.False(t, diff.Common[0].To.Healthy)
+	assert.Equal(t, int32(0), diff.Common[0].To.RunningCount)
+
+	assert.Equal(t, "web", diff.Common[1].ServiceName)
+	assert.False(t, diff.Common[1].HealthDiffers)
+}
@@ -0,0 +1,136 @@
+package differ
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+	"github.com/dev-shimada/phantom-ecs/internal/utils"
+)
+
+// ignoredTaskDefinitionFields は登録時にAWSが付与するため比較対象外とするフィールド
+var ignoredTaskDefinitionFields = []string{
+	"task_definition_arn",
+	"revision",
+	"status",
+}
+
+// Differ はタスク定義の差分検出を行う構造体
+type Differ struct {
+	formatter *utils.Formatter
+}
+
+// NewDiffer は新しいDifferインスタンスを作成
+func NewDiffer() *Differ {
+	return &Differ{
+		formatter: utils.NewFormatter(),
+	}
+}
+
+// ignoredInspectionResultFields は表示オプションや調査時の状況によって付与されるため調査結果の比較対象外とするフィールド
+var ignoredInspectionResultFields = []string{
+	"revision_comparison",
+	"container_filter",
+	"warnings",
+}
+
+// CompareTaskDefinitions は稼働中のタスク定義と期待するタスク定義を比較し、差分をフィールド名順に返す
+func (d *Differ) CompareTaskDefinitions(live, expected models.ECSTaskDefinition) ([]models.FieldDifference, error) {
+	liveFields, err := d.formatter.Flatten(live)
+	if err != nil {
+		return nil, fmt.Errorf("failed to flatten live task definition: %w", err)
+	}
+	expectedFields, err := d.formatter.Flatten(expected)
+	if err != nil {
+		return nil, fmt.Errorf("failed to flatten expected task definition: %w", err)
+	}
+
+	return compareFields(liveFields, expectedFields, ignoredTaskDefinitionFields), nil
+}
+
+// CompareInspectionResults は以前の調査結果と現在の調査結果を比較し、変更されたフィールドをフィールド名順に返す
+func (d *Differ) CompareInspectionResults(previous, current models.InspectionResult) ([]models.RevisionChange, error) {
+	previousFields, err := d.formatter.Flatten(previous)
+	if err != nil {
+		return nil, fmt.Errorf("failed to flatten previous inspection result: %w", err)
+	}
+	currentFields, err := d.formatter.Flatten(current)
+	if err != nil {
+		return nil, fmt.Errorf("failed to flatten current inspection result: %w", err)
+	}
+
+	differences := compareFields(previousFields, currentFields, ignoredInspectionResultFields)
+	changes := make([]models.RevisionChange, 0, len(differences))
+	for _, difference := range differences {
+		changes = append(changes, models.RevisionChange{
+			Field:    difference.Field,
+			Previous: difference.Live,
+			Current:  difference.Expected,
+		})
+	}
+	return changes, nil
+}
+
+// compareFields は平坦化した2つの値を比較し、値の異なるフィールドをフィールド名順に返す（Liveがa、Expectedがbの値）
+func compareFields(a, b map[string]interface{}, ignoredFields []string) []models.FieldDifference {
+	// 両方のキーを集めて比較
+	keys := make(map[string]struct{})
+	for key := range a {
+		keys[key] = struct{}{}
+	}
+	for key := range b {
+		keys[key] = struct{}{}
+	}
+
+	var differences []models.FieldDifference
+	for key := range keys {
+		if isIgnoredField(key, ignoredFields) {
+			continue
+		}
+
+		aValue := formatValue(a[key])
+		bValue := formatValue(b[key])
+		if aValue != bValue {
+			differences = append(differences, models.FieldDifference{
+				Field:    key,
+				Live:     aValue,
+				Expected: bValue,
+			})
+		}
+	}
+
+	sort.Slice(differences, func(i, j int) bool {
+		return differences[i].Field < differences[j].Field
+	})
+
+	return differences
+}
+
+// isIgnoredField は比較対象外のフィールドかどうかを判定
+func isIgnoredField(key string, ignoredFields []string) bool {
+	for _, ignored := range ignoredFields {
+		if key == ignored || strings.HasPrefix(key, ignored+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// formatValue は平坦化された値を比較用の文字列に変換
+// nullと空の配列・オブジェクトは同一視する
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []interface{}:
+		if len(v) == 0 {
+			return ""
+		}
+	case map[string]interface{}:
+		if len(v) == 0 {
+			return ""
+		}
+	}
+	return fmt.Sprintf("%v", value)
+}
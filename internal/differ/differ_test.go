@@ -0,0 +1,61 @@
+package differ_test
+
+import (
+	"testing"
+
+	"github.com/dev-shimada/phantom-ecs/internal/differ"
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func baseTaskDefinition() models.ECSTaskDefinition {
+	return models.ECSTaskDefinition{
+		TaskDefinitionArn: "arn:aws:ecs:us-east-1:123456789012:task-definition/web-task:3",
+		Family:            "web-task",
+		Revision:          3,
+		Status:            "ACTIVE",
+		CPU:               "256",
+		Memory:            "512",
+		NetworkMode:       "awsvpc",
+		Containers: []models.ContainerDefinition{
+			{
+				Name:         "app",
+				Image:        "nginx:1.25",
+				Essential:    true,
+				PortMappings: []models.PortMapping{{ContainerPort: 80, Protocol: "tcp"}},
+			},
+		},
+	}
+}
+
+func TestDiffer_CompareTaskDefinitions_Matching(t *testing.T) {
+	live := baseTaskDefinition()
+
+	// ARN・リビジョン・ステータスが異なっても差分とみなさない
+	expected := baseTaskDefinition()
+	expected.TaskDefinitionArn = ""
+	expected.Revision = 0
+	expected.Status = ""
+
+	differences, err := differ.NewDiffer().CompareTaskDefinitions(live, expected)
+
+	require.NoError(t, err)
+	assert.Empty(t, differences)
+}
+
+func TestDiffer_CompareTaskDefinitions_Drifted(t *testing.T) {
+	live := baseTaskDefinition()
+
+	expected := baseTaskDefinition()
+	expected.Memory = "1024"
+	expected.Containers[0].Image = "nginx:1.27"
+
+	differences, err := differ.NewDiffer().CompareTaskDefinitions(live, expected)
+
+	require.NoError(t, err)
+	assert.Equal(t, []models.FieldDifference{
+		{Field: "containers.0.image", Live: "nginx:1.25", Expected: "nginx:1.27"},
+		{Field: "memory", Live: "512", Expected: "1024"},
+	}, differences)
+}
@@ -0,0 +1,113 @@
+package errors
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+
+	"github.com/aws/smithy-go"
+)
+
+// transientErrorCodes は一時的な障害として再試行可能なAWS APIのエラーコード
+var transientErrorCodes = map[string]struct{}{
+	"Throttling":                  {},
+	"ThrottlingException":         {},
+	"TooManyRequestsException":    {},
+	"RequestLimitExceeded":        {},
+	"RequestTimeout":              {},
+	"RequestTimeoutException":     {},
+	"ServerException":             {},
+	"ServiceUnavailable":          {},
+	"ServiceUnavailableException": {},
+	"InternalFailure":             {},
+}
+
+// accessDeniedErrorCodes はIAM権限の不足を示すAWS APIのエラーコード
+var accessDeniedErrorCodes = map[string]struct{}{
+	"AccessDenied":          {},
+	"AccessDeniedException": {},
+	"UnauthorizedOperation": {},
+}
+
+// deniedActionPattern はエラーメッセージから拒否されたアクション名（例: ecs:DescribeServices）を抽出する
+var deniedActionPattern = regexp.MustCompile(`perform: ([A-Za-z0-9-]+:[A-Za-z0-9*]+)`)
+
+// ClassifyAWSError はAWS呼び出しで発生したエラーをエラータイプに分類する
+// スロットリングやタイムアウトなど再試行で回復し得るエラーはErrTypeNetworkに分類される
+func ClassifyAWSError(err error) ErrorType {
+	if err == nil {
+		return ErrTypeGeneral
+	}
+
+	var phantomErr *PhantomError
+	if stderrors.As(err, &phantomErr) {
+		return phantomErr.Type
+	}
+
+	// 呼び出し元によるキャンセルは再試行しない
+	if stderrors.Is(err, context.Canceled) {
+		return ErrTypeGeneral
+	}
+	if stderrors.Is(err, context.DeadlineExceeded) {
+		return ErrTypeNetwork
+	}
+
+	var apiErr smithy.APIError
+	if stderrors.As(err, &apiErr) {
+		if _, ok := transientErrorCodes[apiErr.ErrorCode()]; ok {
+			return ErrTypeNetwork
+		}
+		return ErrTypeAWS
+	}
+
+	var netErr net.Error
+	if stderrors.As(err, &netErr) {
+		return ErrTypeNetwork
+	}
+
+	return ErrTypeGeneral
+}
+
+// IsTransient はエラーが再試行で回復し得る一時的なものかどうかを判定する
+func IsTransient(err error) bool {
+	return err != nil && ClassifyAWSError(err) == ErrTypeNetwork
+}
+
+// IsAccessDenied はエラーがIAM権限の不足によるものかどうかを判定する
+func IsAccessDenied(err error) bool {
+	var apiErr smithy.APIError
+	if !stderrors.As(err, &apiErr) {
+		return false
+	}
+	_, ok := accessDeniedErrorCodes[apiErr.ErrorCode()]
+	return ok
+}
+
+// NewAccessDeniedError は拒否されたアクションと必要なIAM権限を示すAWS関連のエラーを作成する
+func NewAccessDeniedError(action string, cause error) *PhantomError {
+	message := fmt.Sprintf("権限が不足しています: %s の実行が拒否されました。IAMポリシーで %q を許可してください", action, action)
+	return NewAWSError(message, cause)
+}
+
+// WrapAccessDenied は権限不足のエラーを分かりやすいエラーに変換する（それ以外のエラーはそのまま返す）
+func WrapAccessDenied(err error) error {
+	if err == nil || !IsAccessDenied(err) {
+		return err
+	}
+	return NewAccessDeniedError(deniedAction(err), err)
+}
+
+// deniedAction は拒否されたAPI呼び出しのIAMアクション名を特定する
+func deniedAction(err error) string {
+	var opErr *smithy.OperationError
+	if stderrors.As(err, &opErr) && opErr.ServiceID != "" && opErr.OperationName != "" {
+		return strings.ToLower(opErr.ServiceID) + ":" + opErr.OperationName
+	}
+	if match := deniedActionPattern.FindStringSubmatch(err.Error()); match != nil {
+		return match[1]
+	}
+	return "unknown action"
+}
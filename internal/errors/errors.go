@@ -35,6 +35,11 @@ func (e *PhantomError) Error() string {
 	return e.Message
 }
 
+// Unwrap は原因となったエラーを返す（errors.Is・errors.Asで原因のエラーを判定できるようにする）
+func (e *PhantomError) Unwrap() error {
+	return e.Cause
+}
+
 // GetExitCode はエラータイプに基づいて適切な終了コードを返す
 func (e *PhantomError) GetExitCode() int {
 	switch e.Type {
@@ -1,12 +1,17 @@
 package errors_test
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"net"
 	"testing"
 
+	"github.com/aws/smithy-go"
 	phantomecs_errors "github.com/dev-shimada/phantom-ecs/internal/errors"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewPhantomError(t *testing.T) {
@@ -134,3 +139,88 @@ func TestErrorChaining(t *testing.T) {
 	assert.Contains(t, err2.Error(), "network error")
 	assert.Contains(t, err2.Error(), "root cause")
 }
+
+func TestClassifyAWSError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected phantomecs_errors.ErrorType
+	}{
+		{name: "スロットリング", err: &smithy.GenericAPIError{Code: "ThrottlingException"}, expected: phantomecs_errors.ErrTypeNetwork},
+		{name: "サービス利用不可", err: &smithy.GenericAPIError{Code: "ServiceUnavailableException"}, expected: phantomecs_errors.ErrTypeNetwork},
+		{name: "権限不足", err: &smithy.GenericAPIError{Code: "AccessDeniedException"}, expected: phantomecs_errors.ErrTypeAWS},
+		{name: "タイムアウト", err: context.DeadlineExceeded, expected: phantomecs_errors.ErrTypeNetwork},
+		{name: "キャンセル", err: context.Canceled, expected: phantomecs_errors.ErrTypeGeneral},
+		{name: "ネットワークエラー", err: &net.OpError{Op: "dial", Err: fmt.Errorf("connection refused")}, expected: phantomecs_errors.ErrTypeNetwork},
+		{name: "PhantomError", err: phantomecs_errors.NewValidationError("invalid", nil), expected: phantomecs_errors.ErrTypeValidation},
+		{name: "ラップされたAPIエラー", err: fmt.Errorf("describe failed: %w", &smithy.GenericAPIError{Code: "Throttling"}), expected: phantomecs_errors.ErrTypeNetwork},
+		{name: "その他", err: fmt.Errorf("unknown"), expected: phantomecs_errors.ErrTypeGeneral},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, phantomecs_errors.ClassifyAWSError(tt.err))
+		})
+	}
+}
+
+func TestWrapAccessDenied(t *testing.T) {
+	t.Run("OperationErrorからアクション名を特定", func(t *testing.T) {
+		denied := &smithy.OperationError{
+			ServiceID:     "ECS",
+			OperationName: "DescribeServices",
+			Err:           &smithy.GenericAPIError{Code: "AccessDeniedException", Message: "User is not authorized"},
+		}
+
+		err := phantomecs_errors.WrapAccessDenied(fmt.Errorf("failed to inspect service: %w", denied))
+
+		var phantomErr *phantomecs_errors.PhantomError
+		require.True(t, errors.As(err, &phantomErr))
+		assert.Equal(t, phantomecs_errors.ErrTypeAWS, phantomErr.Type)
+		assert.Contains(t, phantomErr.Message, "ecs:DescribeServices")
+		assert.Equal(t, phantomecs_errors.ErrTypeAWS, phantomecs_errors.ClassifyAWSError(err))
+	})
+
+	t.Run("エラーメッセージからアクション名を特定", func(t *testing.T) {
+		denied := &smithy.GenericAPIError{
+			Code:    "UnauthorizedOperation",
+			Message: "User: arn:aws:iam::123456789012:user/dev is not authorized to perform: ecs:ListClusters",
+		}
+
+		err := phantomecs_errors.WrapAccessDenied(denied)
+
+		assert.True(t, phantomecs_errors.IsPhantomError(err))
+		assert.Contains(t, err.Error(), `IAMポリシーで "ecs:ListClusters" を許可してください`)
+	})
+
+	t.Run("権限不足以外はそのまま返す", func(t *testing.T) {
+		throttled := &smithy.GenericAPIError{Code: "ThrottlingException"}
+
+		assert.Same(t, throttled, phantomecs_errors.WrapAccessDenied(throttled))
+		assert.NoError(t, phantomecs_errors.WrapAccessDenied(nil))
+	})
+}
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"成功", nil, phantomecs_errors.ExitCodeSuccess},
+		{"一般的なエラー", errors.New("boom"), phantomecs_errors.ExitCodeError},
+		{"バリデーションエラー", phantomecs_errors.NewValidationError("invalid", nil), 3},
+		{"ラップされたバリデーションエラー", fmt.Errorf("gate: %w", phantomecs_errors.NewValidationError("invalid", nil)), 3},
+		{"AWSエラー", phantomecs_errors.NewAWSError("denied", nil), 2},
+		{"設定エラー", phantomecs_errors.NewConfigError("bad config", nil), 1},
+		{"一部失敗", phantomecs_errors.NewBatchFailureError(3, 1), phantomecs_errors.ExitCodePartialFailure},
+		{"全て失敗", phantomecs_errors.NewBatchFailureError(3, 3), phantomecs_errors.ExitCodeTotalFailure},
+		{"ラップされたバッチエラー", fmt.Errorf("batch: %w", phantomecs_errors.NewBatchFailureError(2, 2)), phantomecs_errors.ExitCodeTotalFailure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, phantomecs_errors.ExitCode(tt.err))
+		})
+	}
+}
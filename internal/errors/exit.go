@@ -0,0 +1,65 @@
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+)
+
+// コマンドの終了コード
+//
+//	0: 成功
+//	1: エラー（下記以外）
+//	2-5: PhantomErrorのエラータイプに対応する終了コード（PhantomError.GetExitCodeを参照）
+//	6: バッチ処理で一部のサービスが失敗
+//	7: バッチ処理で全てのサービスが失敗
+const (
+	ExitCodeSuccess        = 0
+	ExitCodeError          = 1
+	ExitCodePartialFailure = 6
+	ExitCodeTotalFailure   = 7
+)
+
+// BatchFailureError はバッチ処理で失敗したサービスがあったことを表すエラー
+type BatchFailureError struct {
+	TotalServices int
+	FailedCount   int
+}
+
+// NewBatchFailureError は新しいBatchFailureErrorを作成する
+func NewBatchFailureError(totalServices, failedCount int) *BatchFailureError {
+	return &BatchFailureError{
+		TotalServices: totalServices,
+		FailedCount:   failedCount,
+	}
+}
+
+// Error は error インターフェースの実装
+func (e *BatchFailureError) Error() string {
+	return fmt.Sprintf("バッチ処理で%d/%dサービスが失敗しました", e.FailedCount, e.TotalServices)
+}
+
+// GetExitCode は全てのサービスが失敗した場合と一部が失敗した場合で異なる終了コードを返す
+func (e *BatchFailureError) GetExitCode() int {
+	if e.FailedCount >= e.TotalServices {
+		return ExitCodeTotalFailure
+	}
+	return ExitCodePartialFailure
+}
+
+// ExitCode はコマンドのエラーに対応する終了コードを返す
+// バッチ処理の失敗はBatchFailureError、PhantomErrorはエラータイプに対応する終了コードとし、
+// それ以外のエラーは ExitCodeError とする
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitCodeSuccess
+	}
+	var batchErr *BatchFailureError
+	if stderrors.As(err, &batchErr) {
+		return batchErr.GetExitCode()
+	}
+	var phantomErr *PhantomError
+	if stderrors.As(err, &phantomErr) {
+		return phantomErr.GetExitCode()
+	}
+	return ExitCodeError
+}
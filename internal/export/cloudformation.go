@@ -0,0 +1,155 @@
+package export
+
+import (
+	"strings"
+
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// cfnTemplate はCloudFormationテンプレートのルート
+type cfnTemplate struct {
+	Resources map[string]cfnResource `yaml:"Resources"`
+}
+
+// cfnResource はCloudFormationのリソース定義
+type cfnResource struct {
+	Type       string      `yaml:"Type"`
+	Properties interface{} `yaml:"Properties"`
+}
+
+// cfnTaskDefinition はAWS::ECS::TaskDefinitionのプロパティ
+type cfnTaskDefinition struct {
+	Family                  string         `yaml:"Family"`
+	Cpu                     string         `yaml:"Cpu,omitempty"`
+	Memory                  string         `yaml:"Memory,omitempty"`
+	NetworkMode             string         `yaml:"NetworkMode,omitempty"`
+	RequiresCompatibilities []string       `yaml:"RequiresCompatibilities,omitempty"`
+	ContainerDefinitions    []cfnContainer `yaml:"ContainerDefinitions"`
+	Volumes                 []cfnVolume    `yaml:"Volumes,omitempty"`
+}
+
+// cfnContainer はコンテナ定義のプロパティ
+type cfnContainer struct {
+	Name         string           `yaml:"Name"`
+	Image        string           `yaml:"Image"`
+	Essential    bool             `yaml:"Essential"`
+	PortMappings []cfnPortMapping `yaml:"PortMappings,omitempty"`
+}
+
+// cfnPortMapping はポートマッピングのプロパティ
+type cfnPortMapping struct {
+	ContainerPort int32  `yaml:"ContainerPort"`
+	HostPort      int32  `yaml:"HostPort,omitempty"`
+	Protocol      string `yaml:"Protocol,omitempty"`
+}
+
+// cfnVolume はタスクレベルのボリュームのプロパティ
+type cfnVolume struct {
+	Name                   string            `yaml:"Name"`
+	Host                   map[string]string `yaml:"Host,omitempty"`
+	EFSVolumeConfiguration map[string]string `yaml:"EFSVolumeConfiguration,omitempty"`
+}
+
+// cfnService はAWS::ECS::Serviceのプロパティ
+type cfnService struct {
+	ServiceName          string                   `yaml:"ServiceName"`
+	Cluster              string                   `yaml:"Cluster"`
+	TaskDefinition       map[string]string        `yaml:"TaskDefinition"`
+	DesiredCount         int32                    `yaml:"DesiredCount"`
+	LaunchType           string                   `yaml:"LaunchType,omitempty"`
+	NetworkConfiguration *cfnNetworkConfiguration `yaml:"NetworkConfiguration,omitempty"`
+}
+
+// cfnNetworkConfiguration はサービスのネットワーク設定
+type cfnNetworkConfiguration struct {
+	AwsvpcConfiguration cfnAwsvpcConfiguration `yaml:"AwsvpcConfiguration"`
+}
+
+// cfnAwsvpcConfiguration はawsvpcモードのネットワーク設定
+type cfnAwsvpcConfiguration struct {
+	Subnets        []string `yaml:"Subnets"`
+	SecurityGroups []string `yaml:"SecurityGroups,omitempty"`
+	AssignPublicIp string   `yaml:"AssignPublicIp"`
+}
+
+// renderCloudFormation はインスペクション結果をCloudFormationのYAMLに変換
+func (e *Exporter) renderCloudFormation(result models.InspectionResult) (string, error) {
+	baseID := logicalID(result.Service.ServiceName)
+	taskDefID := baseID + "TaskDefinition"
+	serviceID := baseID + "Service"
+
+	taskDef := cfnTaskDefinition{
+		Family:                  result.TaskDefinition.Family,
+		Cpu:                     result.TaskDefinition.CPU,
+		Memory:                  result.TaskDefinition.Memory,
+		NetworkMode:             result.TaskDefinition.NetworkMode,
+		RequiresCompatibilities: result.TaskDefinition.RequiresAttributes,
+	}
+	for _, container := range result.TaskDefinition.Containers {
+		cfnC := cfnContainer{
+			Name:      container.Name,
+			Image:     container.Image,
+			Essential: container.Essential,
+		}
+		for _, pm := range container.PortMappings {
+			cfnC.PortMappings = append(cfnC.PortMappings, cfnPortMapping{
+				ContainerPort: pm.ContainerPort,
+				HostPort:      pm.HostPort,
+				Protocol:      pm.Protocol,
+			})
+		}
+		taskDef.ContainerDefinitions = append(taskDef.ContainerDefinitions, cfnC)
+	}
+	for _, volume := range result.TaskDefinition.Volumes {
+		cfnV := cfnVolume{Name: volume.Name}
+		if volume.HostSourcePath != "" {
+			cfnV.Host = map[string]string{"SourcePath": volume.HostSourcePath}
+		}
+		if volume.EFSFileSystemID != "" {
+			cfnV.EFSVolumeConfiguration = map[string]string{"FilesystemId": volume.EFSFileSystemID}
+			if volume.EFSRootDirectory != "" {
+				cfnV.EFSVolumeConfiguration["RootDirectory"] = volume.EFSRootDirectory
+			}
+		}
+		taskDef.Volumes = append(taskDef.Volumes, cfnV)
+	}
+
+	service := cfnService{
+		ServiceName:    result.Service.ServiceName,
+		Cluster:        result.Service.ClusterName,
+		TaskDefinition: map[string]string{"Ref": taskDefID},
+		DesiredCount:   result.Service.DesiredCount,
+		LaunchType:     result.Service.LaunchType,
+	}
+	if result.NetworkConfig != nil {
+		assignPublicIP := "DISABLED"
+		if result.NetworkConfig.AssignPublicIP {
+			assignPublicIP = "ENABLED"
+		}
+		service.NetworkConfiguration = &cfnNetworkConfiguration{
+			AwsvpcConfiguration: cfnAwsvpcConfiguration{
+				Subnets:        result.NetworkConfig.Subnets,
+				SecurityGroups: result.NetworkConfig.SecurityGroups,
+				AssignPublicIp: assignPublicIP,
+			},
+		}
+	}
+
+	var builder strings.Builder
+	encoder := yaml.NewEncoder(&builder)
+	encoder.SetIndent(2)
+	err := encoder.Encode(cfnTemplate{
+		Resources: map[string]cfnResource{
+			taskDefID: {Type: "AWS::ECS::TaskDefinition", Properties: taskDef},
+			serviceID: {Type: "AWS::ECS::Service", Properties: service},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+	return builder.String(), nil
+}
@@ -0,0 +1,84 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+)
+
+const (
+	// FormatTerraform はTerraformのHCL形式
+	FormatTerraform = "terraform"
+	// FormatCloudFormation はCloudFormationのYAML形式
+	FormatCloudFormation = "cloudformation"
+)
+
+// Exporter はインスペクション結果をIaCのスニペットに変換する構造体
+type Exporter struct{}
+
+// NewExporter は新しいExporterインスタンスを作成
+func NewExporter() *Exporter {
+	return &Exporter{}
+}
+
+// Export はインスペクション結果を指定された形式のスニペットに変換
+func (e *Exporter) Export(result models.InspectionResult, format string) (string, error) {
+	switch format {
+	case FormatTerraform:
+		return e.renderTerraform(result)
+	case FormatCloudFormation:
+		return e.renderCloudFormation(result)
+	default:
+		return "", fmt.Errorf("unsupported export format: %s. Supported formats: %v", format, e.GetSupportedFormats())
+	}
+}
+
+// GetSupportedFormats はサポートされているエクスポート形式を返す
+func (e *Exporter) GetSupportedFormats() []string {
+	return []string{FormatTerraform, FormatCloudFormation}
+}
+
+// ValidateFormat はエクスポート形式が有効かどうかを判定
+func (e *Exporter) ValidateFormat(format string) bool {
+	for _, supported := range e.GetSupportedFormats() {
+		if format == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// terraformIdentifier はTerraformのリソース名として使える識別子に変換 (例: web-service → web_service)
+func terraformIdentifier(name string) string {
+	identifier := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+
+	if identifier == "" || unicode.IsDigit(rune(identifier[0])) {
+		identifier = "_" + identifier
+	}
+	return identifier
+}
+
+// logicalID はCloudFormationの論理IDとして使える識別子に変換 (例: web-service → WebService)
+func logicalID(name string) string {
+	var builder strings.Builder
+	upperNext := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upperNext = true
+			continue
+		}
+		if upperNext {
+			r = unicode.ToUpper(r)
+			upperNext = false
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
@@ -0,0 +1,125 @@
+package export
+
+import (
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+)
+
+// terraformTemplate はaws_ecs_task_definitionとaws_ecs_serviceリソースのテンプレート
+const terraformTemplate = `resource "aws_ecs_task_definition" "{{ .ID }}" {
+  family                   = {{ quote .TaskDefinition.Family }}
+{{- if .TaskDefinition.CPU }}
+  cpu                      = {{ quote .TaskDefinition.CPU }}
+{{- end }}
+{{- if .TaskDefinition.Memory }}
+  memory                   = {{ quote .TaskDefinition.Memory }}
+{{- end }}
+{{- if .TaskDefinition.NetworkMode }}
+  network_mode             = {{ quote .TaskDefinition.NetworkMode }}
+{{- end }}
+{{- if .TaskDefinition.RequiresAttributes }}
+  requires_compatibilities = {{ list .TaskDefinition.RequiresAttributes }}
+{{- end }}
+
+  container_definitions = jsonencode([
+{{- range .TaskDefinition.Containers }}
+    {
+      name      = {{ quote .Name }}
+      image     = {{ quote .Image }}
+      essential = {{ .Essential }}
+{{- if .PortMappings }}
+      portMappings = [
+{{- range .PortMappings }}
+        {
+          containerPort = {{ .ContainerPort }}
+{{- if .HostPort }}
+          hostPort      = {{ .HostPort }}
+{{- end }}
+{{- if .Protocol }}
+          protocol      = {{ quote .Protocol }}
+{{- end }}
+        },
+{{- end }}
+      ]
+{{- end }}
+    },
+{{- end }}
+  ])
+{{- range .TaskDefinition.Volumes }}
+
+  volume {
+    name = {{ quote .Name }}
+{{- if .HostSourcePath }}
+    host_path = {{ quote .HostSourcePath }}
+{{- end }}
+{{- if .EFSFileSystemID }}
+
+    efs_volume_configuration {
+      file_system_id = {{ quote .EFSFileSystemID }}
+{{- if .EFSRootDirectory }}
+      root_directory = {{ quote .EFSRootDirectory }}
+{{- end }}
+    }
+{{- end }}
+  }
+{{- end }}
+}
+
+resource "aws_ecs_service" "{{ .ID }}" {
+  name            = {{ quote .Service.ServiceName }}
+  cluster         = {{ quote .Service.ClusterName }}
+  task_definition = aws_ecs_task_definition.{{ .ID }}.arn
+  desired_count   = {{ .Service.DesiredCount }}
+{{- if .Service.LaunchType }}
+  launch_type     = {{ quote .Service.LaunchType }}
+{{- end }}
+{{- with .NetworkConfig }}
+
+  network_configuration {
+    subnets          = {{ list .Subnets }}
+    security_groups  = {{ list .SecurityGroups }}
+    assign_public_ip = {{ .AssignPublicIP }}
+  }
+{{- end }}
+}
+`
+
+var terraformTmpl = template.Must(template.New("terraform").Funcs(template.FuncMap{
+	"quote": strconv.Quote,
+	"list":  terraformList,
+}).Parse(terraformTemplate))
+
+// terraformData はTerraformテンプレートに渡すデータ
+type terraformData struct {
+	ID             string
+	Service        models.ECSService
+	TaskDefinition models.ECSTaskDefinition
+	NetworkConfig  *models.NetworkConfig
+}
+
+// renderTerraform はインスペクション結果をTerraformのリソース定義に変換
+func (e *Exporter) renderTerraform(result models.InspectionResult) (string, error) {
+	var builder strings.Builder
+	err := terraformTmpl.Execute(&builder, terraformData{
+		ID:             terraformIdentifier(result.Service.ServiceName),
+		Service:        result.Service,
+		TaskDefinition: result.TaskDefinition,
+		NetworkConfig:  result.NetworkConfig,
+	})
+	if err != nil {
+		return "", err
+	}
+	return builder.String(), nil
+}
+
+// terraformList は文字列のスライスをHCLのリスト表記に変換
+func terraformList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = strconv.Quote(value)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
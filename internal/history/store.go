@@ -0,0 +1,207 @@
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dev-shimada/phantom-ecs/internal/differ"
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+)
+
+// captureIDLayout は調査結果のIDとファイル名に使う取得時刻（UTC）の形式で、辞書順が時刻順になる
+const captureIDLayout = "20060102T150405.000Z"
+
+// captureExt は保存する調査結果のファイルの拡張子
+const captureExt = ".json"
+
+// ErrNotEnoughCaptures は差分を求めるのに必要な件数の調査結果が保存されていない場合に返すエラー
+var ErrNotEnoughCaptures = errors.New("at least two captures are required to diff")
+
+// Store はサービス調査結果を <ディレクトリ>/<クラスター名>/<サービス名>/<取得時刻>.json に保存する履歴ストア
+type Store struct {
+	dir string
+}
+
+// NewStore は指定されたディレクトリに保存する履歴ストアを作成
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Save は調査結果をcapturedAtの時刻で保存し、保存した調査結果の情報を返す
+func (s *Store) Save(result models.InspectionResult, capturedAt time.Time) (*models.HistoryCapture, error) {
+	serviceDir, err := s.serviceDir(result.Service.ClusterName, result.Service.ServiceName)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(serviceDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode inspection result: %w", err)
+	}
+
+	id := capturedAt.UTC().Format(captureIDLayout)
+	path := filepath.Join(serviceDir, id+captureExt)
+	// 同じ時刻の調査結果を上書きしないよう、既存のファイルがあればエラーにする
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create history file: %w", err)
+	}
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to write history file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write history file: %w", err)
+	}
+
+	return &models.HistoryCapture{
+		ID:          id,
+		ServiceName: result.Service.ServiceName,
+		ClusterName: result.Service.ClusterName,
+		CapturedAt:  capturedAt.UTC().Truncate(time.Millisecond),
+		Path:        path,
+	}, nil
+}
+
+// List はサービスの保存済みの調査結果を古い順に返す（保存されていない場合は空）
+func (s *Store) List(clusterName, serviceName string) ([]models.HistoryCapture, error) {
+	serviceDir, err := s.serviceDir(clusterName, serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(serviceDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return []models.HistoryCapture{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history directory: %w", err)
+	}
+
+	captures := []models.HistoryCapture{}
+	for _, entry := range entries {
+		id, ok := strings.CutSuffix(entry.Name(), captureExt)
+		if entry.IsDir() || !ok {
+			continue
+		}
+		// 形式の異なるファイルは保存した調査結果ではないため無視する
+		capturedAt, err := time.Parse(captureIDLayout, id)
+		if err != nil {
+			continue
+		}
+		captures = append(captures, models.HistoryCapture{
+			ID:          id,
+			ServiceName: serviceName,
+			ClusterName: clusterName,
+			CapturedAt:  capturedAt,
+			Path:        filepath.Join(serviceDir, entry.Name()),
+		})
+	}
+
+	sort.Slice(captures, func(i, j int) bool {
+		return captures[i].ID < captures[j].ID
+	})
+	return captures, nil
+}
+
+// Load は保存した調査結果を読み込む
+func (s *Store) Load(capture models.HistoryCapture) (*models.InspectionResult, error) {
+	data, err := os.ReadFile(capture.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history file: %w", err)
+	}
+
+	var result models.InspectionResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse history file %s: %w", capture.Path, err)
+	}
+	return &result, nil
+}
+
+// Diff はサービスの保存済みの調査結果のうちfromIDとtoIDの2件を比較する
+// IDが空の場合、fromIDは最新の1つ前、toIDは最新の調査結果を使用する
+func (s *Store) Diff(clusterName, serviceName, fromID, toID string) (*models.HistoryDiff, error) {
+	captures, err := s.List(clusterName, serviceName)
+	if err != nil {
+		return nil, err
+	}
+	if len(captures) < 2 && (fromID == "" || toID == "") {
+		return nil, fmt.Errorf("%w: found %d for service %s in cluster %s", ErrNotEnoughCaptures, len(captures), serviceName, clusterName)
+	}
+	if fromID == "" {
+		fromID = captures[len(captures)-2].ID
+	}
+	if toID == "" {
+		toID = captures[len(captures)-1].ID
+	}
+
+	from, err := findCapture(captures, fromID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := findCapture(captures, toID)
+	if err != nil {
+		return nil, err
+	}
+
+	fromResult, err := s.Load(from)
+	if err != nil {
+		return nil, err
+	}
+	toResult, err := s.Load(to)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := differ.NewDiffer().CompareInspectionResults(*fromResult, *toResult)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare captures: %w", err)
+	}
+
+	return &models.HistoryDiff{
+		ServiceName: serviceName,
+		ClusterName: clusterName,
+		From:        from,
+		To:          to,
+		Changes:     changes,
+	}, nil
+}
+
+// serviceDir はサービスの調査結果を保存するディレクトリを返す
+// クラスター名・サービス名がパスとして解釈されて履歴ディレクトリの外を指さないよう検証する
+func (s *Store) serviceDir(clusterName, serviceName string) (string, error) {
+	if s.dir == "" {
+		return "", fmt.Errorf("history directory is required")
+	}
+	for _, name := range []struct {
+		kind  string
+		value string
+	}{
+		{kind: "cluster name", value: clusterName},
+		{kind: "service name", value: serviceName},
+	} {
+		if name.value == "" || name.value == "." || name.value == ".." || strings.ContainsAny(name.value, `/\`) {
+			return "", fmt.Errorf("invalid %s for history: %q", name.kind, name.value)
+		}
+	}
+	return filepath.Join(s.dir, clusterName, serviceName), nil
+}
+
+// findCapture はIDに一致する調査結果を返す
+func findCapture(captures []models.HistoryCapture, id string) (models.HistoryCapture, error) {
+	for _, capture := range captures {
+		if capture.ID == id {
+			return capture, nil
+		}
+	}
+	return models.HistoryCapture{}, fmt.Errorf("capture not found: %s", id)
+}
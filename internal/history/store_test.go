@@ -0,0 +1,139 @@
+package history_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dev-shimada/phantom-ecs/internal/history"
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newInspectionResult(image string, desired int32) models.InspectionResult {
+	return models.InspectionResult{
+		Service: models.ECSService{
+			ServiceName:  "web-service",
+			ClusterName:  "prod",
+			Status:       "ACTIVE",
+			DesiredCount: desired,
+		},
+		TaskDefinition: models.ECSTaskDefinition{
+			Family: "web-task",
+			Containers: []models.ContainerDefinition{
+				{Name: "web", Image: image},
+			},
+		},
+	}
+}
+
+func TestStore_SaveAndDiff(t *testing.T) {
+	dir := t.TempDir()
+	store := history.NewStore(dir)
+	first := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	second := first.Add(24 * time.Hour)
+
+	saved, err := store.Save(newInspectionResult("nginx:1.26", 2), first)
+	require.NoError(t, err)
+	assert.Equal(t, "20240101T090000.000Z", saved.ID)
+	assert.Equal(t, filepath.Join(dir, "prod", "web-service", "20240101T090000.000Z.json"), saved.Path)
+	_, err = store.Save(newInspectionResult("nginx:1.27", 3), second)
+	require.NoError(t, err)
+
+	captures, err := store.List("prod", "web-service")
+	require.NoError(t, err)
+	require.Len(t, captures, 2)
+	assert.Equal(t, "20240101T090000.000Z", captures[0].ID)
+	assert.Equal(t, first, captures[0].CapturedAt)
+	assert.Equal(t, "20240102T090000.000Z", captures[1].ID)
+
+	loaded, err := store.Load(captures[0])
+	require.NoError(t, err)
+	assert.Equal(t, "nginx:1.26", loaded.TaskDefinition.Containers[0].Image)
+
+	// ID未指定の場合は最新の2件を比較
+	diff, err := store.Diff("prod", "web-service", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, captures[0], diff.From)
+	assert.Equal(t, captures[1], diff.To)
+	assert.Equal(t, []models.RevisionChange{
+		{Field: "service.desired_count", Previous: "2", Current: "3"},
+		{Field: "task_definition.containers.0.image", Previous: "nginx:1.26", Current: "nginx:1.27"},
+	}, diff.Changes)
+
+	// IDを指定すると逆方向にも比較できる
+	diff, err = store.Diff("prod", "web-service", captures[1].ID, captures[0].ID)
+	require.NoError(t, err)
+	require.Len(t, diff.Changes, 2)
+	assert.Equal(t, "3", diff.Changes[0].Previous)
+
+	// 同じ内容であれば差分なし
+	diff, err = store.Diff("prod", "web-service", captures[0].ID, captures[0].ID)
+	require.NoError(t, err)
+	assert.False(t, diff.HasChanges())
+}
+
+func TestStore_List_Empty(t *testing.T) {
+	store := history.NewStore(t.TempDir())
+
+	captures, err := store.List("prod", "web-service")
+
+	require.NoError(t, err)
+	assert.Empty(t, captures)
+}
+
+func TestStore_List_IgnoresUnknownFiles(t *testing.T) {
+	dir := t.TempDir()
+	store := history.NewStore(dir)
+	_, err := store.Save(newInspectionResult("nginx:1.27", 2), time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "prod", "web-service", "notes.json"), []byte("{}"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "prod", "web-service", "README"), []byte(""), 0o644))
+
+	captures, err := store.List("prod", "web-service")
+
+	require.NoError(t, err)
+	require.Len(t, captures, 1)
+	assert.Equal(t, "20240101T090000.000Z", captures[0].ID)
+}
+
+func TestStore_Save_SameTimestamp(t *testing.T) {
+	store := history.NewStore(t.TempDir())
+	capturedAt := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	_, err := store.Save(newInspectionResult("nginx:1.27", 2), capturedAt)
+	require.NoError(t, err)
+	_, err = store.Save(newInspectionResult("nginx:1.27", 2), capturedAt)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to create history file")
+}
+
+func TestStore_Diff_Errors(t *testing.T) {
+	store := history.NewStore(t.TempDir())
+	_, err := store.Save(newInspectionResult("nginx:1.27", 2), time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	_, err = store.Diff("prod", "web-service", "", "")
+	require.ErrorIs(t, err, history.ErrNotEnoughCaptures)
+
+	_, err = store.Diff("prod", "web-service", "20240101T090000.000Z", "20231231T090000.000Z")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "capture not found: 20231231T090000.000Z")
+}
+
+func TestStore_InvalidNames(t *testing.T) {
+	store := history.NewStore(t.TempDir())
+
+	for _, name := range []string{"", "..", "../other", `a\b`} {
+		_, err := store.List("prod", name)
+		require.Error(t, err, name)
+		assert.Contains(t, err.Error(), "invalid service name for history")
+	}
+
+	_, err := history.NewStore("").List("prod", "web-service")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "history directory is required")
+}
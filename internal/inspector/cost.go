@@ -0,0 +1,61 @@
+package inspector
+
+import (
+	"strconv"
+
+	"github.com/dev-shimada/phantom-ecs/internal/arn"
+)
+
+// hoursPerMonth は月額換算に用いる1ヶ月あたりの時間
+const hoursPerMonth = 730
+
+// fargatePrice はFargateのリージョン別の時間単価 (USD、Linux/x86オンデマンド)
+type fargatePrice struct {
+	perVCPUHour float64
+	perGBHour   float64
+}
+
+// fargatePrices は組み込みのFargate価格表 (概算用)
+var fargatePrices = map[string]fargatePrice{
+	"us-east-1":      {perVCPUHour: 0.04048, perGBHour: 0.004445},
+	"us-east-2":      {perVCPUHour: 0.04048, perGBHour: 0.004445},
+	"us-west-1":      {perVCPUHour: 0.04656, perGBHour: 0.00511},
+	"us-west-2":      {perVCPUHour: 0.04048, perGBHour: 0.004445},
+	"eu-west-1":      {perVCPUHour: 0.04048, perGBHour: 0.004445},
+	"eu-central-1":   {perVCPUHour: 0.04656, perGBHour: 0.00511},
+	"ap-northeast-1": {perVCPUHour: 0.05056, perGBHour: 0.00553},
+	"ap-southeast-1": {perVCPUHour: 0.05056, perGBHour: 0.00553},
+}
+
+// EstimateFargateCost はCPUユニット、メモリ(MiB)、タスク数からFargateの月額コストを概算する
+// 価格表にないリージョンや解析できない値の場合は0を返す
+func EstimateFargateCost(cpu, memory string, count int32, region string) float64 {
+	price, ok := fargatePrices[region]
+	if !ok {
+		return 0
+	}
+
+	cpuUnits, err := strconv.ParseFloat(cpu, 64)
+	if err != nil {
+		return 0
+	}
+	memoryMiB, err := strconv.ParseFloat(memory, 64)
+	if err != nil {
+		return 0
+	}
+
+	vCPU := cpuUnits / 1024
+	memoryGB := memoryMiB / 1024
+	hourly := vCPU*price.perVCPUHour + memoryGB*price.perGBHour
+
+	return hourly * hoursPerMonth * float64(count)
+}
+
+// regionFromTaskDefinitionArn はタスク定義ARNからリージョンを取得
+func regionFromTaskDefinitionArn(taskDefArn string) string {
+	parsed, err := arn.Parse(taskDefArn)
+	if err != nil {
+		return ""
+	}
+	return parsed.Region
+}
@@ -3,8 +3,11 @@ package inspector
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ecs"
 	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
 	"github.com/dev-shimada/phantom-ecs/internal/models"
@@ -18,11 +21,24 @@ type ECSClient interface {
 	DescribeTaskDefinition(ctx context.Context, input *ecs.DescribeTaskDefinitionInput) (*ecs.DescribeTaskDefinitionOutput, error)
 	CreateService(ctx context.Context, input *ecs.CreateServiceInput) (*ecs.CreateServiceOutput, error)
 	RegisterTaskDefinition(ctx context.Context, input *ecs.RegisterTaskDefinitionInput) (*ecs.RegisterTaskDefinitionOutput, error)
+	ListContainerInstances(ctx context.Context, input *ecs.ListContainerInstancesInput) (*ecs.ListContainerInstancesOutput, error)
+	DescribeContainerInstances(ctx context.Context, input *ecs.DescribeContainerInstancesInput) (*ecs.DescribeContainerInstancesOutput, error)
+	ListTaskDefinitionFamilies(ctx context.Context, input *ecs.ListTaskDefinitionFamiliesInput) (*ecs.ListTaskDefinitionFamiliesOutput, error)
+	ListTaskDefinitions(ctx context.Context, input *ecs.ListTaskDefinitionsInput) (*ecs.ListTaskDefinitionsOutput, error)
+}
+
+// ContainerInstanceLister はクラスターのコンテナインスタンスを取得するインターフェース（scanner.Scannerが実装）
+type ContainerInstanceLister interface {
+	ScanContainerInstances(ctx context.Context, clusterName string) ([]models.ContainerInstance, error)
 }
 
 // Inspector はECSサービスの詳細調査を行う
 type Inspector struct {
 	client ECSClient
+	// containerInstances はEC2クラスターの空き容量の確認に使用する（nilの場合は確認しない）
+	containerInstances ContainerInstanceLister
+	// includeTaskDefinitionTags がtrueの場合はタスク定義のタグも取得（ecs:ListTagsForResource権限が必要）
+	includeTaskDefinitionTags bool
 }
 
 // NewInspector は新しいInspectorインスタンスを作成
@@ -32,10 +48,20 @@ func NewInspector(client ECSClient) *Inspector {
 	}
 }
 
+// SetIncludeTaskDefinitionTags はタスク定義のタグを取得するかどうかを設定
+func (i *Inspector) SetIncludeTaskDefinitionTags(include bool) {
+	i.includeTaskDefinitionTags = include
+}
+
+// SetContainerInstanceLister はEC2クラスターの空き容量の確認に使用するコンテナインスタンスの取得方法を設定
+func (i *Inspector) SetContainerInstanceLister(lister ContainerInstanceLister) {
+	i.containerInstances = lister
+}
+
 // InspectService は指定されたサービスの詳細調査を実行
 func (i *Inspector) InspectService(ctx context.Context, serviceName, clusterName string) (*models.InspectionResult, error) {
 	// サービス詳細を取得
-	service, err := i.getServiceDetails(ctx, serviceName, clusterName)
+	service, deployments, err := i.getServiceDetails(ctx, serviceName, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -51,17 +77,50 @@ func (i *Inspector) InspectService(ctx context.Context, serviceName, clusterName
 
 	// レコメンデーションを生成
 	recommendations := i.GenerateRecommendations(*service, *taskDef)
+	recommendations = append(recommendations, i.GenerateDeploymentRecommendations(*service, deployments)...)
+
+	// EC2起動タイプの場合はクラスターの空き容量でスケールアウトできるか確認
+	// （コンテナインスタンスの取得に失敗しても調査全体は中断せず、警告に記録する）
+	var warnings []string
+	if service.LaunchType == string(types.LaunchTypeEc2) && i.containerInstances != nil {
+		instances, err := i.containerInstances.ScanContainerInstances(ctx, clusterName)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("skipped cluster capacity check for %s: %v", clusterName, err))
+		} else if rec := i.GenerateCapacityRecommendation(*taskDef, instances); rec != nil {
+			recommendations = append(recommendations, *rec)
+		}
+	}
 
 	return &models.InspectionResult{
 		Service:         *service,
 		TaskDefinition:  *taskDef,
 		NetworkConfig:   networkConfig,
+		Deployments:     deployments,
 		Recommendations: recommendations,
+		Warnings:        warnings,
 	}, nil
 }
 
-// getServiceDetails はサービスの詳細情報を取得
-func (i *Inspector) getServiceDetails(ctx context.Context, serviceName, clusterName string) (*models.ECSService, error) {
+// getServiceDetails はサービスの詳細情報とデプロイメントを取得
+func (i *Inspector) getServiceDetails(ctx context.Context, serviceName, clusterName string) (*models.ECSService, []models.ServiceDeployment, error) {
+	output, err := i.client.DescribeServices(ctx, &ecs.DescribeServicesInput{
+		Cluster:  &clusterName,
+		Services: []string{serviceName},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(output.Services) == 0 {
+		return nil, nil, fmt.Errorf("service not found: %s", serviceName)
+	}
+
+	service := output.Services[0]
+	return i.convertToECSService(service, clusterName), convertDeployments(service.Deployments), nil
+}
+
+// GetServiceEvents はサービスイベントを古い順に取得
+func (i *Inspector) GetServiceEvents(ctx context.Context, serviceName, clusterName string) ([]models.ServiceEvent, error) {
 	output, err := i.client.DescribeServices(ctx, &ecs.DescribeServicesInput{
 		Cluster:  &clusterName,
 		Services: []string{serviceName},
@@ -74,20 +133,67 @@ func (i *Inspector) getServiceDetails(ctx context.Context, serviceName, clusterN
 		return nil, fmt.Errorf("service not found: %s", serviceName)
 	}
 
-	service := output.Services[0]
-	return i.convertToECSService(service, clusterName), nil
+	// ECSは新しい順にイベントを返すため、逆順にして時系列に並べる
+	serviceEvents := output.Services[0].Events
+	events := make([]models.ServiceEvent, 0, len(serviceEvents))
+	for idx := len(serviceEvents) - 1; idx >= 0; idx-- {
+		event := serviceEvents[idx]
+		converted := models.ServiceEvent{}
+		if event.Id != nil {
+			converted.ID = *event.Id
+		}
+		if event.CreatedAt != nil {
+			converted.CreatedAt = *event.CreatedAt
+		}
+		if event.Message != nil {
+			converted.Message = *event.Message
+		}
+		events = append(events, converted)
+	}
+	return events, nil
 }
 
 // AnalyzeTaskDefinition はタスク定義の詳細分析を実行
 func (i *Inspector) AnalyzeTaskDefinition(ctx context.Context, taskDefArn string) (*models.ECSTaskDefinition, error) {
-	output, err := i.client.DescribeTaskDefinition(ctx, &ecs.DescribeTaskDefinitionInput{
+	input := &ecs.DescribeTaskDefinitionInput{
 		TaskDefinition: &taskDefArn,
-	})
+	}
+	// タグの取得には追加の権限が必要なため、明示的に指定された場合のみ要求
+	if i.includeTaskDefinitionTags {
+		input.Include = []types.TaskDefinitionField{types.TaskDefinitionFieldTags}
+	}
+
+	output, err := i.client.DescribeTaskDefinition(ctx, input)
 	if err != nil {
 		return nil, err
 	}
+	// 不正なレスポンスでタスク定義が含まれていない場合はパニックせずにエラーとする
+	if output == nil || output.TaskDefinition == nil {
+		return nil, fmt.Errorf("task definition not found in DescribeTaskDefinition response: %s", taskDefArn)
+	}
 
-	return i.convertToECSTaskDefinition(output.TaskDefinition), nil
+	taskDef := i.convertToECSTaskDefinition(output.TaskDefinition)
+	taskDef.Tags = convertTags(output.Tags)
+	return taskDef, nil
+}
+
+// convertTags はタグをキーと値のマップに変換（タグがなければnil）
+func convertTags(tags []types.Tag) map[string]string {
+	var result map[string]string
+	for _, tag := range tags {
+		if tag.Key == nil {
+			continue
+		}
+		if result == nil {
+			result = make(map[string]string)
+		}
+		value := ""
+		if tag.Value != nil {
+			value = *tag.Value
+		}
+		result[*tag.Key] = value
+	}
+	return result
 }
 
 // extractNetworkConfig はサービスからネットワーク設定を抽出
@@ -139,30 +245,277 @@ func (i *Inspector) GenerateRecommendations(service models.ECSService, taskDef m
 			Description: fmt.Sprintf("Running count (%d) does not match desired count (%d)", service.RunningCount, service.DesiredCount),
 			Priority:    "high",
 			Action:      "Investigate why not all tasks are running successfully",
+			// 失敗したタスクを置き換えるため、希望タスク数を維持したまま新しいデプロイを強制する
+			RemediationType: models.RemediationUpdateService,
+			Params: map[string]string{
+				"service":              service.ServiceName,
+				"cluster":              service.ClusterName,
+				"desired_count":        strconv.Itoa(int(service.DesiredCount)),
+				"running_count":        strconv.Itoa(int(service.RunningCount)),
+				"force_new_deployment": "true",
+			},
 		})
 	}
 
 	// リソース使用量レコメンデーション
 	if i.isLowResourceConfiguration(taskDef) {
 		recommendations = append(recommendations, models.Recommendation{
-			Category:    "resources",
-			Title:       "Low Resource Configuration",
-			Description: "Current CPU/Memory configuration might be insufficient for production workloads",
-			Priority:    "medium",
-			Action:      "Consider increasing CPU and memory allocations",
+			Category:        "resources",
+			Title:           "Low Resource Configuration",
+			Description:     "Current CPU/Memory configuration might be insufficient for production workloads",
+			Priority:        "medium",
+			Action:          "Consider increasing CPU and memory allocations",
+			RemediationType: models.RemediationUpdateTaskResources,
+			Params: map[string]string{
+				"cpu":    strconv.Itoa(max(atoiOrZero(taskDef.CPU), minimumCPU)),
+				"memory": strconv.Itoa(max(atoiOrZero(taskDef.Memory), minimumMemory)),
+			},
+		})
+	}
+
+	// コンテナレベルのリソース合計がタスクレベルの上限を超えていないか確認
+	if exceeded, params := containerResourcesExceedingTask(taskDef); len(exceeded) > 0 {
+		recommendations = append(recommendations, models.Recommendation{
+			Category:        "resources",
+			Title:           "Container Resources Exceed Task Limits",
+			Description:     fmt.Sprintf("Container-level reservations exceed task-level limits: %s", strings.Join(exceeded, ", ")),
+			Priority:        "high",
+			Action:          "Reduce container CPU/memory reservations or increase the task-level CPU/memory",
+			RemediationType: models.RemediationUpdateTaskResources,
+			Params:          params,
+		})
+	}
+
+	// 可変な:latestタグのイメージはデプロイの再現性を損なうためコンテナごとに確認
+	for _, container := range taskDef.Containers {
+		if usesLatestTag(container.Image) {
+			recommendations = append(recommendations, models.Recommendation{
+				Category:    "reliability",
+				Title:       "Image Uses :latest Tag",
+				Description: "The :latest image tag is mutable, so deployments are not reproducible and rollbacks may pull a different image",
+				Priority:    "medium",
+				Action:      "Pin container images to an immutable version tag or digest",
+				Resources:   []string{container.Name},
+			})
+		}
+	}
+
+	// ロードバランサー配下ではコンテナのヘルスチェックがないと異常なタスクにルーティングされ得るため確認
+	if len(service.LoadBalancers) > 0 {
+		for _, container := range taskDef.Containers {
+			if container.HealthCheck == nil {
+				recommendations = append(recommendations, models.Recommendation{
+					Category:    "reliability",
+					Title:       "Missing Container Health Check",
+					Description: "The service is behind a load balancer but the container has no health check, so traffic may be routed to unhealthy tasks",
+					Priority:    "medium",
+					Action:      "Add a healthCheck to the container definition",
+					Resources:   []string{container.Name},
+				})
+			}
+		}
+	}
+
+	// コスト概算レコメンデーション（Fargateのみ、EC2はインスタンス費用に依存するため対象外）
+	if service.LaunchType == "FARGATE" {
+		region := regionFromTaskDefinitionArn(taskDef.TaskDefinitionArn)
+		if cost := EstimateFargateCost(taskDef.CPU, taskDef.Memory, service.DesiredCount, region); cost > 0 {
+			recommendations = append(recommendations, models.Recommendation{
+				Category: "cost",
+				Title:    "Estimated Monthly Fargate Cost",
+				Description: fmt.Sprintf("Estimated $%.2f/month for %d task(s) with %s CPU units and %s MiB memory in %s (estimate based on on-demand Linux/x86 pricing; actual cost may differ)",
+					cost, service.DesiredCount, taskDef.CPU, taskDef.Memory, region),
+				Priority: "low",
+				Action:   "Review CPU/memory sizing and consider Fargate Spot or Compute Savings Plans",
+			})
+		}
+	}
+
+	return dedupeRecommendations(recommendations)
+}
+
+// GenerateDeploymentRecommendations はデプロイメントのロールアウトの状態に基づいてレコメンデーションを生成
+func (i *Inspector) GenerateDeploymentRecommendations(service models.ECSService, deployments []models.ServiceDeployment) []models.Recommendation {
+	var recommendations []models.Recommendation
+
+	for _, deployment := range deployments {
+		if deployment.RolloutState != string(types.DeploymentRolloutStateFailed) {
+			continue
+		}
+		description := fmt.Sprintf("Deployment %s (%s, task definition %s) failed to roll out with %d failed task(s)",
+			deployment.ID, deployment.Status, deployment.TaskDefinition, deployment.FailedTasks)
+		if deployment.RolloutStateReason != "" {
+			description += ": " + deployment.RolloutStateReason
+		}
+		recommendations = append(recommendations, models.Recommendation{
+			Category:    "deployment",
+			Title:       "Deployment Rollout Failed",
+			Description: description,
+			Priority:    "high",
+			Action:      fmt.Sprintf("Check the service events and stopped task reasons of %s, then fix the task definition or roll back to a working revision", service.ServiceName),
+			Resources:   []string{deployment.ID},
 		})
 	}
 
 	return recommendations
 }
 
+// usesLatestTag はイメージがタグ未指定または:latestタグを参照しているかを判定（ダイジェスト指定は対象外）
+func usesLatestTag(image string) bool {
+	if image == "" || strings.Contains(image, "@") {
+		return false
+	}
+	// レジストリのポート番号（host:5000/repo）と区別するため最後の/以降でタグを判定
+	name := image[strings.LastIndex(image, "/")+1:]
+	tag := ""
+	if idx := strings.LastIndex(name, ":"); idx >= 0 {
+		tag = name[idx+1:]
+	}
+	return tag == "" || tag == "latest"
+}
+
+// dedupeRecommendations はカテゴリとタイトルが同じレコメンデーションを1つにまとめる
+// 対象リソースは結合して説明に列挙し、リソースを持たない重複は発生件数を説明に付記する
+func dedupeRecommendations(recommendations []models.Recommendation) []models.Recommendation {
+	type key struct{ category, title string }
+	indexes := map[key]int{}
+	counts := map[key]int{}
+	var deduped []models.Recommendation
+	for _, rec := range recommendations {
+		k := key{rec.Category, rec.Title}
+		counts[k]++
+		idx, ok := indexes[k]
+		if !ok {
+			indexes[k] = len(deduped)
+			rec.Resources = append([]string{}, rec.Resources...)
+			deduped = append(deduped, rec)
+			continue
+		}
+		for _, resource := range rec.Resources {
+			if !slices.Contains(deduped[idx].Resources, resource) {
+				deduped[idx].Resources = append(deduped[idx].Resources, resource)
+			}
+		}
+	}
+
+	for idx, rec := range deduped {
+		k := key{rec.Category, rec.Title}
+		switch {
+		case len(rec.Resources) > 0:
+			deduped[idx].Description = fmt.Sprintf("%s (affected: %s)", rec.Description, strings.Join(rec.Resources, ", "))
+		case counts[k] > 1:
+			deduped[idx].Description = fmt.Sprintf("%s (%d occurrences)", rec.Description, counts[k])
+		}
+		if len(rec.Resources) == 0 {
+			deduped[idx].Resources = nil
+		}
+	}
+	return deduped
+}
+
+// minimumCPU、minimumMemoryは低リソースと判定しないCPUユニット数とメモリ(MiB)の下限
+const (
+	minimumCPU    = 256
+	minimumMemory = 512
+)
+
 // isLowResourceConfiguration はリソース設定が低いかどうかを判定
 func (i *Inspector) isLowResourceConfiguration(taskDef models.ECSTaskDefinition) bool {
-	cpu, _ := strconv.Atoi(taskDef.CPU)
-	memory, _ := strconv.Atoi(taskDef.Memory)
-
 	// 256 CPU units未満または512MB未満の場合は低リソースと判定
-	return cpu < 256 || memory < 512
+	return atoiOrZero(taskDef.CPU) < minimumCPU || atoiOrZero(taskDef.Memory) < minimumMemory
+}
+
+// atoiOrZero は文字列を整数に変換（変換できない場合は0）
+func atoiOrZero(value string) int {
+	number, _ := strconv.Atoi(value)
+	return number
+}
+
+// GenerateCapacityRecommendation はクラスター内のいずれのコンテナインスタンスにも
+// タスクをもう1つ配置できない場合にスケールアウト失敗の警告を返す
+func (i *Inspector) GenerateCapacityRecommendation(taskDef models.ECSTaskDefinition, instances []models.ContainerInstance) *models.Recommendation {
+	cpu, memory := taskResourceRequirements(taskDef)
+	if cpu == 0 && memory == 0 {
+		return nil
+	}
+
+	var remainingCPU, remainingMemory int64
+	for _, instance := range instances {
+		if instance.Status != "ACTIVE" {
+			continue
+		}
+		// タスクは複数のインスタンスにまたがって配置できないため、インスタンス単位で判定
+		if int64(instance.RemainingCPU) >= cpu && int64(instance.RemainingMemory) >= memory {
+			return nil
+		}
+		remainingCPU += int64(instance.RemainingCPU)
+		remainingMemory += int64(instance.RemainingMemory)
+	}
+
+	return &models.Recommendation{
+		Category: "capacity",
+		Title:    "Insufficient Cluster Capacity for Scale-Out",
+		Description: fmt.Sprintf("No active container instance can fit one more task (%d CPU units, %d MiB); aggregate remaining capacity is %d CPU units and %d MiB across %d instance(s)",
+			cpu, memory, remainingCPU, remainingMemory, len(instances)),
+		Priority:        "high",
+		Action:          "Add container instances or enable a capacity provider with managed scaling before scaling out",
+		RemediationType: models.RemediationAddCapacity,
+		Params: map[string]string{
+			"required_cpu":    strconv.FormatInt(cpu, 10),
+			"required_memory": strconv.FormatInt(memory, 10),
+		},
+	}
+}
+
+// taskResourceRequirements はタスク1つあたりに必要なCPU・メモリを返す
+// タスクレベルの指定がない場合はコンテナレベルの合計を使用する
+func taskResourceRequirements(taskDef models.ECSTaskDefinition) (cpu, memory int64) {
+	containerCPU, containerMemory := containerResourceTotals(taskDef)
+
+	cpu, err := strconv.ParseInt(taskDef.CPU, 10, 64)
+	if err != nil {
+		cpu = containerCPU
+	}
+	memory, err = strconv.ParseInt(taskDef.Memory, 10, 64)
+	if err != nil {
+		memory = containerMemory
+	}
+
+	return cpu, memory
+}
+
+// containerResourceTotals はコンテナレベルのCPUとメモリ予約の合計を返す
+func containerResourceTotals(taskDef models.ECSTaskDefinition) (cpu, memory int64) {
+	for _, container := range taskDef.Containers {
+		cpu += int64(container.CPU)
+		// memoryReservationが未指定の場合はmemory（ハードリミット）が予約量となる
+		if container.MemoryReservation > 0 {
+			memory += int64(container.MemoryReservation)
+		} else {
+			memory += int64(container.Memory)
+		}
+	}
+	return cpu, memory
+}
+
+// containerResourcesExceedingTask はコンテナレベルのCPU・メモリ予約の合計が
+// タスクレベルの値を超えている項目と、必要なタスクレベルの値を返す（タスクレベル未指定の項目は対象外）
+func containerResourcesExceedingTask(taskDef models.ECSTaskDefinition) ([]string, map[string]string) {
+	var exceeded []string
+	params := map[string]string{}
+
+	totalCPU, totalMemory := containerResourceTotals(taskDef)
+
+	if taskCPU, err := strconv.ParseInt(taskDef.CPU, 10, 64); err == nil && totalCPU > taskCPU {
+		exceeded = append(exceeded, fmt.Sprintf("CPU %d > %d units", totalCPU, taskCPU))
+		params["cpu"] = strconv.FormatInt(totalCPU, 10)
+	}
+	if taskMemory, err := strconv.ParseInt(taskDef.Memory, 10, 64); err == nil && totalMemory > taskMemory {
+		exceeded = append(exceeded, fmt.Sprintf("memory %d > %d MiB", totalMemory, taskMemory))
+		params["memory"] = strconv.FormatInt(totalMemory, 10)
+	}
+
+	return exceeded, params
 }
 
 // convertToECSService はAWS ECSサービス情報をモデルに変換
@@ -190,6 +543,10 @@ func (i *Inspector) convertToECSService(service types.Service, clusterName strin
 		ecsService.LaunchType = string(service.LaunchType)
 	}
 
+	if service.SchedulingStrategy != "" {
+		ecsService.SchedulingStrategy = string(service.SchedulingStrategy)
+	}
+
 	if service.CreatedAt != nil {
 		ecsService.CreatedAt = *service.CreatedAt
 	}
@@ -204,12 +561,63 @@ func (i *Inspector) convertToECSService(service types.Service, clusterName strin
 		}
 	}
 
+	// ロードバランサー設定を抽出
+	for _, lb := range service.LoadBalancers {
+		loadBalancer := models.LoadBalancer{}
+		if lb.TargetGroupArn != nil {
+			loadBalancer.TargetGroupArn = *lb.TargetGroupArn
+		}
+		if lb.LoadBalancerName != nil {
+			loadBalancer.LoadBalancerName = *lb.LoadBalancerName
+		}
+		if lb.ContainerName != nil {
+			loadBalancer.ContainerName = *lb.ContainerName
+		}
+		if lb.ContainerPort != nil {
+			loadBalancer.ContainerPort = *lb.ContainerPort
+		}
+		ecsService.LoadBalancers = append(ecsService.LoadBalancers, loadBalancer)
+	}
+
 	return ecsService
 }
 
-// convertToECSTaskDefinition はAWSタスク定義をモデルに変換
+// convertDeployments はAWSのデプロイメント情報をモデルに変換
+func convertDeployments(deployments []types.Deployment) []models.ServiceDeployment {
+	if len(deployments) == 0 {
+		return nil
+	}
+
+	result := make([]models.ServiceDeployment, 0, len(deployments))
+	for _, deployment := range deployments {
+		converted := models.ServiceDeployment{
+			ID:                 aws.ToString(deployment.Id),
+			Status:             aws.ToString(deployment.Status),
+			TaskDefinition:     aws.ToString(deployment.TaskDefinition),
+			RolloutState:       string(deployment.RolloutState),
+			RolloutStateReason: aws.ToString(deployment.RolloutStateReason),
+			DesiredCount:       deployment.DesiredCount,
+			PendingCount:       deployment.PendingCount,
+			RunningCount:       deployment.RunningCount,
+			FailedTasks:        deployment.FailedTasks,
+		}
+		if deployment.CreatedAt != nil {
+			converted.CreatedAt = *deployment.CreatedAt
+		}
+		if deployment.UpdatedAt != nil {
+			converted.UpdatedAt = *deployment.UpdatedAt
+		}
+		result = append(result, converted)
+	}
+	return result
+}
+
+// convertToECSTaskDefinition はAWSタスク定義をモデルに変換（nilの場合は空のモデルを返す）
 func (i *Inspector) convertToECSTaskDefinition(taskDef *types.TaskDefinition) *models.ECSTaskDefinition {
 	ecsTaskDef := &models.ECSTaskDefinition{}
+	if taskDef == nil {
+		return ecsTaskDef
+	}
 
 	if taskDef.TaskDefinitionArn != nil {
 		ecsTaskDef.TaskDefinitionArn = *taskDef.TaskDefinitionArn
@@ -237,10 +645,220 @@ func (i *Inspector) convertToECSTaskDefinition(taskDef *types.TaskDefinition) *m
 		ecsTaskDef.NetworkMode = string(taskDef.NetworkMode)
 	}
 
+	if taskDef.ExecutionRoleArn != nil {
+		ecsTaskDef.ExecutionRoleArn = *taskDef.ExecutionRoleArn
+	}
+
+	if taskDef.TaskRoleArn != nil {
+		ecsTaskDef.TaskRoleArn = *taskDef.TaskRoleArn
+	}
+
 	// 互換性要件を文字列配列に変換
 	for _, compat := range taskDef.RequiresCompatibilities {
 		ecsTaskDef.RequiresAttributes = append(ecsTaskDef.RequiresAttributes, string(compat))
 	}
 
+	// コンテナ定義を変換
+	for _, container := range taskDef.ContainerDefinitions {
+		ecsTaskDef.Containers = append(ecsTaskDef.Containers, i.convertToContainerDefinition(container))
+	}
+
+	// タスクレベルのボリュームを変換
+	for _, volume := range taskDef.Volumes {
+		ecsTaskDef.Volumes = append(ecsTaskDef.Volumes, i.convertToVolume(volume))
+	}
+
+	// App Meshのプロキシ設定を変換
+	if taskDef.ProxyConfiguration != nil {
+		ecsTaskDef.ProxyConfiguration = i.convertToProxyConfiguration(taskDef.ProxyConfiguration)
+	}
+
 	return ecsTaskDef
 }
+
+// convertToContainerDefinition はAWSコンテナ定義をモデルに変換
+func (i *Inspector) convertToContainerDefinition(container types.ContainerDefinition) models.ContainerDefinition {
+	result := models.ContainerDefinition{}
+
+	if container.Name != nil {
+		result.Name = *container.Name
+	}
+
+	if container.Image != nil {
+		result.Image = *container.Image
+	}
+
+	if container.Essential != nil {
+		result.Essential = *container.Essential
+	}
+
+	result.CPU = container.Cpu
+	if container.Memory != nil {
+		result.Memory = *container.Memory
+	}
+	if container.MemoryReservation != nil {
+		result.MemoryReservation = *container.MemoryReservation
+	}
+
+	for _, pm := range container.PortMappings {
+		portMapping := models.PortMapping{
+			Protocol: string(pm.Protocol),
+		}
+		if pm.Name != nil {
+			portMapping.Name = *pm.Name
+		}
+		if pm.ContainerPort != nil {
+			portMapping.ContainerPort = *pm.ContainerPort
+		}
+		if pm.HostPort != nil {
+			portMapping.HostPort = *pm.HostPort
+		}
+		result.PortMappings = append(result.PortMappings, portMapping)
+	}
+
+	for _, ulimit := range container.Ulimits {
+		result.Ulimits = append(result.Ulimits, models.Ulimit{
+			Name:      string(ulimit.Name),
+			SoftLimit: ulimit.SoftLimit,
+			HardLimit: ulimit.HardLimit,
+		})
+	}
+
+	for _, mp := range container.MountPoints {
+		mountPoint := models.MountPoint{}
+		if mp.SourceVolume != nil {
+			mountPoint.SourceVolume = *mp.SourceVolume
+		}
+		if mp.ContainerPath != nil {
+			mountPoint.ContainerPath = *mp.ContainerPath
+		}
+		if mp.ReadOnly != nil {
+			mountPoint.ReadOnly = *mp.ReadOnly
+		}
+		result.MountPoints = append(result.MountPoints, mountPoint)
+	}
+
+	for _, vf := range container.VolumesFrom {
+		volumeFrom := models.VolumeFrom{}
+		if vf.SourceContainer != nil {
+			volumeFrom.SourceContainer = *vf.SourceContainer
+		}
+		if vf.ReadOnly != nil {
+			volumeFrom.ReadOnly = *vf.ReadOnly
+		}
+		result.VolumesFrom = append(result.VolumesFrom, volumeFrom)
+	}
+
+	for _, dep := range container.DependsOn {
+		dependency := models.ContainerDependency{
+			Condition: string(dep.Condition),
+		}
+		if dep.ContainerName != nil {
+			dependency.ContainerName = *dep.ContainerName
+		}
+		result.DependsOn = append(result.DependsOn, dependency)
+	}
+
+	result.EntryPoint = container.EntryPoint
+	result.Command = container.Command
+	if container.WorkingDirectory != nil {
+		result.WorkingDirectory = *container.WorkingDirectory
+	}
+
+	for _, env := range container.Environment {
+		variable := models.EnvironmentVariable{}
+		if env.Name != nil {
+			variable.Name = *env.Name
+		}
+		if env.Value != nil {
+			variable.Value = *env.Value
+		}
+		result.Environment = append(result.Environment, variable)
+	}
+
+	for _, secret := range container.Secrets {
+		converted := models.Secret{}
+		if secret.Name != nil {
+			converted.Name = *secret.Name
+		}
+		if secret.ValueFrom != nil {
+			converted.ValueFrom = *secret.ValueFrom
+		}
+		result.Secrets = append(result.Secrets, converted)
+	}
+
+	for _, file := range container.EnvironmentFiles {
+		converted := models.EnvironmentFile{Type: string(file.Type)}
+		if file.Value != nil {
+			converted.Value = *file.Value
+		}
+		result.EnvironmentFiles = append(result.EnvironmentFiles, converted)
+	}
+
+	if hc := container.HealthCheck; hc != nil {
+		healthCheck := &models.HealthCheck{Command: hc.Command}
+		if hc.Interval != nil {
+			healthCheck.Interval = *hc.Interval
+		}
+		if hc.Timeout != nil {
+			healthCheck.Timeout = *hc.Timeout
+		}
+		if hc.Retries != nil {
+			healthCheck.Retries = *hc.Retries
+		}
+		if hc.StartPeriod != nil {
+			healthCheck.StartPeriod = *hc.StartPeriod
+		}
+		result.HealthCheck = healthCheck
+	}
+
+	return result
+}
+
+// convertToVolume はAWSボリューム定義をモデルに変換
+func (i *Inspector) convertToVolume(volume types.Volume) models.Volume {
+	result := models.Volume{}
+
+	if volume.Name != nil {
+		result.Name = *volume.Name
+	}
+
+	if volume.Host != nil && volume.Host.SourcePath != nil {
+		result.HostSourcePath = *volume.Host.SourcePath
+	}
+
+	if volume.EfsVolumeConfiguration != nil {
+		if volume.EfsVolumeConfiguration.FileSystemId != nil {
+			result.EFSFileSystemID = *volume.EfsVolumeConfiguration.FileSystemId
+		}
+		if volume.EfsVolumeConfiguration.RootDirectory != nil {
+			result.EFSRootDirectory = *volume.EfsVolumeConfiguration.RootDirectory
+		}
+	}
+
+	return result
+}
+
+// convertToProxyConfiguration はAWSのプロキシ設定をモデルに変換
+func (i *Inspector) convertToProxyConfiguration(proxy *types.ProxyConfiguration) *models.ProxyConfiguration {
+	result := &models.ProxyConfiguration{
+		Type: string(proxy.Type),
+	}
+
+	if proxy.ContainerName != nil {
+		result.ContainerName = *proxy.ContainerName
+	}
+
+	for _, property := range proxy.Properties {
+		p := models.ProxyProperty{}
+		if property.Name != nil {
+			p.Name = *property.Name
+		}
+		if property.Value != nil {
+			p.Value = *property.Value
+		}
+		result.Properties = append(result.Properties, p)
+	}
+
+	return result
+}
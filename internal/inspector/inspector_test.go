@@ -2,14 +2,20 @@ package inspector_test
 
 import (
 	"context"
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/ecs"
 	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
 	"github.com/dev-shimada/phantom-ecs/internal/inspector"
 	"github.com/dev-shimada/phantom-ecs/internal/models"
+	"github.com/dev-shimada/phantom-ecs/internal/scanner"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockECSClient はECSクライアントのモック
@@ -47,6 +53,26 @@ func (m *MockECSClient) RegisterTaskDefinition(ctx context.Context, input *ecs.R
 	return args.Get(0).(*ecs.RegisterTaskDefinitionOutput), args.Error(1)
 }
 
+func (m *MockECSClient) ListContainerInstances(ctx context.Context, input *ecs.ListContainerInstancesInput) (*ecs.ListContainerInstancesOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*ecs.ListContainerInstancesOutput), args.Error(1)
+}
+
+func (m *MockECSClient) DescribeContainerInstances(ctx context.Context, input *ecs.DescribeContainerInstancesInput) (*ecs.DescribeContainerInstancesOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*ecs.DescribeContainerInstancesOutput), args.Error(1)
+}
+
+func (m *MockECSClient) ListTaskDefinitionFamilies(ctx context.Context, input *ecs.ListTaskDefinitionFamiliesInput) (*ecs.ListTaskDefinitionFamiliesOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*ecs.ListTaskDefinitionFamiliesOutput), args.Error(1)
+}
+
+func (m *MockECSClient) ListTaskDefinitions(ctx context.Context, input *ecs.ListTaskDefinitionsInput) (*ecs.ListTaskDefinitionsOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*ecs.ListTaskDefinitionsOutput), args.Error(1)
+}
+
 func TestInspector_InspectService_Success(t *testing.T) {
 	mockClient := new(MockECSClient)
 	inspector := inspector.NewInspector(mockClient)
@@ -63,14 +89,15 @@ func TestInspector_InspectService_Success(t *testing.T) {
 		&ecs.DescribeServicesOutput{
 			Services: []types.Service{
 				{
-					ServiceName:    stringPtr("web-service"),
-					ServiceArn:     stringPtr("arn:aws:ecs:us-west-2:123456789012:service/test-cluster/web-service"),
-					ClusterArn:     stringPtr("arn:aws:ecs:us-west-2:123456789012:cluster/test-cluster"),
-					TaskDefinition: stringPtr("web-task:1"),
-					DesiredCount:   2,
-					RunningCount:   2,
-					Status:         stringPtr("ACTIVE"),
-					LaunchType:     types.LaunchTypeFargate,
+					ServiceName:        stringPtr("web-service"),
+					ServiceArn:         stringPtr("arn:aws:ecs:us-west-2:123456789012:service/test-cluster/web-service"),
+					ClusterArn:         stringPtr("arn:aws:ecs:us-west-2:123456789012:cluster/test-cluster"),
+					TaskDefinition:     stringPtr("web-task:1"),
+					DesiredCount:       2,
+					RunningCount:       2,
+					Status:             stringPtr("ACTIVE"),
+					LaunchType:         types.LaunchTypeFargate,
+					SchedulingStrategy: types.SchedulingStrategyReplica,
 					NetworkConfiguration: &types.NetworkConfiguration{
 						AwsvpcConfiguration: &types.AwsVpcConfiguration{
 							Subnets:        []string{"subnet-12345", "subnet-67890"},
@@ -126,6 +153,7 @@ func TestInspector_InspectService_Success(t *testing.T) {
 	assert.Equal(t, int32(2), result.Service.RunningCount)
 	assert.Equal(t, "ACTIVE", result.Service.Status)
 	assert.Equal(t, "FARGATE", result.Service.LaunchType)
+	assert.Equal(t, "REPLICA", result.Service.SchedulingStrategy)
 
 	// タスク定義情報の検証
 	assert.Equal(t, "web-task", result.TaskDefinition.Family)
@@ -229,6 +257,114 @@ func TestInspector_AnalyzeTaskDefinition_Success(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+func TestInspector_AnalyzeTaskDefinition_NilTaskDefinition(t *testing.T) {
+	mockClient := new(MockECSClient)
+	inspector := inspector.NewInspector(mockClient)
+
+	ctx := context.Background()
+	taskDefArn := "web-task:1"
+
+	// タスク定義を含まない不正なレスポンス
+	mockClient.On("DescribeTaskDefinition", ctx, &ecs.DescribeTaskDefinitionInput{
+		TaskDefinition: &taskDefArn,
+	}).Return(&ecs.DescribeTaskDefinitionOutput{}, nil)
+
+	var result *models.ECSTaskDefinition
+	var err error
+	assert.NotPanics(t, func() {
+		result, err = inspector.AnalyzeTaskDefinition(ctx, taskDefArn)
+	})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "task definition not found in DescribeTaskDefinition response: web-task:1")
+
+	mockClient.AssertExpectations(t)
+}
+func TestInspector_AnalyzeTaskDefinition_Tags(t *testing.T) {
+	ctx := context.Background()
+	taskDefArn := "web-task:1"
+	taskDef := &types.TaskDefinition{
+		Family:   stringPtr("web-task"),
+		Revision: 1,
+	}
+
+	t.Run("デフォルトではタグを要求しない", func(t *testing.T) {
+		mockClient := new(MockECSClient)
+		inspector := inspector.NewInspector(mockClient)
+
+		mockClient.On("DescribeTaskDefinition", ctx, &ecs.DescribeTaskDefinitionInput{
+			TaskDefinition: &taskDefArn,
+		}).Return(&ecs.DescribeTaskDefinitionOutput{TaskDefinition: taskDef}, nil)
+
+		result, err := inspector.AnalyzeTaskDefinition(ctx, taskDefArn)
+
+		require.NoError(t, err)
+		assert.Nil(t, result.Tags)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("指定時はタグを要求して変換", func(t *testing.T) {
+		mockClient := new(MockECSClient)
+		inspector := inspector.NewInspector(mockClient)
+		inspector.SetIncludeTaskDefinitionTags(true)
+
+		mockClient.On("DescribeTaskDefinition", ctx, &ecs.DescribeTaskDefinitionInput{
+			TaskDefinition: &taskDefArn,
+			Include:        []types.TaskDefinitionField{types.TaskDefinitionFieldTags},
+		}).Return(&ecs.DescribeTaskDefinitionOutput{
+			TaskDefinition: taskDef,
+			Tags: []types.Tag{
+				{Key: stringPtr("team"), Value: stringPtr("web")},
+				{Key: stringPtr("cost-center"), Value: stringPtr("1234")},
+			},
+		}, nil)
+
+		result, err := inspector.AnalyzeTaskDefinition(ctx, taskDefArn)
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"team": "web", "cost-center": "1234"}, result.Tags)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestInspector_GetServiceEvents_OldestFirst(t *testing.T) {
+	mockClient := new(MockECSClient)
+	inspector := inspector.NewInspector(mockClient)
+
+	ctx := context.Background()
+	serviceName := "web-service"
+	clusterName := "test-cluster"
+	older := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Minute)
+
+	// ECSは新しい順にイベントを返す
+	mockClient.On("DescribeServices", ctx, &ecs.DescribeServicesInput{
+		Cluster:  &clusterName,
+		Services: []string{serviceName},
+	}).Return(
+		&ecs.DescribeServicesOutput{
+			Services: []types.Service{
+				{
+					ServiceName: stringPtr(serviceName),
+					Events: []types.ServiceEvent{
+						{Id: stringPtr("event-2"), CreatedAt: &newer, Message: stringPtr("has reached a steady state.")},
+						{Id: stringPtr("event-1"), CreatedAt: &older, Message: stringPtr("has started 1 tasks.")},
+					},
+				},
+			},
+		}, nil)
+
+	events, err := inspector.GetServiceEvents(ctx, serviceName, clusterName)
+
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, models.ServiceEvent{ID: "event-1", CreatedAt: older, Message: "has started 1 tasks."}, events[0])
+	assert.Equal(t, "event-2", events[1].ID)
+
+	mockClient.AssertExpectations(t)
+}
+
 func TestInspector_GenerateRecommendations_HealthyService(t *testing.T) {
 	inspector := &inspector.Inspector{}
 
@@ -304,6 +440,48 @@ func TestInspector_GenerateRecommendations_UnhealthyService(t *testing.T) {
 	assert.True(t, hasResourceRecommendation)
 }
 
+func TestInspector_GenerateRecommendations_RemediationParams(t *testing.T) {
+	inspector := &inspector.Inspector{}
+
+	service := models.ECSService{
+		ServiceName:  "failing-service",
+		ClusterName:  "prod",
+		Status:       "ACTIVE",
+		DesiredCount: 2,
+		RunningCount: 0,
+	}
+	taskDef := models.ECSTaskDefinition{CPU: "128", Memory: "1024"}
+
+	recommendations := inspector.GenerateRecommendations(service, taskDef)
+
+	byTitle := map[string]models.Recommendation{}
+	for _, rec := range recommendations {
+		byTitle[rec.Title] = rec
+	}
+
+	health, ok := byTitle["Service Health Issue"]
+	require.True(t, ok)
+	assert.Equal(t, models.RemediationUpdateService, health.RemediationType)
+	assert.Equal(t, map[string]string{
+		"service":              "failing-service",
+		"cluster":              "prod",
+		"desired_count":        "2",
+		"running_count":        "0",
+		"force_new_deployment": "true",
+	}, health.Params)
+
+	resources, ok := byTitle["Low Resource Configuration"]
+	require.True(t, ok)
+	assert.Equal(t, models.RemediationUpdateTaskResources, resources.RemediationType)
+	assert.Equal(t, map[string]string{"cpu": "256", "memory": "1024"}, resources.Params)
+
+	// 修正内容を持たないレコメンデーションは空のまま
+	scaling, ok := byTitle["Consider Auto Scaling"]
+	require.True(t, ok)
+	assert.Empty(t, scaling.RemediationType)
+	assert.Nil(t, scaling.Params)
+}
+
 func TestInspector_ExtractNetworkConfig_WithNetworkConfiguration(t *testing.T) {
 	// この時点では、extractNetworkConfigメソッドは非公開なので、
 	// InspectServiceを通してテストする必要がある
@@ -442,3 +620,683 @@ func stringPtr(s string) *string {
 func int32Ptr(i int32) *int32 {
 	return &i
 }
+
+func TestLoadTaskDefinitionFile(t *testing.T) {
+	registerInput := `{
+  "family": "web-task",
+  "cpu": "256",
+  "memory": "512",
+  "networkMode": "awsvpc",
+  "requiresCompatibilities": ["FARGATE"],
+  "containerDefinitions": [
+    {
+      "name": "app",
+      "image": "nginx:1.25",
+      "essential": true,
+      "portMappings": [{"containerPort": 80, "hostPort": 80, "protocol": "tcp"}]
+    }
+  ]
+}`
+	describeOutput := `{"taskDefinition": ` + registerInput + `}`
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "register-task-definition形式", content: registerInput},
+		{name: "describe-task-definition形式", content: describeOutput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "taskdef.json")
+			require.NoError(t, os.WriteFile(filename, []byte(tt.content), 0644))
+
+			taskDef, err := inspector.LoadTaskDefinitionFile(filename)
+
+			require.NoError(t, err)
+			assert.Equal(t, "web-task", taskDef.Family)
+			assert.Equal(t, "256", taskDef.CPU)
+			assert.Equal(t, "awsvpc", taskDef.NetworkMode)
+			assert.Equal(t, []string{"FARGATE"}, taskDef.RequiresAttributes)
+			require.Len(t, taskDef.Containers, 1)
+			assert.Equal(t, "nginx:1.25", taskDef.Containers[0].Image)
+			assert.Equal(t, int32(80), taskDef.Containers[0].PortMappings[0].ContainerPort)
+		})
+	}
+}
+
+func TestLoadTaskDefinitionFile_MissingFamily(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "taskdef.json")
+	require.NoError(t, os.WriteFile(filename, []byte(`{"cpu": "256"}`), 0644))
+
+	_, err := inspector.LoadTaskDefinitionFile(filename)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "family")
+}
+
+func TestEstimateFargateCost(t *testing.T) {
+	tests := []struct {
+		name     string
+		cpu      string
+		memory   string
+		count    int32
+		region   string
+		expected float64
+	}{
+		// (0.25 * 0.04048 + 0.5 * 0.004445) * 730 = 9.010025
+		{name: "0.25vCPU/0.5GB x1 us-east-1", cpu: "256", memory: "512", count: 1, region: "us-east-1", expected: 9.010025},
+		// (1 * 0.05056 + 2 * 0.00553) * 730 * 3 = 134.9478
+		{name: "1vCPU/2GB x3 ap-northeast-1", cpu: "1024", memory: "2048", count: 3, region: "ap-northeast-1", expected: 134.9478},
+		{name: "タスク数0", cpu: "256", memory: "512", count: 0, region: "us-east-1", expected: 0},
+		{name: "価格表にないリージョン", cpu: "256", memory: "512", count: 1, region: "unknown-region-1", expected: 0},
+		{name: "解析できないCPU", cpu: "", memory: "512", count: 1, region: "us-east-1", expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.expected, inspector.EstimateFargateCost(tt.cpu, tt.memory, tt.count, tt.region), 0.0001)
+		})
+	}
+}
+
+func TestInspector_GenerateRecommendations_Cost(t *testing.T) {
+	inspector := &inspector.Inspector{}
+	taskDef := models.ECSTaskDefinition{
+		TaskDefinitionArn: "arn:aws:ecs:us-east-1:123456789012:task-definition/web-task:1",
+		CPU:               "256",
+		Memory:            "512",
+	}
+
+	findCost := func(recommendations []models.Recommendation) *models.Recommendation {
+		for _, rec := range recommendations {
+			if rec.Category == "cost" {
+				return &rec
+			}
+		}
+		return nil
+	}
+
+	fargate := findCost(inspector.GenerateRecommendations(models.ECSService{LaunchType: "FARGATE", DesiredCount: 2, RunningCount: 2}, taskDef))
+	require.NotNil(t, fargate)
+	assert.Contains(t, fargate.Description, "$18.02/month")
+	assert.Contains(t, fargate.Description, "estimate")
+
+	ec2 := findCost(inspector.GenerateRecommendations(models.ECSService{LaunchType: "EC2", DesiredCount: 2, RunningCount: 2}, taskDef))
+	assert.Nil(t, ec2)
+}
+
+func TestInspector_AnalyzeTaskDefinition_ContainerResources(t *testing.T) {
+	mockClient := new(MockECSClient)
+	inspector := inspector.NewInspector(mockClient)
+
+	ctx := context.Background()
+	taskDefArn := "mixed-task:1"
+
+	mockClient.On("DescribeTaskDefinition", ctx, &ecs.DescribeTaskDefinitionInput{
+		TaskDefinition: &taskDefArn,
+	}).Return(
+		&ecs.DescribeTaskDefinitionOutput{
+			TaskDefinition: &types.TaskDefinition{
+				Family: stringPtr("mixed-task"),
+				Cpu:    stringPtr("512"),
+				ContainerDefinitions: []types.ContainerDefinition{
+					{Name: stringPtr("app"), Cpu: 256, Memory: int32Ptr(512), MemoryReservation: int32Ptr(256)},
+					{Name: stringPtr("sidecar")},
+				},
+			},
+		}, nil)
+
+	result, err := inspector.AnalyzeTaskDefinition(ctx, taskDefArn)
+
+	require.NoError(t, err)
+	assert.Equal(t, "512", result.CPU)
+	assert.Empty(t, result.Memory)
+	require.Len(t, result.Containers, 2)
+	assert.Equal(t, int32(256), result.Containers[0].CPU)
+	assert.Equal(t, int32(512), result.Containers[0].Memory)
+	assert.Equal(t, int32(256), result.Containers[0].MemoryReservation)
+	assert.Zero(t, result.Containers[1].CPU)
+	assert.Zero(t, result.Containers[1].Memory)
+	assert.Zero(t, result.Containers[1].MemoryReservation)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestInspector_AnalyzeTaskDefinition_ProxyConfiguration(t *testing.T) {
+	mockClient := new(MockECSClient)
+	inspector := inspector.NewInspector(mockClient)
+
+	ctx := context.Background()
+	taskDefArn := "mesh-task:1"
+
+	mockClient.On("DescribeTaskDefinition", ctx, &ecs.DescribeTaskDefinitionInput{
+		TaskDefinition: &taskDefArn,
+	}).Return(
+		&ecs.DescribeTaskDefinitionOutput{
+			TaskDefinition: &types.TaskDefinition{
+				Family: stringPtr("mesh-task"),
+				ProxyConfiguration: &types.ProxyConfiguration{
+					Type:          types.ProxyConfigurationTypeAppmesh,
+					ContainerName: stringPtr("envoy"),
+					Properties: []types.KeyValuePair{
+						{Name: stringPtr("IgnoredUID"), Value: stringPtr("1337")},
+						{Name: stringPtr("ProxyIngressPort"), Value: stringPtr("15000")},
+					},
+				},
+			},
+		}, nil)
+
+	result, err := inspector.AnalyzeTaskDefinition(ctx, taskDefArn)
+
+	require.NoError(t, err)
+	require.NotNil(t, result.ProxyConfiguration)
+	assert.Equal(t, "APPMESH", result.ProxyConfiguration.Type)
+	assert.Equal(t, "envoy", result.ProxyConfiguration.ContainerName)
+	assert.Equal(t, []models.ProxyProperty{
+		{Name: "IgnoredUID", Value: "1337"},
+		{Name: "ProxyIngressPort", Value: "15000"},
+	}, result.ProxyConfiguration.Properties)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestInspector_GenerateRecommendations_ContainerResources(t *testing.T) {
+	tests := []struct {
+		name            string
+		taskDef         models.ECSTaskDefinition
+		expectExceeded  bool
+		expectedDetails []string
+	}{
+		{
+			name:    "task-level only",
+			taskDef: models.ECSTaskDefinition{CPU: "512", Memory: "1024", Containers: []models.ContainerDefinition{{Name: "app"}}},
+		},
+		{
+			name: "container-level only",
+			taskDef: models.ECSTaskDefinition{Containers: []models.ContainerDefinition{
+				{Name: "app", CPU: 1024, Memory: 2048},
+			}},
+		},
+		{
+			name: "mixed within limits",
+			taskDef: models.ECSTaskDefinition{CPU: "512", Memory: "1024", Containers: []models.ContainerDefinition{
+				{Name: "app", CPU: 256, Memory: 2048, MemoryReservation: 512},
+				{Name: "sidecar", CPU: 256, Memory: 512},
+			}},
+		},
+		{
+			name: "mixed exceeding limits",
+			taskDef: models.ECSTaskDefinition{CPU: "512", Memory: "1024", Containers: []models.ContainerDefinition{
+				{Name: "app", CPU: 512, MemoryReservation: 768},
+				{Name: "sidecar", CPU: 128, Memory: 512},
+			}},
+			expectExceeded:  true,
+			expectedDetails: []string{"CPU 640 > 512 units", "memory 1280 > 1024 MiB"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inspector := &inspector.Inspector{}
+			recommendations := inspector.GenerateRecommendations(models.ECSService{DesiredCount: 1, RunningCount: 1}, tt.taskDef)
+
+			var found *models.Recommendation
+			for _, rec := range recommendations {
+				if rec.Title == "Container Resources Exceed Task Limits" {
+					found = &rec
+				}
+			}
+
+			if !tt.expectExceeded {
+				assert.Nil(t, found)
+				return
+			}
+			require.NotNil(t, found)
+			assert.Equal(t, "resources", found.Category)
+			assert.Equal(t, "high", found.Priority)
+			for _, detail := range tt.expectedDetails {
+				assert.Contains(t, found.Description, detail)
+			}
+			assert.Equal(t, models.RemediationUpdateTaskResources, found.RemediationType)
+			assert.Equal(t, map[string]string{"cpu": "640", "memory": "1280"}, found.Params)
+		})
+	}
+}
+
+func TestInspector_GenerateCapacityRecommendation(t *testing.T) {
+	taskDef := models.ECSTaskDefinition{CPU: "512", Memory: "1024"}
+
+	tests := []struct {
+		name          string
+		instances     []models.ContainerInstance
+		expectWarning bool
+	}{
+		{
+			name: "tightly packed cluster",
+			instances: []models.ContainerInstance{
+				{EC2InstanceID: "i-aaa", Status: "ACTIVE", RemainingCPU: 256, RemainingMemory: 2048},
+				{EC2InstanceID: "i-bbb", Status: "ACTIVE", RemainingCPU: 1024, RemainingMemory: 512},
+				// DRAINING状態のインスタンスには配置されない
+				{EC2InstanceID: "i-ccc", Status: "DRAINING", RemainingCPU: 2048, RemainingMemory: 4096},
+			},
+			expectWarning: true,
+		},
+		{
+			name: "roomy cluster",
+			instances: []models.ContainerInstance{
+				{EC2InstanceID: "i-aaa", Status: "ACTIVE", RemainingCPU: 256, RemainingMemory: 2048},
+				{EC2InstanceID: "i-bbb", Status: "ACTIVE", RemainingCPU: 1024, RemainingMemory: 2048},
+			},
+			expectWarning: false,
+		},
+		{
+			name:          "no container instances",
+			instances:     []models.ContainerInstance{},
+			expectWarning: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inspector := &inspector.Inspector{}
+			rec := inspector.GenerateCapacityRecommendation(taskDef, tt.instances)
+
+			if !tt.expectWarning {
+				assert.Nil(t, rec)
+				return
+			}
+			require.NotNil(t, rec)
+			assert.Equal(t, "capacity", rec.Category)
+			assert.Equal(t, "high", rec.Priority)
+			assert.Contains(t, rec.Description, "512 CPU units, 1024 MiB")
+			assert.Equal(t, models.RemediationAddCapacity, rec.RemediationType)
+			assert.Equal(t, map[string]string{"required_cpu": "512", "required_memory": "1024"}, rec.Params)
+		})
+	}
+}
+
+func TestInspector_InspectService_EC2CapacityRecommendation(t *testing.T) {
+	mockClient := new(MockECSClient)
+	inspector := inspector.NewInspector(mockClient)
+	inspector.SetContainerInstanceLister(scanner.NewScanner(mockClient))
+
+	ctx := context.Background()
+	clusterName := "ec2-cluster"
+	instanceArn := "arn:aws:ecs:us-east-1:123456789012:container-instance/ec2-cluster/aaa"
+
+	mockClient.On("DescribeServices", ctx, mock.Anything).Return(&ecs.DescribeServicesOutput{
+		Services: []types.Service{{
+			ServiceName:    stringPtr("web-service"),
+			TaskDefinition: stringPtr("web-task:1"),
+			Status:         stringPtr("ACTIVE"),
+			LaunchType:     types.LaunchTypeEc2,
+			DesiredCount:   1,
+			RunningCount:   1,
+		}},
+	}, nil)
+	// タスクレベル未指定のためコンテナレベルの合計 (CPU 512, メモリ 768) で判定
+	mockClient.On("DescribeTaskDefinition", ctx, mock.Anything).Return(&ecs.DescribeTaskDefinitionOutput{
+		TaskDefinition: &types.TaskDefinition{
+			Family: stringPtr("web-task"),
+			ContainerDefinitions: []types.ContainerDefinition{
+				{Name: stringPtr("app"), Cpu: 512, MemoryReservation: int32Ptr(768)},
+			},
+		},
+	}, nil)
+	mockClient.On("ListContainerInstances", ctx, &ecs.ListContainerInstancesInput{Cluster: &clusterName}).Return(
+		&ecs.ListContainerInstancesOutput{ContainerInstanceArns: []string{instanceArn}}, nil)
+	mockClient.On("DescribeContainerInstances", ctx, mock.Anything).Return(&ecs.DescribeContainerInstancesOutput{
+		ContainerInstances: []types.ContainerInstance{{
+			ContainerInstanceArn: &instanceArn,
+			Status:               stringPtr("ACTIVE"),
+			RemainingResources: []types.Resource{
+				{Name: stringPtr("CPU"), IntegerValue: 256},
+				{Name: stringPtr("MEMORY"), IntegerValue: 4096},
+			},
+		}},
+	}, nil)
+
+	result, err := inspector.InspectService(ctx, "web-service", clusterName)
+
+	require.NoError(t, err)
+	var found bool
+	for _, rec := range result.Recommendations {
+		if rec.Category == "capacity" {
+			found = true
+			assert.Contains(t, rec.Description, "512 CPU units, 768 MiB")
+		}
+	}
+	assert.True(t, found)
+	mockClient.AssertExpectations(t)
+}
+
+func TestInspector_InspectService_NoContainerInstanceLister(t *testing.T) {
+	mockClient := new(MockECSClient)
+	inspector := inspector.NewInspector(mockClient)
+
+	ctx := context.Background()
+	mockClient.On("DescribeServices", ctx, mock.Anything).Return(&ecs.DescribeServicesOutput{
+		Services: []types.Service{{
+			ServiceName:    stringPtr("web-service"),
+			TaskDefinition: stringPtr("web-task:1"),
+			Status:         stringPtr("ACTIVE"),
+			LaunchType:     types.LaunchTypeEc2,
+			DesiredCount:   1,
+			RunningCount:   1,
+		}},
+	}, nil)
+	mockClient.On("DescribeTaskDefinition", ctx, mock.Anything).Return(&ecs.DescribeTaskDefinitionOutput{
+		TaskDefinition: &types.TaskDefinition{Family: stringPtr("web-task")},
+	}, nil)
+
+	result, err := inspector.InspectService(ctx, "web-service", "ec2-cluster")
+
+	// コンテナインスタンスの取得方法が未設定の場合は空き容量を確認しない
+	require.NoError(t, err)
+	assert.Empty(t, result.Warnings)
+	mockClient.AssertNotCalled(t, "ListContainerInstances", mock.Anything, mock.Anything)
+}
+
+func TestInspector_InspectService_CapacityCheckWarning(t *testing.T) {
+	mockClient := new(MockECSClient)
+	inspector := inspector.NewInspector(mockClient)
+	inspector.SetContainerInstanceLister(scanner.NewScanner(mockClient))
+
+	ctx := context.Background()
+	clusterName := "ec2-cluster"
+
+	mockClient.On("DescribeServices", ctx, mock.Anything).Return(&ecs.DescribeServicesOutput{
+		Services: []types.Service{{
+			ServiceName:    stringPtr("web-service"),
+			TaskDefinition: stringPtr("web-task:1"),
+			Status:         stringPtr("ACTIVE"),
+			LaunchType:     types.LaunchTypeEc2,
+			DesiredCount:   1,
+			RunningCount:   1,
+		}},
+	}, nil)
+	mockClient.On("DescribeTaskDefinition", ctx, mock.Anything).Return(&ecs.DescribeTaskDefinitionOutput{
+		TaskDefinition: &types.TaskDefinition{Family: stringPtr("web-task")},
+	}, nil)
+	// 任意の権限（ecs:ListContainerInstances）がない場合
+	mockClient.On("ListContainerInstances", ctx, &ecs.ListContainerInstancesInput{Cluster: &clusterName}).Return(
+		(*ecs.ListContainerInstancesOutput)(nil), errors.New("AccessDeniedException: not authorized"))
+
+	result, err := inspector.InspectService(ctx, "web-service", clusterName)
+
+	// 調査は失敗せず、空き容量の確認をスキップしたことを警告として返す
+	require.NoError(t, err)
+	assert.Equal(t, "web-service", result.Service.ServiceName)
+	require.Len(t, result.Warnings, 1)
+	assert.Contains(t, result.Warnings[0], "skipped cluster capacity check for ec2-cluster")
+	assert.Contains(t, result.Warnings[0], "AccessDeniedException")
+	for _, rec := range result.Recommendations {
+		assert.NotEqual(t, "capacity", rec.Category)
+	}
+}
+
+func TestInspector_GenerateRecommendations_DedupesLatestTag(t *testing.T) {
+	inspector := &inspector.Inspector{}
+
+	taskDef := models.ECSTaskDefinition{CPU: "512", Memory: "1024", Containers: []models.ContainerDefinition{
+		{Name: "web", Image: "nginx:latest"},
+		{Name: "worker", Image: "123456789012.dkr.ecr.us-east-1.amazonaws.com/worker"},
+		{Name: "pinned", Image: "registry.example.com:5000/app:1.2.3"},
+		{Name: "sidecar", Image: "registry.example.com:5000/sidecar"},
+		{Name: "digest", Image: "busybox@sha256:0123456789abcdef"},
+	}}
+
+	recommendations := inspector.GenerateRecommendations(models.ECSService{DesiredCount: 1, RunningCount: 1}, taskDef)
+
+	var latest []models.Recommendation
+	for _, rec := range recommendations {
+		if rec.Title == "Image Uses :latest Tag" {
+			latest = append(latest, rec)
+		}
+	}
+	require.Len(t, latest, 1)
+	assert.Equal(t, []string{"web", "worker", "sidecar"}, latest[0].Resources)
+	assert.Contains(t, latest[0].Description, "(affected: web, worker, sidecar)")
+	assert.Equal(t, "reliability", latest[0].Category)
+}
+
+func TestInspector_InspectService_MissingHealthCheck(t *testing.T) {
+	mockClient := new(MockECSClient)
+	inspector := inspector.NewInspector(mockClient)
+
+	ctx := context.Background()
+	clusterName := "test-cluster"
+
+	mockClient.On("DescribeServices", ctx, &ecs.DescribeServicesInput{
+		Cluster:  &clusterName,
+		Services: []string{"web-service"},
+	}).Return(
+		&ecs.DescribeServicesOutput{
+			Services: []types.Service{
+				{
+					ServiceName:    stringPtr("web-service"),
+					TaskDefinition: stringPtr("web-task:1"),
+					DesiredCount:   1,
+					RunningCount:   1,
+					Status:         stringPtr("ACTIVE"),
+					LoadBalancers: []types.LoadBalancer{
+						{
+							TargetGroupArn: stringPtr("arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/web/abc"),
+							ContainerName:  stringPtr("web"),
+							ContainerPort:  int32Ptr(80),
+						},
+					},
+				},
+			},
+		}, nil)
+
+	mockClient.On("DescribeTaskDefinition", ctx, &ecs.DescribeTaskDefinitionInput{
+		TaskDefinition: stringPtr("web-task:1"),
+	}).Return(
+		&ecs.DescribeTaskDefinitionOutput{
+			TaskDefinition: &types.TaskDefinition{
+				Family: stringPtr("web-task"),
+				Cpu:    stringPtr("512"),
+				Memory: stringPtr("1024"),
+				ContainerDefinitions: []types.ContainerDefinition{
+					{Name: stringPtr("web"), Image: stringPtr("nginx:1.27")},
+					{
+						Name:  stringPtr("api"),
+						Image: stringPtr("api:1.0.0"),
+						HealthCheck: &types.HealthCheck{
+							Command:  []string{"CMD-SHELL", "curl -f http://localhost/ || exit 1"},
+							Interval: int32Ptr(30),
+							Retries:  int32Ptr(3),
+						},
+					},
+				},
+			},
+		}, nil)
+
+	result, err := inspector.InspectService(ctx, "web-service", clusterName)
+	require.NoError(t, err)
+
+	require.Len(t, result.Service.LoadBalancers, 1)
+	assert.Equal(t, models.LoadBalancer{
+		TargetGroupArn: "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/web/abc",
+		ContainerName:  "web",
+		ContainerPort:  80,
+	}, result.Service.LoadBalancers[0])
+
+	require.Len(t, result.TaskDefinition.Containers, 2)
+	assert.Nil(t, result.TaskDefinition.Containers[0].HealthCheck)
+	assert.Equal(t, &models.HealthCheck{
+		Command:  []string{"CMD-SHELL", "curl -f http://localhost/ || exit 1"},
+		Interval: 30,
+		Retries:  3,
+	}, result.TaskDefinition.Containers[1].HealthCheck)
+
+	var missing []models.Recommendation
+	for _, rec := range result.Recommendations {
+		if rec.Title == "Missing Container Health Check" {
+			missing = append(missing, rec)
+		}
+	}
+	// ヘルスチェックのないwebのみが対象
+	require.Len(t, missing, 1)
+	assert.Equal(t, "reliability", missing[0].Category)
+	assert.Equal(t, "medium", missing[0].Priority)
+	assert.Equal(t, []string{"web"}, missing[0].Resources)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestInspector_GenerateRecommendations_MissingHealthCheckWithoutLoadBalancer(t *testing.T) {
+	inspector := &inspector.Inspector{}
+
+	taskDef := models.ECSTaskDefinition{CPU: "512", Memory: "1024", Containers: []models.ContainerDefinition{
+		{Name: "worker", Image: "worker:1.0.0"},
+	}}
+
+	recommendations := inspector.GenerateRecommendations(models.ECSService{DesiredCount: 1, RunningCount: 1}, taskDef)
+
+	for _, rec := range recommendations {
+		assert.NotEqual(t, "Missing Container Health Check", rec.Title)
+	}
+}
+
+func TestInspector_AnalyzeTaskDefinition_EnvironmentFiles(t *testing.T) {
+	mockClient := new(MockECSClient)
+	inspector := inspector.NewInspector(mockClient)
+
+	ctx := context.Background()
+	taskDefArn := "web-task:1"
+
+	mockClient.On("DescribeTaskDefinition", ctx, &ecs.DescribeTaskDefinitionInput{
+		TaskDefinition: &taskDefArn,
+	}).Return(
+		&ecs.DescribeTaskDefinitionOutput{
+			TaskDefinition: &types.TaskDefinition{
+				Family:           stringPtr("web-task"),
+				ExecutionRoleArn: stringPtr("arn:aws:iam::123456789012:role/ecsTaskExecutionRole"),
+				TaskRoleArn:      stringPtr("arn:aws:iam::123456789012:role/web-task-role"),
+				ContainerDefinitions: []types.ContainerDefinition{
+					{
+						Name: stringPtr("app"),
+						EnvironmentFiles: []types.EnvironmentFile{
+							{Type: types.EnvironmentFileTypeS3, Value: stringPtr("arn:aws:s3:::config-bucket/app.env")},
+						},
+					},
+				},
+			},
+		}, nil)
+
+	result, err := inspector.AnalyzeTaskDefinition(ctx, taskDefArn)
+
+	require.NoError(t, err)
+	require.Len(t, result.Containers, 1)
+	assert.Equal(t, []models.EnvironmentFile{
+		{Value: "arn:aws:s3:::config-bucket/app.env", Type: "s3"},
+	}, result.Containers[0].EnvironmentFiles)
+	assert.Equal(t, "arn:aws:iam::123456789012:role/ecsTaskExecutionRole", result.ExecutionRoleArn)
+	assert.Equal(t, "arn:aws:iam::123456789012:role/web-task-role", result.TaskRoleArn)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestInspector_InspectService_FailedDeployment(t *testing.T) {
+	mockClient := new(MockECSClient)
+	inspector := inspector.NewInspector(mockClient)
+
+	ctx := context.Background()
+	clusterName := "test-cluster"
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mockClient.On("DescribeServices", ctx, &ecs.DescribeServicesInput{
+		Cluster:  &clusterName,
+		Services: []string{"web-service"},
+	}).Return(
+		&ecs.DescribeServicesOutput{
+			Services: []types.Service{
+				{
+					ServiceName:    stringPtr("web-service"),
+					TaskDefinition: stringPtr("web-task:2"),
+					DesiredCount:   2,
+					RunningCount:   1,
+					Status:         stringPtr("ACTIVE"),
+					Deployments: []types.Deployment{
+						{
+							Id:                 stringPtr("ecs-svc/2"),
+							Status:             stringPtr("PRIMARY"),
+							TaskDefinition:     stringPtr("web-task:2"),
+							RolloutState:       types.DeploymentRolloutStateFailed,
+							RolloutStateReason: stringPtr("ECS deployment circuit breaker: tasks failed to start."),
+							DesiredCount:       2,
+							FailedTasks:        3,
+							CreatedAt:          &createdAt,
+						},
+						{
+							Id:             stringPtr("ecs-svc/1"),
+							Status:         stringPtr("ACTIVE"),
+							TaskDefinition: stringPtr("web-task:1"),
+							RolloutState:   types.DeploymentRolloutStateCompleted,
+							DesiredCount:   2,
+							RunningCount:   1,
+						},
+					},
+				},
+			},
+		}, nil)
+
+	mockClient.On("DescribeTaskDefinition", ctx, &ecs.DescribeTaskDefinitionInput{
+		TaskDefinition: stringPtr("web-task:2"),
+	}).Return(
+		&ecs.DescribeTaskDefinitionOutput{
+			TaskDefinition: &types.TaskDefinition{
+				Family: stringPtr("web-task"),
+				Cpu:    stringPtr("512"),
+				Memory: stringPtr("1024"),
+			},
+		}, nil)
+
+	result, err := inspector.InspectService(ctx, "web-service", clusterName)
+	require.NoError(t, err)
+
+	require.Len(t, result.Deployments, 2)
+	assert.Equal(t, models.ServiceDeployment{
+		ID:                 "ecs-svc/2",
+		Status:             "PRIMARY",
+		TaskDefinition:     "web-task:2",
+		RolloutState:       "FAILED",
+		RolloutStateReason: "ECS deployment circuit breaker: tasks failed to start.",
+		DesiredCount:       2,
+		FailedTasks:        3,
+		CreatedAt:          createdAt,
+	}, result.Deployments[0])
+	assert.Equal(t, "COMPLETED", result.Deployments[1].RolloutState)
+
+	var failed []models.Recommendation
+	for _, rec := range result.Recommendations {
+		if rec.Title == "Deployment Rollout Failed" {
+			failed = append(failed, rec)
+		}
+	}
+	// 失敗したデプロイメントのみが対象
+	require.Len(t, failed, 1)
+	assert.Equal(t, "deployment", failed[0].Category)
+	assert.Equal(t, "high", failed[0].Priority)
+	assert.Equal(t, []string{"ecs-svc/2"}, failed[0].Resources)
+	assert.Contains(t, failed[0].Description, "circuit breaker")
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestInspector_GenerateDeploymentRecommendations_NoFailure(t *testing.T) {
+	inspector := &inspector.Inspector{}
+
+	recommendations := inspector.GenerateDeploymentRecommendations(models.ECSService{ServiceName: "web-service"}, []models.ServiceDeployment{
+		{ID: "ecs-svc/2", Status: "PRIMARY", RolloutState: "IN_PROGRESS"},
+		{ID: "ecs-svc/1", Status: "ACTIVE", RolloutState: "COMPLETED"},
+		// ローリングアップデート以外のデプロイメントはロールアウト状態を持たない
+		{ID: "ecs-svc/0", Status: "ACTIVE"},
+	})
+
+	assert.Empty(t, recommendations)
+}
@@ -0,0 +1,77 @@
+package inspector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+)
+
+// DefaultPluginTimeout はレコメンデーションプラグインの実行タイムアウトのデフォルト値
+const DefaultPluginTimeout = 10 * time.Second
+
+// validPriorities はレコメンデーションの優先度として許可する値
+var validPriorities = map[string]bool{"high": true, "medium": true, "low": true}
+
+// RunRecommendationPlugin は外部のレコメンデーションプラグインを実行し、追加のレコメンデーションを返す
+// プラグインには標準入力でインスペクション結果のJSONを渡し、標準出力からレコメンデーションのJSON配列を読み取る
+// resultはそのまま外部プロセスに渡すため、呼び出し側で機密情報をマスクしておくこと
+func RunRecommendationPlugin(ctx context.Context, pluginPath string, result *models.InspectionResult, timeout time.Duration) ([]models.Recommendation, error) {
+	input, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal inspection result: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	command := exec.CommandContext(ctx, pluginPath)
+	command.Stdin = bytes.NewReader(input)
+	command.Stdout = &stdout
+	command.Stderr = &stderr
+	// タイムアウト後に子プロセスが出力を保持し続けても待ち続けない
+	command.WaitDelay = time.Second
+
+	if err := command.Run(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("recommendation plugin %s timed out after %s", pluginPath, timeout)
+		}
+		return nil, fmt.Errorf("recommendation plugin %s failed: %w: %s", pluginPath, err, strings.TrimSpace(stderr.String()))
+	}
+
+	var recommendations []models.Recommendation
+	decoder := json.NewDecoder(&stdout)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&recommendations); err != nil {
+		return nil, fmt.Errorf("recommendation plugin %s returned invalid JSON: %w", pluginPath, err)
+	}
+
+	for idx, rec := range recommendations {
+		if err := validateRecommendation(rec); err != nil {
+			return nil, fmt.Errorf("recommendation plugin %s returned an invalid recommendation at index %d: %w", pluginPath, idx, err)
+		}
+	}
+
+	return recommendations, nil
+}
+
+// validateRecommendation はプラグインが返したレコメンデーションの必須項目を検証
+func validateRecommendation(rec models.Recommendation) error {
+	if rec.Category == "" {
+		return fmt.Errorf("category is required")
+	}
+	if rec.Title == "" {
+		return fmt.Errorf("title is required")
+	}
+	if !validPriorities[rec.Priority] {
+		return fmt.Errorf("priority must be one of high, medium, low: %q", rec.Priority)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package inspector_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/dev-shimada/phantom-ecs/internal/inspector"
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writePluginScript はテスト用のプラグインスクリプトを作成する
+func writePluginScript(t *testing.T, body string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script plugins are not supported on windows")
+	}
+	path := filepath.Join(t.TempDir(), "plugin.sh")
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+body), 0755))
+	return path
+}
+
+func TestRunRecommendationPlugin(t *testing.T) {
+	// 標準入力のサービス名をタイトルに含めて返す
+	plugin := writePluginScript(t, `name=$(sed -n 's/.*"service_name":"\([^"]*\)".*/\1/p')
+echo "[{\"category\":\"policy\",\"title\":\"Missing owner tag on $name\",\"description\":\"All services must have an owner tag\",\"priority\":\"medium\",\"action\":\"Add an owner tag\"}]"
+`)
+
+	result := &models.InspectionResult{
+		Service: models.ECSService{ServiceName: "web-service", ClusterName: "prod"},
+	}
+
+	recommendations, err := inspector.RunRecommendationPlugin(context.Background(), plugin, result, 5*time.Second)
+
+	require.NoError(t, err)
+	require.Len(t, recommendations, 1)
+	assert.Equal(t, "policy", recommendations[0].Category)
+	assert.Equal(t, "Missing owner tag on web-service", recommendations[0].Title)
+	assert.Equal(t, "medium", recommendations[0].Priority)
+}
+
+func TestRunRecommendationPlugin_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		timeout     time.Duration
+		expectedErr string
+	}{
+		{
+			name:        "invalid JSON",
+			body:        "echo 'not json'\n",
+			timeout:     5 * time.Second,
+			expectedErr: "returned invalid JSON",
+		},
+		{
+			name:        "unknown field",
+			body:        `echo '[{"category":"policy","title":"t","priority":"low","severity":"x"}]'` + "\n",
+			timeout:     5 * time.Second,
+			expectedErr: "returned invalid JSON",
+		},
+		{
+			name:        "missing title",
+			body:        `echo '[{"category":"policy","priority":"low"}]'` + "\n",
+			timeout:     5 * time.Second,
+			expectedErr: "title is required",
+		},
+		{
+			name:        "invalid priority",
+			body:        `echo '[{"category":"policy","title":"t","priority":"urgent"}]'` + "\n",
+			timeout:     5 * time.Second,
+			expectedErr: "priority must be one of",
+		},
+		{
+			name:        "non-zero exit",
+			body:        "echo 'policy service unavailable' >&2\nexit 3\n",
+			timeout:     5 * time.Second,
+			expectedErr: "policy service unavailable",
+		},
+		{
+			name:        "timeout",
+			body:        "sleep 5\n",
+			timeout:     100 * time.Millisecond,
+			expectedErr: "timed out",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin := writePluginScript(t, tt.body)
+
+			_, err := inspector.RunRecommendationPlugin(context.Background(), plugin, &models.InspectionResult{}, tt.timeout)
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package inspector
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+)
+
+// taskDefinitionFile はdescribe-task-definitionの出力形式 ({"taskDefinition": {...}}) を表す
+type taskDefinitionFile struct {
+	TaskDefinition *types.TaskDefinition `json:"taskDefinition"`
+}
+
+// LoadTaskDefinitionFile はJSONファイルからタスク定義を読み込む
+// register-task-definitionの入力形式とdescribe-task-definitionの出力形式の両方に対応
+func LoadTaskDefinitionFile(filename string) (*models.ECSTaskDefinition, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read task definition file: %w", err)
+	}
+
+	var wrapped taskDefinitionFile
+	if err := json.Unmarshal(data, &wrapped); err != nil {
+		return nil, fmt.Errorf("failed to parse task definition file: %w", err)
+	}
+
+	taskDef := wrapped.TaskDefinition
+	if taskDef == nil {
+		taskDef = &types.TaskDefinition{}
+		if err := json.Unmarshal(data, taskDef); err != nil {
+			return nil, fmt.Errorf("failed to parse task definition file: %w", err)
+		}
+	}
+
+	if taskDef.Family == nil || *taskDef.Family == "" {
+		return nil, fmt.Errorf("task definition file does not contain a family: %s", filename)
+	}
+
+	return (&Inspector{}).convertToECSTaskDefinition(taskDef), nil
+}
@@ -23,6 +23,8 @@ type Config struct {
 	MaxAge int
 	// MaxBackups は保持するバックアップファイル数
 	MaxBackups int
+	// Compress はローテーションしたファイルをgzip圧縮するかどうか
+	Compress bool
 	// Output はカスタム出力先（テスト用）
 	Output io.Writer
 }
@@ -82,7 +84,7 @@ func NewLogger(config *Config) (Logger, error) {
 			MaxSize:    config.MaxSize,    // MB
 			MaxAge:     config.MaxAge,     // 日
 			MaxBackups: config.MaxBackups, // ファイル数
-			Compress:   true,              // 圧縮
+			Compress:   config.Compress,   // 圧縮
 		})
 	} else {
 		// 標準出力
@@ -117,6 +119,7 @@ func GetDefaultConfig() *Config {
 		MaxSize:    100, // 100MB
 		MaxAge:     30,  // 30日
 		MaxBackups: 10,  // 10ファイル
+		Compress:   true,
 	}
 }
 
@@ -125,6 +128,16 @@ func NewDefaultLogger() (Logger, error) {
 	return NewLogger(GetDefaultConfig())
 }
 
+// NewNoopLogger はすべてのログを破棄するロガーを作成する
+// ロガーが注入されない場合のデフォルトやテストで使用する
+func NewNoopLogger() Logger {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	// 最も低いレベルにしてフォーマット処理自体を行わない
+	logger.SetLevel(logrus.PanicLevel)
+	return &PhantomLogger{Logger: logger}
+}
+
 // WithServiceContext はサービス情報を含むロガーを作成する
 func (l *PhantomLogger) WithServiceContext(serviceName, clusterName, region string) *logrus.Entry {
 	return l.WithFields(logrus.Fields{
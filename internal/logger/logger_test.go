@@ -12,6 +12,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 func TestNewLogger(t *testing.T) {
@@ -152,6 +153,34 @@ func TestLoggerFileOutput(t *testing.T) {
 	assert.Equal(t, "ファイル出力テスト", logEntry["msg"])
 }
 
+func TestLoggerFileOutput_Compress(t *testing.T) {
+	tests := []struct {
+		name     string
+		compress bool
+	}{
+		{name: "圧縮あり", compress: true},
+		{name: "圧縮なし", compress: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := logger.GetDefaultConfig()
+			config.Filename = filepath.Join(t.TempDir(), "test.log")
+			config.Compress = tt.compress
+
+			log, err := logger.NewLogger(config)
+			require.NoError(t, err)
+
+			phantomLogger, ok := log.(*logger.PhantomLogger)
+			require.True(t, ok)
+			rotator, ok := phantomLogger.Out.(*lumberjack.Logger)
+			require.True(t, ok)
+			assert.Equal(t, tt.compress, rotator.Compress)
+			assert.Equal(t, config.Filename, rotator.Filename)
+		})
+	}
+}
+
 func TestLogLevels(t *testing.T) {
 	var buf bytes.Buffer
 	config := &logger.Config{
@@ -202,4 +231,33 @@ func TestDefaultConfig(t *testing.T) {
 	assert.Equal(t, 100, config.MaxSize)
 	assert.Equal(t, 30, config.MaxAge)
 	assert.Equal(t, 10, config.MaxBackups)
+	assert.True(t, config.Compress)
+}
+
+func TestNoopLogger(t *testing.T) {
+	var _ logger.Logger = logger.NewNoopLogger()
+
+	noop := logger.NewNoopLogger()
+
+	// 標準出力・標準エラー出力に何も書き出されないことを確認
+	stdout, stderr := os.Stdout, os.Stderr
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	os.Stdout, os.Stderr = w, w
+
+	noop.Debug("debug")
+	noop.Info("info")
+	noop.Warn("warn")
+	noop.Error("error")
+	entry := noop.WithFields(logrus.Fields{"service": "web"})
+	require.NotNil(t, entry)
+	entry.Info("with fields")
+	entry.Error("with fields")
+
+	os.Stdout, os.Stderr = stdout, stderr
+	require.NoError(t, w.Close())
+	var captured bytes.Buffer
+	_, err = captured.ReadFrom(r)
+	require.NoError(t, err)
+	assert.Empty(t, captured.String())
 }
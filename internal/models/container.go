@@ -0,0 +1,106 @@
+package models
+
+// ContainerDefinition はタスク定義内のコンテナ定義を表す構造体
+type ContainerDefinition struct {
+	Name      string `json:"name" yaml:"name"`
+	Image     string `json:"image" yaml:"image"`
+	Essential bool   `json:"essential" yaml:"essential"`
+	// CPU、Memory、MemoryReservationはコンテナレベルのリソース指定（0は未指定）
+	CPU               int32                 `json:"cpu,omitempty" yaml:"cpu,omitempty"`
+	Memory            int32                 `json:"memory,omitempty" yaml:"memory,omitempty"`
+	MemoryReservation int32                 `json:"memory_reservation,omitempty" yaml:"memory_reservation,omitempty"`
+	PortMappings      []PortMapping         `json:"port_mappings,omitempty" yaml:"port_mappings,omitempty"`
+	Ulimits           []Ulimit              `json:"ulimits,omitempty" yaml:"ulimits,omitempty"`
+	MountPoints       []MountPoint          `json:"mount_points,omitempty" yaml:"mount_points,omitempty"`
+	VolumesFrom       []VolumeFrom          `json:"volumes_from,omitempty" yaml:"volumes_from,omitempty"`
+	DependsOn         []ContainerDependency `json:"depends_on,omitempty" yaml:"depends_on,omitempty"`
+	EntryPoint        []string              `json:"entry_point,omitempty" yaml:"entry_point,omitempty"`
+	Command           []string              `json:"command,omitempty" yaml:"command,omitempty"`
+	WorkingDirectory  string                `json:"working_directory,omitempty" yaml:"working_directory,omitempty"`
+	Environment       []EnvironmentVariable `json:"environment,omitempty" yaml:"environment,omitempty"`
+	Secrets           []Secret              `json:"secrets,omitempty" yaml:"secrets,omitempty"`
+	EnvironmentFiles  []EnvironmentFile     `json:"environment_files,omitempty" yaml:"environment_files,omitempty"`
+	HealthCheck       *HealthCheck          `json:"health_check,omitempty" yaml:"health_check,omitempty"`
+}
+
+// HealthCheck はコンテナのヘルスチェック設定を表す構造体（間隔等の0は未指定でECSのデフォルト値）
+type HealthCheck struct {
+	Command     []string `json:"command" yaml:"command"`
+	Interval    int32    `json:"interval,omitempty" yaml:"interval,omitempty"`
+	Timeout     int32    `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	Retries     int32    `json:"retries,omitempty" yaml:"retries,omitempty"`
+	StartPeriod int32    `json:"start_period,omitempty" yaml:"start_period,omitempty"`
+}
+
+// EnvironmentVariable はコンテナの環境変数を表す構造体
+type EnvironmentVariable struct {
+	Name  string `json:"name" yaml:"name"`
+	Value string `json:"value" yaml:"value"`
+}
+
+// Secret はSecrets ManagerやSSMパラメータストアから注入するシークレットを表す構造体
+type Secret struct {
+	Name      string `json:"name" yaml:"name"`
+	ValueFrom string `json:"value_from" yaml:"value_from"`
+}
+
+// EnvironmentFile はS3から読み込む環境変数ファイルを表す構造体
+type EnvironmentFile struct {
+	Value string `json:"value" yaml:"value"`
+	Type  string `json:"type" yaml:"type"`
+}
+
+// ContainerDependency はコンテナの起動順序の依存関係を表す構造体
+type ContainerDependency struct {
+	ContainerName string `json:"container_name" yaml:"container_name"`
+	Condition     string `json:"condition" yaml:"condition"`
+}
+
+// PortMapping はコンテナのポートマッピングを表す構造体
+type PortMapping struct {
+	Name          string `json:"name,omitempty" yaml:"name,omitempty"`
+	ContainerPort int32  `json:"container_port" yaml:"container_port"`
+	HostPort      int32  `json:"host_port,omitempty" yaml:"host_port,omitempty"`
+	Protocol      string `json:"protocol,omitempty" yaml:"protocol,omitempty"`
+}
+
+// Ulimit はコンテナのulimit設定を表す構造体
+type Ulimit struct {
+	Name      string `json:"name" yaml:"name"`
+	SoftLimit int32  `json:"soft_limit" yaml:"soft_limit"`
+	HardLimit int32  `json:"hard_limit" yaml:"hard_limit"`
+}
+
+// MountPoint はコンテナのマウントポイントを表す構造体
+type MountPoint struct {
+	SourceVolume  string `json:"source_volume" yaml:"source_volume"`
+	ContainerPath string `json:"container_path" yaml:"container_path"`
+	ReadOnly      bool   `json:"read_only" yaml:"read_only"`
+}
+
+// VolumeFrom は他コンテナからのボリューム参照を表す構造体
+type VolumeFrom struct {
+	SourceContainer string `json:"source_container" yaml:"source_container"`
+	ReadOnly        bool   `json:"read_only" yaml:"read_only"`
+}
+
+// Volume はタスクレベルのボリューム定義を表す構造体
+type Volume struct {
+	Name             string `json:"name" yaml:"name"`
+	HostSourcePath   string `json:"host_source_path,omitempty" yaml:"host_source_path,omitempty"`
+	EFSFileSystemID  string `json:"efs_file_system_id,omitempty" yaml:"efs_file_system_id,omitempty"`
+	EFSRootDirectory string `json:"efs_root_directory,omitempty" yaml:"efs_root_directory,omitempty"`
+}
+
+// ProxyConfiguration はApp Meshのプロキシ設定を表す構造体
+type ProxyConfiguration struct {
+	Type          string          `json:"type" yaml:"type"`
+	ContainerName string          `json:"container_name" yaml:"container_name"`
+	Properties    []ProxyProperty `json:"properties,omitempty" yaml:"properties,omitempty"`
+}
+
+// ProxyProperty はプロキシ設定のプロパティ（名前と値の組）を表す構造体
+type ProxyProperty struct {
+	Name  string `json:"name" yaml:"name"`
+	Value string `json:"value" yaml:"value"`
+}
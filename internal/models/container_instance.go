@@ -0,0 +1,16 @@
+package models
+
+// ContainerInstance はEC2クラスターのコンテナインスタンス情報を表す構造体
+type ContainerInstance struct {
+	ContainerInstanceArn string `json:"container_instance_arn" yaml:"container_instance_arn"`
+	EC2InstanceID        string `json:"ec2_instance_id" yaml:"ec2_instance_id"`
+	ClusterName          string `json:"cluster_name" yaml:"cluster_name"`
+	Status               string `json:"status" yaml:"status"`
+	AgentConnected       bool   `json:"agent_connected" yaml:"agent_connected"`
+	RunningTasksCount    int32  `json:"running_tasks_count" yaml:"running_tasks_count"`
+	PendingTasksCount    int32  `json:"pending_tasks_count" yaml:"pending_tasks_count"`
+	RegisteredCPU        int32  `json:"registered_cpu" yaml:"registered_cpu"`
+	RegisteredMemory     int32  `json:"registered_memory" yaml:"registered_memory"`
+	RemainingCPU         int32  `json:"remaining_cpu" yaml:"remaining_cpu"`
+	RemainingMemory      int32  `json:"remaining_memory" yaml:"remaining_memory"`
+}
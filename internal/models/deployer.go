@@ -9,6 +9,8 @@ type DeploymentResult struct {
 	DryRun            bool     `json:"dry_run" yaml:"dry_run"`
 	Operations        []string `json:"operations,omitempty" yaml:"operations,omitempty"`
 	Error             string   `json:"error,omitempty" yaml:"error,omitempty"`
+	// Warnings はデプロイを失敗させずに続行した事象（任意の権限の不足等）の警告
+	Warnings []string `json:"warnings,omitempty" yaml:"warnings,omitempty"`
 }
 
 // DeploymentCustomization はデプロイメントのカスタマイズオプションを表す構造体
@@ -20,3 +22,27 @@ type DeploymentCustomization struct {
 	CPU            *string `json:"cpu,omitempty" yaml:"cpu,omitempty"`
 	Memory         *string `json:"memory,omitempty" yaml:"memory,omitempty"`
 }
+
+// ServiceUpdate は既存サービスの更新内容を表す構造体
+type ServiceUpdate struct {
+	ServiceName    string `json:"service_name" yaml:"service_name"`
+	ClusterName    string `json:"cluster_name" yaml:"cluster_name"`
+	TaskDefinition string `json:"task_definition,omitempty" yaml:"task_definition,omitempty"`
+	DesiredCount   *int32 `json:"desired_count,omitempty" yaml:"desired_count,omitempty"`
+	// ForceNewDeployment はタスク定義の変更有無にかかわらずタスクを再起動する
+	ForceNewDeployment bool `json:"force_new_deployment" yaml:"force_new_deployment"`
+}
+
+// ServiceUpdatePlan は稼働中のサービスと比較した更新内容を表す構造体（update --dry-run用）
+type ServiceUpdatePlan struct {
+	ServiceName string `json:"service_name" yaml:"service_name"`
+	ClusterName string `json:"cluster_name" yaml:"cluster_name"`
+	// Changes のLiveは現在の値、Expectedは更新後の値
+	Changes            []FieldDifference `json:"changes" yaml:"changes"`
+	ForceNewDeployment bool              `json:"force_new_deployment" yaml:"force_new_deployment"`
+}
+
+// HasChanges は更新によってサービスに変更が発生するかどうかを判定
+func (p *ServiceUpdatePlan) HasChanges() bool {
+	return len(p.Changes) > 0 || p.ForceNewDeployment
+}
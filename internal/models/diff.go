@@ -0,0 +1,61 @@
+package models
+
+// FieldDifference は単一フィールドの差分を表す構造体
+type FieldDifference struct {
+	Field    string `json:"field" yaml:"field"`
+	Live     string `json:"live" yaml:"live"`
+	Expected string `json:"expected" yaml:"expected"`
+}
+
+// TaskDefinitionDiff は稼働中のタスク定義と期待するタスク定義の差分を表す構造体
+type TaskDefinitionDiff struct {
+	ServiceName string            `json:"service_name" yaml:"service_name"`
+	ClusterName string            `json:"cluster_name" yaml:"cluster_name"`
+	Family      string            `json:"family" yaml:"family"`
+	Differences []FieldDifference `json:"differences" yaml:"differences"`
+}
+
+// HasDifferences は差分が存在するかどうかを判定
+func (d *TaskDefinitionDiff) HasDifferences() bool {
+	return len(d.Differences) > 0
+}
+
+// RevisionChange は前リビジョンから変更された単一フィールドを表す構造体
+type RevisionChange struct {
+	Field    string `json:"field" yaml:"field"`
+	Previous string `json:"previous" yaml:"previous"`
+	Current  string `json:"current" yaml:"current"`
+}
+
+// RevisionComparison は同一ファミリーの前リビジョンとの差分を表す構造体
+type RevisionComparison struct {
+	PreviousRevision int              `json:"previous_revision" yaml:"previous_revision"`
+	CurrentRevision  int              `json:"current_revision" yaml:"current_revision"`
+	Changes          []RevisionChange `json:"changes" yaml:"changes"`
+}
+
+// ClusterServicesDiff は2つのクラスター間のサービス構成の差分を表す構造体
+type ClusterServicesDiff struct {
+	FromCluster string `json:"from_cluster" yaml:"from_cluster"`
+	ToCluster   string `json:"to_cluster" yaml:"to_cluster"`
+	// Addedは比較先のみ、Removedは比較元のみに存在するサービス名
+	Added   []string        `json:"added" yaml:"added"`
+	Removed []string        `json:"removed" yaml:"removed"`
+	Common  []CommonService `json:"common" yaml:"common"`
+}
+
+// CommonService は両方のクラスターに存在するサービスの状態を表す構造体
+type CommonService struct {
+	ServiceName   string        `json:"service_name" yaml:"service_name"`
+	From          ServiceHealth `json:"from" yaml:"from"`
+	To            ServiceHealth `json:"to" yaml:"to"`
+	HealthDiffers bool          `json:"health_differs" yaml:"health_differs"`
+}
+
+// ServiceHealth はサービスのヘルス状態を表す構造体
+type ServiceHealth struct {
+	Status       string `json:"status" yaml:"status"`
+	DesiredCount int32  `json:"desired_count" yaml:"desired_count"`
+	RunningCount int32  `json:"running_count" yaml:"running_count"`
+	Healthy      bool   `json:"healthy" yaml:"healthy"`
+}
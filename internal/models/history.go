@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// HistoryCapture は履歴ディレクトリに保存したサービス調査結果の1件を表す構造体
+type HistoryCapture struct {
+	ID          string    `json:"id" yaml:"id"`
+	ServiceName string    `json:"service_name" yaml:"service_name"`
+	ClusterName string    `json:"cluster_name" yaml:"cluster_name"`
+	CapturedAt  time.Time `json:"captured_at" yaml:"captured_at"`
+	Path        string    `json:"path" yaml:"path"`
+}
+
+// HistoryDiff は保存した2件の調査結果の差分を表す構造体
+type HistoryDiff struct {
+	ServiceName string           `json:"service_name" yaml:"service_name"`
+	ClusterName string           `json:"cluster_name" yaml:"cluster_name"`
+	From        HistoryCapture   `json:"from" yaml:"from"`
+	To          HistoryCapture   `json:"to" yaml:"to"`
+	Changes     []RevisionChange `json:"changes" yaml:"changes"`
+}
+
+// HasChanges は差分が存在するかどうかを判定
+func (d *HistoryDiff) HasChanges() bool {
+	return len(d.Changes) > 0
+}
@@ -0,0 +1,9 @@
+package models
+
+// CallerIdentity はAWS認証情報の呼び出し元IDを表す構造体
+type CallerIdentity struct {
+	Account string `json:"account" yaml:"account"`
+	ARN     string `json:"arn" yaml:"arn"`
+	UserID  string `json:"user_id" yaml:"user_id"`
+	Region  string `json:"region" yaml:"region"`
+}
@@ -1,11 +1,28 @@
 package models
 
+import "time"
+
 // InspectionResult はサービス調査結果を表す構造体
 type InspectionResult struct {
 	Service         ECSService        `json:"service" yaml:"service"`
 	TaskDefinition  ECSTaskDefinition `json:"task_definition" yaml:"task_definition"`
 	NetworkConfig   *NetworkConfig    `json:"network_config,omitempty" yaml:"network_config,omitempty"`
 	Recommendations []Recommendation  `json:"recommendations" yaml:"recommendations"`
+	// RevisionComparison は前リビジョンとの比較結果（inspect --compare-revision 指定時のみ）
+	RevisionComparison *RevisionComparison `json:"revision_comparison,omitempty" yaml:"revision_comparison,omitempty"`
+	// ContainerFilter はコンテナ名による絞り込みの結果（inspect --container-pattern 指定時のみ）
+	ContainerFilter *ContainerFilter `json:"container_filter,omitempty" yaml:"container_filter,omitempty"`
+	// Deployments はサービスのデプロイメント（PRIMARYと、置き換え中のACTIVE）とロールアウトの状態
+	Deployments []ServiceDeployment `json:"deployments,omitempty" yaml:"deployments,omitempty"`
+	// Warnings は調査を失敗させずに続行した事象（任意の権限の不足等）の警告
+	Warnings []string `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+}
+
+// ContainerFilter はコンテナ名のパターンで表示するコンテナを絞り込んだ結果を表す構造体
+type ContainerFilter struct {
+	Pattern string `json:"pattern" yaml:"pattern"`
+	Matched int    `json:"matched" yaml:"matched"`
+	Omitted int    `json:"omitted" yaml:"omitted"`
 }
 
 // NetworkConfig はネットワーク設定を表す構造体
@@ -15,6 +32,29 @@ type NetworkConfig struct {
 	AssignPublicIP bool     `json:"assign_public_ip" yaml:"assign_public_ip"`
 }
 
+// ServiceDeployment はサービスのデプロイメントとロールアウトの状態を表す構造体
+type ServiceDeployment struct {
+	ID             string `json:"id" yaml:"id"`
+	Status         string `json:"status" yaml:"status"` // PRIMARY, ACTIVE, INACTIVE
+	TaskDefinition string `json:"task_definition" yaml:"task_definition"`
+	// RolloutStateはローリングアップデートの状態（IN_PROGRESS, COMPLETED, FAILED、デプロイサーキットブレーカー未使用時は空の場合がある）
+	RolloutState       string    `json:"rollout_state,omitempty" yaml:"rollout_state,omitempty"`
+	RolloutStateReason string    `json:"rollout_state_reason,omitempty" yaml:"rollout_state_reason,omitempty"`
+	DesiredCount       int32     `json:"desired_count" yaml:"desired_count"`
+	PendingCount       int32     `json:"pending_count" yaml:"pending_count"`
+	RunningCount       int32     `json:"running_count" yaml:"running_count"`
+	FailedTasks        int32     `json:"failed_tasks" yaml:"failed_tasks"`
+	CreatedAt          time.Time `json:"created_at" yaml:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at" yaml:"updated_at"`
+}
+
+// ServiceEvent はサービスイベント（デプロイやタスク配置の履歴）を表す構造体
+type ServiceEvent struct {
+	ID        string    `json:"id" yaml:"id"`
+	CreatedAt time.Time `json:"created_at" yaml:"created_at"`
+	Message   string    `json:"message" yaml:"message"`
+}
+
 // Recommendation はレコメンデーション情報を表す構造体
 type Recommendation struct {
 	Category    string `json:"category" yaml:"category"`
@@ -22,4 +62,28 @@ type Recommendation struct {
 	Description string `json:"description" yaml:"description"`
 	Priority    string `json:"priority" yaml:"priority"` // high, medium, low
 	Action      string `json:"action" yaml:"action"`
+	// Resourcesはレコメンデーションの対象となったリソース（コンテナ名など、該当しない場合は空）
+	Resources []string `json:"resources,omitempty" yaml:"resources,omitempty"`
+	// RemediationTypeとParamsは自動適用用の機械可読な修正内容（該当しない場合は空）
+	RemediationType string            `json:"remediation_type,omitempty" yaml:"remediation_type,omitempty"`
+	Params          map[string]string `json:"params,omitempty" yaml:"params,omitempty"`
+}
+
+// レコメンデーションの修正内容の種別
+const (
+	// RemediationUpdateService はupdateコマンド相当のサービス更新（Paramsはservice、cluster、desired_count等）
+	RemediationUpdateService = "update_service"
+	// RemediationUpdateTaskResources はタスク定義のCPU・メモリの変更（Paramsはcpu、memory）
+	RemediationUpdateTaskResources = "update_task_resources"
+	// RemediationAddCapacity はクラスターへのキャパシティ追加（Paramsはrequired_cpu、required_memory）
+	RemediationAddCapacity = "add_capacity"
+)
+
+// RecommendationCounts はサービスごとの優先度別レコメンデーション件数
+type RecommendationCounts struct {
+	ClusterName string `json:"cluster_name" yaml:"cluster_name"`
+	ServiceName string `json:"service_name" yaml:"service_name"`
+	High        int    `json:"high" yaml:"high"`
+	Medium      int    `json:"medium" yaml:"medium"`
+	Low         int    `json:"low" yaml:"low"`
 }
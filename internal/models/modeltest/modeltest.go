@@ -0,0 +1,174 @@
+// Package modeltest はテスト用のモデルのフィクスチャを簡潔に作成するビルダーを提供する
+// テストコードからのみインポートすること（本番コードからインポートしない限りバイナリには含まれない）
+package modeltest
+
+import (
+	"fmt"
+
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+)
+
+// DefaultCluster はフィクスチャのデフォルトのクラスター名
+const DefaultCluster = "test-cluster"
+
+// ServiceOption はNewServiceで作成するサービスを変更するオプション
+type ServiceOption func(*models.ECSService)
+
+// NewService はACTIVEで希望タスク数・実行中タスク数が1のFARGATEサービスを作成する
+// タスク定義は "<name>-task:1"、クラスターは DefaultCluster
+func NewService(name string, opts ...ServiceOption) models.ECSService {
+	service := models.ECSService{
+		ServiceName:    name,
+		ClusterName:    DefaultCluster,
+		Status:         "ACTIVE",
+		TaskDefinition: fmt.Sprintf("%s-task:1", name),
+		DesiredCount:   1,
+		RunningCount:   1,
+		LaunchType:     "FARGATE",
+	}
+	for _, opt := range opts {
+		opt(&service)
+	}
+	return service
+}
+
+// WithCluster はサービスのクラスター名を設定する
+func WithCluster(clusterName string) ServiceOption {
+	return func(s *models.ECSService) {
+		s.ClusterName = clusterName
+	}
+}
+
+// WithStatus はサービスのステータスを設定する
+func WithStatus(status string) ServiceOption {
+	return func(s *models.ECSService) {
+		s.Status = status
+	}
+}
+
+// WithCounts はサービスの希望タスク数と実行中タスク数を設定する
+func WithCounts(desired, running int32) ServiceOption {
+	return func(s *models.ECSService) {
+		s.DesiredCount = desired
+		s.RunningCount = running
+	}
+}
+
+// WithLaunchType はサービスの起動タイプを設定する
+func WithLaunchType(launchType string) ServiceOption {
+	return func(s *models.ECSService) {
+		s.LaunchType = launchType
+	}
+}
+
+// WithTaskDefinition はサービスが参照するタスク定義を設定する
+func WithTaskDefinition(taskDefinition string) ServiceOption {
+	return func(s *models.ECSService) {
+		s.TaskDefinition = taskDefinition
+	}
+}
+
+// WithSchedulingStrategy はサービスのスケジューリング戦略を設定する
+func WithSchedulingStrategy(strategy string) ServiceOption {
+	return func(s *models.ECSService) {
+		s.SchedulingStrategy = strategy
+	}
+}
+
+// TaskDefinitionOption はNewTaskDefinitionで作成するタスク定義を変更するオプション
+type TaskDefinitionOption func(*models.ECSTaskDefinition)
+
+// NewTaskDefinition はリビジョン1、ACTIVE、CPU 256、メモリ 512のawsvpcタスク定義を作成する
+func NewTaskDefinition(family string, opts ...TaskDefinitionOption) models.ECSTaskDefinition {
+	taskDef := models.ECSTaskDefinition{
+		Family:      family,
+		Revision:    1,
+		Status:      "ACTIVE",
+		CPU:         "256",
+		Memory:      "512",
+		NetworkMode: "awsvpc",
+	}
+	for _, opt := range opts {
+		opt(&taskDef)
+	}
+	return taskDef
+}
+
+// WithRevision はタスク定義のリビジョンを設定する
+func WithRevision(revision int) TaskDefinitionOption {
+	return func(td *models.ECSTaskDefinition) {
+		td.Revision = revision
+	}
+}
+
+// WithTaskDefinitionStatus はタスク定義のステータスを設定する
+func WithTaskDefinitionStatus(status string) TaskDefinitionOption {
+	return func(td *models.ECSTaskDefinition) {
+		td.Status = status
+	}
+}
+
+// WithNetworkMode はタスク定義のネットワークモードを設定する
+func WithNetworkMode(networkMode string) TaskDefinitionOption {
+	return func(td *models.ECSTaskDefinition) {
+		td.NetworkMode = networkMode
+	}
+}
+
+// WithResources はタスク定義のCPUとメモリを設定する
+func WithResources(cpu, memory string) TaskDefinitionOption {
+	return func(td *models.ECSTaskDefinition) {
+		td.CPU = cpu
+		td.Memory = memory
+	}
+}
+
+// WithRequiresCompatibilities はタスク定義のrequiresCompatibilitiesを設定する
+func WithRequiresCompatibilities(compatibilities ...string) TaskDefinitionOption {
+	return func(td *models.ECSTaskDefinition) {
+		td.RequiresAttributes = compatibilities
+	}
+}
+
+// WithContainers はタスク定義のコンテナ定義を設定する
+func WithContainers(containers ...models.ContainerDefinition) TaskDefinitionOption {
+	return func(td *models.ECSTaskDefinition) {
+		td.Containers = containers
+	}
+}
+
+// InspectionResultOption はNewInspectionResultで作成するインスペクション結果を変更するオプション
+type InspectionResultOption func(*models.InspectionResult)
+
+// NewInspectionResult はサービスとタスク定義からインスペクション結果を作成する
+// タスク定義がawsvpcの場合はデプロイの検証を通るようサブネットとセキュリティグループを1つずつ設定する
+func NewInspectionResult(service models.ECSService, taskDef models.ECSTaskDefinition, opts ...InspectionResultOption) *models.InspectionResult {
+	result := &models.InspectionResult{
+		Service:        service,
+		TaskDefinition: taskDef,
+	}
+	if taskDef.NetworkMode == "awsvpc" {
+		result.NetworkConfig = &models.NetworkConfig{
+			Subnets:        []string{"subnet-12345"},
+			SecurityGroups: []string{"sg-12345"},
+		}
+	}
+	for _, opt := range opts {
+		opt(result)
+	}
+	return result
+}
+
+// WithNetworkConfig はインスペクション結果のネットワーク設定を置き換える（nilで削除）
+func WithNetworkConfig(networkConfig *models.NetworkConfig) InspectionResultOption {
+	return func(r *models.InspectionResult) {
+		r.NetworkConfig = networkConfig
+	}
+}
+
+// WithRecommendations はインスペクション結果のレコメンデーションを設定する
+func WithRecommendations(recommendations ...models.Recommendation) InspectionResultOption {
+	return func(r *models.InspectionResult) {
+		r.Recommendations = recommendations
+	}
+}
@@ -0,0 +1,62 @@
+package modeltest_test
+
+import (
+	"testing"
+
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+	"github.com/dev-shimada/phantom-ecs/internal/models/modeltest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewService(t *testing.T) {
+	service := modeltest.NewService("web-service")
+	assert.Equal(t, models.ECSService{
+		ServiceName:    "web-service",
+		ClusterName:    modeltest.DefaultCluster,
+		Status:         "ACTIVE",
+		TaskDefinition: "web-service-task:1",
+		DesiredCount:   1,
+		RunningCount:   1,
+		LaunchType:     "FARGATE",
+	}, service)
+
+	service = modeltest.NewService("agent",
+		modeltest.WithCluster("prod"),
+		modeltest.WithStatus("DRAINING"),
+		modeltest.WithCounts(3, 2),
+		modeltest.WithLaunchType("EC2"),
+		modeltest.WithTaskDefinition("agent:7"),
+		modeltest.WithSchedulingStrategy("DAEMON"),
+	)
+	assert.Equal(t, "prod", service.ClusterName)
+	assert.Equal(t, "DRAINING", service.Status)
+	assert.Equal(t, int32(3), service.DesiredCount)
+	assert.Equal(t, int32(2), service.RunningCount)
+	assert.Equal(t, "EC2", service.LaunchType)
+	assert.Equal(t, "agent:7", service.TaskDefinition)
+	assert.True(t, service.IsDaemon())
+}
+
+func TestNewInspectionResult(t *testing.T) {
+	t.Run("awsvpcではネットワーク設定を付与", func(t *testing.T) {
+		result := modeltest.NewInspectionResult(modeltest.NewService("web"), modeltest.NewTaskDefinition("web-task"))
+
+		require.NotNil(t, result.NetworkConfig)
+		assert.NotEmpty(t, result.NetworkConfig.Subnets)
+		assert.Equal(t, "web-task", result.TaskDefinition.Family)
+		assert.Equal(t, 1, result.TaskDefinition.Revision)
+	})
+
+	t.Run("bridgeではネットワーク設定なし", func(t *testing.T) {
+		result := modeltest.NewInspectionResult(
+			modeltest.NewService("web"),
+			modeltest.NewTaskDefinition("web-task", modeltest.WithNetworkMode("bridge"), modeltest.WithRevision(4)),
+			modeltest.WithRecommendations(models.Recommendation{Title: "Consider Auto Scaling"}),
+		)
+
+		assert.Nil(t, result.NetworkConfig)
+		assert.Equal(t, 4, result.TaskDefinition.Revision)
+		assert.Len(t, result.Recommendations, 1)
+	})
+}
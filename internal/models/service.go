@@ -4,19 +4,34 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/dev-shimada/phantom-ecs/internal/arn"
 )
 
 // ECSService ECSサービス情報を表す構造体
 type ECSService struct {
-	ServiceName    string                `json:"service_name" yaml:"service_name"`
-	ClusterName    string                `json:"cluster_name" yaml:"cluster_name"`
-	Status         string                `json:"status" yaml:"status"`
-	TaskDefinition string                `json:"task_definition" yaml:"task_definition"`
-	DesiredCount   int32                 `json:"desired_count" yaml:"desired_count"`
-	RunningCount   int32                 `json:"running_count" yaml:"running_count"`
-	CreatedAt      time.Time             `json:"created_at" yaml:"created_at"`
-	LaunchType     string                `json:"launch_type" yaml:"launch_type"`
-	NetworkConfig  *ServiceNetworkConfig `json:"network_config,omitempty" yaml:"network_config,omitempty"`
+	ServiceName        string                `json:"service_name" yaml:"service_name"`
+	ClusterName        string                `json:"cluster_name" yaml:"cluster_name"`
+	Status             string                `json:"status" yaml:"status"`
+	TaskDefinition     string                `json:"task_definition" yaml:"task_definition"`
+	DesiredCount       int32                 `json:"desired_count" yaml:"desired_count"`
+	RunningCount       int32                 `json:"running_count" yaml:"running_count"`
+	CreatedAt          time.Time             `json:"created_at" yaml:"created_at"`
+	LaunchType         string                `json:"launch_type" yaml:"launch_type"`
+	SchedulingStrategy string                `json:"scheduling_strategy,omitempty" yaml:"scheduling_strategy,omitempty"`
+	NetworkConfig      *ServiceNetworkConfig `json:"network_config,omitempty" yaml:"network_config,omitempty"`
+	LoadBalancers      []LoadBalancer        `json:"load_balancers,omitempty" yaml:"load_balancers,omitempty"`
+	Tags               map[string]string     `json:"tags,omitempty" yaml:"tags,omitempty"`
+
+	TaskDefinitionDetails     *TaskDefinitionDetails `json:"task_definition_details,omitempty" yaml:"task_definition_details,omitempty"`
+	TaskDefinitionUnavailable bool                   `json:"task_definition_unavailable,omitempty" yaml:"task_definition_unavailable,omitempty"`
+}
+
+// TaskDefinitionDetails はスキャン結果に付与するタスク定義の概要を表す構造体
+type TaskDefinitionDetails struct {
+	CPU         string `json:"cpu" yaml:"cpu"`
+	Memory      string `json:"memory" yaml:"memory"`
+	NetworkMode string `json:"network_mode" yaml:"network_mode"`
 }
 
 // ServiceNetworkConfig はサービスのネットワーク設定を表す構造体
@@ -26,6 +41,46 @@ type ServiceNetworkConfig struct {
 	AssignPublicIP bool     `json:"assign_public_ip" yaml:"assign_public_ip"`
 }
 
+// LoadBalancer はサービスに設定されたロードバランサー（ターゲットグループ）を表す構造体
+type LoadBalancer struct {
+	TargetGroupArn   string `json:"target_group_arn,omitempty" yaml:"target_group_arn,omitempty"`
+	LoadBalancerName string `json:"load_balancer_name,omitempty" yaml:"load_balancer_name,omitempty"`
+	ContainerName    string `json:"container_name" yaml:"container_name"`
+	ContainerPort    int32  `json:"container_port" yaml:"container_port"`
+}
+
+// SchedulingStrategyDaemon はデーモンサービスのスケジューリング戦略
+const SchedulingStrategyDaemon = "DAEMON"
+
+// IsDaemon サービスがデーモンサービス（各インスタンスに1タスクを配置し、希望タスク数を持たない）かどうかを判定
+func (s *ECSService) IsDaemon() bool {
+	return s.SchedulingStrategy == SchedulingStrategyDaemon
+}
+
+// LaunchType はサービスの起動タイプ
+type LaunchType string
+
+// 起動タイプ（タスク定義のrequiresCompatibilitiesと同じ値）
+const (
+	LaunchTypeFargate  LaunchType = "FARGATE"
+	LaunchTypeEC2      LaunchType = "EC2"
+	LaunchTypeExternal LaunchType = "EXTERNAL"
+)
+
+// IsCompatibleWith 起動タイプがタスク定義のrequiresCompatibilitiesで許可されているかを判定
+// 起動タイプまたはrequiresCompatibilitiesが未指定の場合は判定できないため互換とみなす
+func (l LaunchType) IsCompatibleWith(compatibilities []string) bool {
+	if l == "" || len(compatibilities) == 0 {
+		return true
+	}
+	for _, compatibility := range compatibilities {
+		if strings.EqualFold(compatibility, string(l)) {
+			return true
+		}
+	}
+	return false
+}
+
 // IsHealthy サービスが健全状態かどうかを判定
 func (s *ECSService) IsHealthy() bool {
 	return s.Status == "ACTIVE" && s.DesiredCount == s.RunningCount
@@ -33,14 +88,20 @@ func (s *ECSService) IsHealthy() bool {
 
 // ECSTaskDefinition ECSタスク定義情報を表す構造体
 type ECSTaskDefinition struct {
-	TaskDefinitionArn  string   `json:"task_definition_arn" yaml:"task_definition_arn"`
-	Family             string   `json:"family" yaml:"family"`
-	Revision           int      `json:"revision" yaml:"revision"`
-	Status             string   `json:"status" yaml:"status"`
-	CPU                string   `json:"cpu" yaml:"cpu"`
-	Memory             string   `json:"memory" yaml:"memory"`
-	NetworkMode        string   `json:"network_mode" yaml:"network_mode"`
-	RequiresAttributes []string `json:"requires_attributes" yaml:"requires_attributes"`
+	TaskDefinitionArn  string                `json:"task_definition_arn" yaml:"task_definition_arn"`
+	Family             string                `json:"family" yaml:"family"`
+	Revision           int                   `json:"revision" yaml:"revision"`
+	Status             string                `json:"status" yaml:"status"`
+	CPU                string                `json:"cpu" yaml:"cpu"`
+	Memory             string                `json:"memory" yaml:"memory"`
+	NetworkMode        string                `json:"network_mode" yaml:"network_mode"`
+	ExecutionRoleArn   string                `json:"execution_role_arn,omitempty" yaml:"execution_role_arn,omitempty"`
+	TaskRoleArn        string                `json:"task_role_arn,omitempty" yaml:"task_role_arn,omitempty"`
+	RequiresAttributes []string              `json:"requires_attributes" yaml:"requires_attributes"`
+	Containers         []ContainerDefinition `json:"containers,omitempty" yaml:"containers,omitempty"`
+	Volumes            []Volume              `json:"volumes,omitempty" yaml:"volumes,omitempty"`
+	ProxyConfiguration *ProxyConfiguration   `json:"proxy_configuration,omitempty" yaml:"proxy_configuration,omitempty"`
+	Tags               map[string]string     `json:"tags,omitempty" yaml:"tags,omitempty"`
 }
 
 // GetFamilyAndRevision ARNからファミリー名とリビジョン番号を抽出
@@ -50,12 +111,12 @@ func (td *ECSTaskDefinition) GetFamilyAndRevision() (string, int) {
 	}
 
 	// ARN形式: arn:aws:ecs:region:account:task-definition/family:revision
-	parts := strings.Split(td.TaskDefinitionArn, "/")
-	if len(parts) < 2 {
+	parsed, err := arn.Parse(td.TaskDefinitionArn)
+	if err != nil {
 		return "", 0
 	}
 
-	familyRevision := parts[len(parts)-1]
+	familyRevision := parsed.ResourceName
 	familyParts := strings.Split(familyRevision, ":")
 	if len(familyParts) < 2 {
 		return familyRevision, 0
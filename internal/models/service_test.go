@@ -35,6 +35,27 @@ func TestECSService_JsonMarshaling(t *testing.T) {
 	assert.Equal(t, service.Status, unmarshaledService.Status)
 }
 
+func TestLaunchType_IsCompatibleWith(t *testing.T) {
+	tests := []struct {
+		name            string
+		launchType      LaunchType
+		compatibilities []string
+		expected        bool
+	}{
+		{name: "含まれる", launchType: LaunchTypeEC2, compatibilities: []string{"EC2", "FARGATE"}, expected: true},
+		{name: "含まれない", launchType: LaunchTypeEC2, compatibilities: []string{"FARGATE"}, expected: false},
+		{name: "大文字小文字を区別しない", launchType: LaunchTypeFargate, compatibilities: []string{"fargate"}, expected: true},
+		{name: "requiresCompatibilities未指定", launchType: LaunchTypeExternal, compatibilities: nil, expected: true},
+		{name: "起動タイプ未指定", launchType: "", compatibilities: []string{"FARGATE"}, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.launchType.IsCompatibleWith(tt.compatibilities))
+		})
+	}
+}
+
 func TestECSService_IsHealthy(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -103,3 +124,24 @@ func TestECSTaskDefinition_GetFamilyAndRevision(t *testing.T) {
 		})
 	}
 }
+
+func TestScanSummary_Add(t *testing.T) {
+	summary := NewScanSummary()
+
+	summary.Add("us-east-1", 2, []ECSService{
+		{Status: "ACTIVE", DesiredCount: 2, RunningCount: 2, LaunchType: "FARGATE"},
+		{Status: "ACTIVE", DesiredCount: 3, RunningCount: 1, LaunchType: "EC2"},
+	})
+	summary.Add("us-west-2", 1, []ECSService{
+		{Status: "ACTIVE", DesiredCount: 1, RunningCount: 1, LaunchType: "FARGATE"},
+	})
+
+	assert.Equal(t, []string{"us-east-1", "us-west-2"}, summary.Regions)
+	assert.Equal(t, 3, summary.TotalClusters)
+	assert.Equal(t, 3, summary.TotalServices)
+	assert.Equal(t, 2, summary.HealthyServices)
+	assert.Equal(t, 1, summary.UnhealthyServices)
+	assert.Equal(t, map[string]int{"FARGATE": 2, "EC2": 1}, summary.LaunchTypes)
+	assert.Equal(t, int32(6), summary.TotalDesiredTasks)
+	assert.Equal(t, int32(4), summary.TotalRunningTasks)
+}
@@ -0,0 +1,70 @@
+package models
+
+// ScanSummary はスキャン結果の集計情報を表す構造体
+type ScanSummary struct {
+	Regions           []string       `json:"regions" yaml:"regions"`
+	TotalClusters     int            `json:"total_clusters" yaml:"total_clusters"`
+	TotalServices     int            `json:"total_services" yaml:"total_services"`
+	HealthyServices   int            `json:"healthy_services" yaml:"healthy_services"`
+	UnhealthyServices int            `json:"unhealthy_services" yaml:"unhealthy_services"`
+	LaunchTypes       map[string]int `json:"launch_types" yaml:"launch_types"`
+	TotalDesiredTasks int32          `json:"total_desired_tasks" yaml:"total_desired_tasks"`
+	TotalRunningTasks int32          `json:"total_running_tasks" yaml:"total_running_tasks"`
+}
+
+// NewScanSummary は空の集計情報を作成
+func NewScanSummary() *ScanSummary {
+	return &ScanSummary{
+		Regions:     []string{},
+		LaunchTypes: map[string]int{},
+	}
+}
+
+// Add はリージョン単位のスキャン結果を集計に加算
+func (s *ScanSummary) Add(region string, clusterCount int, services []ECSService) {
+	s.Regions = append(s.Regions, region)
+	s.TotalClusters += clusterCount
+
+	for _, service := range services {
+		s.TotalServices++
+		if service.IsHealthy() {
+			s.HealthyServices++
+		} else {
+			s.UnhealthyServices++
+		}
+
+		launchType := service.LaunchType
+		if launchType == "" {
+			launchType = "UNKNOWN"
+		}
+		s.LaunchTypes[launchType]++
+
+		s.TotalDesiredTasks += service.DesiredCount
+		s.TotalRunningTasks += service.RunningCount
+	}
+}
+
+// TaskDefinitionSummary はタスク定義ファミリーとACTIVEなリビジョンの一覧を表す構造体
+type TaskDefinitionSummary struct {
+	Family         string `json:"family" yaml:"family"`
+	Revisions      []int  `json:"revisions" yaml:"revisions"`
+	LatestRevision int    `json:"latest_revision" yaml:"latest_revision"`
+	// Referencedはいずれかのサービスがファミリーを参照しているか（参照を確認していない場合はnil）
+	Referenced *bool `json:"referenced,omitempty" yaml:"referenced,omitempty"`
+}
+
+// ServiceGroups はスキャン結果をキーごとにまとめたグループ一覧を表す構造体
+type ServiceGroups struct {
+	GroupBy string         `json:"group_by" yaml:"group_by"`
+	Groups  []ServiceGroup `json:"groups" yaml:"groups"`
+}
+
+// ServiceGroup は同じキーを持つサービスとその集計を表す構造体
+type ServiceGroup struct {
+	Key               string       `json:"key" yaml:"key"`
+	ServiceCount      int          `json:"service_count" yaml:"service_count"`
+	HealthyServices   int          `json:"healthy_services" yaml:"healthy_services"`
+	TotalDesiredTasks int32        `json:"total_desired_tasks" yaml:"total_desired_tasks"`
+	TotalRunningTasks int32        `json:"total_running_tasks" yaml:"total_running_tasks"`
+	Services          []ECSService `json:"services" yaml:"services"`
+}
@@ -0,0 +1,54 @@
+package prompt
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// ErrConfirmationRequired は入力が端末でないため確認できず、--yesも指定されていない場合に返すエラー
+var ErrConfirmationRequired = errors.New("confirmation required but input is not a terminal; re-run with --yes to proceed")
+
+// Confirm はmsgを表示し、y/yesの入力で実行を承認したかどうかを返す
+// assumeYesの場合は確認せずにtrueを返し、入力が端末でないファイル（パイプやリダイレクト）の場合はErrConfirmationRequiredを返す
+// 空行・EOF・y/yes以外の入力は拒否として扱う
+func Confirm(reader io.Reader, writer io.Writer, msg string, assumeYes bool) (bool, error) {
+	if assumeYes {
+		return true, nil
+	}
+	if !IsInteractive(reader) {
+		return false, ErrConfirmationRequired
+	}
+
+	fmt.Fprintf(writer, "%s [y/N]: ", msg)
+	answer, err := bufio.NewReader(reader).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, fmt.Errorf("failed to read confirmation: %w", err)
+	}
+	// EOF（Ctrl-D）で入力が終わった場合はプロンプトの行を改行で終える
+	if errors.Is(err, io.EOF) {
+		fmt.Fprintln(writer)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// IsInteractive は入力から対話的に確認できるかどうかを判定
+// 端末でないファイルは非対話、ファイル以外のReader（テストやプログラムからの入力）は対話的とみなす
+func IsInteractive(reader io.Reader) bool {
+	f, ok := reader.(*os.File)
+	if !ok {
+		return true
+	}
+	return term.IsTerminal(int(f.Fd()))
+}
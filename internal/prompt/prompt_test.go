@@ -0,0 +1,69 @@
+package prompt_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dev-shimada/phantom-ecs/internal/prompt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected bool
+	}{
+		{name: "y", input: "y\n", expected: true},
+		{name: "yes（大文字小文字と空白を無視）", input: "  YES \n", expected: true},
+		{name: "改行なしのy", input: "y", expected: true},
+		{name: "n", input: "n\n", expected: false},
+		{name: "空行", input: "\n", expected: false},
+		{name: "その他の入力", input: "sure\n", expected: false},
+		{name: "EOF", input: "", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			confirmed, err := prompt.Confirm(strings.NewReader(tt.input), &out, "Deploy web-service?", false)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, confirmed)
+			assert.True(t, strings.HasPrefix(out.String(), "Deploy web-service? [y/N]: "))
+		})
+	}
+}
+
+func TestConfirm_AssumeYes(t *testing.T) {
+	var out bytes.Buffer
+	confirmed, err := prompt.Confirm(strings.NewReader(""), &out, "Deploy web-service?", true)
+
+	require.NoError(t, err)
+	assert.True(t, confirmed)
+	// 確認を省略した場合はプロンプトを表示しない
+	assert.Empty(t, out.String())
+}
+
+func TestConfirm_NonInteractive(t *testing.T) {
+	// 端末でないファイル（リダイレクトされた標準入力に相当）
+	input, err := os.Create(filepath.Join(t.TempDir(), "stdin"))
+	require.NoError(t, err)
+	defer input.Close()
+
+	var out bytes.Buffer
+	confirmed, err := prompt.Confirm(input, &out, "Deploy web-service?", false)
+
+	assert.ErrorIs(t, err, prompt.ErrConfirmationRequired)
+	assert.False(t, confirmed)
+	assert.Empty(t, out.String())
+
+	// --yes指定時は端末でなくても実行できる
+	confirmed, err = prompt.Confirm(input, &out, "Deploy web-service?", true)
+	require.NoError(t, err)
+	assert.True(t, confirmed)
+}
@@ -0,0 +1,77 @@
+package scanner
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+)
+
+// サービスのグループ化キー
+const (
+	GroupByCluster    = "cluster"
+	GroupByLaunchType = "launch-type"
+	GroupByStatus     = "status"
+)
+
+// ValidateGroupBy はサービスのグループ化キーを検証
+func ValidateGroupBy(key string) error {
+	switch key {
+	case GroupByCluster, GroupByLaunchType, GroupByStatus:
+		return nil
+	default:
+		return fmt.Errorf("unsupported group-by key: %s. Supported keys: [%s %s %s]",
+			key, GroupByCluster, GroupByLaunchType, GroupByStatus)
+	}
+}
+
+// GroupServices はサービスを指定キーでグループ化し、グループごとの件数とタスク数を集計する
+// グループはキーの名前順、グループ内のサービスは元の順序を保つ
+func GroupServices(services []models.ECSService, key string) (models.ServiceGroups, error) {
+	if err := ValidateGroupBy(key); err != nil {
+		return models.ServiceGroups{}, err
+	}
+
+	indexes := map[string]int{}
+	groups := []models.ServiceGroup{}
+	for _, service := range services {
+		value := groupKey(service, key)
+		index, ok := indexes[value]
+		if !ok {
+			index = len(groups)
+			indexes[value] = index
+			groups = append(groups, models.ServiceGroup{Key: value, Services: []models.ECSService{}})
+		}
+
+		group := &groups[index]
+		group.Services = append(group.Services, service)
+		group.ServiceCount++
+		if service.IsHealthy() {
+			group.HealthyServices++
+		}
+		group.TotalDesiredTasks += service.DesiredCount
+		group.TotalRunningTasks += service.RunningCount
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Key < groups[j].Key
+	})
+	return models.ServiceGroups{GroupBy: key, Groups: groups}, nil
+}
+
+// groupKey はサービスのグループ化キーの値を返す（値がない場合はUNKNOWN）
+func groupKey(service models.ECSService, key string) string {
+	var value string
+	switch key {
+	case GroupByCluster:
+		value = service.ClusterName
+	case GroupByLaunchType:
+		value = service.LaunchType
+	case GroupByStatus:
+		value = service.Status
+	}
+	if value == "" {
+		return "UNKNOWN"
+	}
+	return value
+}
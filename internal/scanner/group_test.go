@@ -0,0 +1,71 @@
+package scanner_test
+
+import (
+	"testing"
+
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+	"github.com/dev-shimada/phantom-ecs/internal/scanner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupServices_Cluster(t *testing.T) {
+	services := []models.ECSService{
+		{ServiceName: "web", ClusterName: "prod", Status: "ACTIVE", LaunchType: "FARGATE", DesiredCount: 2, RunningCount: 2},
+		{ServiceName: "api", ClusterName: "staging", Status: "ACTIVE", LaunchType: "FARGATE", DesiredCount: 1, RunningCount: 0},
+		{ServiceName: "worker", ClusterName: "prod", Status: "ACTIVE", LaunchType: "EC2", DesiredCount: 3, RunningCount: 3},
+	}
+
+	groups, err := scanner.GroupServices(services, scanner.GroupByCluster)
+	require.NoError(t, err)
+
+	assert.Equal(t, scanner.GroupByCluster, groups.GroupBy)
+	require.Len(t, groups.Groups, 2)
+
+	prod := groups.Groups[0]
+	assert.Equal(t, "prod", prod.Key)
+	assert.Equal(t, 2, prod.ServiceCount)
+	assert.Equal(t, 2, prod.HealthyServices)
+	assert.Equal(t, int32(5), prod.TotalDesiredTasks)
+	assert.Equal(t, int32(5), prod.TotalRunningTasks)
+	// グループ内のサービスは元の順序を保つ
+	require.Len(t, prod.Services, 2)
+	assert.Equal(t, "web", prod.Services[0].ServiceName)
+	assert.Equal(t, "worker", prod.Services[1].ServiceName)
+
+	staging := groups.Groups[1]
+	assert.Equal(t, "staging", staging.Key)
+	assert.Equal(t, 1, staging.ServiceCount)
+	assert.Equal(t, 0, staging.HealthyServices)
+	assert.Equal(t, int32(1), staging.TotalDesiredTasks)
+	assert.Equal(t, int32(0), staging.TotalRunningTasks)
+}
+
+func TestGroupServices_LaunchTypeAndStatus(t *testing.T) {
+	services := []models.ECSService{
+		{ServiceName: "web", Status: "ACTIVE", LaunchType: "FARGATE"},
+		{ServiceName: "worker", Status: "DRAINING", LaunchType: "EC2"},
+		{ServiceName: "capacity-provider", Status: "ACTIVE"},
+	}
+
+	groups, err := scanner.GroupServices(services, scanner.GroupByLaunchType)
+	require.NoError(t, err)
+	require.Len(t, groups.Groups, 3)
+	assert.Equal(t, "EC2", groups.Groups[0].Key)
+	assert.Equal(t, "FARGATE", groups.Groups[1].Key)
+	// 起動タイプのないサービスはUNKNOWNにまとめる
+	assert.Equal(t, "UNKNOWN", groups.Groups[2].Key)
+
+	groups, err = scanner.GroupServices(services, scanner.GroupByStatus)
+	require.NoError(t, err)
+	require.Len(t, groups.Groups, 2)
+	assert.Equal(t, "ACTIVE", groups.Groups[0].Key)
+	assert.Equal(t, 2, groups.Groups[0].ServiceCount)
+	assert.Equal(t, "DRAINING", groups.Groups[1].Key)
+}
+
+func TestGroupServices_InvalidKey(t *testing.T) {
+	_, err := scanner.GroupServices(nil, "region")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported group-by key: region")
+}
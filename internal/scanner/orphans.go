@@ -0,0 +1,36 @@
+package scanner
+
+import (
+	"strings"
+
+	"github.com/dev-shimada/phantom-ecs/internal/arn"
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+)
+
+// MarkReferencedFamilies はタスク定義ファミリーごとにいずれかのサービスから参照されているかを設定した新しいスライスを返す
+// リビジョンに関わらず、同じファミリーのタスク定義を参照するサービスがあれば参照ありとする
+func MarkReferencedFamilies(summaries []models.TaskDefinitionSummary, services []models.ECSService) []models.TaskDefinitionSummary {
+	referenced := map[string]bool{}
+	for _, service := range services {
+		if family := taskDefinitionFamily(service.TaskDefinition); family != "" {
+			referenced[family] = true
+		}
+	}
+
+	marked := make([]models.TaskDefinitionSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		isReferenced := referenced[summary.Family]
+		summary.Referenced = &isReferenced
+		marked = append(marked, summary)
+	}
+	return marked
+}
+
+// taskDefinitionFamily はタスク定義のARNまたは family:revision 形式からファミリー名を取得
+func taskDefinitionFamily(taskDefinition string) string {
+	if parsed, err := arn.Parse(taskDefinition); err == nil {
+		taskDefinition = parsed.ResourceName
+	}
+	family, _, _ := strings.Cut(taskDefinition, ":")
+	return family
+}
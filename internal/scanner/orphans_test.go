@@ -0,0 +1,35 @@
+package scanner_test
+
+import (
+	"testing"
+
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+	"github.com/dev-shimada/phantom-ecs/internal/scanner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkReferencedFamilies(t *testing.T) {
+	summaries := []models.TaskDefinitionSummary{
+		{Family: "web-task", Revisions: []int{1, 2}, LatestRevision: 2},
+		{Family: "api-task", Revisions: []int{5}, LatestRevision: 5},
+		{Family: "batch-task", Revisions: []int{1}, LatestRevision: 1},
+	}
+	services := []models.ECSService{
+		// 古いリビジョンの参照でもファミリーは参照ありとする
+		{ServiceName: "web", TaskDefinition: "arn:aws:ecs:us-east-1:123456789012:task-definition/web-task:1"},
+		{ServiceName: "api", TaskDefinition: "api-task:5"},
+	}
+
+	marked := scanner.MarkReferencedFamilies(summaries, services)
+
+	require.Len(t, marked, 3)
+	referenced := map[string]bool{}
+	for _, summary := range marked {
+		require.NotNil(t, summary.Referenced)
+		referenced[summary.Family] = *summary.Referenced
+	}
+	assert.Equal(t, map[string]bool{"web-task": true, "api-task": true, "batch-task": false}, referenced)
+	// 元のスライスは変更しない
+	assert.Nil(t, summaries[0].Referenced)
+}
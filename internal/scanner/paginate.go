@@ -0,0 +1,24 @@
+package scanner
+
+import "context"
+
+// PaginateAll はNextTokenでページ分割されたAWSのList系APIを最後のページまで取得し、結果を連結して返す
+// fetchには前回のレスポンスのNextToken（初回はnil）が渡され、そのページの要素と次のトークンを返す
+func PaginateAll[T any](ctx context.Context, fetch func(token *string) ([]T, *string, error)) ([]T, error) {
+	var items []T
+	var token *string
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		page, next, err := fetch(token)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page...)
+		if next == nil {
+			return items, nil
+		}
+		token = next
+	}
+}
@@ -0,0 +1,79 @@
+package scanner_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/dev-shimada/phantom-ecs/internal/scanner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePages はトークンごとのページを返すfetch関数を作成し、受け取ったトークンを記録する
+func fakePages(pages map[string][]string, next map[string]string, tokens *[]string) func(token *string) ([]string, *string, error) {
+	return func(token *string) ([]string, *string, error) {
+		key := aws.ToString(token)
+		*tokens = append(*tokens, key)
+		if n, ok := next[key]; ok {
+			return pages[key], aws.String(n), nil
+		}
+		return pages[key], nil, nil
+	}
+}
+
+func TestPaginateAll_MultiplePages(t *testing.T) {
+	var tokens []string
+	fetch := fakePages(
+		map[string][]string{"": {"a", "b"}, "t1": {"c"}, "t2": {"d", "e"}},
+		map[string]string{"": "t1", "t1": "t2"},
+		&tokens,
+	)
+
+	items, err := scanner.PaginateAll(context.Background(), fetch)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, items)
+	assert.Equal(t, []string{"", "t1", "t2"}, tokens)
+}
+
+func TestPaginateAll_SinglePage(t *testing.T) {
+	var tokens []string
+	fetch := fakePages(map[string][]string{"": {"a"}}, nil, &tokens)
+
+	items, err := scanner.PaginateAll(context.Background(), fetch)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, items)
+	assert.Equal(t, []string{""}, tokens)
+}
+
+func TestPaginateAll_Error(t *testing.T) {
+	calls := 0
+	items, err := scanner.PaginateAll(context.Background(), func(token *string) ([]int, *string, error) {
+		calls++
+		if token == nil {
+			return []int{1}, aws.String("t1"), nil
+		}
+		return nil, nil, errors.New("throttled")
+	})
+
+	assert.EqualError(t, err, "throttled")
+	assert.Nil(t, items)
+	assert.Equal(t, 2, calls)
+}
+
+func TestPaginateAll_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	items, err := scanner.PaginateAll(ctx, func(token *string) ([]int, *string, error) {
+		calls++
+		cancel()
+		return []int{calls}, aws.String("next"), nil
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, items)
+	assert.Equal(t, 1, calls)
+}
@@ -2,10 +2,15 @@ package scanner
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/aws/aws-sdk-go-v2/service/ecs"
 	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/dev-shimada/phantom-ecs/internal/arn"
+	"github.com/dev-shimada/phantom-ecs/internal/logger"
 	"github.com/dev-shimada/phantom-ecs/internal/models"
 )
 
@@ -17,84 +22,540 @@ type ECSClient interface {
 	DescribeTaskDefinition(ctx context.Context, input *ecs.DescribeTaskDefinitionInput) (*ecs.DescribeTaskDefinitionOutput, error)
 	CreateService(ctx context.Context, input *ecs.CreateServiceInput) (*ecs.CreateServiceOutput, error)
 	RegisterTaskDefinition(ctx context.Context, input *ecs.RegisterTaskDefinitionInput) (*ecs.RegisterTaskDefinitionOutput, error)
+	ListContainerInstances(ctx context.Context, input *ecs.ListContainerInstancesInput) (*ecs.ListContainerInstancesOutput, error)
+	DescribeContainerInstances(ctx context.Context, input *ecs.DescribeContainerInstancesInput) (*ecs.DescribeContainerInstancesOutput, error)
+	ListTaskDefinitionFamilies(ctx context.Context, input *ecs.ListTaskDefinitionFamiliesInput) (*ecs.ListTaskDefinitionFamiliesOutput, error)
+	ListTaskDefinitions(ctx context.Context, input *ecs.ListTaskDefinitionsInput) (*ecs.ListTaskDefinitionsOutput, error)
+}
+
+// ErrMaxResultsExceeded はスキャンしたサービス数が上限を超えた場合に返すエラー
+var ErrMaxResultsExceeded = errors.New("max results exceeded")
+
+// ClusterScanError はクラスター単位のスキャンエラー
+type ClusterScanError struct {
+	ClusterName string
+	Err         error
+}
+
+// Error はクラスター名を付けてエラーメッセージを返す
+func (e *ClusterScanError) Error() string {
+	return fmt.Sprintf("%s: %v", e.ClusterName, e.Err)
+}
+
+// Unwrap は元のエラーを返す
+func (e *ClusterScanError) Unwrap() error {
+	return e.Err
+}
+
+// PartialScanError はベストエフォートモードで一部のクラスターのスキャンに失敗した場合のエラー
+// ScanServicesは成功したクラスターのサービスとともにこのエラーを返す
+type PartialScanError struct {
+	// Failures は失敗したクラスターのエラー（指定されたクラスターの順）
+	Failures []*ClusterScanError
+	// ClusterCount はスキャン対象のクラスター数
+	ClusterCount int
+}
+
+// Error は失敗したクラスター数と各クラスターのエラーを要約して返す
+func (e *PartialScanError) Error() string {
+	messages := make([]string, len(e.Failures))
+	for i, failure := range e.Failures {
+		messages[i] = failure.Error()
+	}
+	return fmt.Sprintf("failed to scan %d of %d clusters: %s", len(e.Failures), e.ClusterCount, strings.Join(messages, "; "))
+}
+
+// Unwrap は各クラスターのエラーを返す
+func (e *PartialScanError) Unwrap() []error {
+	errs := make([]error, len(e.Failures))
+	for i, failure := range e.Failures {
+		errs[i] = failure
+	}
+	return errs
 }
 
 // Scanner はECSサービスをスキャンする機能を提供
 type Scanner struct {
-	client ECSClient
+	client          ECSClient
+	logger          logger.Logger
+	maxResults      int
+	concurrency     int
+	continueOnError bool
+
+	// mu はクラスターの並列スキャン中に更新されるwarningsとdiscoveredClustersを保護する
+	mu sync.Mutex
+	// warnings はスキャンを失敗させずに続行した事象（スキップしたクラスター等）の警告
+	warnings []string
+	// discoveredClusters は直近のDiscoverClustersで発見したクラスター名
+	discoveredClusters map[string]bool
 }
 
-// NewScanner は新しいScannerインスタンスを作成
+// NewScanner は新しいScannerインスタンスを作成（ログは出力しない）
 func NewScanner(client ECSClient) *Scanner {
+	return NewScannerWithLogger(client, logger.NewNoopLogger())
+}
+
+// NewScannerWithLogger はロガー付きのScannerインスタンスを作成
+func NewScannerWithLogger(client ECSClient, log logger.Logger) *Scanner {
+	if log == nil {
+		log = logger.NewNoopLogger()
+	}
 	return &Scanner{
 		client: client,
+		logger: log,
+	}
+}
+
+// SetMaxResults はスキャンするサービス数の上限を設定（0以下は無制限）
+func (s *Scanner) SetMaxResults(maxResults int) {
+	s.maxResults = maxResults
+}
+
+// SetConcurrency は同時にスキャンするクラスター数を設定（1以下は1クラスターずつ順にスキャン）
+func (s *Scanner) SetConcurrency(concurrency int) {
+	s.concurrency = concurrency
+}
+
+// SetContinueOnError はクラスターのスキャンに失敗しても残りのクラスターのスキャンを続けるベストエフォートモードを設定
+// 有効な場合、ScanServicesは成功したクラスターのサービスと失敗したクラスターをまとめたPartialScanErrorを返す
+func (s *Scanner) SetContinueOnError(continueOnError bool) {
+	s.continueOnError = continueOnError
+}
+
+// Warnings は直近のScanServices以降に発生した警告を発生順に返す
+// 警告はエラーと異なりスキャンを失敗させないため、呼び出し元で結果とは別に表示する
+func (s *Scanner) Warnings() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.warnings...)
+}
+
+// addWarning は警告を追加
+func (s *Scanner) addWarning(format string, args ...interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.warnings = append(s.warnings, fmt.Sprintf(format, args...))
+}
+
+// isDiscoveredCluster はクラスターが直近のDiscoverClustersで発見したものかを返す
+func (s *Scanner) isDiscoveredCluster(clusterName string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.discoveredClusters[clusterName]
+}
+
+// resetWarnings は前回のスキャンの警告を破棄
+func (s *Scanner) resetWarnings() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.warnings = nil
+}
+
+// CheckMaxResults はサービス数が上限を超えていればErrMaxResultsExceededを返す（0以下は無制限）
+func CheckMaxResults(count, maxResults int) error {
+	if maxResults > 0 && count > maxResults {
+		return fmt.Errorf("%w: found more than %d services; narrow the scan with --cluster or raise the limit with --max-results (0 for unlimited)",
+			ErrMaxResultsExceeded, maxResults)
 	}
+	return nil
 }
 
 // ScanServices は指定されたクラスターからECSサービスを取得
+// 上限が設定されている場合は、上限を超えた時点で残りのクラスターをスキャンせずに中断する
+// ベストエフォートモードでは失敗したクラスターを飛ばして続行し、成功したサービスとPartialScanErrorを返す
+// DiscoverClustersで発見した後に削除されたクラスターはエラーにせず、スキップして警告に記録する
+// （利用者が指定したクラスターが存在しない場合はエラーを返す）
 func (s *Scanner) ScanServices(ctx context.Context, clusterNames []string) ([]models.ECSService, error) {
+	s.resetWarnings()
+	if s.concurrency > 1 && len(clusterNames) > 1 {
+		return s.scanServicesConcurrently(ctx, clusterNames)
+	}
+
 	var allServices []models.ECSService
+	var failures []*ClusterScanError
 
 	for _, clusterName := range clusterNames {
 		services, err := s.scanServicesInCluster(ctx, clusterName)
 		if err != nil {
+			if !s.continueOnError || ctx.Err() != nil {
+				return nil, err
+			}
+			failures = append(failures, &ClusterScanError{ClusterName: clusterName, Err: err})
+			continue
+		}
+		allServices = append(allServices, services...)
+
+		if err := CheckMaxResults(len(allServices), s.maxResults); err != nil {
 			return nil, err
 		}
+	}
+
+	return allServices, partialScanError(failures, len(clusterNames))
+}
+
+// partialScanError は失敗したクラスターがあればPartialScanErrorを返す
+func partialScanError(failures []*ClusterScanError, clusterCount int) error {
+	if len(failures) == 0 {
+		return nil
+	}
+	return &PartialScanError{Failures: failures, ClusterCount: clusterCount}
+}
+
+// scanServicesConcurrently は同時実行数の上限までクラスターを並列にスキャンし、結果を指定されたクラスターの順に返す
+// いずれかのクラスターでエラーになるか上限を超えた時点で、未着手のクラスターをスキャンせずに中断する
+// （ベストエフォートモードではクラスターのエラーでは中断しない）
+func (s *Scanner) scanServicesConcurrently(ctx context.Context, clusterNames []string) ([]models.ECSService, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([][]models.ECSService, len(clusterNames))
+	clusterErrors := make([]*ClusterScanError, len(clusterNames))
+	semaphore := make(chan struct{}, s.concurrency)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+	total := 0
+
+	for i, clusterName := range clusterNames {
+		wg.Add(1)
+		go func(index int, clusterName string) {
+			defer wg.Done()
+
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+			if ctx.Err() != nil {
+				return
+			}
+
+			services, err := s.scanServicesInCluster(ctx, clusterName)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err == nil {
+				results[index] = services
+				total += len(services)
+				err = CheckMaxResults(total, s.maxResults)
+			} else if s.continueOnError && ctx.Err() == nil {
+				clusterErrors[index] = &ClusterScanError{ClusterName: clusterName, Err: err}
+				return
+			}
+			if err != nil && firstErr == nil {
+				firstErr = err
+				cancel()
+			}
+		}(i, clusterName)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	// 親のコンテキストがキャンセルされ、スキャンされなかったクラスターがある場合
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var allServices []models.ECSService
+	var failures []*ClusterScanError
+	for i, services := range results {
+		if clusterErrors[i] != nil {
+			failures = append(failures, clusterErrors[i])
+			continue
+		}
 		allServices = append(allServices, services...)
 	}
+	return allServices, partialScanError(failures, len(clusterNames))
+}
+
+// EnrichTaskDefinitions はサービスにタスク定義の概要を付与
+// 個別のタスク定義取得に失敗した場合はスキャン全体を中断せず、
+// 該当サービスをタスク定義取得不可としてマークし、警告に記録して処理を継続する
+func (s *Scanner) EnrichTaskDefinitions(ctx context.Context, services []models.ECSService) []models.ECSService {
+	enriched := make([]models.ECSService, len(services))
+
+	for idx, service := range services {
+		enriched[idx] = service
+
+		taskDefArn := service.TaskDefinition
+		output, err := s.client.DescribeTaskDefinition(ctx, &ecs.DescribeTaskDefinitionInput{
+			TaskDefinition: &taskDefArn,
+		})
+		if err == nil && output.TaskDefinition == nil {
+			err = fmt.Errorf("task definition not found: %s", taskDefArn)
+		}
+		if err != nil {
+			s.logger.WithFields(map[string]interface{}{
+				"service":         service.ServiceName,
+				"cluster":         service.ClusterName,
+				"task_definition": taskDefArn,
+				"error":           err.Error(),
+			}).Debug("タスク定義の取得に失敗しました")
+			s.addWarning("task definition unavailable for service %s in cluster %s: %v", service.ServiceName, service.ClusterName, err)
+			enriched[idx].TaskDefinitionUnavailable = true
+			continue
+		}
 
-	return allServices, nil
+		details := &models.TaskDefinitionDetails{
+			NetworkMode: string(output.TaskDefinition.NetworkMode),
+		}
+		if output.TaskDefinition.Cpu != nil {
+			details.CPU = *output.TaskDefinition.Cpu
+		}
+		if output.TaskDefinition.Memory != nil {
+			details.Memory = *output.TaskDefinition.Memory
+		}
+		enriched[idx].TaskDefinitionDetails = details
+	}
+
+	return enriched
+}
+
+// ExcludeInactive はINACTIVE状態のサービスを除外したサービス一覧を返す
+func ExcludeInactive(services []models.ECSService) []models.ECSService {
+	filtered := make([]models.ECSService, 0, len(services))
+	for _, service := range services {
+		if service.Status == "INACTIVE" {
+			continue
+		}
+		filtered = append(filtered, service)
+	}
+	return filtered
 }
 
 // DiscoverClusters は利用可能なクラスターを発見
 func (s *Scanner) DiscoverClusters(ctx context.Context) ([]string, error) {
-	output, err := s.client.ListClusters(ctx, &ecs.ListClustersInput{})
+	clusterArns, err := PaginateAll(ctx, func(token *string) ([]string, *string, error) {
+		output, err := s.client.ListClusters(ctx, &ecs.ListClustersInput{NextToken: token})
+		if err != nil {
+			return nil, nil, err
+		}
+		return output.ClusterArns, output.NextToken, nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	var clusterNames []string
-	for _, clusterArn := range output.ClusterArns {
+	for _, clusterArn := range clusterArns {
 		// ARN形式からクラスター名を抽出
 		// arn:aws:ecs:region:account:cluster/cluster-name
-		parts := strings.Split(clusterArn, "/")
-		if len(parts) > 0 {
-			clusterNames = append(clusterNames, parts[len(parts)-1])
+		parsed, err := arn.Parse(clusterArn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse cluster ARN: %w", err)
 		}
+		clusterNames = append(clusterNames, parsed.ResourceName)
+	}
+
+	discovered := make(map[string]bool, len(clusterNames))
+	for _, clusterName := range clusterNames {
+		discovered[clusterName] = true
 	}
+	s.mu.Lock()
+	s.discoveredClusters = discovered
+	s.mu.Unlock()
 
 	return clusterNames, nil
 }
 
-// scanServicesInCluster は単一のクラスター内のサービスをスキャン
-func (s *Scanner) scanServicesInCluster(ctx context.Context, clusterName string) ([]models.ECSService, error) {
-	// サービス一覧を取得
-	listOutput, err := s.client.ListServices(ctx, &ecs.ListServicesInput{
-		Cluster: &clusterName,
+// ScanContainerInstances は指定されたクラスターのコンテナインスタンスを取得
+func (s *Scanner) ScanContainerInstances(ctx context.Context, clusterName string) ([]models.ContainerInstance, error) {
+	// コンテナインスタンス一覧を取得（ページネーション対応）
+	instanceArns, err := PaginateAll(ctx, func(token *string) ([]string, *string, error) {
+		listOutput, err := s.client.ListContainerInstances(ctx, &ecs.ListContainerInstancesInput{
+			Cluster:   &clusterName,
+			NextToken: token,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return listOutput.ContainerInstanceArns, listOutput.NextToken, nil
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	// サービスがない場合は空のスライスを返す
-	if len(listOutput.ServiceArns) == 0 {
-		return []models.ECSService{}, nil
+	instances := []models.ContainerInstance{}
+
+	// DescribeContainerInstancesは1回あたり最大100件まで
+	for start := 0; start < len(instanceArns); start += maxDescribeContainerInstances {
+		end := min(start+maxDescribeContainerInstances, len(instanceArns))
+		describeOutput, err := s.client.DescribeContainerInstances(ctx, &ecs.DescribeContainerInstancesInput{
+			Cluster:            &clusterName,
+			ContainerInstances: instanceArns[start:end],
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, instance := range describeOutput.ContainerInstances {
+			instances = append(instances, convertToContainerInstance(instance, clusterName))
+		}
+	}
+
+	return instances, nil
+}
+
+// ScanTaskDefinitions はタスク定義ファミリーごとにACTIVEなリビジョンを取得
+// familyを指定した場合はそのファミリーのみを対象とする
+func (s *Scanner) ScanTaskDefinitions(ctx context.Context, family string) ([]models.TaskDefinitionSummary, error) {
+	families := []string{family}
+	if family == "" {
+		var err error
+		families, err = s.listTaskDefinitionFamilies(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	summaries := []models.TaskDefinitionSummary{}
+	for _, name := range families {
+		revisions, err := s.listTaskDefinitionRevisions(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		if len(revisions) == 0 {
+			continue
+		}
+		summaries = append(summaries, models.TaskDefinitionSummary{
+			Family:         name,
+			Revisions:      revisions,
+			LatestRevision: revisions[len(revisions)-1],
+		})
+	}
+
+	return summaries, nil
+}
+
+// listTaskDefinitionFamilies はACTIVEなリビジョンを持つタスク定義ファミリーを取得（ページネーション対応）
+func (s *Scanner) listTaskDefinitionFamilies(ctx context.Context) ([]string, error) {
+	return PaginateAll(ctx, func(token *string) ([]string, *string, error) {
+		output, err := s.client.ListTaskDefinitionFamilies(ctx, &ecs.ListTaskDefinitionFamiliesInput{
+			Status:    types.TaskDefinitionFamilyStatusActive,
+			NextToken: token,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return output.Families, output.NextToken, nil
+	})
+}
+
+// listTaskDefinitionRevisions は指定したファミリーのACTIVEなリビジョン番号を昇順で取得（ページネーション対応）
+func (s *Scanner) listTaskDefinitionRevisions(ctx context.Context, family string) ([]int, error) {
+	taskDefArns, err := PaginateAll(ctx, func(token *string) ([]string, *string, error) {
+		output, err := s.client.ListTaskDefinitions(ctx, &ecs.ListTaskDefinitionsInput{
+			FamilyPrefix: &family,
+			Status:       types.TaskDefinitionStatusActive,
+			Sort:         types.SortOrderAsc,
+			NextToken:    token,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return output.TaskDefinitionArns, output.NextToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := []int{}
+	for _, taskDefArn := range taskDefArns {
+		// 念のためファミリー名が完全に一致するリビジョンのみを対象とする
+		taskDef := models.ECSTaskDefinition{TaskDefinitionArn: taskDefArn}
+		if name, revision := taskDef.GetFamilyAndRevision(); name == family && revision > 0 {
+			revisions = append(revisions, revision)
+		}
+	}
+	return revisions, nil
+}
+
+// maxDescribeContainerInstances はDescribeContainerInstancesで一度に指定できる最大件数
+const maxDescribeContainerInstances = 100
+
+// maxDescribeServices はDescribeServicesで一度に指定できる最大件数
+const maxDescribeServices = 10
+
+// convertToContainerInstance はAWSコンテナインスタンス情報をモデルに変換
+func convertToContainerInstance(instance types.ContainerInstance, clusterName string) models.ContainerInstance {
+	result := models.ContainerInstance{
+		ClusterName:       clusterName,
+		AgentConnected:    instance.AgentConnected,
+		RunningTasksCount: instance.RunningTasksCount,
+		PendingTasksCount: instance.PendingTasksCount,
+		RegisteredCPU:     resourceValue(instance.RegisteredResources, "CPU"),
+		RegisteredMemory:  resourceValue(instance.RegisteredResources, "MEMORY"),
+		RemainingCPU:      resourceValue(instance.RemainingResources, "CPU"),
+		RemainingMemory:   resourceValue(instance.RemainingResources, "MEMORY"),
+	}
+
+	if instance.ContainerInstanceArn != nil {
+		result.ContainerInstanceArn = *instance.ContainerInstanceArn
+	}
+	if instance.Ec2InstanceId != nil {
+		result.EC2InstanceID = *instance.Ec2InstanceId
+	}
+	if instance.Status != nil {
+		result.Status = *instance.Status
 	}
 
-	// サービス詳細を取得
-	describeOutput, err := s.client.DescribeServices(ctx, &ecs.DescribeServicesInput{
-		Cluster:  &clusterName,
-		Services: listOutput.ServiceArns,
+	return result
+}
+
+// resourceValue はリソース一覧から指定した名前の整数値を取得（存在しない場合は0）
+func resourceValue(resources []types.Resource, name string) int32 {
+	for _, resource := range resources {
+		if resource.Name != nil && *resource.Name == name {
+			return resource.IntegerValue
+		}
+	}
+	return 0
+}
+
+// scanServicesInCluster は単一のクラスター内のサービスをスキャン
+func (s *Scanner) scanServicesInCluster(ctx context.Context, clusterName string) ([]models.ECSService, error) {
+	// サービス一覧を取得（ページネーション対応）
+	serviceArns, err := PaginateAll(ctx, func(token *string) ([]string, *string, error) {
+		listOutput, err := s.client.ListServices(ctx, &ecs.ListServicesInput{
+			Cluster:   &clusterName,
+			NextToken: token,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return listOutput.ServiceArns, listOutput.NextToken, nil
 	})
+	// クラスターの一覧の取得後に削除された（INACTIVEになった）クラスターはスキップする
+	var notFoundErr *types.ClusterNotFoundException
+	if errors.As(err, &notFoundErr) && s.isDiscoveredCluster(clusterName) {
+		s.addWarning("skipped cluster %s: cluster not found", clusterName)
+		return []models.ECSService{}, nil
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	// AWS ECSサービス情報をモデルに変換
-	var services []models.ECSService
-	for _, service := range describeOutput.Services {
-		ecsService := s.convertToECSService(service, clusterName)
-		services = append(services, ecsService)
+	return s.DescribeServices(ctx, clusterName, serviceArns)
+}
+
+// DescribeServices は指定したサービス名またはARNのサービス詳細を取得
+// ListServicesが返さないINACTIVEのサービスも取得でき、存在しないサービスはスキップする
+func (s *Scanner) DescribeServices(ctx context.Context, clusterName string, serviceNames []string) ([]models.ECSService, error) {
+	// サービス詳細を取得（DescribeServicesは1回あたり最大10件まで）
+	services := []models.ECSService{}
+	for start := 0; start < len(serviceNames); start += maxDescribeServices {
+		end := min(start+maxDescribeServices, len(serviceNames))
+		describeOutput, err := s.client.DescribeServices(ctx, &ecs.DescribeServicesInput{
+			Cluster:  &clusterName,
+			Services: serviceNames[start:end],
+			Include:  []types.ServiceField{types.ServiceFieldTags},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		// AWS ECSサービス情報をモデルに変換
+		for _, service := range describeOutput.Services {
+			services = append(services, s.convertToECSService(service, clusterName))
+		}
 	}
 
 	return services, nil
@@ -130,5 +591,20 @@ func (s *Scanner) convertToECSService(service types.Service, clusterName string)
 		ecsService.CreatedAt = *service.CreatedAt
 	}
 
+	// タグをキーと値のマップに変換
+	for _, tag := range service.Tags {
+		if tag.Key == nil {
+			continue
+		}
+		if ecsService.Tags == nil {
+			ecsService.Tags = make(map[string]string)
+		}
+		value := ""
+		if tag.Value != nil {
+			value = *tag.Value
+		}
+		ecsService.Tags[*tag.Key] = value
+	}
+
 	return ecsService
 }
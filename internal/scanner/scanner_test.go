@@ -2,13 +2,18 @@ package scanner_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/service/ecs"
 	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/dev-shimada/phantom-ecs/internal/models"
 	"github.com/dev-shimada/phantom-ecs/internal/scanner"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockECSClient はECSクライアントのモック
@@ -46,6 +51,26 @@ func (m *MockECSClient) RegisterTaskDefinition(ctx context.Context, input *ecs.R
 	return args.Get(0).(*ecs.RegisterTaskDefinitionOutput), args.Error(1)
 }
 
+func (m *MockECSClient) ListContainerInstances(ctx context.Context, input *ecs.ListContainerInstancesInput) (*ecs.ListContainerInstancesOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*ecs.ListContainerInstancesOutput), args.Error(1)
+}
+
+func (m *MockECSClient) DescribeContainerInstances(ctx context.Context, input *ecs.DescribeContainerInstancesInput) (*ecs.DescribeContainerInstancesOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*ecs.DescribeContainerInstancesOutput), args.Error(1)
+}
+
+func (m *MockECSClient) ListTaskDefinitionFamilies(ctx context.Context, input *ecs.ListTaskDefinitionFamiliesInput) (*ecs.ListTaskDefinitionFamiliesOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*ecs.ListTaskDefinitionFamiliesOutput), args.Error(1)
+}
+
+func (m *MockECSClient) ListTaskDefinitions(ctx context.Context, input *ecs.ListTaskDefinitionsInput) (*ecs.ListTaskDefinitionsOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*ecs.ListTaskDefinitionsOutput), args.Error(1)
+}
+
 func TestScanner_ScanServices_SingleCluster(t *testing.T) {
 	mockClient := new(MockECSClient)
 	scanner := scanner.NewScanner(mockClient)
@@ -71,6 +96,7 @@ func TestScanner_ScanServices_SingleCluster(t *testing.T) {
 			"arn:aws:ecs:us-west-2:123456789012:service/test-cluster/web-service",
 			"arn:aws:ecs:us-west-2:123456789012:service/test-cluster/api-service",
 		},
+		Include: []types.ServiceField{types.ServiceFieldTags},
 	}).Return(
 		&ecs.DescribeServicesOutput{
 			Services: []types.Service{
@@ -82,6 +108,9 @@ func TestScanner_ScanServices_SingleCluster(t *testing.T) {
 					DesiredCount:   2,
 					RunningCount:   2,
 					Status:         stringPtr("ACTIVE"),
+					Tags: []types.Tag{
+						{Key: stringPtr("deploy-order"), Value: stringPtr("1")},
+					},
 				},
 				{
 					ServiceName:    stringPtr("api-service"),
@@ -105,6 +134,8 @@ func TestScanner_ScanServices_SingleCluster(t *testing.T) {
 	// 最初のサービスを検証
 	assert.Equal(t, "web-service", result[0].ServiceName)
 	assert.Equal(t, "test-cluster", result[0].ClusterName)
+	assert.Equal(t, map[string]string{"deploy-order": "1"}, result[0].Tags)
+	assert.Nil(t, result[1].Tags)
 	assert.Equal(t, "web-task:1", result[0].TaskDefinition)
 	assert.Equal(t, int32(2), result[0].DesiredCount)
 	assert.Equal(t, int32(2), result[0].RunningCount)
@@ -135,6 +166,7 @@ func TestScanner_ScanServices_MultipleClusters(t *testing.T) {
 	mockClient.On("DescribeServices", ctx, &ecs.DescribeServicesInput{
 		Cluster:  &clusters[0],
 		Services: []string{"arn:aws:ecs:us-west-2:123456789012:service/cluster1/service1"},
+		Include:  []types.ServiceField{types.ServiceFieldTags},
 	}).Return(
 		&ecs.DescribeServicesOutput{
 			Services: []types.Service{
@@ -161,6 +193,7 @@ func TestScanner_ScanServices_MultipleClusters(t *testing.T) {
 	mockClient.On("DescribeServices", ctx, &ecs.DescribeServicesInput{
 		Cluster:  &clusters[1],
 		Services: []string{"arn:aws:ecs:us-west-2:123456789012:service/cluster2/service2"},
+		Include:  []types.ServiceField{types.ServiceFieldTags},
 	}).Return(
 		&ecs.DescribeServicesOutput{
 			Services: []types.Service{
@@ -192,6 +225,107 @@ func TestScanner_ScanServices_MultipleClusters(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+func TestScanner_ScanServices_Paginated(t *testing.T) {
+	mockClient := new(MockECSClient)
+	scanner := scanner.NewScanner(mockClient)
+
+	ctx := context.Background()
+	clusterName := "big-cluster"
+
+	// ListServicesは1ページ最大10件のため、12件のサービスは2ページに分かれる
+	var serviceArns []string
+	for i := 1; i <= 12; i++ {
+		serviceArns = append(serviceArns, fmt.Sprintf("arn:aws:ecs:us-east-1:123456789012:service/big-cluster/service%d", i))
+	}
+	mockClient.On("ListServices", ctx, &ecs.ListServicesInput{Cluster: &clusterName}).Return(
+		&ecs.ListServicesOutput{ServiceArns: serviceArns[:10], NextToken: stringPtr("page2")}, nil)
+	mockClient.On("ListServices", ctx, &ecs.ListServicesInput{Cluster: &clusterName, NextToken: stringPtr("page2")}).Return(
+		&ecs.ListServicesOutput{ServiceArns: serviceArns[10:]}, nil)
+
+	// DescribeServicesは1回あたり最大10件のため分割して呼び出す
+	describeOutput := func(arns []string) *ecs.DescribeServicesOutput {
+		output := &ecs.DescribeServicesOutput{}
+		for _, serviceArn := range arns {
+			output.Services = append(output.Services, types.Service{
+				ServiceName: stringPtr(serviceArn[strings.LastIndex(serviceArn, "/")+1:]),
+				ServiceArn:  stringPtr(serviceArn),
+				Status:      stringPtr("ACTIVE"),
+			})
+		}
+		return output
+	}
+	for _, arns := range [][]string{serviceArns[:10], serviceArns[10:]} {
+		mockClient.On("DescribeServices", ctx, &ecs.DescribeServicesInput{
+			Cluster:  &clusterName,
+			Services: arns,
+			Include:  []types.ServiceField{types.ServiceFieldTags},
+		}).Return(describeOutput(arns), nil).Once()
+	}
+
+	result, err := scanner.ScanServices(ctx, []string{clusterName})
+
+	require.NoError(t, err)
+	require.Len(t, result, 12)
+	assert.Equal(t, "service1", result[0].ServiceName)
+	assert.Equal(t, "service11", result[10].ServiceName)
+	assert.Equal(t, "service12", result[11].ServiceName)
+	mockClient.AssertExpectations(t)
+}
+
+func TestScanner_ScanServices_SkipsMissingCluster(t *testing.T) {
+	mockClient := new(MockECSClient)
+	scanner := scanner.NewScanner(mockClient)
+
+	ctx := context.Background()
+	clusters := []string{"deleted-cluster", "cluster1"}
+
+	// 存在しない（クラスターの一覧の取得後に削除された）クラスター
+	mockClient.On("ListServices", ctx, &ecs.ListServicesInput{
+		Cluster: &clusters[0],
+	}).Return((*ecs.ListServicesOutput)(nil), &types.ClusterNotFoundException{Message: stringPtr("Cluster not found.")})
+
+	mockClient.On("ListServices", ctx, &ecs.ListServicesInput{
+		Cluster: &clusters[1],
+	}).Return(&ecs.ListServicesOutput{
+		ServiceArns: []string{"arn:aws:ecs:us-west-2:123456789012:service/cluster1/service1"},
+	}, nil)
+	mockClient.On("DescribeServices", ctx, mock.Anything).Return(&ecs.DescribeServicesOutput{
+		Services: []types.Service{{
+			ServiceName:    stringPtr("service1"),
+			TaskDefinition: stringPtr("task1:1"),
+			Status:         stringPtr("ACTIVE"),
+		}},
+	}, nil)
+
+	// 利用者が指定したクラスターが存在しない場合はエラーにする
+	_, err := scanner.ScanServices(ctx, clusters)
+	var notFoundErr *types.ClusterNotFoundException
+	require.ErrorAs(t, err, &notFoundErr)
+	assert.Empty(t, scanner.Warnings())
+
+	mockClient.On("ListClusters", ctx, &ecs.ListClustersInput{}).Return(&ecs.ListClustersOutput{
+		ClusterArns: []string{
+			"arn:aws:ecs:us-west-2:123456789012:cluster/deleted-cluster",
+			"arn:aws:ecs:us-west-2:123456789012:cluster/cluster1",
+		},
+	}, nil)
+	discovered, err := scanner.DiscoverClusters(ctx)
+	require.NoError(t, err)
+
+	result, err := scanner.ScanServices(ctx, discovered)
+
+	// 発見したクラスターが削除されていた場合はスキャンを失敗させず、スキップしたクラスターを警告として記録する
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "cluster1", result[0].ClusterName)
+	assert.Equal(t, []string{"skipped cluster deleted-cluster: cluster not found"}, scanner.Warnings())
+
+	// 次のスキャンでは前回の警告を破棄する
+	_, err = scanner.ScanServices(ctx, clusters[1:])
+	require.NoError(t, err)
+	assert.Empty(t, scanner.Warnings())
+}
+
 func TestScanner_DiscoverClusters(t *testing.T) {
 	mockClient := new(MockECSClient)
 	scanner := scanner.NewScanner(mockClient)
@@ -221,6 +355,31 @@ func TestScanner_DiscoverClusters(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+func TestScanner_DiscoverClusters_Paginated(t *testing.T) {
+	mockClient := new(MockECSClient)
+	scanner := scanner.NewScanner(mockClient)
+
+	ctx := context.Background()
+	nextToken := "page-2"
+
+	mockClient.On("ListClusters", ctx, &ecs.ListClustersInput{}).Return(
+		&ecs.ListClustersOutput{
+			ClusterArns: []string{"arn:aws:ecs:us-west-2:123456789012:cluster/cluster1"},
+			NextToken:   &nextToken,
+		}, nil)
+	mockClient.On("ListClusters", ctx, &ecs.ListClustersInput{NextToken: &nextToken}).Return(
+		&ecs.ListClustersOutput{
+			ClusterArns: []string{"arn:aws:ecs:us-west-2:123456789012:cluster/cluster2"},
+		}, nil)
+
+	clusters, err := scanner.DiscoverClusters(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cluster1", "cluster2"}, clusters)
+
+	mockClient.AssertExpectations(t)
+}
+
 func TestScanner_ScanServices_EmptyCluster(t *testing.T) {
 	mockClient := new(MockECSClient)
 	scanner := scanner.NewScanner(mockClient)
@@ -246,7 +405,427 @@ func TestScanner_ScanServices_EmptyCluster(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+func TestScanner_EnrichTaskDefinitions_PartialFailure(t *testing.T) {
+	mockClient := new(MockECSClient)
+	scanner := scanner.NewScanner(mockClient)
+
+	ctx := context.Background()
+
+	services := []models.ECSService{
+		{ServiceName: "web-service", ClusterName: "test-cluster", TaskDefinition: "web-task:1"},
+		{ServiceName: "api-service", ClusterName: "test-cluster", TaskDefinition: "api-task:1"},
+		{ServiceName: "worker-service", ClusterName: "test-cluster", TaskDefinition: "worker-task:1"},
+	}
+
+	mockClient.On("DescribeTaskDefinition", ctx, &ecs.DescribeTaskDefinitionInput{
+		TaskDefinition: stringPtr("web-task:1"),
+	}).Return(&ecs.DescribeTaskDefinitionOutput{
+		TaskDefinition: &types.TaskDefinition{
+			Cpu:         stringPtr("256"),
+			Memory:      stringPtr("512"),
+			NetworkMode: types.NetworkModeAwsvpc,
+		},
+	}, nil)
+
+	// 2番目のタスク定義の取得は失敗
+	mockClient.On("DescribeTaskDefinition", ctx, &ecs.DescribeTaskDefinitionInput{
+		TaskDefinition: stringPtr("api-task:1"),
+	}).Return((*ecs.DescribeTaskDefinitionOutput)(nil), errors.New("AccessDeniedException"))
+
+	mockClient.On("DescribeTaskDefinition", ctx, &ecs.DescribeTaskDefinitionInput{
+		TaskDefinition: stringPtr("worker-task:1"),
+	}).Return(&ecs.DescribeTaskDefinitionOutput{
+		TaskDefinition: &types.TaskDefinition{
+			Cpu:         stringPtr("1024"),
+			Memory:      stringPtr("2048"),
+			NetworkMode: types.NetworkModeBridge,
+		},
+	}, nil)
+
+	// テスト実行
+	result := scanner.EnrichTaskDefinitions(ctx, services)
+
+	// アサーション - 失敗したサービスを含め全てのサービスが返される
+	assert.Len(t, result, 3)
+	assert.Equal(t, []string{"task definition unavailable for service api-service in cluster test-cluster: AccessDeniedException"}, scanner.Warnings())
+
+	assert.Equal(t, "web-service", result[0].ServiceName)
+	assert.False(t, result[0].TaskDefinitionUnavailable)
+	assert.Equal(t, "256", result[0].TaskDefinitionDetails.CPU)
+	assert.Equal(t, "512", result[0].TaskDefinitionDetails.Memory)
+
+	assert.Equal(t, "api-service", result[1].ServiceName)
+	assert.True(t, result[1].TaskDefinitionUnavailable)
+	assert.Nil(t, result[1].TaskDefinitionDetails)
+
+	assert.Equal(t, "worker-service", result[2].ServiceName)
+	assert.False(t, result[2].TaskDefinitionUnavailable)
+	assert.Equal(t, "bridge", result[2].TaskDefinitionDetails.NetworkMode)
+
+	mockClient.AssertExpectations(t)
+}
+
 // ヘルパー関数
 func stringPtr(s string) *string {
 	return &s
 }
+
+func TestExcludeInactive(t *testing.T) {
+	services := []models.ECSService{
+		{ServiceName: "active-service", Status: "ACTIVE"},
+		{ServiceName: "deleted-service", Status: "INACTIVE"},
+		{ServiceName: "draining-service", Status: "DRAINING"},
+	}
+
+	result := scanner.ExcludeInactive(services)
+
+	assert.Len(t, result, 2)
+	assert.Equal(t, "active-service", result[0].ServiceName)
+	assert.Equal(t, "draining-service", result[1].ServiceName)
+}
+
+func TestScanner_DescribeServices_Inactive(t *testing.T) {
+	mockClient := new(MockECSClient)
+	scanner := scanner.NewScanner(mockClient)
+
+	ctx := context.Background()
+	clusterName := "test-cluster"
+
+	// 削除済みのサービスはListServicesに含まれないため、名前を指定して取得する
+	mockClient.On("DescribeServices", ctx, &ecs.DescribeServicesInput{
+		Cluster:  &clusterName,
+		Services: []string{"deleted-service", "unknown-service"},
+		Include:  []types.ServiceField{types.ServiceFieldTags},
+	}).Return(
+		&ecs.DescribeServicesOutput{
+			Services: []types.Service{
+				{ServiceName: stringPtr("deleted-service"), Status: stringPtr("INACTIVE")},
+			},
+			Failures: []types.Failure{
+				{Arn: stringPtr("arn:aws:ecs:us-west-2:123456789012:service/test-cluster/unknown-service"), Reason: stringPtr("MISSING")},
+			},
+		}, nil)
+
+	result, err := scanner.DescribeServices(ctx, clusterName, []string{"deleted-service", "unknown-service"})
+
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "deleted-service", result[0].ServiceName)
+	assert.Equal(t, "test-cluster", result[0].ClusterName)
+	assert.Equal(t, "INACTIVE", result[0].Status)
+	mockClient.AssertExpectations(t)
+}
+
+func TestScanner_ScanContainerInstances(t *testing.T) {
+	mockClient := new(MockECSClient)
+	scanner := scanner.NewScanner(mockClient)
+
+	ctx := context.Background()
+	clusterName := "ec2-cluster"
+	instanceArns := []string{
+		"arn:aws:ecs:us-east-1:123456789012:container-instance/ec2-cluster/aaa",
+		"arn:aws:ecs:us-east-1:123456789012:container-instance/ec2-cluster/bbb",
+	}
+
+	mockClient.On("ListContainerInstances", ctx, &ecs.ListContainerInstancesInput{
+		Cluster: &clusterName,
+	}).Return(&ecs.ListContainerInstancesOutput{ContainerInstanceArns: instanceArns}, nil)
+
+	resources := func(cpu, memory int32) []types.Resource {
+		return []types.Resource{
+			{Name: stringPtr("CPU"), Type: stringPtr("INTEGER"), IntegerValue: cpu},
+			{Name: stringPtr("MEMORY"), Type: stringPtr("INTEGER"), IntegerValue: memory},
+			{Name: stringPtr("PORTS"), Type: stringPtr("STRINGSET"), StringSetValue: []string{"22"}},
+		}
+	}
+	mockClient.On("DescribeContainerInstances", ctx, &ecs.DescribeContainerInstancesInput{
+		Cluster:            &clusterName,
+		ContainerInstances: instanceArns,
+	}).Return(&ecs.DescribeContainerInstancesOutput{
+		ContainerInstances: []types.ContainerInstance{
+			{
+				ContainerInstanceArn: &instanceArns[0],
+				Ec2InstanceId:        stringPtr("i-0123456789abcdef0"),
+				Status:               stringPtr("ACTIVE"),
+				AgentConnected:       true,
+				RunningTasksCount:    3,
+				RegisteredResources:  resources(2048, 7982),
+				RemainingResources:   resources(1024, 3886),
+			},
+			{
+				ContainerInstanceArn: &instanceArns[1],
+				Ec2InstanceId:        stringPtr("i-0fedcba9876543210"),
+				Status:               stringPtr("DRAINING"),
+				PendingTasksCount:    1,
+				RegisteredResources:  resources(2048, 7982),
+				RemainingResources:   resources(2048, 7982),
+			},
+		},
+	}, nil)
+
+	instances, err := scanner.ScanContainerInstances(ctx, clusterName)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.ContainerInstance{
+		{
+			ContainerInstanceArn: instanceArns[0],
+			EC2InstanceID:        "i-0123456789abcdef0",
+			ClusterName:          clusterName,
+			Status:               "ACTIVE",
+			AgentConnected:       true,
+			RunningTasksCount:    3,
+			RegisteredCPU:        2048,
+			RegisteredMemory:     7982,
+			RemainingCPU:         1024,
+			RemainingMemory:      3886,
+		},
+		{
+			ContainerInstanceArn: instanceArns[1],
+			EC2InstanceID:        "i-0fedcba9876543210",
+			ClusterName:          clusterName,
+			Status:               "DRAINING",
+			PendingTasksCount:    1,
+			RegisteredCPU:        2048,
+			RegisteredMemory:     7982,
+			RemainingCPU:         2048,
+			RemainingMemory:      7982,
+		},
+	}, instances)
+	mockClient.AssertExpectations(t)
+}
+
+func TestScanner_ScanContainerInstances_Empty(t *testing.T) {
+	mockClient := new(MockECSClient)
+	scanner := scanner.NewScanner(mockClient)
+
+	ctx := context.Background()
+	clusterName := "fargate-cluster"
+
+	mockClient.On("ListContainerInstances", ctx, &ecs.ListContainerInstancesInput{
+		Cluster: &clusterName,
+	}).Return(&ecs.ListContainerInstancesOutput{}, nil)
+
+	instances, err := scanner.ScanContainerInstances(ctx, clusterName)
+
+	assert.NoError(t, err)
+	assert.Empty(t, instances)
+	mockClient.AssertNotCalled(t, "DescribeContainerInstances", mock.Anything, mock.Anything)
+}
+
+func TestScanner_ScanTaskDefinitions(t *testing.T) {
+	mockClient := new(MockECSClient)
+	scanner := scanner.NewScanner(mockClient)
+
+	ctx := context.Background()
+	taskDefArn := func(family string, revision int) string {
+		return fmt.Sprintf("arn:aws:ecs:us-east-1:123456789012:task-definition/%s:%d", family, revision)
+	}
+	listInput := func(family string) *ecs.ListTaskDefinitionsInput {
+		return &ecs.ListTaskDefinitionsInput{
+			FamilyPrefix: &family,
+			Status:       types.TaskDefinitionStatusActive,
+			Sort:         types.SortOrderAsc,
+		}
+	}
+
+	mockClient.On("ListTaskDefinitionFamilies", ctx, &ecs.ListTaskDefinitionFamiliesInput{
+		Status: types.TaskDefinitionFamilyStatusActive,
+	}).Return(&ecs.ListTaskDefinitionFamiliesOutput{Families: []string{"api-task", "web-task"}}, nil)
+	mockClient.On("ListTaskDefinitions", ctx, listInput("api-task")).Return(&ecs.ListTaskDefinitionsOutput{
+		TaskDefinitionArns: []string{taskDefArn("api-task", 1)},
+	}, nil)
+	// 2ページに分かれたリビジョン一覧
+	mockClient.On("ListTaskDefinitions", ctx, listInput("web-task")).Return(&ecs.ListTaskDefinitionsOutput{
+		TaskDefinitionArns: []string{taskDefArn("web-task", 3), taskDefArn("web-task", 5)},
+		NextToken:          stringPtr("page-2"),
+	}, nil).Once()
+	secondPage := listInput("web-task")
+	secondPage.NextToken = stringPtr("page-2")
+	mockClient.On("ListTaskDefinitions", ctx, secondPage).Return(&ecs.ListTaskDefinitionsOutput{
+		TaskDefinitionArns: []string{taskDefArn("web-task", 6)},
+	}, nil)
+
+	summaries, err := scanner.ScanTaskDefinitions(ctx, "")
+
+	require.NoError(t, err)
+	assert.Equal(t, []models.TaskDefinitionSummary{
+		{Family: "api-task", Revisions: []int{1}, LatestRevision: 1},
+		{Family: "web-task", Revisions: []int{3, 5, 6}, LatestRevision: 6},
+	}, summaries)
+	mockClient.AssertExpectations(t)
+}
+
+func TestScanner_ScanTaskDefinitions_Family(t *testing.T) {
+	mockClient := new(MockECSClient)
+	scanner := scanner.NewScanner(mockClient)
+
+	ctx := context.Background()
+	family := "web-task"
+
+	mockClient.On("ListTaskDefinitions", ctx, &ecs.ListTaskDefinitionsInput{
+		FamilyPrefix: &family,
+		Status:       types.TaskDefinitionStatusActive,
+		Sort:         types.SortOrderAsc,
+	}).Return(&ecs.ListTaskDefinitionsOutput{
+		TaskDefinitionArns: []string{"arn:aws:ecs:us-east-1:123456789012:task-definition/web-task:2"},
+	}, nil)
+
+	summaries, err := scanner.ScanTaskDefinitions(ctx, family)
+
+	require.NoError(t, err)
+	require.Len(t, summaries, 1)
+	assert.Equal(t, []int{2}, summaries[0].Revisions)
+	mockClient.AssertNotCalled(t, "ListTaskDefinitionFamilies", mock.Anything, mock.Anything)
+}
+
+func TestScanner_ScanServices_MaxResultsExceeded(t *testing.T) {
+	mockClient := new(MockECSClient)
+	s := scanner.NewScanner(mockClient)
+	s.SetMaxResults(2)
+
+	ctx := context.Background()
+	clusterName := "big-cluster"
+	serviceArns := []string{"svc-1", "svc-2", "svc-3"}
+
+	mockClient.On("ListServices", ctx, &ecs.ListServicesInput{Cluster: &clusterName}).Return(
+		&ecs.ListServicesOutput{ServiceArns: serviceArns}, nil)
+	mockClient.On("DescribeServices", ctx, mock.Anything).Return(&ecs.DescribeServicesOutput{
+		Services: []types.Service{
+			{ServiceName: stringPtr("svc-1")},
+			{ServiceName: stringPtr("svc-2")},
+			{ServiceName: stringPtr("svc-3")},
+		},
+	}, nil)
+
+	services, err := s.ScanServices(ctx, []string{clusterName, "other-cluster"})
+
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, scanner.ErrMaxResultsExceeded))
+	assert.Contains(t, err.Error(), "--cluster")
+	assert.Nil(t, services)
+	// 上限を超えた時点で残りのクラスターはスキャンしない
+	mockClient.AssertNotCalled(t, "ListServices", ctx, &ecs.ListServicesInput{Cluster: stringPtr("other-cluster")})
+}
+
+func TestCheckMaxResults(t *testing.T) {
+	assert.NoError(t, scanner.CheckMaxResults(1000, 1000))
+	assert.NoError(t, scanner.CheckMaxResults(5000, 0))
+	assert.ErrorIs(t, scanner.CheckMaxResults(1001, 1000), scanner.ErrMaxResultsExceeded)
+}
+
+func TestScanner_ScanServices_Concurrent(t *testing.T) {
+	clusters := []string{"cluster1", "cluster2", "cluster3"}
+	newMockClient := func(failCluster string) *MockECSClient {
+		mockClient := new(MockECSClient)
+		for _, clusterName := range clusters {
+			serviceArn := "service-" + clusterName
+			var err error
+			if clusterName == failCluster {
+				err = errors.New("access denied")
+			}
+			mockClient.On("ListServices", mock.Anything, &ecs.ListServicesInput{Cluster: stringPtr(clusterName)}).Return(
+				&ecs.ListServicesOutput{ServiceArns: []string{serviceArn}}, err).Maybe()
+			mockClient.On("DescribeServices", mock.Anything, &ecs.DescribeServicesInput{
+				Cluster:  stringPtr(clusterName),
+				Services: []string{serviceArn},
+				Include:  []types.ServiceField{types.ServiceFieldTags},
+			}).Return(&ecs.DescribeServicesOutput{
+				Services: []types.Service{{ServiceName: stringPtr(serviceArn), Status: stringPtr("ACTIVE")}},
+			}, nil).Maybe()
+		}
+		return mockClient
+	}
+
+	t.Run("結果は指定したクラスターの順に返す", func(t *testing.T) {
+		s := scanner.NewScanner(newMockClient(""))
+		s.SetConcurrency(2)
+
+		services, err := s.ScanServices(context.Background(), clusters)
+		require.NoError(t, err)
+		require.Len(t, services, 3)
+		for i, clusterName := range clusters {
+			assert.Equal(t, clusterName, services[i].ClusterName)
+			assert.Equal(t, "service-"+clusterName, services[i].ServiceName)
+		}
+	})
+
+	t.Run("いずれかのクラスターが失敗した場合はエラー", func(t *testing.T) {
+		s := scanner.NewScanner(newMockClient("cluster2"))
+		s.SetConcurrency(3)
+
+		services, err := s.ScanServices(context.Background(), clusters)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "access denied")
+		assert.Nil(t, services)
+	})
+
+	t.Run("上限を超えた場合はエラー", func(t *testing.T) {
+		s := scanner.NewScanner(newMockClient(""))
+		s.SetConcurrency(2)
+		s.SetMaxResults(2)
+
+		services, err := s.ScanServices(context.Background(), clusters)
+		assert.ErrorIs(t, err, scanner.ErrMaxResultsExceeded)
+		assert.Nil(t, services)
+	})
+}
+
+func TestScanner_ScanServices_ContinueOnError(t *testing.T) {
+	clusters := []string{"cluster1", "cluster2", "cluster3"}
+	newMockClient := func() *MockECSClient {
+		mockClient := new(MockECSClient)
+		for _, clusterName := range clusters {
+			serviceArn := "service-" + clusterName
+			if clusterName == "cluster2" {
+				mockClient.On("ListServices", mock.Anything, &ecs.ListServicesInput{Cluster: stringPtr(clusterName)}).Return(
+					(*ecs.ListServicesOutput)(nil), errors.New("access denied"))
+				continue
+			}
+			mockClient.On("ListServices", mock.Anything, &ecs.ListServicesInput{Cluster: stringPtr(clusterName)}).Return(
+				&ecs.ListServicesOutput{ServiceArns: []string{serviceArn}}, nil)
+			mockClient.On("DescribeServices", mock.Anything, &ecs.DescribeServicesInput{
+				Cluster:  stringPtr(clusterName),
+				Services: []string{serviceArn},
+				Include:  []types.ServiceField{types.ServiceFieldTags},
+			}).Return(&ecs.DescribeServicesOutput{
+				Services: []types.Service{{ServiceName: stringPtr(serviceArn), Status: stringPtr("ACTIVE")}},
+			}, nil)
+		}
+		return mockClient
+	}
+
+	for _, concurrency := range []int{1, 3} {
+		t.Run(fmt.Sprintf("同時実行数%d", concurrency), func(t *testing.T) {
+			mockClient := newMockClient()
+			s := scanner.NewScanner(mockClient)
+			s.SetConcurrency(concurrency)
+			s.SetContinueOnError(true)
+
+			services, err := s.ScanServices(context.Background(), clusters)
+
+			// 失敗したクラスター以外のサービスは返される
+			require.Len(t, services, 2)
+			assert.Equal(t, "cluster1", services[0].ClusterName)
+			assert.Equal(t, "cluster3", services[1].ClusterName)
+
+			var partialErr *scanner.PartialScanError
+			require.ErrorAs(t, err, &partialErr)
+			assert.Equal(t, 3, partialErr.ClusterCount)
+			require.Len(t, partialErr.Failures, 1)
+			assert.Equal(t, "cluster2", partialErr.Failures[0].ClusterName)
+			assert.EqualError(t, err, "failed to scan 1 of 3 clusters: cluster2: access denied")
+			mockClient.AssertExpectations(t)
+		})
+	}
+
+	t.Run("全クラスター成功時はエラーなし", func(t *testing.T) {
+		mockClient := new(MockECSClient)
+		mockClient.On("ListServices", mock.Anything, mock.Anything).Return(&ecs.ListServicesOutput{}, nil)
+		s := scanner.NewScanner(mockClient)
+		s.SetContinueOnError(true)
+
+		services, err := s.ScanServices(context.Background(), clusters)
+		require.NoError(t, err)
+		assert.Empty(t, services)
+	})
+}
@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+)
+
+// serviceColumn はサービス一覧のテーブルで選択できる列
+type serviceColumn struct {
+	name  string
+	value func(service models.ECSService) string
+}
+
+// serviceColumns はサービス一覧のテーブルで選択できる列（列名はJSON出力のキーと同じ）
+var serviceColumns = []serviceColumn{
+	{"service_name", func(s models.ECSService) string { return s.ServiceName }},
+	{"cluster_name", func(s models.ECSService) string { return s.ClusterName }},
+	{"status", func(s models.ECSService) string { return s.Status }},
+	{"task_definition", func(s models.ECSService) string { return s.TaskDefinition }},
+	{"desired_count", func(s models.ECSService) string { return strconv.Itoa(int(s.DesiredCount)) }},
+	{"running_count", func(s models.ECSService) string { return strconv.Itoa(int(s.RunningCount)) }},
+	{"launch_type", func(s models.ECSService) string { return s.LaunchType }},
+	{"scheduling_strategy", func(s models.ECSService) string { return s.SchedulingStrategy }},
+}
+
+// ServiceColumnNames はサービス一覧のテーブルで選択できる列名一覧を返す
+func ServiceColumnNames() []string {
+	names := make([]string, 0, len(serviceColumns))
+	for _, column := range serviceColumns {
+		names = append(names, column.name)
+	}
+	return names
+}
+
+// ValidateServiceColumns はサービス一覧のテーブルの列名を検証
+func ValidateServiceColumns(columns []string) error {
+	for _, name := range columns {
+		if _, ok := findServiceColumn(name); !ok {
+			return fmt.Errorf("unsupported column: %s. Supported columns: %v", name, ServiceColumnNames())
+		}
+	}
+	return nil
+}
+
+// findServiceColumn は列名に対応する列を返す
+func findServiceColumn(name string) (serviceColumn, bool) {
+	for _, column := range serviceColumns {
+		if column.name == name {
+			return column, true
+		}
+	}
+	return serviceColumn{}, false
+}
+
+// formatECSServicesColumnsTable はECSサービス一覧を指定した列のみのテーブル形式でフォーマット
+func (f *Formatter) formatECSServicesColumnsTable(services []models.ECSService, columns []string) (string, error) {
+	if err := ValidateServiceColumns(columns); err != nil {
+		return "", err
+	}
+	if len(services) == 0 {
+		return "No services found.", nil
+	}
+
+	headers := make([]string, 0, len(columns))
+	for _, name := range columns {
+		headers = append(headers, strings.ToUpper(strings.ReplaceAll(name, "_", " ")))
+	}
+
+	rows := make([][]string, 0, len(services))
+	for _, service := range services {
+		row := make([]string, 0, len(columns))
+		for _, name := range columns {
+			column, _ := findServiceColumn(name)
+			row = append(row, column.value(service))
+		}
+		rows = append(rows, row)
+	}
+	return f.formatDynamicTable(headers, rows), nil
+}
@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+)
+
+// ValidateContainerPattern はコンテナ名のパターンがpath.Matchの形式として正しいか検証
+func ValidateContainerPattern(pattern string) error {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return fmt.Errorf("invalid container pattern %q: %w", pattern, err)
+	}
+	return nil
+}
+
+// FilterContainers は名前がパターン（path.Match形式）に一致するコンテナのみを残した結果を返す
+// 除外したコンテナ数はContainerFilterに記録し、元の結果は変更しない
+func FilterContainers(result models.InspectionResult, pattern string) (models.InspectionResult, error) {
+	if err := ValidateContainerPattern(pattern); err != nil {
+		return result, err
+	}
+
+	filtered := result
+	filtered.TaskDefinition.Containers = nil
+	for _, container := range result.TaskDefinition.Containers {
+		// パターンは検証済みのためエラーは発生しない
+		if matched, _ := path.Match(pattern, container.Name); matched {
+			filtered.TaskDefinition.Containers = append(filtered.TaskDefinition.Containers, container)
+		}
+	}
+
+	matched := len(filtered.TaskDefinition.Containers)
+	filtered.ContainerFilter = &models.ContainerFilter{
+		Pattern: pattern,
+		Matched: matched,
+		Omitted: len(result.TaskDefinition.Containers) - matched,
+	}
+	return filtered, nil
+}
@@ -0,0 +1,48 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+	"github.com/dev-shimada/phantom-ecs/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func containerResult(names ...string) models.InspectionResult {
+	result := models.InspectionResult{TaskDefinition: models.ECSTaskDefinition{Family: "web-task"}}
+	for _, name := range names {
+		result.TaskDefinition.Containers = append(result.TaskDefinition.Containers, models.ContainerDefinition{Name: name})
+	}
+	return result
+}
+
+func TestFilterContainers(t *testing.T) {
+	result := containerResult("web-app", "web-proxy", "log-router", "datadog-agent")
+
+	filtered, err := utils.FilterContainers(result, "web-*")
+
+	require.NoError(t, err)
+	require.Len(t, filtered.TaskDefinition.Containers, 2)
+	assert.Equal(t, "web-app", filtered.TaskDefinition.Containers[0].Name)
+	assert.Equal(t, "web-proxy", filtered.TaskDefinition.Containers[1].Name)
+	assert.Equal(t, &models.ContainerFilter{Pattern: "web-*", Matched: 2, Omitted: 2}, filtered.ContainerFilter)
+
+	// 元の結果は変更しない
+	assert.Len(t, result.TaskDefinition.Containers, 4)
+	assert.Nil(t, result.ContainerFilter)
+}
+
+func TestFilterContainers_NoMatch(t *testing.T) {
+	filtered, err := utils.FilterContainers(containerResult("app", "sidecar"), "web-*")
+
+	require.NoError(t, err)
+	assert.Empty(t, filtered.TaskDefinition.Containers)
+	assert.Equal(t, 2, filtered.ContainerFilter.Omitted)
+}
+
+func TestFilterContainers_InvalidPattern(t *testing.T) {
+	_, err := utils.FilterContainers(containerResult("app"), "web-[")
+
+	assert.ErrorContains(t, err, "invalid container pattern")
+}
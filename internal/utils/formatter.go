@@ -1,22 +1,48 @@
 package utils
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/dev-shimada/phantom-ecs/internal/models"
 	"gopkg.in/yaml.v3"
 )
 
 // Formatter は出力フォーマット機能を提供
-type Formatter struct{}
+type Formatter struct {
+	wide      bool // テーブルの値を切り詰めない
+	truncated bool // テーブルの出力中に値を切り詰めた
+}
 
 // FormatOptions はフォーマットオプションを表す構造体
 type FormatOptions struct {
-	Format       string `json:"format"`        // json, yaml, table, compact
-	PrettyPrint  bool   `json:"pretty_print"`  // プリティプリント有効
-	IncludeEmpty bool   `json:"include_empty"` // 空の値を含める
+	Format       string   `json:"format"`        // json, yaml, table, compact
+	PrettyPrint  bool     `json:"pretty_print"`  // プリティプリント有効
+	IncludeEmpty bool     `json:"include_empty"` // 空の値を含める
+	Flatten      bool     `json:"flatten"`       // ネストしたキーをドット区切りで平坦化 (json, yamlのみ)
+	Indent       string   `json:"indent"`        // JSONのインデント文字列 (空の場合はDefaultJSONIndent)
+	Columns      []string `json:"columns"`       // サービス一覧のテーブルに表示する列 (空の場合は既定の列、tableのみ)
+	YAMLFlow     bool     `json:"yaml_flow"`     // YAMLをフロースタイル ([]や{}によるインライン表記) で出力 (yamlのみ)
+	Wide         bool     `json:"wide"`          // 値を切り詰めずに出力 (tableのみ)
+}
+
+// DefaultJSONIndent はJSONのデフォルトのインデント文字列
+const DefaultJSONIndent = "  "
+
+// TruncationNote はテーブルの値を切り詰めた場合に末尾へ付ける注記
+const TruncationNote = `Note: some values were truncated ("..."). Use --wide or --output json to see the full values.`
+
+// formatAliases は出力形式の短縮名と正式名の対応
+var formatAliases = map[string]string{
+	"j": "json",
+	"y": "yaml",
+	"t": "table",
+	"c": "compact",
 }
 
 // NewFormatter は新しいFormatterインスタンスを作成
@@ -26,7 +52,12 @@ func NewFormatter() *Formatter {
 
 // FormatJSON はデータをJSON形式でフォーマット
 func (f *Formatter) FormatJSON(data interface{}) (string, error) {
-	jsonBytes, err := json.MarshalIndent(data, "", "  ")
+	return f.formatJSONWithIndent(data, DefaultJSONIndent)
+}
+
+// formatJSONWithIndent はデータを指定したインデントのJSON形式でフォーマット
+func (f *Formatter) formatJSONWithIndent(data interface{}, indent string) (string, error) {
+	jsonBytes, err := json.MarshalIndent(data, "", indent)
 	if err != nil {
 		return "", err
 	}
@@ -42,6 +73,35 @@ func (f *Formatter) FormatYAML(data interface{}) (string, error) {
 	return string(yamlBytes), nil
 }
 
+// formatYAMLFlow はデータをフロースタイル（[]や{}によるインライン表記）のYAML形式でフォーマット
+func (f *Formatter) formatYAMLFlow(data interface{}) (string, error) {
+	var node yaml.Node
+	if err := node.Encode(data); err != nil {
+		return "", err
+	}
+	setYAMLFlowStyle(&node)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	if err := encoder.Encode(&node); err != nil {
+		return "", err
+	}
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// setYAMLFlowStyle はノード配下のマッピングとシーケンスをすべてフロースタイルに設定
+func setYAMLFlowStyle(node *yaml.Node) {
+	if node.Kind == yaml.MappingNode || node.Kind == yaml.SequenceNode {
+		node.Style |= yaml.FlowStyle
+	}
+	for _, child := range node.Content {
+		setYAMLFlowStyle(child)
+	}
+}
+
 // FormatTable はデータをテーブル形式でフォーマット
 func (f *Formatter) FormatTable(data interface{}) (string, error) {
 	switch v := data.(type) {
@@ -51,6 +111,28 @@ func (f *Formatter) FormatTable(data interface{}) (string, error) {
 		return f.formatDeploymentResultTable(v), nil
 	case models.InspectionResult:
 		return f.formatInspectionResultTable(v), nil
+	case []models.InspectionResult:
+		return f.formatInspectionResultsTable(v), nil
+	case models.ScanSummary:
+		return f.formatScanSummaryTable(v), nil
+	case models.TaskDefinitionDiff:
+		return f.formatTaskDefinitionDiffTable(v), nil
+	case []models.ContainerInstance:
+		return f.formatContainerInstancesTable(v), nil
+	case models.ClusterServicesDiff:
+		return f.formatClusterServicesDiffTable(v), nil
+	case []models.TaskDefinitionSummary:
+		return f.formatTaskDefinitionSummariesTable(v), nil
+	case models.CallerIdentity:
+		return f.formatCallerIdentityTable(v), nil
+	case models.ServiceUpdatePlan:
+		return f.formatServiceUpdatePlanTable(v), nil
+	case models.ServiceGroups:
+		return f.formatServiceGroupsTable(v, nil)
+	case []models.HistoryCapture:
+		return f.formatHistoryCapturesTable(v), nil
+	case models.HistoryDiff:
+		return f.formatHistoryDiffTable(v), nil
 	default:
 		return "", fmt.Errorf("unsupported data type for table format: %T", data)
 	}
@@ -61,6 +143,8 @@ func (f *Formatter) FormatCompact(data interface{}) (string, error) {
 	switch v := data.(type) {
 	case []models.ECSService:
 		return f.formatECSServicesCompact(v), nil
+	case models.ServiceGroups:
+		return f.formatServiceGroupsCompact(v), nil
 	default:
 		return "", fmt.Errorf("unsupported data type for compact format: %T", data)
 	}
@@ -68,10 +152,25 @@ func (f *Formatter) FormatCompact(data interface{}) (string, error) {
 
 // FormatWithOptions は指定されたオプションでデータをフォーマット
 func (f *Formatter) FormatWithOptions(data interface{}, options FormatOptions) (string, error) {
+	options.Format = f.NormalizeFormat(options.Format)
+
+	// 平坦化はJSON/YAMLのみに適用
+	if options.Flatten && (options.Format == "json" || options.Format == "yaml") {
+		flattened, err := f.Flatten(data)
+		if err != nil {
+			return "", err
+		}
+		data = flattened
+	}
+
 	switch options.Format {
 	case "json":
 		if options.PrettyPrint {
-			return f.FormatJSON(data)
+			indent := options.Indent
+			if indent == "" {
+				indent = DefaultJSONIndent
+			}
+			return f.formatJSONWithIndent(data, indent)
 		}
 		jsonBytes, err := json.Marshal(data)
 		if err != nil {
@@ -79,9 +178,12 @@ func (f *Formatter) FormatWithOptions(data interface{}, options FormatOptions) (
 		}
 		return string(jsonBytes), nil
 	case "yaml":
+		if options.YAMLFlow {
+			return f.formatYAMLFlow(data)
+		}
 		return f.FormatYAML(data)
 	case "table":
-		return f.FormatTable(data)
+		return f.formatTableWithOptions(data, options)
 	case "compact":
 		return f.FormatCompact(data)
 	default:
@@ -89,6 +191,83 @@ func (f *Formatter) FormatWithOptions(data interface{}, options FormatOptions) (
 	}
 }
 
+// formatTableWithOptions はデータをテーブル形式でフォーマットし、値を切り詰めた場合は注記を付ける
+// 切り詰めの有無は呼び出しごとに判定するため、専用のFormatterでフォーマットする
+func (f *Formatter) formatTableWithOptions(data interface{}, options FormatOptions) (string, error) {
+	table := &Formatter{wide: options.Wide}
+
+	var output string
+	var err error
+	if services, ok := data.([]models.ECSService); ok && len(options.Columns) > 0 {
+		output, err = table.formatECSServicesColumnsTable(services, options.Columns)
+	} else if groups, ok := data.(models.ServiceGroups); ok {
+		output, err = table.formatServiceGroupsTable(groups, options.Columns)
+	} else {
+		output, err = table.FormatTable(data)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if table.truncated {
+		if !strings.HasSuffix(output, "\n") {
+			output += "\n"
+		}
+		output += "\n" + TruncationNote + "\n"
+	}
+	return output, nil
+}
+
+// Flatten はデータをJSONとしてマーシャルした結果をドット区切りのキーで平坦化する
+// 配列の要素はインデックスをキーとして展開される (例: network_config.subnets.0)
+func (f *Formatter) Flatten(data interface{}) (map[string]interface{}, error) {
+	jsonBytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var generic interface{}
+	if err := json.Unmarshal(jsonBytes, &generic); err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]interface{})
+	flattenValue("", generic, result)
+	return result, nil
+}
+
+// flattenValue は値を再帰的に平坦化してresultに格納する
+func flattenValue(prefix string, value interface{}, result map[string]interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 && prefix != "" {
+			result[prefix] = v
+			return
+		}
+		for key, child := range v {
+			flattenValue(joinFlattenKey(prefix, key), child, result)
+		}
+	case []interface{}:
+		if len(v) == 0 && prefix != "" {
+			result[prefix] = v
+			return
+		}
+		for i, child := range v {
+			flattenValue(joinFlattenKey(prefix, strconv.Itoa(i)), child, result)
+		}
+	default:
+		result[prefix] = v
+	}
+}
+
+// joinFlattenKey は平坦化したキーをドットで連結する
+func joinFlattenKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
 // formatECSServicesTable はECSサービス一覧をテーブル形式でフォーマット
 func (f *Formatter) formatECSServicesTable(services []models.ECSService) string {
 	if len(services) == 0 {
@@ -119,6 +298,97 @@ func (f *Formatter) formatECSServicesTable(services []models.ECSService) string
 		result.WriteString(row + "\n")
 	}
 
+	// 複数サービスの場合は合計行を表示
+	if len(services) > 1 {
+		var totalDesired, totalRunning int32
+		for _, service := range services {
+			totalDesired += service.DesiredCount
+			totalRunning += service.RunningCount
+		}
+		result.WriteString(separator + "\n")
+		footer := fmt.Sprintf("%-20s %-15s %-10s %-25s %-8d %-8d",
+			f.truncateString(fmt.Sprintf("TOTAL: %d services", len(services)), 20),
+			"", "", "",
+			totalDesired,
+			totalRunning)
+		result.WriteString(footer + "\n")
+	}
+
+	return result.String()
+}
+
+// formatContainerInstancesTable はコンテナインスタンス一覧をテーブル形式でフォーマット
+func (f *Formatter) formatContainerInstancesTable(instances []models.ContainerInstance) string {
+	if len(instances) == 0 {
+		return "No container instances found."
+	}
+
+	var result strings.Builder
+
+	header := fmt.Sprintf("%-20s %-10s %-8s %-8s %-14s %-16s",
+		"INSTANCE ID", "STATUS", "RUNNING", "PENDING", "REMAINING CPU", "REMAINING MEMORY")
+	result.WriteString(header + "\n")
+	result.WriteString(strings.Repeat("-", len(header)) + "\n")
+
+	for _, instance := range instances {
+		row := fmt.Sprintf("%-20s %-10s %-8d %-8d %-14s %-16s",
+			f.truncateString(instance.EC2InstanceID, 20),
+			instance.Status,
+			instance.RunningTasksCount,
+			instance.PendingTasksCount,
+			fmt.Sprintf("%d/%d", instance.RemainingCPU, instance.RegisteredCPU),
+			fmt.Sprintf("%d/%d", instance.RemainingMemory, instance.RegisteredMemory))
+		result.WriteString(row + "\n")
+	}
+
+	return result.String()
+}
+
+// formatTaskDefinitionSummariesTable はタスク定義ファミリー一覧をテーブル形式でフォーマット
+// サービスからの参照を確認済みの場合はREFERENCED列を追加
+func (f *Formatter) formatTaskDefinitionSummariesTable(summaries []models.TaskDefinitionSummary) string {
+	if len(summaries) == 0 {
+		return "No task definitions found."
+	}
+
+	showReferenced := false
+	for _, summary := range summaries {
+		if summary.Referenced != nil {
+			showReferenced = true
+			break
+		}
+	}
+
+	var result strings.Builder
+
+	header := fmt.Sprintf("%-30s %-8s %-8s %-30s",
+		"FAMILY", "LATEST", "ACTIVE", "REVISIONS")
+	if showReferenced {
+		header += fmt.Sprintf(" %-10s", "REFERENCED")
+	}
+	result.WriteString(header + "\n")
+	result.WriteString(strings.Repeat("-", len(header)) + "\n")
+
+	for _, summary := range summaries {
+		revisions := make([]string, 0, len(summary.Revisions))
+		for _, revision := range summary.Revisions {
+			revisions = append(revisions, strconv.Itoa(revision))
+		}
+		row := fmt.Sprintf("%-30s %-8d %-8d %-30s",
+			f.truncateString(summary.Family, 30),
+			summary.LatestRevision,
+			len(summary.Revisions),
+			f.truncateString(strings.Join(revisions, ","), 30))
+		if showReferenced {
+			referenced := "no"
+			if summary.Referenced != nil && *summary.Referenced {
+				referenced = "yes"
+			}
+			row += fmt.Sprintf(" %-10s", referenced)
+		}
+		result.WriteString(row + "\n")
+	}
+
 	return result.String()
 }
 
@@ -150,13 +420,38 @@ func (f *Formatter) formatInspectionResultTable(result models.InspectionResult)
 
 	output.WriteString("=== SERVICE INFORMATION ===\n")
 	output.WriteString(f.formatECSServicesTable([]models.ECSService{result.Service}))
+	if result.Service.SchedulingStrategy != "" {
+		output.WriteString(fmt.Sprintf("Scheduling Strategy: %s\n", result.Service.SchedulingStrategy))
+	}
 
 	output.WriteString("\n=== TASK DEFINITION ===\n")
 	output.WriteString(fmt.Sprintf("Family: %s\n", result.TaskDefinition.Family))
 	output.WriteString(fmt.Sprintf("Revision: %d\n", result.TaskDefinition.Revision))
-	output.WriteString(fmt.Sprintf("CPU: %s\n", result.TaskDefinition.CPU))
-	output.WriteString(fmt.Sprintf("Memory: %s\n", result.TaskDefinition.Memory))
+	output.WriteString(fmt.Sprintf("CPU: %s\n", f.valueOrNotSet(result.TaskDefinition.CPU)))
+	output.WriteString(fmt.Sprintf("Memory: %s\n", f.valueOrNotSet(result.TaskDefinition.Memory)))
 	output.WriteString(fmt.Sprintf("Network Mode: %s\n", result.TaskDefinition.NetworkMode))
+	if len(result.TaskDefinition.Tags) > 0 {
+		output.WriteString(fmt.Sprintf("Tags: %s\n", f.formatParams(result.TaskDefinition.Tags)))
+	}
+
+	if len(result.TaskDefinition.Containers) > 0 || result.ContainerFilter != nil {
+		output.WriteString("\n=== CONTAINER RESOURCES ===\n")
+		header := fmt.Sprintf("%-30s %-8s %-8s %-18s", "CONTAINER", "CPU", "MEMORY", "MEMORY RESERVATION")
+		output.WriteString(header + "\n")
+		output.WriteString(strings.Repeat("-", len(header)) + "\n")
+		for _, container := range result.TaskDefinition.Containers {
+			row := fmt.Sprintf("%-30s %-8s %-8s %-18s",
+				f.truncateString(container.Name, 30),
+				f.int32OrNotSet(container.CPU),
+				f.int32OrNotSet(container.Memory),
+				f.int32OrNotSet(container.MemoryReservation))
+			output.WriteString(row + "\n")
+		}
+		if filter := result.ContainerFilter; filter != nil {
+			output.WriteString(fmt.Sprintf("Showing %d container(s) matching %q (%d omitted)\n",
+				filter.Matched, filter.Pattern, filter.Omitted))
+		}
+	}
 
 	if result.NetworkConfig != nil {
 		output.WriteString("\n=== NETWORK CONFIGURATION ===\n")
@@ -165,20 +460,395 @@ func (f *Formatter) formatInspectionResultTable(result models.InspectionResult)
 		output.WriteString(fmt.Sprintf("Assign Public IP: %t\n", result.NetworkConfig.AssignPublicIP))
 	}
 
+	if len(result.Deployments) > 0 {
+		output.WriteString("\n=== DEPLOYMENTS ===\n")
+		rows := make([][]string, 0, len(result.Deployments))
+		for _, deployment := range result.Deployments {
+			rows = append(rows, []string{
+				deployment.ID,
+				deployment.Status,
+				f.valueOrNotSet(deployment.RolloutState),
+				deployment.TaskDefinition,
+				strconv.Itoa(int(deployment.DesiredCount)),
+				strconv.Itoa(int(deployment.PendingCount)),
+				strconv.Itoa(int(deployment.RunningCount)),
+				strconv.Itoa(int(deployment.FailedTasks)),
+			})
+		}
+		output.WriteString(f.formatDynamicTable([]string{"ID", "STATUS", "ROLLOUT STATE", "TASK DEFINITION", "DESIRED", "PENDING", "RUNNING", "FAILED"}, rows))
+		for _, deployment := range result.Deployments {
+			if deployment.RolloutStateReason != "" {
+				output.WriteString(fmt.Sprintf("%s: %s\n", deployment.ID, deployment.RolloutStateReason))
+			}
+		}
+	}
+
 	if len(result.Recommendations) > 0 {
 		output.WriteString("\n=== RECOMMENDATIONS ===\n")
+		rows := make([][]string, 0, len(result.Recommendations))
 		for i, rec := range result.Recommendations {
-			output.WriteString(fmt.Sprintf("%d. [%s] %s\n", i+1, strings.ToUpper(rec.Priority), rec.Title))
-			output.WriteString(fmt.Sprintf("   Category: %s\n", rec.Category))
-			output.WriteString(fmt.Sprintf("   Description: %s\n", rec.Description))
-			output.WriteString(fmt.Sprintf("   Action: %s\n", rec.Action))
-			output.WriteString("\n")
+			rows = append(rows, []string{strconv.Itoa(i + 1), strings.ToUpper(rec.Priority), rec.Category, rec.Title, rec.Action})
+		}
+		output.WriteString(f.formatDynamicTable([]string{"#", "PRIORITY", "CATEGORY", "TITLE", "ACTION"}, rows))
+		for i, rec := range result.Recommendations {
+			if rec.RemediationType != "" {
+				output.WriteString(fmt.Sprintf("%d. Remediation: %s %s\n", i+1, rec.RemediationType, f.formatParams(rec.Params)))
+			}
+		}
+	}
+
+	if comparison := result.RevisionComparison; comparison != nil {
+		output.WriteString(fmt.Sprintf("\n=== CHANGES FROM REVISION %d ===\n", comparison.PreviousRevision))
+		if len(comparison.Changes) == 0 {
+			output.WriteString("No changes found.\n")
+		} else {
+			header := fmt.Sprintf("%-40s %-30s %-30s", "FIELD", "PREVIOUS", "CURRENT")
+			output.WriteString(header + "\n")
+			output.WriteString(strings.Repeat("-", len(header)) + "\n")
+			for _, change := range comparison.Changes {
+				row := fmt.Sprintf("%-40s %-30s %-30s",
+					f.truncateString(change.Field, 40),
+					f.truncateString(change.Previous, 30),
+					f.truncateString(change.Current, 30))
+				output.WriteString(row + "\n")
+			}
+		}
+	}
+
+	if len(result.Warnings) > 0 {
+		output.WriteString("\n=== WARNINGS ===\n")
+		for _, warning := range result.Warnings {
+			output.WriteString(fmt.Sprintf("- %s\n", warning))
 		}
 	}
 
 	return output.String()
 }
 
+// formatDynamicTable は各列の幅を内容の最大長に合わせたテーブルをフォーマット
+func (f *Formatter) formatDynamicTable(headers []string, rows [][]string) string {
+	widths := make([]int, len(headers))
+	for i, header := range headers {
+		widths[i] = len(header)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			if i < len(widths) && len(cell) > widths[i] {
+				widths[i] = len(cell)
+			}
+		}
+	}
+
+	formatRow := func(cells []string) string {
+		padded := make([]string, len(widths))
+		for i, width := range widths {
+			cell := ""
+			if i < len(cells) {
+				cell = cells[i]
+			}
+			padded[i] = fmt.Sprintf("%-*s", width, cell)
+		}
+		return strings.TrimRight(strings.Join(padded, "  "), " ")
+	}
+
+	var output strings.Builder
+	header := formatRow(headers)
+	output.WriteString(header + "\n")
+	output.WriteString(strings.Repeat("-", len(header)) + "\n")
+	for _, row := range rows {
+		output.WriteString(formatRow(row) + "\n")
+	}
+	return output.String()
+}
+
+// formatParams はパラメータをキー順の key=value 形式でフォーマット
+func (f *Formatter) formatParams(params map[string]string) string {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", key, params[key]))
+	}
+	return strings.Join(pairs, " ")
+}
+
+// formatInspectionResultsTable は複数のインスペクション結果をテーブル形式でフォーマット
+// 結果は同じクラスターごとに連続して並んでいることを前提とする
+func (f *Formatter) formatInspectionResultsTable(results []models.InspectionResult) string {
+	if len(results) == 0 {
+		return "No services found."
+	}
+
+	// 複数クラスターの結果はクラスターごとに見出しを付ける
+	multipleClusters := false
+	for _, result := range results {
+		if result.Service.ClusterName != results[0].Service.ClusterName {
+			multipleClusters = true
+			break
+		}
+	}
+
+	tables := make([]string, 0, len(results))
+	for idx, result := range results {
+		table := f.formatInspectionResultTable(result)
+		if multipleClusters && (idx == 0 || result.Service.ClusterName != results[idx-1].Service.ClusterName) {
+			table = fmt.Sprintf("##### CLUSTER: %s #####\n\n%s", result.Service.ClusterName, table)
+		}
+		tables = append(tables, table)
+	}
+	return strings.Join(tables, "\n") + "\n" + f.formatRecommendationCountsTable(results)
+}
+
+// formatRecommendationCountsTable はサービスごとの優先度別レコメンデーション件数を結果の順序でテーブル形式にフォーマット
+func (f *Formatter) formatRecommendationCountsTable(results []models.InspectionResult) string {
+	rows := make([][]string, 0, len(results))
+	for _, result := range results {
+		counts := CountRecommendations(result)
+		rows = append(rows, []string{
+			counts.ClusterName,
+			counts.ServiceName,
+			strconv.Itoa(counts.High),
+			strconv.Itoa(counts.Medium),
+			strconv.Itoa(counts.Low),
+		})
+	}
+	return "=== RECOMMENDATION SUMMARY ===\n" + f.formatDynamicTable([]string{"CLUSTER", "SERVICE", "HIGH", "MEDIUM", "LOW"}, rows)
+}
+
+// formatScanSummaryTable はスキャン集計結果をテーブル形式でフォーマット
+func (f *Formatter) formatScanSummaryTable(summary models.ScanSummary) string {
+	var output strings.Builder
+
+	output.WriteString("=== SUMMARY ===\n")
+	output.WriteString(fmt.Sprintf("Regions: %s\n", strings.Join(summary.Regions, ", ")))
+	output.WriteString(fmt.Sprintf("Clusters: %d\n", summary.TotalClusters))
+	output.WriteString(fmt.Sprintf("Services: %d\n", summary.TotalServices))
+	output.WriteString(fmt.Sprintf("Healthy: %d\n", summary.HealthyServices))
+	output.WriteString(fmt.Sprintf("Unhealthy: %d\n", summary.UnhealthyServices))
+	output.WriteString(fmt.Sprintf("Tasks (running/desired): %d/%d\n", summary.TotalRunningTasks, summary.TotalDesiredTasks))
+
+	if len(summary.LaunchTypes) > 0 {
+		output.WriteString("\n=== LAUNCH TYPES ===\n")
+		launchTypes := make([]string, 0, len(summary.LaunchTypes))
+		for launchType := range summary.LaunchTypes {
+			launchTypes = append(launchTypes, launchType)
+		}
+		sort.Strings(launchTypes)
+		for _, launchType := range launchTypes {
+			output.WriteString(fmt.Sprintf("%-12s %d\n", launchType, summary.LaunchTypes[launchType]))
+		}
+	}
+
+	return output.String()
+}
+
+// formatCallerIdentityTable は呼び出し元IDをテーブル形式でフォーマット
+func (f *Formatter) formatCallerIdentityTable(identity models.CallerIdentity) string {
+	var output strings.Builder
+
+	output.WriteString(fmt.Sprintf("Account: %s\n", identity.Account))
+	output.WriteString(fmt.Sprintf("ARN: %s\n", identity.ARN))
+	output.WriteString(fmt.Sprintf("User ID: %s\n", identity.UserID))
+	output.WriteString(fmt.Sprintf("Region: %s\n", identity.Region))
+
+	return output.String()
+}
+
+// formatTaskDefinitionDiffTable はタスク定義の差分をテーブル形式でフォーマット
+func (f *Formatter) formatTaskDefinitionDiffTable(diff models.TaskDefinitionDiff) string {
+	var output strings.Builder
+
+	output.WriteString(fmt.Sprintf("Service: %s (cluster: %s)\n", diff.ServiceName, diff.ClusterName))
+	output.WriteString(fmt.Sprintf("Family: %s\n", diff.Family))
+
+	if !diff.HasDifferences() {
+		output.WriteString("No differences found.\n")
+		return output.String()
+	}
+
+	output.WriteString(fmt.Sprintf("\n=== DIFFERENCES (%d) ===\n", len(diff.Differences)))
+	header := fmt.Sprintf("%-40s %-30s %-30s", "FIELD", "LIVE", "EXPECTED")
+	output.WriteString(header + "\n")
+	output.WriteString(strings.Repeat("-", len(header)) + "\n")
+
+	for _, d := range diff.Differences {
+		row := fmt.Sprintf("%-40s %-30s %-30s",
+			f.truncateString(d.Field, 40),
+			f.truncateString(d.Live, 30),
+			f.truncateString(d.Expected, 30))
+		output.WriteString(row + "\n")
+	}
+
+	return output.String()
+}
+
+// formatHistoryCapturesTable は保存済みの調査結果の一覧をテーブル形式でフォーマット
+func (f *Formatter) formatHistoryCapturesTable(captures []models.HistoryCapture) string {
+	if len(captures) == 0 {
+		return "No captures found.\n"
+	}
+
+	rows := make([][]string, 0, len(captures))
+	for _, capture := range captures {
+		rows = append(rows, []string{
+			capture.ID,
+			capture.CapturedAt.Format(time.RFC3339),
+			capture.ClusterName,
+			capture.ServiceName,
+		})
+	}
+	return f.formatDynamicTable([]string{"ID", "CAPTURED AT", "CLUSTER", "SERVICE"}, rows)
+}
+
+// formatHistoryDiffTable は保存済みの2件の調査結果の差分をテーブル形式でフォーマット
+func (f *Formatter) formatHistoryDiffTable(diff models.HistoryDiff) string {
+	var output strings.Builder
+
+	output.WriteString(fmt.Sprintf("Service: %s (cluster: %s)\n", diff.ServiceName, diff.ClusterName))
+	output.WriteString(fmt.Sprintf("From: %s\n", diff.From.ID))
+	output.WriteString(fmt.Sprintf("To: %s\n", diff.To.ID))
+
+	if !diff.HasChanges() {
+		output.WriteString("No changes found.\n")
+		return output.String()
+	}
+
+	output.WriteString(fmt.Sprintf("\n=== CHANGES (%d) ===\n", len(diff.Changes)))
+	header := fmt.Sprintf("%-40s %-30s %-30s", "FIELD", "PREVIOUS", "CURRENT")
+	output.WriteString(header + "\n")
+	output.WriteString(strings.Repeat("-", len(header)) + "\n")
+
+	for _, change := range diff.Changes {
+		row := fmt.Sprintf("%-40s %-30s %-30s",
+			f.truncateString(change.Field, 40),
+			f.truncateString(change.Previous, 30),
+			f.truncateString(change.Current, 30))
+		output.WriteString(row + "\n")
+	}
+
+	return output.String()
+}
+
+// formatServiceUpdatePlanTable は更新内容の差分をテーブル形式でフォーマット
+func (f *Formatter) formatServiceUpdatePlanTable(plan models.ServiceUpdatePlan) string {
+	var output strings.Builder
+
+	output.WriteString(fmt.Sprintf("Service: %s (cluster: %s)\n", plan.ServiceName, plan.ClusterName))
+
+	if !plan.HasChanges() {
+		output.WriteString("No changes.\n")
+		return output.String()
+	}
+
+	if len(plan.Changes) > 0 {
+		output.WriteString(fmt.Sprintf("\n=== CHANGES (%d) ===\n", len(plan.Changes)))
+		header := fmt.Sprintf("%-20s %-50s %-50s", "FIELD", "CURRENT", "NEW")
+		output.WriteString(header + "\n")
+		output.WriteString(strings.Repeat("-", len(header)) + "\n")
+
+		for _, change := range plan.Changes {
+			row := fmt.Sprintf("%-20s %-50s %-50s",
+				f.truncateString(change.Field, 20),
+				f.truncateString(change.Live, 50),
+				f.truncateString(change.Expected, 50))
+			output.WriteString(row + "\n")
+		}
+	}
+
+	if plan.ForceNewDeployment {
+		output.WriteString("\nA new deployment will be forced (running tasks will be replaced).\n")
+	}
+
+	return output.String()
+}
+
+// formatClusterServicesDiffTable はクラスター間のサービス構成の差分をテーブル形式でフォーマット
+func (f *Formatter) formatClusterServicesDiffTable(diff models.ClusterServicesDiff) string {
+	var output strings.Builder
+
+	output.WriteString(fmt.Sprintf("From: %s\n", diff.FromCluster))
+	output.WriteString(fmt.Sprintf("To: %s\n", diff.ToCluster))
+
+	output.WriteString(fmt.Sprintf("\n=== ADDED (%d) ===\n", len(diff.Added)))
+	for _, name := range diff.Added {
+		output.WriteString(fmt.Sprintf("+ %s\n", name))
+	}
+
+	output.WriteString(fmt.Sprintf("\n=== REMOVED (%d) ===\n", len(diff.Removed)))
+	for _, name := range diff.Removed {
+		output.WriteString(fmt.Sprintf("- %s\n", name))
+	}
+
+	output.WriteString(fmt.Sprintf("\n=== COMMON (%d) ===\n", len(diff.Common)))
+	if len(diff.Common) > 0 {
+		header := fmt.Sprintf("%-30s %-20s %-20s %-10s", "SERVICE", "FROM", "TO", "HEALTH")
+		output.WriteString(header + "\n")
+		output.WriteString(strings.Repeat("-", len(header)) + "\n")
+		for _, service := range diff.Common {
+			health := "same"
+			if service.HealthDiffers {
+				health = "DIFFERS"
+			}
+			row := fmt.Sprintf("%-30s %-20s %-20s %-10s",
+				f.truncateString(service.ServiceName, 30),
+				fmt.Sprintf("%s %d/%d", service.From.Status, service.From.RunningCount, service.From.DesiredCount),
+				fmt.Sprintf("%s %d/%d", service.To.Status, service.To.RunningCount, service.To.DesiredCount),
+				health)
+			output.WriteString(row + "\n")
+		}
+	}
+
+	return output.String()
+}
+
+// formatServiceGroupsTable はグループ化したECSサービス一覧をグループごとのセクションに分けてテーブル形式でフォーマット
+// columnsを指定した場合は各セクションを指定した列のみで表示する
+func (f *Formatter) formatServiceGroupsTable(groups models.ServiceGroups, columns []string) (string, error) {
+	if len(groups.Groups) == 0 {
+		return "No services found.", nil
+	}
+
+	sections := make([]string, 0, len(groups.Groups))
+	for _, group := range groups.Groups {
+		var section strings.Builder
+		section.WriteString(fmt.Sprintf("=== %s: %s (%d services, %d healthy, tasks %d/%d) ===\n",
+			strings.ToUpper(groups.GroupBy), group.Key, group.ServiceCount, group.HealthyServices,
+			group.TotalRunningTasks, group.TotalDesiredTasks))
+
+		if len(columns) > 0 {
+			table, err := f.formatECSServicesColumnsTable(group.Services, columns)
+			if err != nil {
+				return "", err
+			}
+			section.WriteString(table)
+		} else {
+			section.WriteString(f.formatECSServicesTable(group.Services))
+		}
+		sections = append(sections, section.String())
+	}
+	return strings.Join(sections, "\n"), nil
+}
+
+// formatServiceGroupsCompact はグループ化したECSサービス一覧をグループごとにコンパクト形式でフォーマット
+func (f *Formatter) formatServiceGroupsCompact(groups models.ServiceGroups) string {
+	if len(groups.Groups) == 0 {
+		return "No services found."
+	}
+
+	var result strings.Builder
+	for _, group := range groups.Groups {
+		result.WriteString(fmt.Sprintf("%s (%d services)\n", group.Key, group.ServiceCount))
+		for _, line := range strings.Split(strings.TrimSuffix(f.formatECSServicesCompact(group.Services), "\n"), "\n") {
+			result.WriteString("  " + line + "\n")
+		}
+	}
+	return result.String()
+}
+
 // formatECSServicesCompact はECSサービス一覧をコンパクト形式でフォーマット
 func (f *Formatter) formatECSServicesCompact(services []models.ECSService) string {
 	if len(services) == 0 {
@@ -212,24 +882,51 @@ func (f *Formatter) IsHealthyService(service models.ECSService) bool {
 	return service.Status == "ACTIVE" && service.DesiredCount == service.RunningCount
 }
 
-// truncateString は文字列を指定された長さに切り詰める
+// truncateString は文字列を指定された長さに切り詰め、切り詰めたことを記録する
+// wideが指定されている場合は切り詰めない
 func (f *Formatter) truncateString(s string, maxLen int) string {
-	if len(s) <= maxLen {
+	if f.wide || len(s) <= maxLen {
 		return s
 	}
+	f.truncated = true
 	if maxLen <= 3 {
 		return s[:maxLen]
 	}
 	return s[:maxLen-3] + "..."
 }
 
+// valueOrNotSet は未指定の値を"-"として表示する
+func (f *Formatter) valueOrNotSet(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// int32OrNotSet は0（未指定）を"-"として表示する
+func (f *Formatter) int32OrNotSet(i int32) string {
+	if i == 0 {
+		return "-"
+	}
+	return strconv.Itoa(int(i))
+}
+
 // GetSupportedFormats はサポートされている出力形式一覧を返す
 func (f *Formatter) GetSupportedFormats() []string {
 	return []string{"json", "yaml", "table", "compact"}
 }
 
-// ValidateFormat は指定された形式がサポートされているかチェック
+// NormalizeFormat は短縮名（j, y, t, c）を正式な形式名に変換する（短縮名以外はそのまま返す）
+func (f *Formatter) NormalizeFormat(format string) string {
+	if canonical, ok := formatAliases[format]; ok {
+		return canonical
+	}
+	return format
+}
+
+// ValidateFormat は指定された形式（短縮名を含む）がサポートされているかチェック
 func (f *Formatter) ValidateFormat(format string) bool {
+	format = f.NormalizeFormat(format)
 	supportedFormats := f.GetSupportedFormats()
 	for _, supported := range supportedFormats {
 		if format == supported {
@@ -1,12 +1,16 @@
 package utils_test
 
 import (
+	"encoding/json"
 	"strings"
 	"testing"
 
 	"github.com/dev-shimada/phantom-ecs/internal/models"
+	"github.com/dev-shimada/phantom-ecs/internal/models/modeltest"
 	"github.com/dev-shimada/phantom-ecs/internal/utils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 func TestFormatter_FormatJSON_ECSServices(t *testing.T) {
@@ -100,24 +104,8 @@ func TestFormatter_FormatTable_ECSServices(t *testing.T) {
 	formatter := utils.NewFormatter()
 
 	services := []models.ECSService{
-		{
-			ServiceName:    "web-service",
-			ClusterName:    "test-cluster",
-			Status:         "ACTIVE",
-			TaskDefinition: "web-task:1",
-			DesiredCount:   2,
-			RunningCount:   2,
-			LaunchType:     "FARGATE",
-		},
-		{
-			ServiceName:    "api-service",
-			ClusterName:    "prod-cluster",
-			Status:         "ACTIVE",
-			TaskDefinition: "api-task:1",
-			DesiredCount:   1,
-			RunningCount:   1,
-			LaunchType:     "EC2",
-		},
+		modeltest.NewService("web-service", modeltest.WithCounts(2, 2)),
+		modeltest.NewService("api-service", modeltest.WithCluster("prod-cluster"), modeltest.WithLaunchType("EC2")),
 	}
 
 	result, err := formatter.FormatTable(services)
@@ -147,6 +135,29 @@ func TestFormatter_FormatTable_ECSServices(t *testing.T) {
 	assert.True(t, len(lines) >= 3) // ヘッダー + 区切り線 + データ行以上
 }
 
+func TestFormatter_FormatTable_InspectionResult_ContainerResources(t *testing.T) {
+	formatter := utils.NewFormatter()
+
+	result, err := formatter.FormatTable(models.InspectionResult{
+		Service: models.ECSService{ServiceName: "web-service"},
+		TaskDefinition: models.ECSTaskDefinition{
+			Family: "web-task",
+			CPU:    "512",
+			Containers: []models.ContainerDefinition{
+				{Name: "app", CPU: 256, Memory: 1024, MemoryReservation: 512},
+				{Name: "sidecar"},
+			},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Contains(t, result, "CPU: 512\n")
+	assert.Contains(t, result, "Memory: -\n")
+	assert.Contains(t, result, "=== CONTAINER RESOURCES ===")
+	assert.Regexp(t, `app\s+256\s+1024\s+512`, result)
+	assert.Regexp(t, `sidecar\s+-\s+-\s+-`, result)
+}
+
 func TestFormatter_FormatTable_DeploymentResult(t *testing.T) {
 	formatter := utils.NewFormatter()
 
@@ -172,6 +183,41 @@ func TestFormatter_FormatTable_DeploymentResult(t *testing.T) {
 	assert.Contains(t, result, "false")
 }
 
+func TestFormatter_FormatTable_ECSServicesFooter(t *testing.T) {
+	formatter := utils.NewFormatter()
+
+	services := []models.ECSService{
+		modeltest.NewService("web-service", modeltest.WithCounts(3, 2)),
+		modeltest.NewService("api-service", modeltest.WithCounts(2, 2)),
+		modeltest.NewService("worker", modeltest.WithCounts(4, 1)),
+	}
+
+	t.Run("複数サービスでは合計行を表示", func(t *testing.T) {
+		result, err := formatter.FormatTable(services)
+		require.NoError(t, err)
+
+		lines := strings.Split(strings.TrimRight(result, "\n"), "\n")
+		footer := lines[len(lines)-1]
+		assert.Regexp(t, `^TOTAL: 3 services\s+9\s+5\s*$`, footer)
+		assert.Equal(t, strings.Repeat("-", len(lines[0])), lines[len(lines)-2])
+		// 合計はDESIRED、RUNNING列に揃える
+		assert.Equal(t, strings.Index(lines[0], "DESIRED"), strings.Index(footer, "9"))
+		assert.Equal(t, strings.Index(lines[0], "RUNNING"), strings.Index(footer, "5"))
+	})
+
+	t.Run("単一サービスでは合計行を表示しない", func(t *testing.T) {
+		result, err := formatter.FormatTable(services[:1])
+		require.NoError(t, err)
+		assert.NotContains(t, result, "TOTAL")
+	})
+
+	t.Run("JSONには含めない", func(t *testing.T) {
+		result, err := formatter.FormatJSON(services)
+		require.NoError(t, err)
+		assert.NotContains(t, result, "TOTAL")
+	})
+}
+
 func TestFormatter_FormatCompact_ECSServices(t *testing.T) {
 	formatter := utils.NewFormatter()
 
@@ -237,6 +283,35 @@ func TestFormatter_FormatWithOptions_JSON_Pretty(t *testing.T) {
 	assert.Contains(t, result, "  ") // インデント
 }
 
+func TestFormatter_FormatWithOptions_JSON_Indent(t *testing.T) {
+	formatter := utils.NewFormatter()
+
+	service := models.ECSService{ServiceName: "web-service"}
+
+	tests := []struct {
+		name   string
+		indent string
+		want   string
+	}{
+		{"未指定時は2スペース", "", "{\n  \"service_name\": \"web-service\","},
+		{"4スペース", "    ", "{\n    \"service_name\": \"web-service\","},
+		{"タブ", "\t", "{\n\t\"service_name\": \"web-service\","},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := formatter.FormatWithOptions(service, utils.FormatOptions{
+				Format:      "json",
+				PrettyPrint: true,
+				Indent:      tt.indent,
+			})
+
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(result, tt.want), result)
+		})
+	}
+}
+
 func TestFormatter_FormatWithOptions_UnsupportedFormat(t *testing.T) {
 	formatter := utils.NewFormatter()
 
@@ -255,6 +330,107 @@ func TestFormatter_FormatWithOptions_UnsupportedFormat(t *testing.T) {
 	assert.Contains(t, err.Error(), "unsupported format")
 }
 
+func TestFormatter_FormatWithOptions_YAMLFlow(t *testing.T) {
+	formatter := utils.NewFormatter()
+
+	services := []models.ECSService{
+		modeltest.NewService("web-service"),
+		modeltest.NewService("api-service", modeltest.WithCounts(2, 1)),
+	}
+
+	flow, err := formatter.FormatWithOptions(services, utils.FormatOptions{Format: "yaml", YAMLFlow: true})
+	require.NoError(t, err)
+
+	// 1行のフロースタイルでシーケンスとマッピングを出力
+	assert.Equal(t, 1, strings.Count(flow, "\n"), flow)
+	assert.True(t, strings.HasPrefix(flow, "[{service_name: web-service, cluster_name: test-cluster, status: ACTIVE,"), flow)
+	assert.Contains(t, flow, "}, {service_name: api-service,")
+	assert.True(t, strings.HasSuffix(flow, "}]\n"), flow)
+
+	// デフォルトはブロックスタイル
+	block, err := formatter.FormatWithOptions(services, utils.FormatOptions{Format: "yaml"})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(block, "- service_name: web-service\n"), block)
+
+	// 同じ内容として読み込める
+	var fromFlow, fromBlock []models.ECSService
+	require.NoError(t, yaml.Unmarshal([]byte(flow), &fromFlow))
+	require.NoError(t, yaml.Unmarshal([]byte(block), &fromBlock))
+	assert.Equal(t, fromBlock, fromFlow)
+}
+
+func TestFormatter_FormatAliases(t *testing.T) {
+	formatter := utils.NewFormatter()
+
+	services := []models.ECSService{modeltest.NewService("web-service")}
+
+	for _, alias := range []struct{ short, canonical string }{
+		{"j", "json"},
+		{"y", "yaml"},
+		{"t", "table"},
+		{"c", "compact"},
+	} {
+		t.Run(alias.short, func(t *testing.T) {
+			assert.True(t, formatter.ValidateFormat(alias.short))
+			assert.Equal(t, alias.canonical, formatter.NormalizeFormat(alias.short))
+
+			got, err := formatter.FormatWithOptions(services, utils.FormatOptions{Format: alias.short, PrettyPrint: true})
+			require.NoError(t, err)
+			want, err := formatter.FormatWithOptions(services, utils.FormatOptions{Format: alias.canonical, PrettyPrint: true})
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+
+	t.Run("未知の短縮名はエラー", func(t *testing.T) {
+		assert.False(t, formatter.ValidateFormat("x"))
+		assert.Equal(t, "x", formatter.NormalizeFormat("x"))
+
+		_, err := formatter.FormatWithOptions(services, utils.FormatOptions{Format: "x"})
+		assert.EqualError(t, err, "unsupported format: x")
+	})
+}
+
+func TestFormatter_FormatWithOptions_TruncationNote(t *testing.T) {
+	formatter := utils.NewFormatter()
+
+	short := []models.ECSService{modeltest.NewService("web")}
+	long := []models.ECSService{modeltest.NewService("very-long-service-name-exceeding-column")}
+
+	t.Run("切り詰めがない場合は注記を付けない", func(t *testing.T) {
+		output, err := formatter.FormatWithOptions(short, utils.FormatOptions{Format: "table"})
+		require.NoError(t, err)
+		assert.NotContains(t, output, utils.TruncationNote)
+	})
+
+	t.Run("切り詰めた場合は注記を付ける", func(t *testing.T) {
+		output, err := formatter.FormatWithOptions(long, utils.FormatOptions{Format: "table"})
+		require.NoError(t, err)
+		assert.Contains(t, output, "very-long-service...")
+		assert.True(t, strings.HasSuffix(output, "\n\n"+utils.TruncationNote+"\n"))
+
+		// 切り詰めの有無は呼び出しごとに判定する
+		output, err = formatter.FormatWithOptions(short, utils.FormatOptions{Format: "table"})
+		require.NoError(t, err)
+		assert.NotContains(t, output, utils.TruncationNote)
+	})
+
+	t.Run("wide指定時は切り詰めない", func(t *testing.T) {
+		output, err := formatter.FormatWithOptions(long, utils.FormatOptions{Format: "table", Wide: true})
+		require.NoError(t, err)
+		assert.Contains(t, output, "very-long-service-name-exceeding-column")
+		assert.NotContains(t, output, utils.TruncationNote)
+	})
+
+	t.Run("テーブル以外の形式には注記を付けない", func(t *testing.T) {
+		for _, format := range []string{"json", "yaml", "compact"} {
+			output, err := formatter.FormatWithOptions(long, utils.FormatOptions{Format: format, PrettyPrint: true})
+			require.NoError(t, err)
+			assert.NotContains(t, output, utils.TruncationNote, format)
+		}
+	})
+}
+
 func TestFormatter_IsHealthyService(t *testing.T) {
 	formatter := &utils.Formatter{}
 
@@ -280,3 +456,179 @@ func TestFormatter_IsHealthyService(t *testing.T) {
 	assert.False(t, formatter.IsHealthyService(unhealthyService))
 	assert.False(t, formatter.IsHealthyService(inactiveService))
 }
+
+func TestFormatter_Flatten_InspectionResult(t *testing.T) {
+	formatter := utils.NewFormatter()
+
+	result := models.InspectionResult{
+		Service: models.ECSService{
+			ServiceName:  "web-service",
+			DesiredCount: 2,
+		},
+		NetworkConfig: &models.NetworkConfig{
+			Subnets:        []string{"subnet-1", "subnet-2"},
+			SecurityGroups: []string{"sg-1"},
+		},
+	}
+
+	flattened, err := formatter.Flatten(result)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "web-service", flattened["service.service_name"])
+	assert.Equal(t, float64(2), flattened["service.desired_count"])
+	assert.Equal(t, "subnet-1", flattened["network_config.subnets.0"])
+	assert.Equal(t, "subnet-2", flattened["network_config.subnets.1"])
+	assert.Equal(t, "sg-1", flattened["network_config.security_groups.0"])
+	assert.NotContains(t, flattened, "service")
+}
+
+func TestFormatter_FormatWithOptions_FlattenYAML(t *testing.T) {
+	formatter := utils.NewFormatter()
+
+	result := models.InspectionResult{
+		Service: models.ECSService{
+			ServiceName:  "web-service",
+			DesiredCount: 2,
+		},
+		NetworkConfig: &models.NetworkConfig{
+			Subnets: []string{"subnet-1"},
+		},
+	}
+
+	output, err := formatter.FormatWithOptions(result, utils.FormatOptions{
+		Format:  "yaml",
+		Flatten: true,
+	})
+
+	assert.NoError(t, err)
+	assert.Contains(t, output, "service.desired_count: 2")
+	assert.Contains(t, output, "network_config.subnets.0: subnet-1")
+}
+
+func TestFormatter_InspectionResult_Remediation(t *testing.T) {
+	formatter := utils.NewFormatter()
+
+	inspectionResult := models.InspectionResult{
+		Service:        models.ECSService{ServiceName: "web-service", ClusterName: "prod"},
+		TaskDefinition: models.ECSTaskDefinition{Family: "web-task", Revision: 1},
+		Recommendations: []models.Recommendation{
+			{
+				Category:        "health",
+				Title:           "Service Health Issue",
+				Priority:        "high",
+				RemediationType: models.RemediationUpdateService,
+				Params:          map[string]string{"service": "web-service", "desired_count": "2"},
+			},
+		},
+	}
+
+	table, err := formatter.FormatTable(inspectionResult)
+	require.NoError(t, err)
+	assert.Contains(t, table, "Remediation: update_service desired_count=2 service=web-service")
+
+	jsonOutput, err := formatter.FormatJSON(inspectionResult)
+	require.NoError(t, err)
+	assert.Contains(t, jsonOutput, `"remediation_type": "update_service"`)
+	assert.Contains(t, jsonOutput, `"desired_count": "2"`)
+}
+
+func TestFormatter_InspectionResult_RecommendationsTable(t *testing.T) {
+	formatter := utils.NewFormatter()
+
+	inspectionResult := models.InspectionResult{
+		Service:        models.ECSService{ServiceName: "web-service", ClusterName: "prod"},
+		TaskDefinition: models.ECSTaskDefinition{Family: "web-task", Revision: 1},
+		Recommendations: []models.Recommendation{
+			{Category: "scaling", Title: "Consider Auto Scaling", Priority: "medium", Action: "Configure Auto Scaling policies"},
+			{Category: "health", Title: "Service Health Issue", Priority: "high", Action: "Check task logs"},
+		},
+	}
+
+	table, err := formatter.FormatTable(inspectionResult)
+	require.NoError(t, err)
+
+	lines := strings.Split(table[strings.Index(table, "=== RECOMMENDATIONS ==="):], "\n")
+	require.GreaterOrEqual(t, len(lines), 5)
+	assert.Regexp(t, `^#\s+PRIORITY\s+CATEGORY\s+TITLE\s+ACTION$`, lines[1])
+	assert.Regexp(t, `^1\s+MEDIUM\s+scaling\s+Consider Auto Scaling\s+Configure Auto Scaling policies$`, lines[3])
+	assert.Regexp(t, `^2\s+HIGH\s+health\s+Service Health Issue\s+Check task logs$`, lines[4])
+	// 列幅は内容の最大長に合わせる
+	assert.Equal(t, strings.Index(lines[1], "TITLE"), strings.Index(lines[3], "Consider"))
+	assert.Equal(t, strings.Index(lines[1], "ACTION"), strings.Index(lines[4], "Check"))
+}
+
+func TestFormatter_InspectionResult_SchedulingStrategy(t *testing.T) {
+	formatter := utils.NewFormatter()
+
+	inspectionResult := models.InspectionResult{
+		Service:        models.ECSService{ServiceName: "log-agent", ClusterName: "prod", SchedulingStrategy: "DAEMON"},
+		TaskDefinition: models.ECSTaskDefinition{Family: "log-agent", Revision: 1},
+	}
+
+	table, err := formatter.FormatTable(inspectionResult)
+	require.NoError(t, err)
+	assert.Contains(t, table, "Scheduling Strategy: DAEMON\n")
+
+	inspectionResult.Service.SchedulingStrategy = ""
+	table, err = formatter.FormatTable(inspectionResult)
+	require.NoError(t, err)
+	assert.NotContains(t, table, "Scheduling Strategy")
+}
+
+func TestFormatter_InspectionResult_Deployments(t *testing.T) {
+	formatter := utils.NewFormatter()
+
+	inspectionResult := models.InspectionResult{
+		Service:        models.ECSService{ServiceName: "web-service", ClusterName: "prod"},
+		TaskDefinition: models.ECSTaskDefinition{Family: "web-task", Revision: 2},
+		Deployments: []models.ServiceDeployment{
+			{ID: "ecs-svc/2", Status: "PRIMARY", TaskDefinition: "web-task:2", RolloutState: "FAILED", RolloutStateReason: "tasks failed to start", DesiredCount: 2, FailedTasks: 3},
+			{ID: "ecs-svc/1", Status: "ACTIVE", TaskDefinition: "web-task:1", DesiredCount: 2, RunningCount: 1},
+		},
+	}
+
+	table, err := formatter.FormatTable(inspectionResult)
+	require.NoError(t, err)
+
+	lines := strings.Split(table[strings.Index(table, "=== DEPLOYMENTS ==="):], "\n")
+	require.GreaterOrEqual(t, len(lines), 6)
+	assert.Regexp(t, `^ID\s+STATUS\s+ROLLOUT STATE\s+TASK DEFINITION\s+DESIRED\s+PENDING\s+RUNNING\s+FAILED$`, lines[1])
+	assert.Regexp(t, `^ecs-svc/2\s+PRIMARY\s+FAILED\s+web-task:2\s+2\s+0\s+0\s+3$`, lines[3])
+	assert.Regexp(t, `^ecs-svc/1\s+ACTIVE\s+\S+\s+web-task:1\s+2\s+0\s+1\s+0$`, lines[4])
+	assert.Contains(t, table, "ecs-svc/2: tasks failed to start\n")
+
+	// デプロイメントがない場合はセクションを表示しない
+	inspectionResult.Deployments = nil
+	table, err = formatter.FormatTable(inspectionResult)
+	require.NoError(t, err)
+	assert.NotContains(t, table, "DEPLOYMENTS")
+}
+
+func TestFormatter_InspectionResult_Warnings(t *testing.T) {
+	formatter := utils.NewFormatter()
+
+	inspectionResult := models.InspectionResult{
+		Service:        models.ECSService{ServiceName: "web-service", ClusterName: "prod"},
+		TaskDefinition: models.ECSTaskDefinition{Family: "web-task", Revision: 2},
+		Warnings:       []string{"skipped cluster capacity check for prod: AccessDeniedException"},
+	}
+
+	table, err := formatter.FormatTable(inspectionResult)
+	require.NoError(t, err)
+	assert.Contains(t, table, "=== WARNINGS ===\n- skipped cluster capacity check for prod: AccessDeniedException\n")
+
+	jsonOutput, err := formatter.FormatJSON(inspectionResult)
+	require.NoError(t, err)
+	var decoded models.InspectionResult
+	require.NoError(t, json.Unmarshal([]byte(jsonOutput), &decoded))
+	assert.Equal(t, []string{"skipped cluster capacity check for prod: AccessDeniedException"}, decoded.Warnings)
+
+	// 警告がない場合はセクションもフィールドも出力しない
+	inspectionResult.Warnings = nil
+	table, err = formatter.FormatTable(inspectionResult)
+	require.NoError(t, err)
+	assert.NotContains(t, table, "WARNINGS")
+	jsonOutput, err = formatter.FormatJSON(inspectionResult)
+	require.NoError(t, err)
+	assert.NotContains(t, jsonOutput, "warnings")
+}
@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"regexp"
+
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+)
+
+// MaskedValue はマスクした値の置き換え文字列
+const MaskedValue = "***"
+
+// DefaultSensitiveEnvPattern は値をマスクする環境変数名のデフォルトパターン
+const DefaultSensitiveEnvPattern = `(?i)(password|passwd|secret|token|api[_-]?key|private[_-]?key|credential)`
+
+// MaskInspectionResult はシークレットの参照先と、名前がパターンに一致する環境変数の値をマスクした結果を返す
+// 元の結果は変更しない
+func MaskInspectionResult(result models.InspectionResult, envPattern *regexp.Regexp) models.InspectionResult {
+	masked := result
+	if result.TaskDefinition.Containers == nil {
+		return masked
+	}
+
+	masked.TaskDefinition.Containers = make([]models.ContainerDefinition, len(result.TaskDefinition.Containers))
+	for idx, container := range result.TaskDefinition.Containers {
+		if container.Environment != nil {
+			environment := make([]models.EnvironmentVariable, len(container.Environment))
+			for envIdx, env := range container.Environment {
+				if envPattern != nil && envPattern.MatchString(env.Name) {
+					env.Value = MaskedValue
+				}
+				environment[envIdx] = env
+			}
+			container.Environment = environment
+		}
+
+		if container.Secrets != nil {
+			secrets := make([]models.Secret, len(container.Secrets))
+			for secretIdx, secret := range container.Secrets {
+				secret.ValueFrom = MaskedValue
+				secrets[secretIdx] = secret
+			}
+			container.Secrets = secrets
+		}
+
+		masked.TaskDefinition.Containers[idx] = container
+	}
+
+	return masked
+}
@@ -0,0 +1,53 @@
+package utils_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+	"github.com/dev-shimada/phantom-ecs/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaskInspectionResult(t *testing.T) {
+	result := models.InspectionResult{
+		Service: models.ECSService{ServiceName: "web-service"},
+		TaskDefinition: models.ECSTaskDefinition{
+			Family: "web-task",
+			Containers: []models.ContainerDefinition{
+				{
+					Name:  "web",
+					Image: "nginx:latest",
+					Environment: []models.EnvironmentVariable{
+						{Name: "DB_PASSWORD", Value: "hunter2"},
+						{Name: "STRIPE_API_KEY", Value: "sk_live_xxx"},
+						{Name: "LOG_LEVEL", Value: "info"},
+					},
+					Secrets: []models.Secret{
+						{Name: "DB_URL", ValueFrom: "arn:aws:secretsmanager:us-east-1:123456789012:secret:db-url"},
+					},
+				},
+			},
+		},
+	}
+
+	masked := utils.MaskInspectionResult(result, regexp.MustCompile(utils.DefaultSensitiveEnvPattern))
+
+	require.Len(t, masked.TaskDefinition.Containers, 1)
+	container := masked.TaskDefinition.Containers[0]
+	assert.Equal(t, []models.EnvironmentVariable{
+		{Name: "DB_PASSWORD", Value: "***"},
+		{Name: "STRIPE_API_KEY", Value: "***"},
+		{Name: "LOG_LEVEL", Value: "info"},
+	}, container.Environment)
+	assert.Equal(t, []models.Secret{{Name: "DB_URL", ValueFrom: "***"}}, container.Secrets)
+
+	// 機密でないフィールドはそのまま
+	assert.Equal(t, "nginx:latest", container.Image)
+	assert.Equal(t, "web-service", masked.Service.ServiceName)
+
+	// 元の結果は変更されない
+	assert.Equal(t, "hunter2", result.TaskDefinition.Containers[0].Environment[0].Value)
+	assert.Contains(t, result.TaskDefinition.Containers[0].Secrets[0].ValueFrom, "arn:aws:secretsmanager")
+}
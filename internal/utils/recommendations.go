@@ -0,0 +1,144 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+)
+
+// レコメンデーションの並び替えキー
+const (
+	RecommendationSortPriority = "priority"
+	RecommendationSortCategory = "category"
+)
+
+// InspectionSortRecommendations は調査結果を優先度の高いレコメンデーションが多い順に並び替えるキー
+const InspectionSortRecommendations = "recommendations"
+
+// recommendationPriorityRank は優先度の並び順（未知の優先度は最後）
+var recommendationPriorityRank = map[string]int{
+	"high":   0,
+	"medium": 1,
+	"low":    2,
+}
+
+// ValidateRecommendationSort はレコメンデーションの並び替えキーを検証
+func ValidateRecommendationSort(key string) error {
+	switch key {
+	case RecommendationSortPriority, RecommendationSortCategory:
+		return nil
+	default:
+		return fmt.Errorf("unsupported recommendation sort key: %s. Supported keys: [%s %s]",
+			key, RecommendationSortPriority, RecommendationSortCategory)
+	}
+}
+
+// SortRecommendations はレコメンデーションを指定キーで並び替えた新しいスライスを返す
+// priorityは優先度の高い順、categoryはカテゴリ名順（同一カテゴリ内は優先度順）で、同順位は元の順序を保つ
+func SortRecommendations(recommendations []models.Recommendation, key string) ([]models.Recommendation, error) {
+	if err := ValidateRecommendationSort(key); err != nil {
+		return nil, err
+	}
+
+	sorted := append([]models.Recommendation{}, recommendations...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if key == RecommendationSortCategory && sorted[i].Category != sorted[j].Category {
+			return sorted[i].Category < sorted[j].Category
+		}
+		return priorityRank(sorted[i].Priority) < priorityRank(sorted[j].Priority)
+	})
+	return sorted, nil
+}
+
+// ValidateRecommendationPriority はレコメンデーションの優先度のしきい値を検証
+func ValidateRecommendationPriority(priority string) error {
+	if _, ok := recommendationPriorityRank[strings.ToLower(priority)]; !ok {
+		return fmt.Errorf("unsupported recommendation priority: %s. Supported priorities: [high medium low]", priority)
+	}
+	return nil
+}
+
+// RecommendationsAtOrAbove は優先度がしきい値以上のレコメンデーションを元の順序で返す
+// 未知の優先度はlowより低いものとして扱う
+func RecommendationsAtOrAbove(recommendations []models.Recommendation, threshold string) ([]models.Recommendation, error) {
+	if err := ValidateRecommendationPriority(threshold); err != nil {
+		return nil, err
+	}
+
+	thresholdRank := priorityRank(threshold)
+	var matched []models.Recommendation
+	for _, rec := range recommendations {
+		if priorityRank(rec.Priority) <= thresholdRank {
+			matched = append(matched, rec)
+		}
+	}
+	return matched, nil
+}
+
+// CountRecommendations は調査結果のレコメンデーションを優先度別に集計（未知の優先度は集計しない）
+func CountRecommendations(result models.InspectionResult) models.RecommendationCounts {
+	counts := models.RecommendationCounts{
+		ClusterName: result.Service.ClusterName,
+		ServiceName: result.Service.ServiceName,
+	}
+	for _, rec := range result.Recommendations {
+		switch strings.ToLower(rec.Priority) {
+		case "high":
+			counts.High++
+		case "medium":
+			counts.Medium++
+		case "low":
+			counts.Low++
+		}
+	}
+	return counts
+}
+
+// ValidateInspectionSort は調査結果の並び替えキーを検証
+func ValidateInspectionSort(key string) error {
+	if key != InspectionSortRecommendations {
+		return fmt.Errorf("unsupported sort key: %s. Supported keys: [%s]", key, InspectionSortRecommendations)
+	}
+	return nil
+}
+
+// SortInspectionResults は調査結果を指定キーで並び替えた新しいスライスを返す
+// recommendationsはhigh、medium、lowの件数の多い順で比較し、同順位は元の順序を保つ
+func SortInspectionResults(results []models.InspectionResult, key string) ([]models.InspectionResult, error) {
+	if err := ValidateInspectionSort(key); err != nil {
+		return nil, err
+	}
+
+	counts := make([]models.RecommendationCounts, len(results))
+	indexes := make([]int, len(results))
+	for i, result := range results {
+		counts[i] = CountRecommendations(result)
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		a, b := counts[indexes[i]], counts[indexes[j]]
+		if a.High != b.High {
+			return a.High > b.High
+		}
+		if a.Medium != b.Medium {
+			return a.Medium > b.Medium
+		}
+		return a.Low > b.Low
+	})
+
+	sorted := make([]models.InspectionResult, 0, len(results))
+	for _, idx := range indexes {
+		sorted = append(sorted, results[idx])
+	}
+	return sorted, nil
+}
+
+// priorityRank は優先度の並び順を返す
+func priorityRank(priority string) int {
+	if rank, ok := recommendationPriorityRank[strings.ToLower(priority)]; ok {
+		return rank
+	}
+	return len(recommendationPriorityRank)
+}
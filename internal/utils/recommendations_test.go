@@ -0,0 +1,146 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/dev-shimada/phantom-ecs/internal/models"
+	"github.com/dev-shimada/phantom-ecs/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func recommendationTitles(recommendations []models.Recommendation) []string {
+	titles := make([]string, 0, len(recommendations))
+	for _, rec := range recommendations {
+		titles = append(titles, rec.Title)
+	}
+	return titles
+}
+
+func TestSortRecommendations(t *testing.T) {
+	recommendations := []models.Recommendation{
+		{Title: "low-scaling", Category: "scaling", Priority: "low"},
+		{Title: "high-security", Category: "security", Priority: "high"},
+		{Title: "medium-cost", Category: "cost", Priority: "medium"},
+		{Title: "high-scaling", Category: "scaling", Priority: "HIGH"},
+		{Title: "unknown-cost", Category: "cost", Priority: "info"},
+	}
+
+	tests := []struct {
+		name string
+		key  string
+		want []string
+	}{
+		{
+			name: "優先度の高い順（同順位は元の順序）",
+			key:  "priority",
+			want: []string{"high-security", "high-scaling", "medium-cost", "low-scaling", "unknown-cost"},
+		},
+		{
+			name: "カテゴリ名順（同一カテゴリ内は優先度順）",
+			key:  "category",
+			want: []string{"medium-cost", "unknown-cost", "high-scaling", "low-scaling", "high-security"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sorted, err := utils.SortRecommendations(recommendations, tt.key)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, recommendationTitles(sorted))
+		})
+	}
+
+	// 元のスライスは変更しない
+	assert.Equal(t, "low-scaling", recommendations[0].Title)
+}
+
+func TestSortRecommendations_InvalidKey(t *testing.T) {
+	_, err := utils.SortRecommendations(nil, "title")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported recommendation sort key: title")
+}
+
+func TestRecommendationsAtOrAbove(t *testing.T) {
+	recommendations := []models.Recommendation{
+		{Title: "low-scaling", Priority: "low"},
+		{Title: "high-security", Priority: "high"},
+		{Title: "medium-cost", Priority: "medium"},
+		{Title: "unknown-cost", Priority: "info"},
+	}
+
+	tests := []struct {
+		threshold string
+		want      []string
+	}{
+		{threshold: "high", want: []string{"high-security"}},
+		{threshold: "MEDIUM", want: []string{"high-security", "medium-cost"}},
+		{threshold: "low", want: []string{"low-scaling", "high-security", "medium-cost"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.threshold, func(t *testing.T) {
+			matched, err := utils.RecommendationsAtOrAbove(recommendations, tt.threshold)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, recommendationTitles(matched))
+		})
+	}
+}
+
+func TestRecommendationsAtOrAbove_InvalidPriority(t *testing.T) {
+	_, err := utils.RecommendationsAtOrAbove(nil, "critical")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported recommendation priority: critical")
+}
+
+func TestCountRecommendations(t *testing.T) {
+	counts := utils.CountRecommendations(models.InspectionResult{
+		Service: models.ECSService{ServiceName: "web-service", ClusterName: "prod"},
+		Recommendations: []models.Recommendation{
+			{Title: "a", Priority: "high"},
+			{Title: "b", Priority: "HIGH"},
+			{Title: "c", Priority: "medium"},
+			{Title: "d", Priority: "low"},
+			{Title: "e", Priority: "info"},
+		},
+	})
+
+	assert.Equal(t, models.RecommendationCounts{ClusterName: "prod", ServiceName: "web-service", High: 2, Medium: 1, Low: 1}, counts)
+}
+
+func TestSortInspectionResults(t *testing.T) {
+	result := func(name string, priorities ...string) models.InspectionResult {
+		r := models.InspectionResult{Service: models.ECSService{ServiceName: name}}
+		for _, priority := range priorities {
+			r.Recommendations = append(r.Recommendations, models.Recommendation{Priority: priority})
+		}
+		return r
+	}
+	results := []models.InspectionResult{
+		result("none"),
+		result("low-only", "low", "low"),
+		result("one-high", "high"),
+		result("medium-and-low", "medium", "low"),
+		result("one-high-more-medium", "high", "medium"),
+		result("medium-only", "medium"),
+	}
+
+	sorted, err := utils.SortInspectionResults(results, "recommendations")
+	require.NoError(t, err)
+
+	var names []string
+	for _, r := range sorted {
+		names = append(names, r.Service.ServiceName)
+	}
+	assert.Equal(t, []string{"one-high-more-medium", "one-high", "medium-and-low", "medium-only", "low-only", "none"}, names)
+	// 元のスライスは変更しない
+	assert.Equal(t, "none", results[0].Service.ServiceName)
+
+	_, err = utils.SortInspectionResults(results, "name")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported sort key: name")
+}
@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// spinnerFrames はスピナーのアニメーションフレーム
+var spinnerFrames = []string{"|", "/", "-", "\\"}
+
+// spinnerInterval はスピナーのフレーム更新間隔
+const spinnerInterval = 100 * time.Millisecond
+
+// Spinner は時間のかかる単一操作の実行中に進行状況を表示する
+// 出力先が端末でない場合は何も出力しない
+type Spinner struct {
+	output  io.Writer
+	message string
+	enabled bool
+
+	mu   sync.Mutex
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewSpinner は新しいSpinnerを作成（enabledがfalseまたは出力先が端末でない場合は無効）
+func NewSpinner(output io.Writer, message string, enabled bool) *Spinner {
+	return &Spinner{
+		output:  output,
+		message: message,
+		enabled: enabled && IsTerminal(output),
+	}
+}
+
+// Start はスピナーの表示を開始
+func (s *Spinner) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.enabled || s.stop != nil {
+		return
+	}
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+
+	go s.run(s.stop, s.done)
+}
+
+// Stop はスピナーを停止し、表示した行を消去する
+func (s *Spinner) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stop == nil {
+		return
+	}
+	close(s.stop)
+	<-s.done
+	s.stop = nil
+	s.done = nil
+}
+
+// run はフレームを一定間隔で描画し、停止時に行を消去する
+func (s *Spinner) run(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(spinnerInterval)
+	defer ticker.Stop()
+
+	for frame := 0; ; frame++ {
+		fmt.Fprintf(s.output, "\r%s %s", spinnerFrames[frame%len(spinnerFrames)], s.message)
+		select {
+		case <-stop:
+			fmt.Fprint(s.output, "\r\033[K")
+			return
+		case <-ticker.C:
+		}
+	}
+}
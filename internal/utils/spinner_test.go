@@ -0,0 +1,33 @@
+package utils_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/dev-shimada/phantom-ecs/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpinner_NonTerminalWriter(t *testing.T) {
+	var buf bytes.Buffer
+	spinner := utils.NewSpinner(&buf, "Inspecting service...", true)
+
+	spinner.Start()
+	time.Sleep(150 * time.Millisecond)
+	spinner.Stop()
+
+	assert.Empty(t, buf.String())
+}
+
+func TestSpinner_StopWithoutStart(t *testing.T) {
+	var buf bytes.Buffer
+	spinner := utils.NewSpinner(&buf, "Deploying service...", false)
+
+	assert.NotPanics(t, func() {
+		spinner.Stop()
+		spinner.Start()
+		spinner.Stop()
+	})
+	assert.Empty(t, buf.String())
+}
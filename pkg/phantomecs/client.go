@@ -49,3 +49,18 @@ func (p *PhantomECSClient) GetECSService() *aws.ECSService {
 func (p *PhantomECSClient) GetAWSClient() *aws.Client {
 	return p.awsClient
 }
+
+// ListServices 指定されたクラスターのサービス一覧を取得
+func (p *PhantomECSClient) ListServices(ctx context.Context, clusterName string) ([]string, error) {
+	return p.ecsService.ListServices(ctx, clusterName)
+}
+
+// DescribeServices 指定されたサービスの詳細情報を取得
+func (p *PhantomECSClient) DescribeServices(ctx context.Context, clusterName string, serviceNames []string) (map[string]interface{}, error) {
+	return p.ecsService.DescribeServices(ctx, clusterName, serviceNames)
+}
+
+// DescribeTaskDefinition 指定されたタスク定義の詳細情報を取得
+func (p *PhantomECSClient) DescribeTaskDefinition(ctx context.Context, taskDefArn string) (map[string]interface{}, error) {
+	return p.ecsService.DescribeTaskDefinition(ctx, taskDefArn)
+}
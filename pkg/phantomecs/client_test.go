@@ -2,7 +2,9 @@ package phantomecs_test
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/dev-shimada/phantom-ecs/pkg/phantomecs"
 	"github.com/stretchr/testify/assert"
@@ -64,3 +66,54 @@ func TestPhantomECSClient_GetECSService(t *testing.T) {
 	ecsService := client.GetECSService()
 	assert.NotNil(t, ecsService)
 }
+
+func TestPhantomECSClient_CancelledContext(t *testing.T) {
+	// 認証情報の探索でネットワークに出ないよう静的な認証情報を使用
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+
+	client, err := phantomecs.NewPhantomECSClient(context.Background(), "us-east-1", "")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		call func(ctx context.Context) error
+	}{
+		{
+			name: "ListServices",
+			call: func(ctx context.Context) error {
+				_, err := client.ListServices(ctx, "test-cluster")
+				return err
+			},
+		},
+		{
+			name: "DescribeServices",
+			call: func(ctx context.Context) error {
+				_, err := client.DescribeServices(ctx, "test-cluster", []string{"web"})
+				return err
+			},
+		},
+		{
+			name: "DescribeTaskDefinition",
+			call: func(ctx context.Context) error {
+				_, err := client.DescribeTaskDefinition(ctx, "web-task:1")
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			start := time.Now()
+			err := tt.call(ctx)
+
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, context.Canceled), "expected context.Canceled, got: %v", err)
+			assert.Less(t, time.Since(start), time.Second)
+		})
+	}
+}
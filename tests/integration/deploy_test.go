@@ -0,0 +1,197 @@
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/dev-shimada/phantom-ecs/internal/deployer"
+	"github.com/dev-shimada/phantom-ecs/internal/inspector"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// FakeECSClient はテスト用のECSクライアント
+type FakeECSClient struct {
+	Services        []types.Service
+	TaskDefinitions map[string]*types.TaskDefinition
+
+	RegisteredTaskDefinitions []*ecs.RegisterTaskDefinitionInput
+	CreatedServices           []*ecs.CreateServiceInput
+}
+
+func (f *FakeECSClient) ListClusters(ctx context.Context, input *ecs.ListClustersInput) (*ecs.ListClustersOutput, error) {
+	return &ecs.ListClustersOutput{}, nil
+}
+
+func (f *FakeECSClient) ListServices(ctx context.Context, input *ecs.ListServicesInput) (*ecs.ListServicesOutput, error) {
+	return &ecs.ListServicesOutput{}, nil
+}
+
+func (f *FakeECSClient) DescribeServices(ctx context.Context, input *ecs.DescribeServicesInput) (*ecs.DescribeServicesOutput, error) {
+	return &ecs.DescribeServicesOutput{Services: f.Services}, nil
+}
+
+func (f *FakeECSClient) DescribeTaskDefinition(ctx context.Context, input *ecs.DescribeTaskDefinitionInput) (*ecs.DescribeTaskDefinitionOutput, error) {
+	return &ecs.DescribeTaskDefinitionOutput{TaskDefinition: f.TaskDefinitions[*input.TaskDefinition]}, nil
+}
+
+func (f *FakeECSClient) CreateService(ctx context.Context, input *ecs.CreateServiceInput) (*ecs.CreateServiceOutput, error) {
+	f.CreatedServices = append(f.CreatedServices, input)
+	return &ecs.CreateServiceOutput{Service: &types.Service{ServiceName: input.ServiceName}}, nil
+}
+
+func (f *FakeECSClient) UpdateService(ctx context.Context, input *ecs.UpdateServiceInput) (*ecs.UpdateServiceOutput, error) {
+	return &ecs.UpdateServiceOutput{Service: &types.Service{ServiceName: input.Service, TaskDefinition: input.TaskDefinition}}, nil
+}
+
+func (f *FakeECSClient) RegisterTaskDefinition(ctx context.Context, input *ecs.RegisterTaskDefinitionInput) (*ecs.RegisterTaskDefinitionOutput, error) {
+	f.RegisteredTaskDefinitions = append(f.RegisteredTaskDefinitions, input)
+	return &ecs.RegisterTaskDefinitionOutput{
+		TaskDefinition: &types.TaskDefinition{
+			TaskDefinitionArn: aws.String("arn:aws:ecs:us-east-1:123456789012:task-definition/" + *input.Family + ":1"),
+			Family:            input.Family,
+			Revision:          1,
+		},
+	}, nil
+}
+
+func (f *FakeECSClient) ListContainerInstances(ctx context.Context, input *ecs.ListContainerInstancesInput) (*ecs.ListContainerInstancesOutput, error) {
+	return &ecs.ListContainerInstancesOutput{}, nil
+}
+
+func (f *FakeECSClient) DescribeContainerInstances(ctx context.Context, input *ecs.DescribeContainerInstancesInput) (*ecs.DescribeContainerInstancesOutput, error) {
+	return &ecs.DescribeContainerInstancesOutput{}, nil
+}
+
+func (f *FakeECSClient) ListTaskDefinitionFamilies(ctx context.Context, input *ecs.ListTaskDefinitionFamiliesInput) (*ecs.ListTaskDefinitionFamiliesOutput, error) {
+	return &ecs.ListTaskDefinitionFamiliesOutput{}, nil
+}
+
+func (f *FakeECSClient) ListTaskDefinitions(ctx context.Context, input *ecs.ListTaskDefinitionsInput) (*ecs.ListTaskDefinitionsOutput, error) {
+	return &ecs.ListTaskDefinitionsOutput{}, nil
+}
+
+func TestInspectAndDeploy_PreservesPortMappingsAndVolumes(t *testing.T) {
+	client := &FakeECSClient{
+		Services: []types.Service{
+			{
+				ServiceName:    aws.String("web-service"),
+				Status:         aws.String("ACTIVE"),
+				TaskDefinition: aws.String("web-task:3"),
+				DesiredCount:   2,
+				RunningCount:   2,
+				LaunchType:     types.LaunchTypeEc2,
+			},
+		},
+		TaskDefinitions: map[string]*types.TaskDefinition{
+			"web-task:3": {
+				TaskDefinitionArn: aws.String("arn:aws:ecs:us-east-1:123456789012:task-definition/web-task:3"),
+				Family:            aws.String("web-task"),
+				Revision:          3,
+				Status:            types.TaskDefinitionStatusActive,
+				Cpu:               aws.String("512"),
+				Memory:            aws.String("1024"),
+				NetworkMode:       types.NetworkModeBridge,
+				ContainerDefinitions: []types.ContainerDefinition{
+					{
+						Name:      aws.String("web"),
+						Image:     aws.String("nginx:1.25"),
+						Essential: aws.Bool(true),
+						PortMappings: []types.PortMapping{
+							{ContainerPort: aws.Int32(80), HostPort: aws.Int32(8080), Protocol: types.TransportProtocolTcp},
+						},
+						MountPoints: []types.MountPoint{
+							{SourceVolume: aws.String("logs"), ContainerPath: aws.String("/var/log/nginx"), ReadOnly: aws.Bool(false)},
+						},
+					},
+				},
+				Volumes: []types.Volume{
+					{Name: aws.String("logs"), Host: &types.HostVolumeProperties{SourcePath: aws.String("/var/log/web")}},
+				},
+			},
+		},
+	}
+
+	ctx := context.Background()
+
+	result, err := inspector.NewInspector(client).InspectService(ctx, "web-service", "source-cluster")
+	require.NoError(t, err)
+
+	_, err = deployer.NewDeployer(client).DeployService(ctx, result, "target-cluster", "web-service", false)
+	require.NoError(t, err)
+
+	require.Len(t, client.RegisteredTaskDefinitions, 1)
+	registered := client.RegisteredTaskDefinitions[0]
+
+	require.Len(t, registered.ContainerDefinitions, 1)
+	container := registered.ContainerDefinitions[0]
+	assert.Equal(t, "nginx:1.25", *container.Image)
+	require.Len(t, container.PortMappings, 1)
+	assert.Equal(t, int32(80), *container.PortMappings[0].ContainerPort)
+	assert.Equal(t, int32(8080), *container.PortMappings[0].HostPort)
+	assert.Equal(t, "/var/log/nginx", *container.MountPoints[0].ContainerPath)
+
+	require.Len(t, registered.Volumes, 1)
+	assert.Equal(t, "logs", *registered.Volumes[0].Name)
+	assert.Equal(t, "/var/log/web", *registered.Volumes[0].Host.SourcePath)
+}
+
+func TestInspectAndDeploy_PreservesDependsOnAndCommand(t *testing.T) {
+	client := &FakeECSClient{
+		Services: []types.Service{
+			{
+				ServiceName:    aws.String("app-service"),
+				Status:         aws.String("ACTIVE"),
+				TaskDefinition: aws.String("app-task:1"),
+				DesiredCount:   1,
+				RunningCount:   1,
+				LaunchType:     types.LaunchTypeFargate,
+			},
+		},
+		TaskDefinitions: map[string]*types.TaskDefinition{
+			"app-task:1": {
+				Family: aws.String("app-task"),
+				Status: types.TaskDefinitionStatusActive,
+				ContainerDefinitions: []types.ContainerDefinition{
+					{
+						Name:             aws.String("app"),
+						Image:            aws.String("app:1.0"),
+						Command:          []string{"./server", "--port", "8080"},
+						EntryPoint:       []string{"/bin/sh", "-c"},
+						WorkingDirectory: aws.String("/srv"),
+						Environment:      []types.KeyValuePair{{Name: aws.String("LOG_LEVEL"), Value: aws.String("info")}},
+						Secrets:          []types.Secret{{Name: aws.String("DB_URL"), ValueFrom: aws.String("arn:aws:ssm:us-east-1:123456789012:parameter/db-url")}},
+						DependsOn: []types.ContainerDependency{
+							{ContainerName: aws.String("proxy"), Condition: types.ContainerConditionStart},
+						},
+					},
+					{Name: aws.String("proxy"), Image: aws.String("envoy:v1.29")},
+				},
+			},
+		},
+	}
+
+	ctx := context.Background()
+
+	result, err := inspector.NewInspector(client).InspectService(ctx, "app-service", "source-cluster")
+	require.NoError(t, err)
+
+	_, err = deployer.NewDeployer(client).DeployService(ctx, result, "target-cluster", "app-service", false)
+	require.NoError(t, err)
+
+	require.Len(t, client.RegisteredTaskDefinitions, 1)
+	container := client.RegisteredTaskDefinitions[0].ContainerDefinitions[0]
+	require.Len(t, container.DependsOn, 1)
+	assert.Equal(t, "proxy", *container.DependsOn[0].ContainerName)
+	assert.Equal(t, types.ContainerConditionStart, container.DependsOn[0].Condition)
+	assert.Equal(t, []string{"./server", "--port", "8080"}, container.Command)
+	assert.Equal(t, []string{"/bin/sh", "-c"}, container.EntryPoint)
+	assert.Equal(t, "/srv", *container.WorkingDirectory)
+	require.Len(t, container.Environment, 1)
+	assert.Equal(t, "info", *container.Environment[0].Value)
+	require.Len(t, container.Secrets, 1)
+	assert.Equal(t, "arn:aws:ssm:us-east-1:123456789012:parameter/db-url", *container.Secrets[0].ValueFrom)
+}
@@ -211,6 +211,7 @@ func TestFullWorkflow(t *testing.T) {
 		MaxSize:    enhancedConfig.Logging.MaxSize,
 		MaxAge:     enhancedConfig.Logging.MaxAge,
 		MaxBackups: enhancedConfig.Logging.MaxBackups,
+		Compress:   enhancedConfig.Logging.CompressEnabled(),
 	})
 	require.NoError(t, err)
 